@@ -9,6 +9,7 @@ import (
 
 	"github.com/lasthyphen/dijetsnodego/api"
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowman/poll"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/rpc"
 )
@@ -24,6 +25,10 @@ type Client interface {
 	Alias(ctx context.Context, endpoint string, alias string, options ...rpc.Option) error
 	AliasChain(ctx context.Context, chainID string, alias string, options ...rpc.Option) error
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
+	PauseChain(ctx context.Context, chain string, options ...rpc.Option) error
+	ResumeChain(ctx context.Context, chain string, options ...rpc.Option) error
+	ExportProcessing(ctx context.Context, chain string, format string, options ...rpc.Option) (*ExportProcessingReply, error)
+	GetPollRecords(ctx context.Context, chain string, blkID ids.ID, options ...rpc.Option) ([]*poll.Record, error)
 	Stacktrace(context.Context, ...rpc.Option) error
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) error
@@ -81,6 +86,36 @@ func (c *client) GetChainAliases(ctx context.Context, chain string, options ...r
 	return res.Aliases, err
 }
 
+func (c *client) PauseChain(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.pauseChain", &PauseChainArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) ResumeChain(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.resumeChain", &PauseChainArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) ExportProcessing(ctx context.Context, chain, format string, options ...rpc.Option) (*ExportProcessingReply, error) {
+	res := &ExportProcessingReply{}
+	err := c.requester.SendRequest(ctx, "admin.exportProcessing", &ExportProcessingArgs{
+		Chain:  chain,
+		Format: format,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetPollRecords(ctx context.Context, chain string, blkID ids.ID, options ...rpc.Option) ([]*poll.Record, error) {
+	res := &GetPollRecordsReply{}
+	err := c.requester.SendRequest(ctx, "admin.getPollRecords", &GetPollRecordsArgs{
+		Chain:   chain,
+		BlockID: blkID,
+	}, res, options...)
+	return res.Records, err
+}
+
 func (c *client) Stacktrace(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "admin.stacktrace", struct{}{}, &api.EmptyReply{}, options...)
 }
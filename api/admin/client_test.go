@@ -12,6 +12,7 @@ import (
 
 	"github.com/lasthyphen/dijetsnodego/api"
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowman/poll"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/rpc"
 )
@@ -64,6 +65,12 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *GetLoggerLevelReply:
 		response := mc.response.(*GetLoggerLevelReply)
 		*p = *response
+	case *ExportProcessingReply:
+		response := mc.response.(*ExportProcessingReply)
+		*p = *response
+	case *GetPollRecordsReply:
+		response := mc.response.(*GetPollRecordsReply)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -190,6 +197,83 @@ func TestGetChainAliases(t *testing.T) {
 	})
 }
 
+func TestPauseChain(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.PauseChain(context.Background(), "chain")
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+}
+
+func TestResumeChain(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.ResumeChain(context.Background(), "chain")
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+}
+
+func TestExportProcessing(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedDOT := "digraph processing {\n}\n"
+		mockClient := client{requester: NewMockClient(&ExportProcessingReply{
+			DOT: expectedDOT,
+		}, nil)}
+
+		reply, err := mockClient.ExportProcessing(context.Background(), "chain", DOTFormat)
+		require.NoError(t, err)
+		require.Equal(t, expectedDOT, reply.DOT)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ExportProcessingReply{}, errors.New("some error"))}
+
+		_, err := mockClient.ExportProcessing(context.Background(), "chain", JSONFormat)
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestGetPollRecords(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedRecords := []*poll.Record{
+			{RequestID: 1},
+			{RequestID: 2},
+		}
+		mockClient := client{requester: NewMockClient(&GetPollRecordsReply{
+			Records: expectedRecords,
+		}, nil)}
+
+		records, err := mockClient.GetPollRecords(context.Background(), "chain", ids.GenerateTestID())
+		require.NoError(t, err)
+		require.Equal(t, expectedRecords, records)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetPollRecordsReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetPollRecords(context.Background(), "chain", ids.GenerateTestID())
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
 func TestStacktrace(t *testing.T) {
 	tests := GetSuccessResponseTests()
 
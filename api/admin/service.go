@@ -5,6 +5,7 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"path"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/api/server"
 	"github.com/lasthyphen/dijetsnodego/chains"
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowman/poll"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
 	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
@@ -30,13 +32,18 @@ import (
 const (
 	maxAliasLength = 512
 
+	// Formats that the processing decisions of a chain can be exported in
+	JSONFormat = "json"
+	DOTFormat  = "dot"
+
 	// Name of file that stacktraces are written to
 	stacktraceFile = "stacktrace.txt"
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong  = errors.New("alias length is too long")
+	errNoLogLevel    = errors.New("need to specify either displayLevel or logLevel")
+	errUnknownFormat = errors.New("unknown format")
 )
 
 type Config struct {
@@ -175,6 +182,109 @@ func (a *Admin) GetChainAliases(_ *http.Request, args *GetChainAliasesArgs, repl
 	return err
 }
 
+// PauseChainArgs are the arguments for calling PauseChain and ResumeChain
+type PauseChainArgs struct {
+	Chain string `json:"chain"`
+}
+
+// PauseChain stops the chain from processing consensus messages and building
+// blocks until it is resumed. The node and its other chains keep running.
+func (a *Admin) PauseChain(r *http.Request, args *PauseChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("Admin: PauseChain called",
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.ChainManager.PauseChain(r.Context(), chainID)
+}
+
+// ResumeChain resumes a chain that was paused by PauseChain
+func (a *Admin) ResumeChain(r *http.Request, args *PauseChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("Admin: ResumeChain called",
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.ChainManager.ResumeChain(r.Context(), chainID)
+}
+
+// ExportProcessingArgs are the arguments for calling ExportProcessing
+type ExportProcessingArgs struct {
+	Chain string `json:"chain"`
+	// Format is either "json" or "dot". Defaults to "json".
+	Format string `json:"format"`
+}
+
+// ExportProcessingReply is the response from calling ExportProcessing. Only
+// the field matching the requested format is populated.
+type ExportProcessingReply struct {
+	Processing interface{} `json:"processing,omitempty"`
+	DOT        string      `json:"dot,omitempty"`
+}
+
+// ExportProcessing returns the blocks, or vertices and transactions, that the
+// chain's consensus engine is currently processing, along with their snowball
+// preferences, confidences and the outstanding polls.
+func (a *Admin) ExportProcessing(r *http.Request, args *ExportProcessingArgs, reply *ExportProcessingReply) error {
+	a.Log.Debug("Admin: ExportProcessing called",
+		logging.UserString("chain", args.Chain),
+		logging.UserString("format", args.Format),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	processing, err := a.ChainManager.ExportProcessing(r.Context(), chainID)
+	if err != nil {
+		return err
+	}
+
+	switch args.Format {
+	case "", JSONFormat:
+		reply.Processing = processing
+	case DOTFormat:
+		reply.DOT = processing.DOT()
+	default:
+		return fmt.Errorf("%w: %q", errUnknownFormat, args.Format)
+	}
+	return nil
+}
+
+// GetPollRecordsArgs are the arguments for calling GetPollRecords
+type GetPollRecordsArgs struct {
+	Chain   string `json:"chain"`
+	BlockID ids.ID `json:"blockID"`
+}
+
+// GetPollRecordsReply is the response from calling GetPollRecords
+type GetPollRecordsReply struct {
+	Records []*poll.Record `json:"records"`
+}
+
+// GetPollRecords returns the audit records of the consensus polls that
+// referred to a block, from oldest to newest. The chain's polls must be
+// audited.
+func (a *Admin) GetPollRecords(_ *http.Request, args *GetPollRecordsArgs, reply *GetPollRecordsReply) error {
+	a.Log.Debug("Admin: GetPollRecords called",
+		logging.UserString("chain", args.Chain),
+		zap.Stringer("blockID", args.BlockID),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	reply.Records, err = a.ChainManager.GetPollRecords(chainID, args.BlockID)
+	return err
+}
+
 // Stacktrace returns the current global stacktrace
 func (a *Admin) Stacktrace(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
 	a.Log.Debug("Admin: Stacktrace called")
@@ -112,6 +112,14 @@ type Index struct {
 	UTXO    string `json:"utxo"`    // The UTXO ID as a string
 }
 
+// HistoricalArgs optionally requests the state as of a past block height, or
+// as of the last block accepted no later than a unix timestamp. At most one of
+// them may be specified. If neither is specified, the latest state is used.
+type HistoricalArgs struct {
+	Height    *json.Uint64 `json:"height,omitempty"`
+	Timestamp *json.Uint64 `json:"timestamp,omitempty"`
+}
+
 // GetUTXOsArgs are arguments for passing into GetUTXOs.
 // Gets the UTXOs that reference at least one address in [Addresses].
 // Returns at most [limit] addresses.
@@ -124,7 +132,9 @@ type Index struct {
 // If [StartIndex] is omitted, gets all UTXOs.
 // If GetUTXOs is called multiple times, with our without [StartIndex], it is not guaranteed
 // that returned UTXOs are unique. That is, the same UTXO may appear in the response of multiple calls.
+// If [Height] or [Timestamp] is specified, the native UTXOs are fetched as of that point.
 type GetUTXOsArgs struct {
+	HistoricalArgs
 	Addresses   []string            `json:"addresses"`
 	SourceChain string              `json:"sourceChain"`
 	Limit       json.Uint32         `json:"limit"`
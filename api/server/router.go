@@ -67,6 +67,38 @@ func (r *router) AddRouter(base, endpoint string, handler http.Handler) error {
 	return r.addRouter(base, endpoint, handler)
 }
 
+// ReplaceRouter routes [base]+[endpoint] to [handler]. If it was already routed
+// to a handler, that handler is replaced.
+func (r *router) ReplaceRouter(base, endpoint string, handler http.Handler) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.routeLock.Lock()
+	defer r.routeLock.Unlock()
+
+	if _, exists := r.routes[base][endpoint]; !exists {
+		return r.addRouter(base, endpoint, handler)
+	}
+	return r.replaceRouter(base, endpoint, handler)
+}
+
+func (r *router) replaceRouter(base, endpoint string, handler http.Handler) error {
+	url := base + endpoint
+	route := r.router.Get(url)
+	if route == nil {
+		return fmt.Errorf("failed to find route for %s", url)
+	}
+	route.Handler(handler)
+	r.routes[base][endpoint] = handler
+
+	var err error
+	for _, alias := range r.aliases[base] {
+		if innerErr := r.replaceRouter(alias, endpoint, handler); err == nil {
+			err = innerErr
+		}
+	}
+	return err
+}
+
 func (r *router) addRouter(base, endpoint string, handler http.Handler) error {
 	if r.reservedRoutes[base] {
 		return fmt.Errorf("couldn't route to %s as that route is either aliased or already maps to a handler", base)
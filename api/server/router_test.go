@@ -75,3 +75,29 @@ func TestBlock(t *testing.T) {
 		t.Fatalf("Permanently locked %s", "1")
 	}
 }
+
+func TestReplaceRouter(t *testing.T) {
+	r := newRouter()
+
+	if err := r.AddAlias("/1", "/2"); err != nil {
+		t.Fatal(err)
+	}
+
+	handler1 := &testHandler{}
+	if err := r.ReplaceRouter("/1", "", handler1); err != nil {
+		t.Fatal(err)
+	}
+
+	handler2 := &testHandler{}
+	if err := r.ReplaceRouter("/1", "", handler2); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, base := range []string{"/1", "/2"} {
+		if handler, err := r.GetHandler(base, ""); err != nil {
+			t.Fatalf("Should have added %s", base)
+		} else if handler != handler2 {
+			t.Fatalf("Should have replaced the handler of %s", base)
+		}
+	}
+}
@@ -278,7 +278,9 @@ func (s *server) addChainRoute(chainName string, handler *common.HTTPHandler, ct
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	h = rejectMiddleware(h, ctx)
-	return s.router.AddRouter(url, endpoint, h)
+	// If the chain was restarted, its routes are replaced by those of the new
+	// instance.
+	return s.router.ReplaceRouter(url, endpoint, h)
 }
 
 func (s *server) AddRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base, endpoint string) error {
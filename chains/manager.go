@@ -63,6 +63,7 @@ import (
 	avagetter "github.com/lasthyphen/dijetsnodego/snow/engine/avalanche/getter"
 
 	smcon "github.com/lasthyphen/dijetsnodego/snow/consensus/snowman"
+	smpoll "github.com/lasthyphen/dijetsnodego/snow/consensus/snowman/poll"
 	smeng "github.com/lasthyphen/dijetsnodego/snow/engine/snowman"
 	smbootstrap "github.com/lasthyphen/dijetsnodego/snow/engine/snowman/bootstrap"
 	snowgetter "github.com/lasthyphen/dijetsnodego/snow/engine/snowman/getter"
@@ -78,6 +79,11 @@ var (
 	errUnknownVMType    = errors.New("the vm should have type avalanche.DAGVM or snowman.ChainVM")
 	errCreatePlatformVM = errors.New("attempted to create a chain running the PlatformVM")
 	errNotBootstrapped  = errors.New("subnets not bootstrapped")
+	errPausePlatform    = errors.New("the platform chain can't be paused")
+	errHaltPlatform     = errors.New("the platform chain can't be halted")
+	errRestartPlatform  = errors.New("the platform chain can't be restarted")
+	errChainNotQueued   = errors.New("couldn't enqueue chain")
+	errPollsNotAudited  = errors.New("polls aren't audited for this chain")
 
 	_ Manager = (*manager)(nil)
 )
@@ -116,6 +122,32 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Stops delivering consensus messages to the chain with the given ID until
+	// it is resumed. The platform chain can't be paused.
+	PauseChain(ctx context.Context, chainID ids.ID) error
+
+	// Resumes the chain with the given ID after it was paused.
+	ResumeChain(ctx context.Context, chainID ids.ID) error
+
+	// Permanently shuts down the chain with the given ID. Unlike a paused
+	// chain, a halted chain can't be resumed and doesn't make the node
+	// unhealthy. The platform chain can't be halted.
+	HaltChain(ctx context.Context, chainID ids.ID) error
+
+	// Shuts down the chain with ID [chainParams.ID], if this node runs it, and
+	// creates it again with [chainParams]. This allows a chain to switch to a
+	// different VM without restarting the node. The platform chain can't be
+	// restarted.
+	RestartChain(ctx context.Context, chainParams ChainParameters) error
+
+	// Returns a snapshot of the decisions that the chain with the given ID is
+	// currently processing.
+	ExportProcessing(ctx context.Context, chainID ids.ID) (common.ProcessingExport, error)
+
+	// Returns the audit records of the polls that referred to [blkID] on the
+	// chain with the given ID. Fails if the chain's polls aren't audited.
+	GetPollRecords(chainID ids.ID, blkID ids.ID) ([]*smpoll.Record, error)
+
 	// Starts the chain creator with the initial platform chain parameters, must
 	// be called once.
 	StartChainCreator(platformChain ChainParameters)
@@ -214,6 +246,11 @@ type ManagerConfig struct {
 	StateSyncBeacons []ids.NodeID
 
 	ChainDataDir string
+
+	// Chains whose consensus polls are recorded in an on-disk audit log
+	PollAuditChainIDs set.Set[ids.ID]
+	// Max number of poll records kept per audited chain
+	PollAuditMaxRecords uint64
 }
 
 type manager struct {
@@ -240,6 +277,20 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]handler.Handler
+	// Key: Chain's ID
+	// Value: The handler of the previous instance of a restarted chain, which
+	//        must be stopped before the chain is created again
+	restartedChains map[ids.ID]handler.Handler
+
+	// Key: Chain's ID
+	// Value: The node wide registrations made for the chain
+	// Only accessed by the chain creator.
+	registrations map[ids.ID]*chainRegistrations
+
+	pollAuditsLock sync.RWMutex
+	// Key: Chain's ID
+	// Value: The audit log of the chain's polls
+	pollAudits map[ids.ID]*smpoll.AuditLog
 
 	// snowman++ related interface to allow validators retrieval
 	validatorState validators.State
@@ -252,6 +303,9 @@ func New(config *ManagerConfig) Manager {
 		ManagerConfig:          *config,
 		subnets:                make(map[ids.ID]Subnet),
 		chains:                 make(map[ids.ID]handler.Handler),
+		restartedChains:        make(map[ids.ID]handler.Handler),
+		registrations:          make(map[ids.ID]*chainRegistrations),
+		pollAudits:             make(map[ids.ID]*smpoll.AuditLog),
 		chainsQueue:            buffer.NewUnboundedBlockingDeque[ChainParameters](initialQueueSize),
 		unblockChainCreatorCh:  make(chan struct{}),
 		chainCreatorShutdownCh: make(chan struct{}),
@@ -309,12 +363,32 @@ func (m *manager) createChain(chainParams ChainParameters) {
 	sb := m.subnets[chainParams.SubnetID]
 	m.subnetsLock.Unlock()
 
+	// If a previous instance of the chain is running or shutting down, it must
+	// release its resources before they are used by the new one.
+	m.chainsLock.Lock()
+	previousHandlers := make([]handler.Handler, 0, 2)
+	if previousHandler, ok := m.restartedChains[chainParams.ID]; ok {
+		previousHandlers = append(previousHandlers, previousHandler)
+		delete(m.restartedChains, chainParams.ID)
+	}
+	if previousHandler, ok := m.chains[chainParams.ID]; ok {
+		previousHandlers = append(previousHandlers, previousHandler)
+		delete(m.chains, chainParams.ID)
+	}
+	m.chainsLock.Unlock()
+	for _, previousHandler := range previousHandlers {
+		previousHandler.Stop(context.TODO())
+		<-previousHandler.Stopped()
+	}
+
+	r := m.getRegistrations(chainParams.ID)
+
 	// Note: buildChain builds all chain's relevant objects (notably engine and handler)
 	// but does not start their operations. Starting of the handler (which could potentially
 	// issue some internal messages), is delayed until chain dispatching is started and
 	// the chain is registered in the manager. This ensures that no message generated by handler
 	// upon start is dropped.
-	chain, err := m.buildChain(chainParams, sb)
+	chain, err := m.buildChain(chainParams, sb, r)
 	if err != nil {
 		if m.CriticalChains.Contains(chainParams.ID) {
 			// Shut down if we fail to create a required chain (i.e. X, P or C)
@@ -342,7 +416,8 @@ func (m *manager) createChain(chainParams ChainParameters) {
 		// node may not be properly validating the subnet they expect to be
 		// validating.
 		healthCheckErr := fmt.Errorf("failed to create chain on subnet: %s", chainParams.SubnetID)
-		err := m.Health.RegisterHealthCheck(
+		err := m.registerHealthCheck(
+			r,
 			chainAlias,
 			health.CheckerFunc(func(context.Context) (interface{}, error) {
 				return nil, healthCheckErr
@@ -365,13 +440,16 @@ func (m *manager) createChain(chainParams ChainParameters) {
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
-	if err := m.Alias(chainParams.ID, chainParams.ID.String()); err != nil {
-		m.Log.Error("failed to alias the new chain with itself",
-			zap.Stringer("subnetID", chainParams.SubnetID),
-			zap.Stringer("chainID", chainParams.ID),
-			zap.Stringer("vmID", chainParams.VMID),
-			zap.Error(err),
-		)
+	if !r.aliased {
+		if err := m.Alias(chainParams.ID, chainParams.ID.String()); err != nil {
+			m.Log.Error("failed to alias the new chain with itself",
+				zap.Stringer("subnetID", chainParams.SubnetID),
+				zap.Stringer("chainID", chainParams.ID),
+				zap.Stringer("vmID", chainParams.VMID),
+				zap.Error(err),
+			)
+		}
+		r.aliased = true
 	}
 
 	// Notify those that registered to be notified when a new chain is created
@@ -400,7 +478,7 @@ func (m *manager) createChain(chainParams ChainParameters) {
 }
 
 // Create a chain
-func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, error) {
+func (m *manager) buildChain(chainParams ChainParameters, sb Subnet, r *chainRegistrations) (*chain, error) {
 	if chainParams.ID != constants.PlatformChainID && chainParams.VMID == constants.PlatformVMID {
 		return nil, errCreatePlatformVM
 	}
@@ -413,20 +491,24 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 	}
 
 	// Create the log and context of the chain
-	chainLog, err := m.LogFactory.MakeChain(primaryAlias)
-	if err != nil {
-		return nil, fmt.Errorf("error while creating chain's log %w", err)
+	if r.log == nil {
+		chainLog, err := m.LogFactory.MakeChain(primaryAlias)
+		if err != nil {
+			return nil, fmt.Errorf("error while creating chain's log %w", err)
+		}
+		r.log = chainLog
 	}
+	chainLog := r.log
 
 	consensusMetrics := prometheus.NewRegistry()
 	chainNamespace := fmt.Sprintf("%s_%s", constants.PlatformName, primaryAlias)
-	if err := m.Metrics.Register(chainNamespace, consensusMetrics); err != nil {
+	if err := m.registerGatherer(r, chainNamespace, consensusMetrics); err != nil {
 		return nil, fmt.Errorf("error while registering chain's metrics %w", err)
 	}
 
 	vmMetrics := metrics.NewOptionalGatherer()
 	vmNamespace := fmt.Sprintf("%s_vm", chainNamespace)
-	if err := m.Metrics.Register(vmNamespace, vmMetrics); err != nil {
+	if err := m.registerGatherer(r, vmNamespace, vmMetrics); err != nil {
 		return nil, fmt.Errorf("error while registering vm's metrics %w", err)
 	}
 
@@ -563,9 +645,17 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 		return nil, errUnknownVMType
 	}
 
+	// Register health check for this chain
+	if err := m.registerHealthCheck(r, chain.Name, chain.Handler); err != nil {
+		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chain.Name, err)
+	}
+
 	// Register the chain with the timeout manager
-	if err := m.TimeoutManager.RegisterChain(ctx); err != nil {
-		return nil, err
+	if !r.timeoutsRegistered {
+		if err := m.TimeoutManager.RegisterChain(ctx); err != nil {
+			return nil, err
+		}
+		r.timeoutsRegistered = true
 	}
 
 	return chain, nil
@@ -601,6 +691,7 @@ func (m *manager) createAvalancheChain(
 	vertexDB := prefixdb.New([]byte("vertex"), db.Database)
 	vertexBootstrappingDB := prefixdb.New([]byte("vertex_bs"), db.Database)
 	txBootstrappingDB := prefixdb.New([]byte("tx_bs"), db.Database)
+	stateSyncDB := prefixdb.New([]byte("ss"), db.Database)
 
 	vtxBlocker, err := queue.NewWithMissing(vertexBootstrappingDB, "vtx", ctx.Registerer)
 	if err != nil {
@@ -724,7 +815,7 @@ func (m *manager) createAvalancheChain(
 		SharedCfg:                      &common.SharedConfig{},
 	}
 
-	avaGetHandler, err := avagetter.New(vtxManager, commonCfg)
+	avaGetHandler, err := avagetter.New(vtxManager, vm, commonCfg)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize avalanche base message handler: %w", err)
 	}
@@ -755,6 +846,28 @@ func (m *manager) createAvalancheChain(
 
 	handler.SetBootstrapper(bootstrapper)
 
+	// create state sync gear
+	stateSyncCfg, err := syncer.NewConfig(
+		commonCfg,
+		m.StateSyncBeacons,
+		avaGetHandler,
+		vm,
+		stateSyncDB,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize state syncer configuration: %w", err)
+	}
+	stateSyncer := syncer.New(
+		stateSyncCfg,
+		bootstrapper.Start,
+	)
+
+	if m.TracingEnabled {
+		stateSyncer = common.TraceStateSyncer(stateSyncer, m.Tracer)
+	}
+
+	handler.SetStateSyncer(stateSyncer)
+
 	var consensus avcon.Consensus = &avcon.Topological{}
 	if m.TracingEnabled {
 		consensus = avcon.Trace(consensus, m.Tracer)
@@ -782,13 +895,7 @@ func (m *manager) createAvalancheChain(
 
 	handler.SetConsensus(engine)
 
-	// Register health check for this chain
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
-
-	if err := m.Health.RegisterHealthCheck(chainAlias, handler); err != nil {
-		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
-	}
-
 	return &chain{
 		Name:    chainAlias,
 		Engine:  engine,
@@ -820,12 +927,26 @@ func (m *manager) createSnowmanChain(
 
 	db := prefixDBManager.Current()
 	bootstrappingDB := prefixdb.New([]byte("bs"), db.Database)
+	stateSyncDB := prefixdb.New([]byte("ss"), db.Database)
 
 	blocked, err := queue.NewWithMissing(bootstrappingDB, "block", ctx.Registerer)
 	if err != nil {
 		return nil, err
 	}
 
+	var pollAudit *smpoll.AuditLog
+	if m.PollAuditChainIDs.Contains(ctx.ChainID) {
+		pollAuditDB := prefixdb.New([]byte("pa"), db.Database)
+		pollAudit, err = smpoll.NewAuditLog(pollAuditDB, m.PollAuditMaxRecords)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't initialize poll audit log: %w", err)
+		}
+
+		m.pollAuditsLock.Lock()
+		m.pollAudits[ctx.ChainID] = pollAudit
+		m.pollAuditsLock.Unlock()
+	}
+
 	// The channel through which a VM may send messages to the consensus engine
 	// VM uses this channel to notify engine that a block is ready to be made
 	msgChan := make(chan common.Message, defaultChannelSize)
@@ -1017,6 +1138,7 @@ func (m *manager) createSnowmanChain(
 		Validators:    vdrs,
 		Params:        consensusParams,
 		Consensus:     consensus,
+		PollAudit:     pollAudit,
 	}
 	engine, err := smeng.New(engineConfig)
 	if err != nil {
@@ -1058,6 +1180,7 @@ func (m *manager) createSnowmanChain(
 		m.StateSyncBeacons,
 		snowGetHandler,
 		vm,
+		stateSyncDB,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize state syncer configuration: %w", err)
@@ -1073,11 +1196,6 @@ func (m *manager) createSnowmanChain(
 
 	handler.SetStateSyncer(stateSyncer)
 
-	// Register health checks
-	if err := m.Health.RegisterHealthCheck(chainAlias, handler); err != nil {
-		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
-	}
-
 	return &chain{
 		Name:    chainAlias,
 		Engine:  engine,
@@ -1107,6 +1225,118 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Context().GetState() == snow.NormalOp
 }
 
+func (m *manager) PauseChain(ctx context.Context, chainID ids.ID) error {
+	if chainID == constants.PlatformChainID {
+		return errPausePlatform
+	}
+
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownChainID, chainID)
+	}
+
+	chain.Pause(ctx)
+	return nil
+}
+
+func (m *manager) ResumeChain(ctx context.Context, chainID ids.ID) error {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownChainID, chainID)
+	}
+
+	return chain.Resume(ctx)
+}
+
+func (m *manager) HaltChain(ctx context.Context, chainID ids.ID) error {
+	if chainID == constants.PlatformChainID {
+		return errHaltPlatform
+	}
+
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	delete(m.chains, chainID)
+	m.chainsLock.Unlock()
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownChainID, chainID)
+	}
+
+	chain.Halt(ctx)
+	return nil
+}
+
+func (m *manager) RestartChain(ctx context.Context, chainParams ChainParameters) error {
+	if chainParams.ID == constants.PlatformChainID {
+		return errRestartPlatform
+	}
+
+	m.subnetsLock.Lock()
+	sb, exists := m.subnets[chainParams.SubnetID]
+	if !exists {
+		sb = newSubnet()
+		m.subnets[chainParams.SubnetID] = sb
+	}
+	// If the chain wasn't staged yet, it is created as usual.
+	restarted := !sb.addChain(chainParams.ID)
+	m.subnetsLock.Unlock()
+
+	if restarted {
+		m.chainsLock.Lock()
+		previousHandler, running := m.chains[chainParams.ID]
+		if running {
+			delete(m.chains, chainParams.ID)
+			m.restartedChains[chainParams.ID] = previousHandler
+		}
+		m.chainsLock.Unlock()
+
+		if running {
+			previousHandler.Stop(ctx)
+		}
+	}
+
+	if ok := m.chainsQueue.PushRight(chainParams); !ok {
+		return fmt.Errorf("%w: %s", errChainNotQueued, chainParams.ID)
+	}
+	return nil
+}
+
+// getRegistrations returns the node wide registrations of the chain with ID
+// [chainID]. Must only be called by the chain creator.
+func (m *manager) getRegistrations(chainID ids.ID) *chainRegistrations {
+	r, ok := m.registrations[chainID]
+	if !ok {
+		r = newChainRegistrations()
+		m.registrations[chainID] = r
+	}
+	return r
+}
+
+func (m *manager) ExportProcessing(ctx context.Context, chainID ids.ID) (common.ProcessingExport, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", errUnknownChainID, chainID)
+	}
+
+	return chain.ExportProcessing(ctx)
+}
+
+func (m *manager) GetPollRecords(chainID ids.ID, blkID ids.ID) ([]*smpoll.Record, error) {
+	m.pollAuditsLock.RLock()
+	pollAudit, ok := m.pollAudits[chainID]
+	m.pollAuditsLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errPollsNotAudited, chainID)
+	}
+
+	return pollAudit.GetByBlock(blkID)
+}
+
 func (m *manager) subnetsNotBootstrapped() []ids.ID {
 	m.subnetsLock.Lock()
 	defer m.subnetsLock.Unlock()
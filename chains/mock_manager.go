@@ -4,7 +4,11 @@
 package chains
 
 import (
+	"context"
+
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowman/poll"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/router"
 )
 
@@ -54,6 +58,30 @@ func (mm MockManager) IsBootstrapped(ids.ID) bool {
 	return false
 }
 
+func (mm MockManager) PauseChain(context.Context, ids.ID) error {
+	return nil
+}
+
+func (mm MockManager) ResumeChain(context.Context, ids.ID) error {
+	return nil
+}
+
+func (mm MockManager) HaltChain(context.Context, ids.ID) error {
+	return nil
+}
+
+func (mm MockManager) RestartChain(context.Context, ChainParameters) error {
+	return nil
+}
+
+func (mm MockManager) ExportProcessing(context.Context, ids.ID) (common.ProcessingExport, error) {
+	return nil, nil
+}
+
+func (mm MockManager) GetPollRecords(ids.ID, ids.ID) ([]*poll.Record, error) {
+	return nil, nil
+}
+
 func (mm MockManager) Lookup(s string) (ids.ID, error) {
 	id, err := ids.FromString(s)
 	if err == nil {
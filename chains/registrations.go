@@ -0,0 +1,72 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/lasthyphen/dijetsnodego/api/health"
+	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+)
+
+// chainRegistrations are the node wide registrations made for a chain. They
+// can only be made once, so they are kept when the chain is restarted and
+// point to the objects of the latest instance of the chain.
+type chainRegistrations struct {
+	log                logging.Logger
+	gatherers          map[string]*utils.AtomicInterface
+	healthCheck        *utils.AtomicInterface
+	timeoutsRegistered bool
+	aliased            bool
+}
+
+func newChainRegistrations() *chainRegistrations {
+	return &chainRegistrations{
+		gatherers: make(map[string]*utils.AtomicInterface),
+	}
+}
+
+// registerGatherer reports the metrics of [gatherer] under [namespace]. If
+// metrics were already reported under [namespace], they are replaced.
+func (m *manager) registerGatherer(r *chainRegistrations, namespace string, gatherer prometheus.Gatherer) error {
+	if current, ok := r.gatherers[namespace]; ok {
+		current.SetValue(gatherer)
+		return nil
+	}
+
+	current := utils.NewAtomicInterface(gatherer)
+	err := m.Metrics.Register(namespace, prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		return current.GetValue().(prometheus.Gatherer).Gather()
+	}))
+	if err != nil {
+		return err
+	}
+	r.gatherers[namespace] = current
+	return nil
+}
+
+// registerHealthCheck reports the health of [checker] under [name]. If the
+// health of a previous instance of the chain was already reported, it is
+// replaced.
+func (m *manager) registerHealthCheck(r *chainRegistrations, name string, checker health.Checker) error {
+	if r.healthCheck != nil {
+		r.healthCheck.SetValue(checker)
+		return nil
+	}
+
+	current := utils.NewAtomicInterface(checker)
+	err := m.Health.RegisterHealthCheck(name, health.CheckerFunc(func(ctx context.Context) (interface{}, error) {
+		return current.GetValue().(health.Checker).HealthCheck(ctx)
+	}))
+	if err != nil {
+		return err
+	}
+	r.healthCheck = current
+	return nil
+}
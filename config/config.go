@@ -889,6 +889,21 @@ func getWhitelistedSubnets(v *viper.Viper) (set.Set[ids.ID], error) {
 	return whitelistedSubnetIDs, nil
 }
 
+func getPollAuditChainIDs(v *viper.Viper) (set.Set[ids.ID], error) {
+	chainIDs := set.Set[ids.ID]{}
+	for _, chain := range strings.Split(v.GetString(ConsensusPollAuditChainIDsKey), ",") {
+		if chain == "" {
+			continue
+		}
+		chainID, err := ids.FromString(chain)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse chainID %q: %w", chain, err)
+		}
+		chainIDs.Add(chainID)
+	}
+	return chainIDs, nil
+}
+
 func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, error) {
 	var (
 		configBytes []byte
@@ -1271,6 +1286,16 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	nodeConfig.UseCurrentHeight = v.GetBool(ProposerVMUseCurrentHeightKey)
 
 	var err error
+	// Poll auditing
+	nodeConfig.PollAuditChainIDs, err = getPollAuditChainIDs(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+	nodeConfig.PollAuditMaxRecords = v.GetUint64(ConsensusPollAuditMaxRecordsKey)
+	if nodeConfig.PollAuditChainIDs.Len() > 0 && nodeConfig.PollAuditMaxRecords == 0 {
+		return node.Config{}, fmt.Errorf("%q must be > 0", ConsensusPollAuditMaxRecordsKey)
+	}
+
 	// Logging
 	nodeConfig.LoggingConfig, err = getLoggingConfig(v)
 	if err != nil {
@@ -147,6 +147,8 @@ const (
 	AppGossipNonValidatorSizeKey                       = "consensus-app-gossip-non-validator-size"
 	AppGossipPeerSizeKey                               = "consensus-app-gossip-peer-size"
 	ConsensusShutdownTimeoutKey                        = "consensus-shutdown-timeout"
+	ConsensusPollAuditChainIDsKey                      = "consensus-poll-audit-chain-ids"
+	ConsensusPollAuditMaxRecordsKey                    = "consensus-poll-audit-max-records"
 	ProposerVMUseCurrentHeightKey                      = "proposervm-use-current-height"
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
@@ -8,6 +8,8 @@ import (
 
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/vms/freezefx"
+	"github.com/lasthyphen/dijetsnodego/vms/htlcfx"
 	"github.com/lasthyphen/dijetsnodego/vms/nftfx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/genesis"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
@@ -75,5 +77,7 @@ func GetVMAliases() map[ids.ID][]string {
 		secp256k1fx.ID:         {"secp256k1fx"},
 		nftfx.ID:               {"nftfx"},
 		propertyfx.ID:          {"propertyfx"},
+		htlcfx.ID:              {"htlcfx"},
+		freezefx.ID:            {"freezefx"},
 	}
 }
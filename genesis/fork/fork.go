@@ -0,0 +1,369 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package fork builds the genesis of a local network that starts from the
+// balances of an existing network, validated by newly generated nodes.
+//
+// Only DJTX balances are forked. DJTX owned by a single address is allocated in
+// the genesis. DJTX owned by multiple addresses is allocated to the funding key
+// and must be sent to its owners once the network starts. Other assets can't
+// be forked, because their asset IDs are derived from the X-chain txs that
+// created them. Subnets and their chains are re-created from their genesis once
+// the network starts, so the state of the subnet chains and of the C-chain
+// isn't forked.
+package fork
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/lasthyphen/dijetsnodego/genesis"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/staking"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/stakeable"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+
+	safemath "github.com/lasthyphen/dijetsnodego/utils/math"
+)
+
+var (
+	errStandardNetworkID = errors.New("network ID of a forked network can't be a standard network ID")
+	errNoNodes           = errors.New("forked network must have at least one node")
+	errNoStake           = errors.New("stake amount must be positive")
+	errInvalidCert       = errors.New("invalid staking certificate")
+	errUnsupportedUTXOs  = errors.New("state includes UTXOs that can't be forked")
+)
+
+// Config describes the network that is forked off.
+type Config struct {
+	// NetworkID of the forked network
+	NetworkID uint32
+	// NumNodes is the number of validators that are generated
+	NumNodes int
+	// StakeAmount is the amount of DJTX staked by each generated validator
+	StakeAmount uint64
+	// DelegationFee of each generated validator
+	DelegationFee uint32
+	// StartTime of the forked network
+	StartTime time.Time
+	// InitialStakeDuration of the generated validators
+	InitialStakeDuration time.Duration
+	// CChainGenesis of the forked network. The C-chain state isn't forked.
+	CChainGenesis string
+	// Message included in the genesis
+	Message string
+	// SkipUnsupportedUTXOs drops the UTXOs that can't be forked rather than
+	// failing the fork.
+	SkipUnsupportedUTXOs bool
+}
+
+// Node is a validator of the forked network.
+type Node struct {
+	NodeID ids.NodeID
+	// PEM encoded staking certificate and key
+	StakingCert []byte
+	StakingKey  []byte
+}
+
+// Chain is a blockchain of the forked network that must be re-created after
+// the network starts. The chain starts from its genesis, so its state isn't
+// forked.
+type Chain struct {
+	ChainID     ids.ID   `json:"chainID"`
+	Name        string   `json:"name"`
+	VMID        ids.ID   `json:"vmID"`
+	FxIDs       []ids.ID `json:"fxIDs"`
+	GenesisData []byte   `json:"genesisData"`
+}
+
+// Subnet is a subnet of the forked network that must be re-created after the
+// network starts, because subnets can't be created in the genesis.
+type Subnet struct {
+	SubnetID ids.ID                    `json:"subnetID"`
+	Owner    *secp256k1fx.OutputOwners `json:"owner"`
+	Chains   []*Chain                  `json:"chains"`
+}
+
+// Output is DJTX of the forked network that must be sent from the funding key
+// to its owners after the network starts, because the genesis can only
+// allocate DJTX to a single address.
+type Output struct {
+	Amount uint64                    `json:"amount"`
+	Owners *secp256k1fx.OutputOwners `json:"owners"`
+}
+
+// Network is the result of a fork.
+type Network struct {
+	Genesis *genesis.Config
+	Nodes   []*Node
+	// FundingKey owns the stake of the generated validators and receives
+	// their rewards. It also holds the DJTX of [Outputs] on the X-chain.
+	FundingKey *crypto.PrivateKeySECP256K1R
+	Subnets    []*Subnet
+	// Outputs owned by multiple addresses
+	Outputs []*Output
+	// SkippedUTXOs is the number of UTXOs that weren't carried over, because
+	// they hold an asset other than DJTX or an output other than a transfer
+	// output. Only non-zero if [Config.SkipUnsupportedUTXOs] is set.
+	SkippedUTXOs int
+}
+
+// Builder accumulates the state of the network that is forked.
+type Builder struct {
+	djtxAssetID ids.ID
+	// unlocked X-chain DJTX per address
+	unlocked map[ids.ShortID]uint64
+	// P-chain DJTX per address and locktime
+	locked map[ids.ShortID]map[uint64]uint64
+	// DJTX owned by multiple addresses, with the locktime set in the owners
+	outputs []*Output
+	subnets []*Subnet
+
+	// number of UTXOs that can't be forked per asset
+	unsupportedUTXOs map[ids.ID]int
+}
+
+func NewBuilder(djtxAssetID ids.ID) *Builder {
+	return &Builder{
+		djtxAssetID:      djtxAssetID,
+		unlocked:         make(map[ids.ShortID]uint64),
+		locked:           make(map[ids.ShortID]map[uint64]uint64),
+		unsupportedUTXOs: make(map[ids.ID]int),
+	}
+}
+
+// AddPChainOutput carries over DJTX held on the P-chain, including staked
+// DJTX, which is returned to its owner.
+func (b *Builder) AddPChainOutput(assetID ids.ID, out verify.State) error {
+	var locktime uint64
+	if lockOut, ok := out.(*stakeable.LockOut); ok {
+		locktime = lockOut.Locktime
+		out = lockOut.TransferableOut
+	}
+	transferOut, ok := out.(*secp256k1fx.TransferOutput)
+	if !ok || assetID != b.djtxAssetID {
+		b.unsupportedUTXOs[assetID]++
+		return nil
+	}
+	locktime = safemath.Max(locktime, transferOut.Locktime)
+	return b.addLocked(&transferOut.OutputOwners, transferOut.Amt, locktime)
+}
+
+// AddXChainUTXO carries over DJTX held on the X-chain. Other assets can't be
+// carried over. DJTX that is locked at [now] is moved to the P-chain, because
+// the X-chain genesis can't lock DJTX.
+func (b *Builder) AddXChainUTXO(utxo *djtx.UTXO, now uint64) error {
+	assetID := utxo.AssetID()
+	transferOut, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+	if !ok || assetID != b.djtxAssetID {
+		b.unsupportedUTXOs[assetID]++
+		return nil
+	}
+	if transferOut.Locktime > now {
+		return b.addLocked(&transferOut.OutputOwners, transferOut.Amt, transferOut.Locktime)
+	}
+
+	addr, ok := singleOwner(&transferOut.OutputOwners)
+	if !ok {
+		b.addOutput(&transferOut.OutputOwners, transferOut.Amt, 0)
+		return nil
+	}
+	balance, err := safemath.Add64(b.unlocked[addr], transferOut.Amt)
+	if err != nil {
+		return err
+	}
+	b.unlocked[addr] = balance
+	return nil
+}
+
+// AddSubnet records a subnet that must be re-created.
+func (b *Builder) AddSubnet(subnet *Subnet) {
+	b.subnets = append(b.subnets, subnet)
+}
+
+func (b *Builder) addLocked(owners *secp256k1fx.OutputOwners, amount, locktime uint64) error {
+	addr, ok := singleOwner(owners)
+	if !ok {
+		b.addOutput(owners, amount, locktime)
+		return nil
+	}
+	amounts, ok := b.locked[addr]
+	if !ok {
+		amounts = make(map[uint64]uint64)
+		b.locked[addr] = amounts
+	}
+	balance, err := safemath.Add64(amounts[locktime], amount)
+	if err != nil {
+		return err
+	}
+	amounts[locktime] = balance
+	return nil
+}
+
+// addOutput records DJTX owned by multiple addresses. Stakeable locked DJTX is
+// re-created as DJTX that can't be spent before [locktime], because only the
+// genesis can allocate stakeable locked DJTX.
+func (b *Builder) addOutput(owners *secp256k1fx.OutputOwners, amount, locktime uint64) {
+	b.outputs = append(b.outputs, &Output{
+		Amount: amount,
+		Owners: &secp256k1fx.OutputOwners{
+			Locktime:  locktime,
+			Threshold: owners.Threshold,
+			Addrs:     slices.Clone(owners.Addrs),
+		},
+	})
+}
+
+// Build returns the forked network.
+func (b *Builder) Build(config *Config) (*Network, error) {
+	switch {
+	case config.NetworkID == constants.MainnetID,
+		config.NetworkID == constants.TahoeID,
+		config.NetworkID == constants.LocalID,
+		config.NetworkID == constants.UnitTestID:
+		return nil, fmt.Errorf("%w: %d", errStandardNetworkID, config.NetworkID)
+	case config.NumNodes <= 0:
+		return nil, errNoNodes
+	case config.StakeAmount == 0:
+		return nil, errNoStake
+	}
+
+	skippedUTXOs := 0
+	for _, numUTXOs := range b.unsupportedUTXOs {
+		skippedUTXOs += numUTXOs
+	}
+	if skippedUTXOs != 0 && !config.SkipUnsupportedUTXOs {
+		return nil, fmt.Errorf("%w: %d UTXOs of %d assets hold an asset other than DJTX or an output other than a transfer output",
+			errUnsupportedUTXOs,
+			skippedUTXOs,
+			len(b.unsupportedUTXOs),
+		)
+	}
+
+	factory := crypto.FactorySECP256K1R{}
+	fundingKeyIntf, err := factory.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	fundingKey := fundingKeyIntf.(*crypto.PrivateKeySECP256K1R)
+	fundingAddr := fundingKey.PublicKey().Address()
+
+	totalStake, err := safemath.Mul64(config.StakeAmount, uint64(config.NumNodes))
+	if err != nil {
+		return nil, err
+	}
+	var outputsAmount uint64
+	for _, output := range b.outputs {
+		outputsAmount, err = safemath.Add64(outputsAmount, output.Amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	addrs := make([]ids.ShortID, 0, len(b.unlocked)+len(b.locked))
+	addrs = append(addrs, maps.Keys(b.unlocked)...)
+	for addr := range b.locked {
+		if _, ok := b.unlocked[addr]; !ok {
+			addrs = append(addrs, addr)
+		}
+	}
+	slices.SortFunc(addrs, func(a, b ids.ShortID) bool {
+		return a.Less(b)
+	})
+
+	allocations := make([]genesis.Allocation, 0, len(addrs)+1)
+	for _, addr := range addrs {
+		allocation := genesis.Allocation{
+			DJTXAddr:      addr,
+			InitialAmount: b.unlocked[addr],
+		}
+		amounts := b.locked[addr]
+		locktimes := maps.Keys(amounts)
+		slices.Sort(locktimes)
+		for _, locktime := range locktimes {
+			allocation.UnlockSchedule = append(allocation.UnlockSchedule, genesis.LockedAmount{
+				Amount:   amounts[locktime],
+				Locktime: locktime,
+			})
+		}
+		allocations = append(allocations, allocation)
+	}
+	allocations = append(allocations, genesis.Allocation{
+		DJTXAddr:      fundingAddr,
+		InitialAmount: outputsAmount,
+		UnlockSchedule: []genesis.LockedAmount{{
+			Amount: totalStake,
+		}},
+	})
+
+	nodes := make([]*Node, config.NumNodes)
+	stakers := make([]genesis.Staker, config.NumNodes)
+	for i := range nodes {
+		node, err := newNode()
+		if err != nil {
+			return nil, err
+		}
+		nodes[i] = node
+		stakers[i] = genesis.Staker{
+			NodeID:        node.NodeID,
+			RewardAddress: fundingAddr,
+			DelegationFee: config.DelegationFee,
+		}
+	}
+
+	return &Network{
+		Genesis: &genesis.Config{
+			NetworkID:            config.NetworkID,
+			Allocations:          allocations,
+			StartTime:            uint64(config.StartTime.Unix()),
+			InitialStakeDuration: uint64(config.InitialStakeDuration / time.Second),
+			InitialStakedFunds:   []ids.ShortID{fundingAddr},
+			InitialStakers:       stakers,
+			CChainGenesis:        config.CChainGenesis,
+			Message:              config.Message,
+		},
+		Nodes:        nodes,
+		FundingKey:   fundingKey,
+		Subnets:      b.subnets,
+		Outputs:      b.outputs,
+		SkippedUTXOs: skippedUTXOs,
+	}, nil
+}
+
+func newNode() (*Node, error) {
+	certBytes, keyBytes, err := staking.NewCertAndKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(certBytes)
+	if block == nil {
+		return nil, errInvalidCert
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &Node{
+		NodeID:      ids.NodeIDFromCert(cert),
+		StakingCert: certBytes,
+		StakingKey:  keyBytes,
+	}, nil
+}
+
+// singleOwner returns the address that can spend [owners] on its own.
+func singleOwner(owners *secp256k1fx.OutputOwners) (ids.ShortID, bool) {
+	if owners.Threshold != 1 || len(owners.Addrs) != 1 {
+		return ids.ShortEmpty, false
+	}
+	return owners.Addrs[0], true
+}
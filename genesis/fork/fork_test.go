@@ -0,0 +1,163 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fork
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/genesis"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/stakeable"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func newTransferOutput(amount, locktime uint64, addrs ...ids.ShortID) *secp256k1fx.TransferOutput {
+	return &secp256k1fx.TransferOutput{
+		Amt: amount,
+		OutputOwners: secp256k1fx.OutputOwners{
+			Locktime:  locktime,
+			Threshold: 1,
+			Addrs:     addrs,
+		},
+	}
+}
+
+func newUTXO(assetID ids.ID, out *secp256k1fx.TransferOutput) *djtx.UTXO {
+	return &djtx.UTXO{
+		UTXOID: djtx.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: djtx.Asset{ID: assetID},
+		Out:   out,
+	}
+}
+
+func TestBuild(t *testing.T) {
+	require := require.New(t)
+
+	djtxAssetID := ids.GenerateTestID()
+	otherAssetID := ids.GenerateTestID()
+	now := time.Now()
+	nowUnix := uint64(now.Unix())
+
+	addr0 := ids.GenerateTestShortID()
+	addr1 := ids.GenerateTestShortID()
+	addr2 := ids.GenerateTestShortID()
+
+	b := NewBuilder(djtxAssetID)
+
+	// Unlocked X-chain DJTX
+	require.NoError(b.AddXChainUTXO(newUTXO(djtxAssetID, newTransferOutput(1000, 0, addr0)), nowUnix))
+	require.NoError(b.AddXChainUTXO(newUTXO(djtxAssetID, newTransferOutput(500, nowUnix-1, addr0)), nowUnix))
+	// Locked X-chain DJTX is moved to the P-chain
+	require.NoError(b.AddXChainUTXO(newUTXO(djtxAssetID, newTransferOutput(200, nowUnix+100, addr1)), nowUnix))
+	// Other assets can't be forked
+	require.NoError(b.AddXChainUTXO(newUTXO(otherAssetID, newTransferOutput(300, 0, addr0)), nowUnix))
+	// Multisig outputs are funded by the funding key
+	require.NoError(b.AddXChainUTXO(newUTXO(djtxAssetID, newTransferOutput(400, 0, addr0, addr1)), nowUnix))
+
+	// Stakeable locked P-chain DJTX
+	require.NoError(b.AddPChainOutput(djtxAssetID, &stakeable.LockOut{
+		Locktime:        nowUnix + 100,
+		TransferableOut: newTransferOutput(50, 0, addr1),
+	}))
+	require.NoError(b.AddPChainOutput(djtxAssetID, newTransferOutput(25, 0, addr2)))
+	require.NoError(b.AddPChainOutput(djtxAssetID, &stakeable.LockOut{
+		Locktime:        nowUnix + 100,
+		TransferableOut: newTransferOutput(75, 0, addr1, addr2),
+	}))
+
+	b.AddSubnet(&Subnet{
+		SubnetID: ids.GenerateTestID(),
+	})
+
+	config := &Config{
+		NetworkID:            54321,
+		NumNodes:             3,
+		StakeAmount:          2000,
+		DelegationFee:        20000,
+		StartTime:            now.Add(-time.Hour),
+		InitialStakeDuration: 24 * time.Hour,
+		CChainGenesis:        genesis.LocalConfig.CChainGenesis,
+		Message:              "fork",
+	}
+	_, err := b.Build(config)
+	require.True(errors.Is(err, errUnsupportedUTXOs))
+
+	config.SkipUnsupportedUTXOs = true
+	network, err := b.Build(config)
+	require.NoError(err)
+
+	require.Len(network.Nodes, 3)
+	require.Len(network.Subnets, 1)
+	require.Equal(1, network.SkippedUTXOs)
+	require.Equal([]*Output{
+		{
+			Amount: 400,
+			Owners: &secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr0, addr1},
+			},
+		},
+		{
+			Amount: 75,
+			Owners: &secp256k1fx.OutputOwners{
+				Locktime:  nowUnix + 100,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr1, addr2},
+			},
+		},
+	}, network.Outputs)
+
+	allocations := make(map[ids.ShortID]genesis.Allocation)
+	for _, allocation := range network.Genesis.Allocations {
+		allocations[allocation.DJTXAddr] = allocation
+	}
+	require.Len(allocations, 4)
+
+	require.EqualValues(1500, allocations[addr0].InitialAmount)
+	require.Empty(allocations[addr0].UnlockSchedule)
+
+	require.Zero(allocations[addr1].InitialAmount)
+	require.Equal([]genesis.LockedAmount{{
+		Amount:   250,
+		Locktime: nowUnix + 100,
+	}}, allocations[addr1].UnlockSchedule)
+
+	require.Equal([]genesis.LockedAmount{{
+		Amount: 25,
+	}}, allocations[addr2].UnlockSchedule)
+
+	fundingAddr := network.FundingKey.PublicKey().Address()
+	require.EqualValues(475, allocations[fundingAddr].InitialAmount)
+	require.Equal([]genesis.LockedAmount{{
+		Amount: 6000,
+	}}, allocations[fundingAddr].UnlockSchedule)
+
+	// The forked genesis must be accepted by a node.
+	unparsedConfig, err := network.Genesis.Unparse()
+	require.NoError(err)
+	configBytes, err := json.Marshal(unparsedConfig)
+	require.NoError(err)
+	_, _, err = genesis.FromFlag(config.NetworkID, base64.StdEncoding.EncodeToString(configBytes))
+	require.NoError(err)
+}
+
+func TestBuildStandardNetworkID(t *testing.T) {
+	b := NewBuilder(ids.GenerateTestID())
+	_, err := b.Build(&Config{
+		NetworkID:   constants.MainnetID,
+		NumNodes:    1,
+		StakeAmount: 1,
+	})
+	require.True(t, errors.Is(err, errStandardNetworkID))
+}
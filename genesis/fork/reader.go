@@ -0,0 +1,244 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fork
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
+	"github.com/lasthyphen/dijetsnodego/database/versiondb"
+	"github.com/lasthyphen/dijetsnodego/genesis"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/vms/avm/fxs"
+	"github.com/lasthyphen/dijetsnodego/vms/avm/states"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/freezefx"
+	"github.com/lasthyphen/dijetsnodego/vms/htlcfx"
+	"github.com/lasthyphen/dijetsnodego/vms/nftfx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/config"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/metrics"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+	"github.com/lasthyphen/dijetsnodego/vms/propertyfx"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+
+	avmtxs "github.com/lasthyphen/dijetsnodego/vms/avm/txs"
+)
+
+var (
+	vmDBPrefix = []byte("vm")
+
+	errNoXChain = errors.New("X-chain not found")
+)
+
+// Read loads the state of the network whose node database is [db], as of the
+// last accepted block of the P-chain. The node must not be running.
+//
+// Atomic UTXOs that were exported but not imported yet aren't carried over.
+//
+// Returns the height of the last accepted P-chain block.
+func Read(db database.Database) (*Builder, uint64, error) {
+	vdrs := validators.NewManager()
+	vdrs.Add(constants.PrimaryNetworkID, validators.NewSet())
+
+	// Nothing is written to [db].
+	pChainDB := versiondb.New(chainDB(db, constants.PlatformChainID))
+	pState, err := state.New(
+		pChainDB,
+		nil,
+		prometheus.NewRegistry(),
+		&config.Config{
+			Validators:         vdrs,
+			WhitelistedSubnets: set.Set[ids.ID]{},
+		},
+		&config.ExecutionConfig{},
+		&snow.Context{Log: logging.NoLog{}},
+		metrics.Noop,
+		reward.NewCalculator(reward.Config{}),
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("couldn't load P-chain state: %w", err)
+	}
+
+	lastAccepted, _, err := pState.GetStatelessBlock(pState.GetLastAccepted())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	primaryChains, err := pState.GetChains(constants.PrimaryNetworkID)
+	if err != nil {
+		return nil, 0, err
+	}
+	var xChainTx *txs.Tx
+	for _, chainTx := range primaryChains {
+		if chainTx.Unsigned.(*txs.CreateChainTx).VMID == constants.AVMID {
+			xChainTx = chainTx
+			break
+		}
+	}
+	if xChainTx == nil {
+		return nil, 0, errNoXChain
+	}
+	djtxAssetID, err := genesis.DJTXAssetID(xChainTx.Unsigned.(*txs.CreateChainTx).GenesisData)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	b := NewBuilder(djtxAssetID)
+	if err := readPChain(b, pChainDB, pState); err != nil {
+		return nil, 0, err
+	}
+
+	now := uint64(pState.GetTimestamp().Unix())
+	if err := readXChain(b, versiondb.New(chainDB(db, xChainTx.ID())), now); err != nil {
+		return nil, 0, err
+	}
+	return b, lastAccepted.Height(), nil
+}
+
+func readPChain(b *Builder, db database.Database, s state.State) error {
+	iter := state.NewUTXOIterator(db)
+	defer iter.Release()
+
+	for iter.Next() {
+		utxo := &djtx.UTXO{}
+		if _, err := txs.GenesisCodec.Unmarshal(iter.Value(), utxo); err != nil {
+			return err
+		}
+		if err := b.AddPChainOutput(utxo.AssetID(), utxo.Out); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	// Staked DJTX is returned to its owners, because the stakers are replaced.
+	currentStakers, err := s.GetCurrentStakerIterator()
+	if err != nil {
+		return err
+	}
+	err = readStakes(b, s, currentStakers)
+	currentStakers.Release()
+	if err != nil {
+		return err
+	}
+
+	pendingStakers, err := s.GetPendingStakerIterator()
+	if err != nil {
+		return err
+	}
+	err = readStakes(b, s, pendingStakers)
+	pendingStakers.Release()
+	if err != nil {
+		return err
+	}
+
+	subnets, err := s.GetSubnets()
+	if err != nil {
+		return err
+	}
+	for _, subnetTx := range subnets {
+		subnetID := subnetTx.ID()
+		subnet := &Subnet{
+			SubnetID: subnetID,
+		}
+		subnetOwner, err := s.GetSubnetOwner(subnetID)
+		if err != nil {
+			return err
+		}
+		if owner, ok := subnetOwner.(*secp256k1fx.OutputOwners); ok {
+			subnet.Owner = owner
+		}
+
+		chains, err := s.GetChains(subnetID)
+		if err != nil {
+			return err
+		}
+		for _, chainTx := range chains {
+			chain := chainTx.Unsigned.(*txs.CreateChainTx)
+			subnet.Chains = append(subnet.Chains, &Chain{
+				ChainID:     chainTx.ID(),
+				Name:        chain.ChainName,
+				VMID:        chain.VMID,
+				FxIDs:       chain.FxIDs,
+				GenesisData: chain.GenesisData,
+			})
+		}
+		b.AddSubnet(subnet)
+	}
+	return nil
+}
+
+func readStakes(b *Builder, s state.State, stakers state.StakerIterator) error {
+	for stakers.Next() {
+		staker := stakers.Value()
+		if staker.SubnetID != constants.PrimaryNetworkID {
+			continue
+		}
+
+		stakerTx, _, err := s.GetTx(staker.TxID)
+		if err != nil {
+			return err
+		}
+		permissionlessStaker, ok := stakerTx.Unsigned.(txs.PermissionlessStaker)
+		if !ok {
+			continue
+		}
+		for _, out := range permissionlessStaker.Stake() {
+			if err := b.AddPChainOutput(out.AssetID(), out.Out); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readXChain(b *Builder, db database.Database, now uint64) error {
+	parser, err := avmtxs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+		&htlcfx.Fx{},
+		&freezefx.Fx{},
+	})
+	if err != nil {
+		return err
+	}
+	xState, err := states.New(db, parser, prometheus.NewRegistry())
+	if err != nil {
+		return fmt.Errorf("couldn't load X-chain state: %w", err)
+	}
+
+	iter := xState.UTXOs()
+	defer iter.Release()
+
+	codec := parser.Codec()
+	for iter.Next() {
+		utxo := &djtx.UTXO{}
+		if _, err := codec.Unmarshal(iter.Value(), utxo); err != nil {
+			return err
+		}
+		if err := b.AddXChainUTXO(utxo, now); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// chainDB returns the database of the VM of [chainID] in the node database
+// [db].
+func chainDB(db database.Database, chainID ids.ID) database.Database {
+	return prefixdb.New(vmDBPrefix, prefixdb.New(chainID[:], db))
+}
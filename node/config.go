@@ -189,6 +189,11 @@ type Config struct {
 	// Gossip a container in the accepted frontier every [ConsensusGossipFrequency]
 	ConsensusGossipFrequency time.Duration `json:"consensusGossipFreq"`
 
+	// Chains whose consensus polls are recorded in an on-disk audit log
+	PollAuditChainIDs set.Set[ids.ID] `json:"pollAuditChainIDs"`
+	// Max number of poll records kept per audited chain
+	PollAuditMaxRecords uint64 `json:"pollAuditMaxRecords"`
+
 	// Subnet Whitelist
 	WhitelistedSubnets set.Set[ids.ID] `json:"whitelistedSubnets"`
 
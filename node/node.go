@@ -73,6 +73,8 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 	"github.com/lasthyphen/dijetsnodego/version"
 	"github.com/lasthyphen/dijetsnodego/vms/avm"
+	"github.com/lasthyphen/dijetsnodego/vms/freezefx"
+	"github.com/lasthyphen/dijetsnodego/vms/htlcfx"
 	"github.com/lasthyphen/dijetsnodego/vms/nftfx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/config"
@@ -717,6 +719,8 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 		TracingEnabled:                          n.Config.TraceConfig.Enabled,
 		Tracer:                                  n.tracer,
 		ChainDataDir:                            n.Config.ChainDataDir,
+		PollAuditChainIDs:                       n.Config.PollAuditChainIDs,
+		PollAuditMaxRecords:                     n.Config.PollAuditMaxRecords,
 	})
 
 	// Notify the API server when new chains are created
@@ -775,6 +779,12 @@ func (n *Node) initVMs() error {
 				ApricotPhase3Time:               version.GetApricotPhase3Time(n.Config.NetworkID),
 				ApricotPhase5Time:               version.GetApricotPhase5Time(n.Config.NetworkID),
 				BanffTime:                       version.GetBanffTime(n.Config.NetworkID),
+				StateRootTime:                   version.GetStateRootTime(n.Config.NetworkID),
+				ExpiryTime:                      version.GetExpiryTime(n.Config.NetworkID),
+				SubnetOwnershipTransferTime:     version.GetSubnetOwnershipTransferTime(n.Config.NetworkID),
+				SubnetValidatorModificationTime: version.GetSubnetValidatorModificationTime(n.Config.NetworkID),
+				BlockchainModificationTime:      version.GetBlockchainModificationTime(n.Config.NetworkID),
+				RestakeTime:                     version.GetRestakeTime(n.Config.NetworkID),
 				MinPercentConnectedStakeHealthy: n.Config.MinPercentConnectedStakeHealthy,
 				UseCurrentHeight:                n.Config.UseCurrentHeight,
 			},
@@ -782,11 +792,15 @@ func (n *Node) initVMs() error {
 		vmRegisterer.Register(context.TODO(), constants.AVMID, &avm.Factory{
 			TxFee:            n.Config.TxFee,
 			CreateAssetTxFee: n.Config.CreateAssetTxFee,
+			HTLCFxTime:       version.GetHTLCFxTime(n.Config.NetworkID),
+			FreezeFxTime:     version.GetFreezeFxTime(n.Config.NetworkID),
 		}),
 		vmRegisterer.Register(context.TODO(), constants.EVMID, &coreth.Factory{}),
 		n.Config.VMManager.RegisterFactory(context.TODO(), secp256k1fx.ID, &secp256k1fx.Factory{}),
 		n.Config.VMManager.RegisterFactory(context.TODO(), nftfx.ID, &nftfx.Factory{}),
 		n.Config.VMManager.RegisterFactory(context.TODO(), propertyfx.ID, &propertyfx.Factory{}),
+		n.Config.VMManager.RegisterFactory(context.TODO(), htlcfx.ID, &htlcfx.Factory{}),
+		n.Config.VMManager.RegisterFactory(context.TODO(), freezefx.ID, &freezefx.Factory{}),
 	)
 	if errs.Errored() {
 		return errs.Err
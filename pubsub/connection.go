@@ -175,6 +175,8 @@ func (c *connection) readMessage() error {
 		c.handleNewSet(cmd.NewSet)
 	case cmd.AddAddresses != nil:
 		err = c.handleAddAddresses(cmd.AddAddresses)
+	case cmd.AddIDs != nil:
+		err = c.handleAddIDs(cmd.AddIDs)
 	default:
 		err = ErrInvalidCommand
 	}
@@ -213,3 +215,15 @@ func (c *connection) handleAddAddresses(cmd *AddAddresses) error {
 	c.s.subscribedConnections.Add(c)
 	return nil
 }
+
+func (c *connection) handleAddIDs(cmd *AddIDs) error {
+	if err := cmd.parseIDs(); err != nil {
+		return fmt.Errorf("id parse failed %w", err)
+	}
+	err := c.fp.Add(cmd.idBytes...)
+	if err != nil {
+		return fmt.Errorf("id append failed %w", err)
+	}
+	c.s.subscribedConnections.Add(c)
+	return nil
+}
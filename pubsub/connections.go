@@ -26,6 +26,13 @@ func (c *connections) Conns() []Filter {
 	return append([]Filter{}, c.connsList...)
 }
 
+func (c *connections) Len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return len(c.connsList)
+}
+
 func (c *connections) Remove(conn *connection) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -0,0 +1,26 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+)
+
+func TestNumSubscribers(t *testing.T) {
+	require := require.New(t)
+
+	s := New(logging.NoLog{})
+	require.Zero(s.NumSubscribers())
+
+	conn := &connection{s: s}
+	s.subscribedConnections.Add(conn)
+	require.Equal(1, s.NumSubscribers())
+
+	s.subscribedConnections.Remove(conn)
+	require.Zero(s.NumSubscribers())
+}
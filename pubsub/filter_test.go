@@ -38,6 +38,27 @@ func TestAddAddressesParseAddresses(t *testing.T) {
 	require.Equal(addrID[:], msg.addressIds[0])
 }
 
+func TestAddIDsParseIDs(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+
+	msg := &AddIDs{IDs: []string{
+		subnetID.String(),
+		nodeID.String(),
+	}}
+
+	err := msg.parseIDs()
+	require.NoError(err)
+
+	require.Equal([][]byte{subnetID[:], nodeID[:]}, msg.idBytes)
+
+	msg = &AddIDs{IDs: []string{"NodeID-invalid"}}
+	err = msg.parseIDs()
+	require.Error(err)
+}
+
 func TestFilterParamUpdateMulti(t *testing.T) {
 	fp := NewFilterParam()
 
@@ -4,7 +4,10 @@
 package pubsub
 
 import (
+	"strings"
+
 	"github.com/lasthyphen/dijetsnodego/api"
+	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/utils/formatting/address"
 	"github.com/lasthyphen/dijetsnodego/utils/json"
 )
@@ -28,11 +31,21 @@ type AddAddresses struct {
 	addressIds [][]byte
 }
 
+// AddIDs command to add IDs, such as subnet IDs, or node IDs prefixed with
+// "NodeID-"
+type AddIDs struct {
+	IDs []string `json:"ids"`
+
+	// idBytes array of IDs, kept as a [][]byte for use in the bloom filter
+	idBytes [][]byte
+}
+
 // Command execution command
 type Command struct {
 	NewBloom     *NewBloom     `json:"newBloom,omitempty"`
 	NewSet       *NewSet       `json:"newSet,omitempty"`
 	AddAddresses *AddAddresses `json:"addAddresses,omitempty"`
+	AddIDs       *AddIDs       `json:"addIDs,omitempty"`
 }
 
 func (c *Command) String() string {
@@ -43,6 +56,8 @@ func (c *Command) String() string {
 		return "newSet"
 	case c.AddAddresses != nil:
 		return "addAddresses"
+	case c.AddIDs != nil:
+		return "addIDs"
 	default:
 		return "unknown"
 	}
@@ -67,3 +82,25 @@ func (c *AddAddresses) parseAddresses() error {
 	}
 	return nil
 }
+
+// parseIDs converts the IDs to their byte format.
+func (c *AddIDs) parseIDs() error {
+	c.idBytes = make([][]byte, len(c.IDs))
+	for i, idStr := range c.IDs {
+		if strings.HasPrefix(idStr, ids.NodeIDPrefix) {
+			nodeID, err := ids.NodeIDFromString(idStr)
+			if err != nil {
+				return err
+			}
+			c.idBytes[i] = nodeID.Bytes()
+			continue
+		}
+
+		id, err := ids.FromString(idStr)
+		if err != nil {
+			return err
+		}
+		c.idBytes[i] = id[:]
+	}
+	return nil
+}
@@ -93,6 +93,12 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.addConnection(conn)
 }
 
+// NumSubscribers returns the number of connections that have activated
+// subscriptions. Publishing is a no-op if it is 0.
+func (s *Server) NumSubscribers() int {
+	return s.subscribedConnections.Len()
+}
+
 func (s *Server) Publish(parser Filterer) {
 	conns := s.subscribedConnections.Conns()
 	toNotify, msg := parser.Filter(conns)
@@ -74,4 +74,8 @@ type Consensus interface {
 	// finalized. Note, it is possible that after returning finalized, a new
 	// decision may be added such that this instance is no longer finalized.
 	Finalized() bool
+
+	// ProcessingGraph returns a snapshot of the processing vertices and their
+	// undecided transactions.
+	ProcessingGraph(context.Context) (*ProcessingGraph, error)
 }
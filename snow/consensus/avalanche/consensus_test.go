@@ -6,6 +6,7 @@ package avalanche
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"path"
 	"reflect"
@@ -15,6 +16,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/snow"
 	"github.com/lasthyphen/dijetsnodego/snow/choices"
@@ -53,6 +56,7 @@ var testFuncs = []testFunc{
 	ErrorOnParentVtxRejectTest,
 	ErrorOnTransitiveVtxRejectTest,
 	SilenceTransactionVertexEventsTest,
+	ProcessingGraphTest,
 }
 
 func runConsensusTests(t *testing.T, factory Factory) {
@@ -256,6 +260,115 @@ func NumProcessingTest(t *testing.T, factory Factory) {
 	}
 }
 
+func ProcessingGraphTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	avl := factory.New()
+
+	params := Parameters{
+		Parameters: snowball.Parameters{
+			K:                     2,
+			Alpha:                 2,
+			BetaVirtuous:          3,
+			BetaRogue:             3,
+			ConcurrentRepolls:     1,
+			OptimalProcessing:     1,
+			MaxOutstandingItems:   1,
+			MaxItemProcessingTime: 1,
+		},
+		Parents:   2,
+		BatchSize: 1,
+	}
+	seedVertices := []Vertex{
+		&TestVertex{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Accepted,
+			},
+		},
+	}
+
+	ctx := snow.DefaultConsensusContextTest()
+	require.NoError(avl.Initialize(context.Background(), ctx, params, seedVertices))
+
+	utxos := []ids.ID{ids.GenerateTestID()}
+	tx0 := &snowstorm.TestTx{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		InputIDsV: utxos,
+	}
+	tx1 := &snowstorm.TestTx{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		InputIDsV: utxos,
+	}
+	vtx0 := &TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: seedVertices,
+		HeightV:  1,
+		TxsV:     []snowstorm.Tx{tx0},
+	}
+	vtx1 := &TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: seedVertices,
+		HeightV:  1,
+		TxsV:     []snowstorm.Tx{tx1},
+	}
+	require.NoError(avl.Add(context.Background(), vtx0))
+	require.NoError(avl.Add(context.Background(), vtx1))
+
+	votes := ids.UniqueBag{}
+	votes.Add(0, vtx0.IDV)
+	votes.Add(1, vtx0.IDV)
+	require.NoError(avl.RecordPoll(context.Background(), votes))
+
+	graph, err := avl.ProcessingGraph(context.Background())
+	require.NoError(err)
+	require.EqualValues(1, graph.PollNumber)
+	require.Len(graph.Vertices, 2)
+	require.Len(graph.Txs, 2)
+
+	vertices := make(map[ids.ID]ProcessingVertex)
+	for _, vtx := range graph.Vertices {
+		vertices[vtx.ID] = vtx
+	}
+	processingVtx0 := vertices[vtx0.IDV]
+	require.Equal([]ids.ID{seedVertices[0].ID()}, processingVtx0.ParentIDs)
+	require.Equal([]ids.ID{tx0.ID()}, processingVtx0.TxIDs)
+	require.EqualValues(1, processingVtx0.Height)
+	require.True(processingVtx0.Preferred)
+	require.Equal(1, processingVtx0.Confidence)
+	require.Equal(1, processingVtx0.NumSuccessfulPolls)
+
+	processingVtx1 := vertices[vtx1.IDV]
+	require.False(processingVtx1.Preferred)
+	require.Zero(processingVtx1.Confidence)
+
+	txs := make(map[ids.ID]ProcessingTx)
+	for _, tx := range graph.Txs {
+		txs[tx.ID] = tx
+	}
+	processingTx0 := txs[tx0.ID()]
+	require.Equal([]ids.ID{vtx0.IDV}, processingTx0.VertexIDs)
+	require.True(processingTx0.Preferred)
+	require.False(processingTx0.Virtuous)
+	require.Equal(1, processingTx0.Confidence)
+
+	dot := graph.DOT()
+	require.Contains(dot, fmt.Sprintf("%q -> \"decided\"", vtx0.IDV))
+	require.Contains(dot, fmt.Sprintf("%q -> \"decided\"", vtx1.IDV))
+}
+
 func AddTest(t *testing.T, factory Factory) {
 	avl := factory.New()
 
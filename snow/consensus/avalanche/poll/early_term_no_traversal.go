@@ -79,6 +79,11 @@ func (p *earlyTermNoTraversalPoll) Finished() bool {
 	return partialVotes.Len()+numPending < p.alpha
 }
 
+// Pending returns the validators that haven't responded to this poll
+func (p *earlyTermNoTraversalPoll) Pending() ids.NodeIDBag {
+	return p.polled
+}
+
 // Result returns the result of this poll
 func (p *earlyTermNoTraversalPoll) Result() ids.UniqueBag {
 	return p.votes
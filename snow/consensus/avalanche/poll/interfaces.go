@@ -17,6 +17,8 @@ type Set interface {
 	Add(requestID uint32, vdrs ids.NodeIDBag) bool
 	Vote(requestID uint32, vdr ids.NodeID, votes []ids.ID) []ids.UniqueBag
 	Len() int
+	// States returns the outstanding polls, in the order they were created.
+	States() []State
 }
 
 // Poll is an outstanding poll
@@ -25,6 +27,8 @@ type Poll interface {
 
 	Vote(vdr ids.NodeID, votes []ids.ID)
 	Finished() bool
+	// Pending returns the sampled validators that haven't responded yet.
+	Pending() ids.NodeIDBag
 	Result() ids.UniqueBag
 }
 
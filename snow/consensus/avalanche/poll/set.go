@@ -13,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/linkedhashmap"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/metric"
@@ -174,6 +175,29 @@ func (s *set) Len() int {
 	return s.polls.Len()
 }
 
+func (s *set) States() []State {
+	states := make([]State, 0, s.polls.Len())
+	iter := s.polls.NewIterator()
+	for iter.Next() {
+		poll := iter.Value().GetPoll()
+		result := poll.Result()
+		votes := make(map[ids.ID]int)
+		for _, id := range result.List() {
+			voters := result.GetSet(id)
+			votes[id] = voters.Len()
+		}
+		pending := poll.Pending()
+		pendingList := pending.List()
+		utils.Sort(pendingList)
+		states = append(states, State{
+			RequestID: iter.Key(),
+			Votes:     votes,
+			Pending:   pendingList,
+		})
+	}
+	return states
+}
+
 func (s *set) String() string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("current polls: (Size = %d)", s.polls.Len()))
@@ -209,6 +209,37 @@ func TestCreateAndFinishPollOutOfOrder_UnfinishedPollsGaps(t *testing.T) {
 	require.Equal(t, vtx3.String(), results[2].List()[0].String())
 }
 
+func TestSetStates(t *testing.T) {
+	require := require.New(t)
+
+	factory := NewNoEarlyTermFactory()
+	log := logging.NoLog{}
+	namespace := ""
+	registerer := prometheus.NewRegistry()
+	s := NewSet(factory, log, namespace, registerer)
+
+	vtxID := ids.ID{1}
+
+	vdr1 := ids.NodeID{1}
+	vdr2 := ids.NodeID{2} // k = 2
+
+	vdrs := ids.NodeIDBag{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+	)
+
+	require.True(s.Add(0, vdrs))
+	require.Empty(s.Vote(0, vdr1, []ids.ID{vtxID}))
+	require.Equal([]State{{
+		RequestID: 0,
+		Votes: map[ids.ID]int{
+			vtxID: 1,
+		},
+		Pending: []ids.NodeID{vdr2},
+	}}, s.States())
+}
+
 func TestSetString(t *testing.T) {
 	factory := NewNoEarlyTermFactory()
 	log := logging.NoLog{}
@@ -0,0 +1,17 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+// State is a snapshot of an outstanding poll.
+type State struct {
+	RequestID uint32 `json:"requestID"`
+	// Votes is the number of votes received for each vertex so far.
+	Votes map[ids.ID]int `json:"votes"`
+	// Pending are the sampled validators that haven't responded yet.
+	Pending []ids.NodeID `json:"pending"`
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avalanche
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/avalanche/poll"
+	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
+)
+
+var (
+	_ utils.Sortable[ProcessingVertex] = ProcessingVertex{}
+	_ utils.Sortable[ProcessingTx]     = ProcessingTx{}
+)
+
+// ProcessingVertex describes a vertex in the processing graph.
+type ProcessingVertex struct {
+	ID ids.ID `json:"id"`
+	// ParentIDs are the parents of the vertex, including the decided ones.
+	ParentIDs []ids.ID    `json:"parentIDs"`
+	Height    json.Uint64 `json:"height"`
+	TxIDs     []ids.ID    `json:"txIDs"`
+	// Preferred is true if the vertex is strongly preferred.
+	Preferred bool `json:"preferred"`
+	// Virtuous is true if the vertex is strongly virtuous.
+	Virtuous           bool `json:"virtuous"`
+	NumSuccessfulPolls int  `json:"numSuccessfulPolls"`
+	Confidence         int  `json:"confidence"`
+}
+
+func (v ProcessingVertex) Less(other ProcessingVertex) bool {
+	if v.Height != other.Height {
+		return v.Height < other.Height
+	}
+	return v.ID.Less(other.ID)
+}
+
+// ProcessingTx describes a transaction in the conflict graph.
+type ProcessingTx struct {
+	ID                 ids.ID   `json:"id"`
+	VertexIDs          []ids.ID `json:"vertexIDs"`
+	Preferred          bool     `json:"preferred"`
+	Virtuous           bool     `json:"virtuous"`
+	NumSuccessfulPolls int      `json:"numSuccessfulPolls"`
+	Confidence         int      `json:"confidence"`
+}
+
+func (t ProcessingTx) Less(other ProcessingTx) bool {
+	return t.ID.Less(other.ID)
+}
+
+// ProcessingGraph is a snapshot of the vertices and transactions that are
+// currently processing.
+type ProcessingGraph struct {
+	// PollNumber is the number of polls that have been applied.
+	PollNumber json.Uint64 `json:"pollNumber"`
+	// Vertices are the processing vertices, sorted by height.
+	Vertices []ProcessingVertex `json:"vertices"`
+	// Txs are the undecided transactions of the processing vertices.
+	Txs []ProcessingTx `json:"txs"`
+	// Orphans are the virtuous transactions that aren't in any preferred
+	// vertex.
+	Orphans []ids.ID `json:"orphans,omitempty"`
+
+	// The following fields are populated by the engine.
+	//
+	// NumPolls and Polls describe the outstanding network polls.
+	NumPolls int          `json:"numPolls"`
+	Polls    []poll.State `json:"polls,omitempty"`
+	// Pending are the vertices that are waiting for their dependencies to be
+	// issued before they can be added to consensus.
+	Pending []ids.ID `json:"pending,omitempty"`
+}
+
+func (g *ProcessingGraph) DOT() string {
+	processing := make(map[ids.ID]struct{}, len(g.Vertices))
+	for _, vtx := range g.Vertices {
+		processing[vtx.ID] = struct{}{}
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("digraph processing {\n")
+	sb.WriteString("\trankdir=BT;\n")
+	sb.WriteString("\tnode [shape=box];\n")
+	for _, vtx := range g.Vertices {
+		label := fmt.Sprintf(
+			"%s\nheight %d, %d txs\nconfidence %d, successful polls %d",
+			vtx.ID,
+			vtx.Height,
+			len(vtx.TxIDs),
+			vtx.Confidence,
+			vtx.NumSuccessfulPolls,
+		)
+		attrs := []string{
+			fmt.Sprintf("label=%q", label),
+		}
+		switch {
+		case vtx.Preferred:
+			attrs = append(attrs, "style=filled", "fillcolor=lightblue")
+		case !vtx.Virtuous:
+			attrs = append(attrs, "style=filled", "fillcolor=orange")
+		}
+		sb.WriteString(fmt.Sprintf("\t%q [%s];\n", vtx.ID, strings.Join(attrs, ", ")))
+	}
+	// Decided parents are drawn as a single node so that the boundary of the
+	// processing graph is visible.
+	sb.WriteString("\t\"decided\" [style=filled, fillcolor=green];\n")
+	for _, vtx := range g.Vertices {
+		hasDecidedParent := false
+		for _, parentID := range vtx.ParentIDs {
+			if _, ok := processing[parentID]; ok {
+				sb.WriteString(fmt.Sprintf("\t%q -> %q;\n", vtx.ID, parentID))
+			} else {
+				hasDecidedParent = true
+			}
+		}
+		if hasDecidedParent {
+			sb.WriteString(fmt.Sprintf("\t%q -> \"decided\";\n", vtx.ID))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow/choices"
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/metrics"
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowstorm"
+	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 )
 
@@ -283,6 +285,86 @@ func (ta *Topological) Finalized() bool {
 	return ta.cg.Finalized()
 }
 
+func (ta *Topological) ProcessingGraph(ctx context.Context) (*ProcessingGraph, error) {
+	var (
+		preferredTxs = ta.cg.Preferences()
+		virtuousTxs  = ta.cg.Virtuous()
+		vertices     = make([]ProcessingVertex, 0, len(ta.nodes))
+		txs          = make(map[ids.ID]*ProcessingTx)
+	)
+	for vtxID, txv := range ta.nodes {
+		vtx := txv.vtx
+		parents, err := vtx.Parents()
+		if err != nil {
+			return nil, err
+		}
+		height, err := vtx.Height()
+		if err != nil {
+			return nil, err
+		}
+		vtxTxs, err := vtx.Txs(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// The caches hold the strong preference and virtuousness of the
+		// vertices as of the last update of the frontiers.
+		numSuccessfulPolls, confidence, _ := ta.cg.Confidence(vtxID)
+		processingVtx := ProcessingVertex{
+			ID:                 vtxID,
+			ParentIDs:          make([]ids.ID, len(parents)),
+			Height:             json.Uint64(height),
+			TxIDs:              make([]ids.ID, len(vtxTxs)),
+			Preferred:          ta.preferenceCache[vtxID],
+			Virtuous:           ta.virtuousCache[vtxID],
+			NumSuccessfulPolls: numSuccessfulPolls,
+			Confidence:         confidence,
+		}
+		for i, parent := range parents {
+			processingVtx.ParentIDs[i] = parent.ID()
+		}
+		for i, tx := range vtxTxs {
+			txID := tx.ID()
+			processingVtx.TxIDs[i] = txID
+
+			processingTx, ok := txs[txID]
+			if ok {
+				processingTx.VertexIDs = append(processingTx.VertexIDs, vtxID)
+				continue
+			}
+			numSuccessfulPolls, confidence, processing := ta.cg.Confidence(txID)
+			if !processing {
+				continue
+			}
+			txs[txID] = &ProcessingTx{
+				ID:                 txID,
+				VertexIDs:          []ids.ID{vtxID},
+				Preferred:          preferredTxs.Contains(txID),
+				Virtuous:           virtuousTxs.Contains(txID),
+				NumSuccessfulPolls: numSuccessfulPolls,
+				Confidence:         confidence,
+			}
+		}
+		vertices = append(vertices, processingVtx)
+	}
+	utils.Sort(vertices)
+
+	processingTxs := make([]ProcessingTx, 0, len(txs))
+	for _, tx := range txs {
+		processingTxs = append(processingTxs, *tx)
+	}
+	utils.Sort(processingTxs)
+
+	orphans := ta.orphans.List()
+	utils.Sort(orphans)
+	return &ProcessingGraph{
+		PollNumber: json.Uint64(ta.pollNumber),
+		Vertices:   vertices,
+		Txs:        processingTxs,
+		Orphans:    orphans,
+	}, nil
+}
+
 // HealthCheck returns information about the consensus health.
 func (ta *Topological) HealthCheck(ctx context.Context) (interface{}, error) {
 	numOutstandingVtx := ta.Latency.NumProcessing()
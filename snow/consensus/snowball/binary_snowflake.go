@@ -57,6 +57,10 @@ func (sf *binarySnowflake) Finalized() bool {
 	return sf.finalized
 }
 
+func (sf *binarySnowflake) Confidence() int {
+	return sf.confidence
+}
+
 func (sf *binarySnowflake) String() string {
 	return fmt.Sprintf("SF(Confidence = %d, Finalized = %v, %s)",
 		sf.confidence,
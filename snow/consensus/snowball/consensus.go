@@ -40,6 +40,10 @@ type Consensus interface {
 
 	// Return whether a choice has been finalized
 	Finalized() bool
+
+	// Returns the number of consecutive successful polls that count towards
+	// finalizing a choice
+	Confidence() int
 }
 
 // NnarySnowball augments NnarySnowflake with a counter that tracks the total
@@ -71,6 +75,10 @@ type NnarySnowflake interface {
 
 	// Return whether a choice has been finalized
 	Finalized() bool
+
+	// Returns the number of consecutive successful polls that count towards
+	// finalizing a choice
+	Confidence() int
 }
 
 // NnarySlush is a slush instance deciding between an unbounded number of
@@ -116,6 +124,10 @@ type BinarySnowflake interface {
 
 	// Return whether a choice has been finalized
 	Finalized() bool
+
+	// Returns the number of consecutive successful polls that count towards
+	// finalizing a choice
+	Confidence() int
 }
 
 // BinarySlush is a slush instance deciding between two values. After performing
@@ -153,6 +165,10 @@ type UnarySnowball interface {
 	// Return whether a choice has been finalized
 	Finalized() bool
 
+	// Returns the number of consecutive successful polls that count towards
+	// finalizing a choice
+	Confidence() int
+
 	// Returns a new binary snowball instance with the agreement parameters
 	// transferred. Takes in the new beta value and the original choice
 	Extend(beta, originalPreference int) BinarySnowball
@@ -179,6 +195,10 @@ type UnarySnowflake interface {
 	// Return whether a choice has been finalized
 	Finalized() bool
 
+	// Returns the number of consecutive successful polls that count towards
+	// finalizing a choice
+	Confidence() int
+
 	// Returns a new binary snowball instance with the agreement parameters
 	// transferred. Takes in the new beta value and the original choice
 	Extend(beta, originalPreference int) BinarySnowflake
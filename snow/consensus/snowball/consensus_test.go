@@ -41,6 +41,10 @@ func (*Byzantine) Finalized() bool {
 	return true
 }
 
+func (*Byzantine) Confidence() int {
+	return 0
+}
+
 func (b *Byzantine) String() string {
 	return b.preference.String()
 }
@@ -73,6 +73,10 @@ func (sf *nnarySnowflake) Finalized() bool {
 	return sf.finalized
 }
 
+func (sf *nnarySnowflake) Confidence() int {
+	return sf.confidence
+}
+
 func (sf *nnarySnowflake) String() string {
 	return fmt.Sprintf("SF(Confidence = %d, Finalized = %v, %s)",
 		sf.confidence,
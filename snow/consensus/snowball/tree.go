@@ -90,6 +90,16 @@ func (t *Tree) RecordUnsuccessfulPoll() {
 	t.shouldReset = true
 }
 
+// Confidence returns the confidence of the root snowball instance, which is
+// the next instance to be finalized.
+func (t *Tree) Confidence() int {
+	if t.shouldReset {
+		// The reset is applied lazily by the next poll.
+		return 0
+	}
+	return t.node.Confidence()
+}
+
 func (t *Tree) String() string {
 	sb := strings.Builder{}
 
@@ -134,6 +144,9 @@ type node interface {
 	RecordPoll(votes ids.Bag, shouldReset bool) (newChild node, successful bool)
 	// Returns true if consensus has been reached on this node
 	Finalized() bool
+	// Returns the number of consecutive successful polls that count towards
+	// finalizing this node
+	Confidence() int
 
 	Printable() (string, []node)
 }
@@ -450,6 +463,10 @@ func (u *unaryNode) Finalized() bool {
 	return u.snowball.Finalized()
 }
 
+func (u *unaryNode) Confidence() int {
+	return u.snowball.Confidence()
+}
+
 func (u *unaryNode) Printable() (string, []node) {
 	s := fmt.Sprintf("%s Bits = [%d, %d)",
 		u.snowball, u.decidedPrefix, u.commonPrefix)
@@ -562,6 +579,10 @@ func (b *binaryNode) Finalized() bool {
 	return b.snowball.Finalized()
 }
 
+func (b *binaryNode) Confidence() int {
+	return b.snowball.Confidence()
+}
+
 func (b *binaryNode) Printable() (string, []node) {
 	s := fmt.Sprintf("%s Bit = %d", b.snowball, b.bit)
 	if b.children[0] == nil {
@@ -57,6 +57,33 @@ func TestSnowballSingleton(t *testing.T) {
 	require.True(tree.Finalized())
 }
 
+func TestSnowballConfidence(t *testing.T) {
+	require := require.New(t)
+
+	params := Parameters{
+		K: 1, Alpha: 1, BetaVirtuous: 3, BetaRogue: 5,
+	}
+	tree := Tree{}
+	tree.Initialize(params, Red)
+	tree.Add(Blue)
+	require.Zero(tree.Confidence())
+
+	oneRed := ids.Bag{}
+	oneRed.Add(Red)
+	require.True(tree.RecordPoll(oneRed))
+	require.True(tree.RecordPoll(oneRed))
+	require.Equal(2, tree.Confidence())
+
+	// The reset is reported before it is applied.
+	tree.RecordUnsuccessfulPoll()
+	require.Zero(tree.Confidence())
+
+	oneBlue := ids.Bag{}
+	oneBlue.Add(Blue)
+	require.True(tree.RecordPoll(oneBlue))
+	require.Equal(1, tree.Confidence())
+}
+
 func TestSnowballRecordUnsuccessfulPoll(t *testing.T) {
 	require := require.New(t)
 
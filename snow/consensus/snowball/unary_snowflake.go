@@ -41,6 +41,10 @@ func (sf *unarySnowflake) Finalized() bool {
 	return sf.finalized
 }
 
+func (sf *unarySnowflake) Confidence() int {
+	return sf.confidence
+}
+
 func (sf *unarySnowflake) Extend(beta int, choice int) BinarySnowflake {
 	return &binarySnowflake{
 		binarySlush: binarySlush{preference: choice},
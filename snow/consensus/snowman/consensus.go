@@ -56,4 +56,8 @@ type Consensus interface {
 	// finalized. Note, it is possible that after returning finalized, a new
 	// decision may be added such that this instance is no longer finalized.
 	Finalized() bool
+
+	// ProcessingTree returns a snapshot of the last accepted block and all the
+	// processing blocks.
+	ProcessingTree() *ProcessingTree
 }
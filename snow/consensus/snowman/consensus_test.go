@@ -6,6 +6,7 @@ package snowman
 import (
 	"context"
 	"errors"
+	"fmt"
 	"path"
 	"reflect"
 	"runtime"
@@ -66,6 +67,7 @@ var (
 		RandomizedConsistencyTest,
 		ErrorOnAddDecidedBlock,
 		ErrorOnAddDuplicateBlockID,
+		ProcessingTreeTest,
 	}
 )
 
@@ -251,6 +253,95 @@ func AddToNonTailTest(t *testing.T, factory Factory) {
 	}
 }
 
+// Make sure that the processing tree reports the processing blocks and the
+// preferred branch
+func ProcessingTreeTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	sm := factory.New()
+
+	ctx := snow.DefaultConsensusContextTest()
+	params := snowball.Parameters{
+		K:                     1,
+		Alpha:                 1,
+		BetaVirtuous:          3,
+		BetaRogue:             5,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(ctx, params, GenesisID, GenesisHeight, GenesisTimestamp))
+
+	firstBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	secondBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	thirdBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(3),
+			StatusV: choices.Processing,
+		},
+		ParentV: firstBlock.IDV,
+		HeightV: firstBlock.HeightV + 1,
+	}
+	require.NoError(sm.Add(context.Background(), firstBlock))
+	require.NoError(sm.Add(context.Background(), secondBlock))
+	require.NoError(sm.Add(context.Background(), thirdBlock))
+
+	votes := ids.Bag{}
+	votes.Add(thirdBlock.IDV)
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+
+	tree := sm.ProcessingTree()
+	require.Equal(GenesisID, tree.LastAcceptedID)
+	require.Equal(thirdBlock.IDV, tree.Preference)
+	require.EqualValues(1, tree.PollNumber)
+	require.Len(tree.Blocks, 4)
+
+	genesis := tree.Blocks[0]
+	require.Equal(GenesisID, genesis.ID)
+	require.True(genesis.Accepted)
+	require.NotNil(genesis.ChildPreference)
+	require.Equal(firstBlock.IDV, *genesis.ChildPreference)
+	require.Equal(1, genesis.ChildConfidence)
+
+	first := tree.Blocks[1]
+	require.Equal(firstBlock.IDV, first.ID)
+	require.Equal(GenesisID, first.ParentID)
+	require.True(first.Preferred)
+	require.NotNil(first.ChildPreference)
+	require.Equal(thirdBlock.IDV, *first.ChildPreference)
+
+	second := tree.Blocks[2]
+	require.Equal(secondBlock.IDV, second.ID)
+	require.False(second.Preferred)
+	require.Nil(second.ChildPreference)
+
+	third := tree.Blocks[3]
+	require.Equal(thirdBlock.IDV, third.ID)
+	require.Equal(firstBlock.IDV, third.ParentID)
+	require.EqualValues(2, third.Height)
+	require.True(third.Preferred)
+
+	dot := tree.DOT()
+	require.Contains(dot, "child confidence 1")
+	require.Contains(dot, fmt.Sprintf("%q -> %q", thirdBlock.IDV, firstBlock.IDV))
+	require.Contains(dot, fmt.Sprintf("%q -> %q", secondBlock.IDV, GenesisID))
+}
+
 // Make sure that adding a block that is detached from the rest of the tree
 // rejects the block
 func AddToUnknownTest(t *testing.T, factory Factory) {
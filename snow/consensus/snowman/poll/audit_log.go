@@ -0,0 +1,174 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+)
+
+var (
+	recordPrefix = []byte("record")
+	blockPrefix  = []byte("block")
+	nextKey      = []byte("next")
+
+	errZeroMaxRecords = errors.New("max records must be positive")
+)
+
+// AuditLog persists the records of the most recent polls in a bounded ring and
+// indexes them by the blocks they refer to.
+type AuditLog struct {
+	lock sync.RWMutex
+
+	db database.Database
+	// seq -> record
+	records database.Database
+	// blkID + seq -> nil
+	blocks database.Database
+
+	maxRecords uint64
+	// next is the sequence number of the next record
+	next uint64
+}
+
+// NewAuditLog returns an audit log that keeps the last [maxRecords] records in
+// [db].
+func NewAuditLog(db database.Database, maxRecords uint64) (*AuditLog, error) {
+	if maxRecords == 0 {
+		return nil, errZeroMaxRecords
+	}
+
+	next, err := database.GetUInt64(db, nextKey)
+	if err == database.ErrNotFound {
+		next, err = 0, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a := &AuditLog{
+		db:         db,
+		records:    prefixdb.New(recordPrefix, db),
+		blocks:     prefixdb.New(blockPrefix, db),
+		maxRecords: maxRecords,
+		next:       next,
+	}
+	// If [maxRecords] was reduced since the last run, the records that no
+	// longer fit in the ring are removed.
+	for seq := a.oldest(); seq > 0; seq-- {
+		deleted, err := a.delete(seq - 1)
+		if err != nil {
+			return nil, err
+		}
+		if !deleted {
+			break
+		}
+	}
+	return a, nil
+}
+
+// Put adds [record] to the log, evicting the oldest record if the log is full.
+func (a *AuditLog) Put(record *Record) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	seq := a.next
+	if seq >= a.maxRecords {
+		if _, err := a.delete(seq - a.maxRecords); err != nil {
+			return err
+		}
+	}
+
+	recordBytes, err := c.Marshal(codecVersion, record)
+	if err != nil {
+		return err
+	}
+	seqBytes := database.PackUInt64(seq)
+	errs := wrappers.Errs{}
+	errs.Add(a.records.Put(seqBytes, recordBytes))
+	for _, blkID := range record.BlockIDs() {
+		errs.Add(a.blocks.Put(blockKey(blkID, seq), nil))
+	}
+	errs.Add(database.PutUInt64(a.db, nextKey, seq+1))
+	if errs.Errored() {
+		return errs.Err
+	}
+
+	a.next = seq + 1
+	return nil
+}
+
+// GetByBlock returns the records of the polls that referred to [blkID], from
+// oldest to newest.
+func (a *AuditLog) GetByBlock(blkID ids.ID) ([]*Record, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	iter := a.blocks.NewIteratorWithPrefix(blkID[:])
+	defer iter.Release()
+
+	var records []*Record
+	for iter.Next() {
+		key := iter.Key()
+		seqBytes := key[len(blkID):]
+		recordBytes, err := a.records.Get(seqBytes)
+		if err == database.ErrNotFound {
+			// Ignore index entries left behind by a failed write.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		record := &Record{}
+		if _, err := c.Unmarshal(recordBytes, record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, iter.Error()
+}
+
+// oldest returns the sequence number of the oldest record that should be kept.
+func (a *AuditLog) oldest() uint64 {
+	if a.next < a.maxRecords {
+		return 0
+	}
+	return a.next - a.maxRecords
+}
+
+// delete removes the record with sequence number [seq] along with its index
+// entries. Returns false if the record didn't exist.
+func (a *AuditLog) delete(seq uint64) (bool, error) {
+	seqBytes := database.PackUInt64(seq)
+	recordBytes, err := a.records.Get(seqBytes)
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	record := &Record{}
+	if _, err := c.Unmarshal(recordBytes, record); err != nil {
+		return false, err
+	}
+	for _, blkID := range record.BlockIDs() {
+		if err := a.blocks.Delete(blockKey(blkID, seq)); err != nil {
+			return false, err
+		}
+	}
+	return true, a.records.Delete(seqBytes)
+}
+
+func blockKey(blkID ids.ID, seq uint64) []byte {
+	key := make([]byte, len(blkID)+wrappers.LongLen)
+	copy(key, blkID[:])
+	copy(key[len(blkID):], database.PackUInt64(seq))
+	return key
+}
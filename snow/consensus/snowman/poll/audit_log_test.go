@@ -0,0 +1,120 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/choices"
+)
+
+func TestAuditLogGetByBlock(t *testing.T) {
+	require := require.New(t)
+
+	log, err := NewAuditLog(memdb.New(), 10)
+	require.NoError(err)
+
+	blkID0 := ids.GenerateTestID()
+	blkID1 := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+
+	record0 := &Record{
+		RequestID: 1,
+		Responses: []Response{{
+			NodeID:     nodeID,
+			NumSamples: 1,
+			Weight:     5,
+			Responded:  true,
+			BlockID:    blkID0,
+		}},
+		Results: []BlockResult{{
+			BlockID:  blkID0,
+			NumVotes: 1,
+			Status:   choices.Processing,
+		}},
+		Preference: blkID0,
+	}
+	record1 := &Record{
+		RequestID: 2,
+		Responses: []Response{{
+			NodeID:     nodeID,
+			NumSamples: 1,
+		}},
+		Results: []BlockResult{{
+			BlockID:  blkID1,
+			NumVotes: 1,
+			Status:   choices.Accepted,
+		}},
+	}
+	require.NoError(log.Put(record0))
+	require.NoError(log.Put(record1))
+
+	records, err := log.GetByBlock(blkID0)
+	require.NoError(err)
+	require.Len(records, 1)
+	require.Equal(record0.RequestID, records[0].RequestID)
+	require.Equal(record0.Responses, records[0].Responses)
+	require.Equal(record0.Results, records[0].Results)
+	require.Equal(record0.Preference, records[0].Preference)
+
+	records, err = log.GetByBlock(blkID1)
+	require.NoError(err)
+	require.Len(records, 1)
+	require.Equal(record1.RequestID, records[0].RequestID)
+
+	records, err = log.GetByBlock(ids.GenerateTestID())
+	require.NoError(err)
+	require.Empty(records)
+}
+
+func TestAuditLogEvictsOldestRecords(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	log, err := NewAuditLog(db, 3)
+	require.NoError(err)
+
+	blkID := ids.GenerateTestID()
+	for i := uint32(0); i < 5; i++ {
+		require.NoError(log.Put(&Record{
+			RequestID: i,
+			Results: []BlockResult{{
+				BlockID:  blkID,
+				NumVotes: 1,
+			}},
+		}))
+	}
+
+	records, err := log.GetByBlock(blkID)
+	require.NoError(err)
+	require.Len(records, 3)
+	for i, record := range records {
+		require.Equal(uint32(i+2), record.RequestID)
+	}
+
+	// Restarting with a smaller ring evicts the records that no longer fit.
+	log, err = NewAuditLog(db, 1)
+	require.NoError(err)
+
+	records, err = log.GetByBlock(blkID)
+	require.NoError(err)
+	require.Len(records, 1)
+	require.Equal(uint32(4), records[0].RequestID)
+
+	require.NoError(log.Put(&Record{
+		RequestID: 5,
+		Results: []BlockResult{{
+			BlockID:  blkID,
+			NumVotes: 1,
+		}},
+	}))
+	records, err = log.GetByBlock(blkID)
+	require.NoError(err)
+	require.Len(records, 1)
+	require.Equal(uint32(5), records[0].RequestID)
+}
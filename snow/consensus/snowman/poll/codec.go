@@ -0,0 +1,25 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"math"
+
+	"github.com/lasthyphen/dijetsnodego/codec"
+	"github.com/lasthyphen/dijetsnodego/codec/linearcodec"
+)
+
+const codecVersion = 0
+
+// c is used to persist poll records
+var c codec.Manager
+
+func init() {
+	lc := linearcodec.NewCustomMaxLength(math.MaxInt32)
+	c = codec.NewManager(math.MaxInt)
+
+	if err := c.RegisterCodec(codecVersion, lc); err != nil {
+		panic(err)
+	}
+}
@@ -60,6 +60,11 @@ func (p *earlyTermNoTraversalPoll) Finished() bool {
 		received+remaining < p.alpha // An alpha majority can never return
 }
 
+// Pending returns the validators that haven't responded to this poll
+func (p *earlyTermNoTraversalPoll) Pending() ids.NodeIDBag {
+	return p.polled
+}
+
 // Result returns the result of this poll
 func (p *earlyTermNoTraversalPoll) Result() ids.Bag {
 	return p.votes
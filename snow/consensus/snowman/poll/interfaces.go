@@ -18,6 +18,8 @@ type Set interface {
 	Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []ids.Bag
 	Drop(requestID uint32, vdr ids.NodeID) []ids.Bag
 	Len() int
+	// States returns the outstanding polls, in the order they were created.
+	States() []State
 }
 
 // Poll is an outstanding poll
@@ -27,6 +29,8 @@ type Poll interface {
 	Vote(vdr ids.NodeID, vote ids.ID)
 	Drop(vdr ids.NodeID)
 	Finished() bool
+	// Pending returns the sampled validators that haven't responded yet.
+	Pending() ids.NodeIDBag
 	Result() ids.Bag
 }
 
@@ -48,6 +48,11 @@ func (p *noEarlyTermPoll) Finished() bool {
 	return p.polled.Len() == 0
 }
 
+// Pending returns the validators that haven't responded to this poll
+func (p *noEarlyTermPoll) Pending() ids.NodeIDBag {
+	return p.polled
+}
+
 // Result returns the result of this poll
 func (p *noEarlyTermPoll) Result() ids.Bag {
 	return p.votes
@@ -0,0 +1,111 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"time"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/choices"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
+)
+
+// Auditor is notified of every poll that finishes in an audited set.
+type Auditor interface {
+	// Finished is called with the record of every finished poll, in the same
+	// order as the results returned by the set.
+	Finished(*Record)
+}
+
+// Response describes the response of a sampled validator to a poll.
+type Response struct {
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// NumSamples is the number of times the validator was sampled.
+	NumSamples uint32 `serialize:"true" json:"numSamples"`
+	// Weight is the stake weight of the validator when the poll finished. It
+	// is populated by the engine.
+	Weight json.Uint64 `serialize:"true" json:"weight"`
+	// Responded is false if the poll finished before the validator responded.
+	Responded bool `serialize:"true" json:"responded"`
+	// BlockID is the block the validator voted for. It is empty if the
+	// validator's query failed.
+	BlockID ids.ID `serialize:"true" json:"blockID"`
+	// Latency is the time between the start of the poll and the response.
+	Latency time.Duration `serialize:"true" json:"latency"`
+}
+
+// BlockResult is the number of votes a block received in a poll, after the
+// votes were bubbled to blocks that were issued to consensus.
+type BlockResult struct {
+	BlockID  ids.ID `serialize:"true" json:"blockID"`
+	NumVotes uint32 `serialize:"true" json:"numVotes"`
+	// Status is the status of the block after the poll was applied.
+	Status choices.Status `serialize:"true" json:"status"`
+}
+
+// Record is the audit record of a single poll.
+type Record struct {
+	RequestID uint32 `serialize:"true" json:"requestID"`
+	// Start is the unix time, in nanoseconds, that the poll was created.
+	Start     int64         `serialize:"true" json:"start"`
+	Duration  time.Duration `serialize:"true" json:"duration"`
+	Responses []Response    `serialize:"true" json:"responses"`
+
+	// The following fields are populated by the engine once the poll has been
+	// applied to consensus.
+	Results []BlockResult `serialize:"true" json:"results"`
+	// Preference is the preferred block after the poll was applied.
+	Preference ids.ID `serialize:"true" json:"preference"`
+
+	// nodeID -> index into [Responses]
+	indices map[ids.NodeID]int
+}
+
+func newRecord(requestID uint32, vdrs ids.NodeIDBag, start time.Time) *Record {
+	nodeIDs := vdrs.List()
+	r := &Record{
+		RequestID: requestID,
+		Start:     start.UnixNano(),
+		Responses: make([]Response, len(nodeIDs)),
+		indices:   make(map[ids.NodeID]int, len(nodeIDs)),
+	}
+	for i, nodeID := range nodeIDs {
+		r.Responses[i] = Response{
+			NodeID:     nodeID,
+			NumSamples: uint32(vdrs.Count(nodeID)),
+		}
+		r.indices[nodeID] = i
+	}
+	return r
+}
+
+func (r *Record) respond(vdr ids.NodeID, blkID ids.ID, now time.Time) {
+	i, ok := r.indices[vdr]
+	if !ok || r.Responses[i].Responded {
+		return
+	}
+	r.Responses[i].Responded = true
+	r.Responses[i].BlockID = blkID
+	r.Responses[i].Latency = now.Sub(time.Unix(0, r.Start))
+}
+
+func (r *Record) finish(now time.Time) {
+	r.Duration = now.Sub(time.Unix(0, r.Start))
+}
+
+// BlockIDs returns the IDs of all the blocks the record refers to.
+func (r *Record) BlockIDs() []ids.ID {
+	blkIDs := make(map[ids.ID]struct{}, len(r.Responses)+len(r.Results))
+	for _, response := range r.Responses {
+		if response.BlockID != ids.Empty {
+			blkIDs[response.BlockID] = struct{}{}
+		}
+	}
+	for _, result := range r.Results {
+		blkIDs[result.BlockID] = struct{}{}
+	}
+	return maps.Keys(blkIDs)
+}
@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/linkedhashmap"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/metric"
@@ -21,11 +22,13 @@ import (
 type pollHolder interface {
 	GetPoll() Poll
 	StartTime() time.Time
+	GetRecord() *Record
 }
 
 type poll struct {
 	Poll
-	start time.Time
+	start  time.Time
+	record *Record
 }
 
 func (p poll) GetPoll() Poll {
@@ -36,6 +39,10 @@ func (p poll) StartTime() time.Time {
 	return p.start
 }
 
+func (p poll) GetRecord() *Record {
+	return p.record
+}
+
 type set struct {
 	log      logging.Logger
 	numPolls prometheus.Gauge
@@ -43,6 +50,8 @@ type set struct {
 	factory  Factory
 	// maps requestID -> poll
 	polls linkedhashmap.LinkedHashmap[uint32, pollHolder]
+	// auditor is nil if the polls aren't audited
+	auditor Auditor
 }
 
 // NewSet returns a new empty set of polls
@@ -84,6 +93,20 @@ func NewSet(
 	}
 }
 
+// NewAuditedSet returns a new empty set of polls that reports the record of
+// every finished poll to [auditor].
+func NewAuditedSet(
+	factory Factory,
+	log logging.Logger,
+	namespace string,
+	reg prometheus.Registerer,
+	auditor Auditor,
+) Set {
+	s := NewSet(factory, log, namespace, reg).(*set)
+	s.auditor = auditor
+	return s
+}
+
 // Add to the current set of polls
 // Returns true if the poll was registered correctly and the network sample
 //         should be made.
@@ -101,9 +124,15 @@ func (s *set) Add(requestID uint32, vdrs ids.NodeIDBag) bool {
 		zap.Stringer("validators", &vdrs),
 	)
 
+	start := time.Now()
+	var record *Record
+	if s.auditor != nil {
+		record = newRecord(requestID, vdrs, start)
+	}
 	s.polls.Put(requestID, poll{
-		Poll:  s.factory.New(vdrs), // create the new poll
-		start: time.Now(),
+		Poll:   s.factory.New(vdrs), // create the new poll
+		start:  start,
+		record: record,
 	})
 	s.numPolls.Inc() // increase the metrics
 	return true
@@ -131,6 +160,9 @@ func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []ids.Bag {
 	)
 
 	p.Vote(vdr, vote)
+	if record := holder.GetRecord(); record != nil {
+		record.respond(vdr, vote, time.Now())
+	}
 	if !p.Finished() {
 		return nil
 	}
@@ -160,7 +192,13 @@ func (s *set) processFinishedPolls() []ids.Bag {
 		s.durPolls.Observe(float64(time.Since(holder.StartTime())))
 		s.numPolls.Dec() // decrease the metrics
 
-		results = append(results, p.Result())
+		result := p.Result()
+		if record := holder.GetRecord(); record != nil {
+			record.finish(time.Now())
+			s.auditor.Finished(record)
+		}
+
+		results = append(results, result)
 		s.polls.Delete(iter.Key())
 	}
 
@@ -190,6 +228,9 @@ func (s *set) Drop(requestID uint32, vdr ids.NodeID) []ids.Bag {
 	poll := holder.GetPoll()
 
 	poll.Drop(vdr)
+	if record := holder.GetRecord(); record != nil {
+		record.respond(vdr, ids.Empty, time.Now())
+	}
 	if !poll.Finished() {
 		return nil
 	}
@@ -202,6 +243,28 @@ func (s *set) Len() int {
 	return s.polls.Len()
 }
 
+func (s *set) States() []State {
+	states := make([]State, 0, s.polls.Len())
+	iter := s.polls.NewIterator()
+	for iter.Next() {
+		poll := iter.Value().GetPoll()
+		result := poll.Result()
+		votes := make(map[ids.ID]int)
+		for _, id := range result.List() {
+			votes[id] = result.Count(id)
+		}
+		pending := poll.Pending()
+		pendingList := pending.List()
+		utils.Sort(pendingList)
+		states = append(states, State{
+			RequestID: iter.Key(),
+			Votes:     votes,
+			Pending:   pendingList,
+		})
+	}
+	return states
+}
+
 func (s *set) String() string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("current polls: (Size = %d)", s.polls.Len()))
@@ -301,6 +301,37 @@ func TestCreateAndFinishFailedPoll(t *testing.T) {
 	}
 }
 
+func TestSetStates(t *testing.T) {
+	require := require.New(t)
+
+	factory := NewNoEarlyTermFactory()
+	log := logging.NoLog{}
+	namespace := ""
+	registerer := prometheus.NewRegistry()
+	s := NewSet(factory, log, namespace, registerer)
+
+	blkID := ids.ID{1}
+
+	vdr1 := ids.NodeID{1}
+	vdr2 := ids.NodeID{2} // k = 2
+
+	vdrs := ids.NodeIDBag{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+	)
+
+	require.True(s.Add(0, vdrs))
+	require.Empty(s.Vote(0, vdr1, blkID))
+	require.Equal([]State{{
+		RequestID: 0,
+		Votes: map[ids.ID]int{
+			blkID: 1,
+		},
+		Pending: []ids.NodeID{vdr2},
+	}}, s.States())
+}
+
 func TestSetString(t *testing.T) {
 	factory := NewNoEarlyTermFactory()
 	log := logging.NoLog{}
@@ -326,3 +357,67 @@ func TestSetString(t *testing.T) {
 			str)
 	}
 }
+
+type testAuditor struct {
+	records []*Record
+}
+
+func (a *testAuditor) Finished(record *Record) {
+	a.records = append(a.records, record)
+}
+
+func TestAuditedSetRecordsPolls(t *testing.T) {
+	require := require.New(t)
+
+	factory := NewNoEarlyTermFactory()
+	log := logging.NoLog{}
+	namespace := ""
+	registerer := prometheus.NewRegistry()
+	auditor := &testAuditor{}
+	s := NewAuditedSet(factory, log, namespace, registerer, auditor)
+
+	vdr1 := ids.NodeID{1}
+	vdr2 := ids.NodeID{2}
+	vdr3 := ids.NodeID{3}
+
+	vdrBag := ids.NodeIDBag{}
+	vdrBag.Add(vdr1, vdr1, vdr2, vdr3)
+	require.True(s.Add(1, vdrBag))
+
+	vdrBag = ids.NodeIDBag{}
+	vdrBag.Add(vdr1)
+	require.True(s.Add(2, vdrBag))
+
+	vtx1 := ids.ID{1}
+	vtx2 := ids.ID{2}
+
+	// the newer poll finishes first, so nothing is reported
+	require.Empty(s.Vote(2, vdr1, vtx2))
+	require.Empty(auditor.records)
+
+	require.Empty(s.Vote(1, vdr1, vtx1))
+	require.Empty(s.Drop(1, vdr2))
+	results := s.Vote(1, vdr3, vtx2)
+	require.Len(results, 2)
+	require.Len(auditor.records, 2)
+
+	record := auditor.records[0]
+	require.Equal(uint32(1), record.RequestID)
+	require.Len(record.Responses, 3)
+
+	responses := make(map[ids.NodeID]Response)
+	for _, response := range record.Responses {
+		require.True(response.Responded)
+		responses[response.NodeID] = response
+	}
+	require.Equal(uint32(2), responses[vdr1].NumSamples)
+	require.Equal(vtx1, responses[vdr1].BlockID)
+	require.Equal(ids.Empty, responses[vdr2].BlockID)
+	require.Equal(vtx2, responses[vdr3].BlockID)
+	require.ElementsMatch([]ids.ID{vtx1, vtx2}, record.BlockIDs())
+
+	record = auditor.records[1]
+	require.Equal(uint32(2), record.RequestID)
+	require.Len(record.Responses, 1)
+	require.Equal(vtx2, record.Responses[0].BlockID)
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowman/poll"
+	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
+)
+
+var _ utils.Sortable[ProcessingBlock] = ProcessingBlock{}
+
+// ProcessingBlock describes a block in the processing tree.
+type ProcessingBlock struct {
+	ID       ids.ID      `json:"id"`
+	ParentID ids.ID      `json:"parentID"`
+	Height   json.Uint64 `json:"height"`
+	// Accepted is only true for the last accepted block, which is the root of
+	// the processing tree.
+	Accepted bool `json:"accepted"`
+	// Preferred is true if the block is on the preferred chain.
+	Preferred bool `json:"preferred"`
+	// ChildPreference is the child that is currently preferred by the snowball
+	// instance deciding between the children of this block. It is nil if the
+	// block has no processing children.
+	ChildPreference *ids.ID `json:"childPreference,omitempty"`
+	// ChildConfidence is the number of consecutive successful polls that
+	// count towards finalizing a child of this block.
+	ChildConfidence int `json:"childConfidence"`
+}
+
+func (b ProcessingBlock) Less(other ProcessingBlock) bool {
+	if b.Height != other.Height {
+		return b.Height < other.Height
+	}
+	return b.ID.Less(other.ID)
+}
+
+// ProcessingTree is a snapshot of the blocks that are currently processing.
+type ProcessingTree struct {
+	LastAcceptedID     ids.ID      `json:"lastAcceptedID"`
+	LastAcceptedHeight json.Uint64 `json:"lastAcceptedHeight"`
+	Preference         ids.ID      `json:"preference"`
+	// PollNumber is the number of polls that have been applied.
+	PollNumber json.Uint64 `json:"pollNumber"`
+	// Blocks contains the last accepted block followed by all the processing
+	// blocks, sorted by height.
+	Blocks []ProcessingBlock `json:"blocks"`
+	// The following fields are populated by the engine.
+	//
+	// NumPolls and Polls describe the outstanding network polls.
+	NumPolls int          `json:"numPolls"`
+	Polls    []poll.State `json:"polls,omitempty"`
+	// Pending are the blocks that are waiting for their ancestors to be
+	// issued before they can be added to consensus.
+	Pending []ids.ID `json:"pending,omitempty"`
+}
+
+func (t *ProcessingTree) DOT() string {
+	sb := strings.Builder{}
+	sb.WriteString("digraph processing {\n")
+	sb.WriteString("\trankdir=BT;\n")
+	sb.WriteString("\tnode [shape=box];\n")
+	for _, blk := range t.Blocks {
+		label := fmt.Sprintf("%s\nheight %d", blk.ID, blk.Height)
+		if blk.ChildPreference != nil {
+			label += fmt.Sprintf("\nchild confidence %d", blk.ChildConfidence)
+		}
+		attrs := []string{
+			fmt.Sprintf("label=%q", label),
+		}
+		switch {
+		case blk.Accepted:
+			attrs = append(attrs, "style=filled", "fillcolor=green")
+		case blk.Preferred:
+			attrs = append(attrs, "style=filled", "fillcolor=lightblue")
+		}
+		sb.WriteString(fmt.Sprintf("\t%q [%s];\n", blk.ID, strings.Join(attrs, ", ")))
+	}
+	for _, blk := range t.Blocks {
+		if blk.Accepted {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\t%q -> %q;\n", blk.ID, blk.ParentID))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
@@ -19,6 +19,8 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow/choices"
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/metrics"
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowball"
+	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 )
 
@@ -227,6 +229,39 @@ func (ts *Topological) Preference() ids.ID {
 	return ts.tail
 }
 
+func (ts *Topological) ProcessingTree() *ProcessingTree {
+	blocks := make([]ProcessingBlock, 0, len(ts.blocks))
+	for blkID, node := range ts.blocks {
+		blk := ProcessingBlock{
+			ID:        blkID,
+			Preferred: ts.preferredIDs.Contains(blkID),
+		}
+		if blkID == ts.head {
+			blk.Height = json.Uint64(ts.height)
+			blk.Accepted = true
+			blk.Preferred = true
+		} else {
+			blk.ParentID = node.blk.Parent()
+			blk.Height = json.Uint64(node.blk.Height())
+		}
+		if node.sb != nil {
+			childPreference := node.sb.Preference()
+			blk.ChildPreference = &childPreference
+			blk.ChildConfidence = node.sb.Confidence()
+		}
+		blocks = append(blocks, blk)
+	}
+	utils.Sort(blocks)
+
+	return &ProcessingTree{
+		LastAcceptedID:     ts.head,
+		LastAcceptedHeight: json.Uint64(ts.height),
+		Preference:         ts.tail,
+		PollNumber:         json.Uint64(ts.pollNumber),
+		Blocks:             blocks,
+	}
+}
+
 // The votes bag contains at most K votes for blocks in the tree. If there is a
 // vote for a block that isn't in the tree, the vote is dropped.
 //
@@ -67,4 +67,9 @@ type Consensus interface {
 	// possible that after returning finalized, a new decision may be added such
 	// that this instance is no longer finalized.
 	Finalized() bool
+
+	// Confidence returns the number of successful polls and the current
+	// confidence of the processing transaction [txID]. Returns false if
+	// [txID] isn't processing.
+	Confidence(txID ids.ID) (numSuccessfulPolls int, confidence int, processing bool)
 }
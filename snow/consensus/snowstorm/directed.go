@@ -503,6 +503,14 @@ func (dg *Directed) RecordPoll(ctx context.Context, votes ids.Bag) (bool, error)
 	return changed, dg.errs.Err
 }
 
+func (dg *Directed) Confidence(txID ids.ID) (int, int, bool) {
+	txNode, exists := dg.txs[txID]
+	if !exists {
+		return 0, 0, false
+	}
+	return txNode.numSuccessfulPolls, txNode.getConfidence(dg.pollNumber), true
+}
+
 func (dg *Directed) String() string {
 	nodes := make([]*snowballNode, 0, len(dg.txs))
 	for _, txNode := range dg.txs {
@@ -61,11 +61,14 @@ func New(ctx context.Context, config Config, onFinished func(ctx context.Context
 		return nil, err
 	}
 
+	syncedVM, _ := config.VM.(vertex.SyncedVM)
+	b.syncedVM = syncedVM
 	if err := b.VtxBlocked.SetParser(ctx, &vtxParser{
 		log:         config.Ctx.Log,
 		numAccepted: b.numAcceptedVts,
 		numDropped:  b.numDroppedVts,
 		manager:     b.Manager,
+		syncedVM:    syncedVM,
 	}); err != nil {
 		return nil, err
 	}
@@ -111,6 +114,9 @@ type bootstrapper struct {
 	executedStateTransitions int
 
 	awaitingTimeout bool
+
+	// syncedVM is the VM if it supports being state synced, nil otherwise
+	syncedVM vertex.SyncedVM
 }
 
 func (b *bootstrapper) Clear() error {
@@ -424,6 +430,7 @@ func (b *bootstrapper) process(ctx context.Context, vtxs ...avalanche.Vertex) er
 				log:         b.Ctx.Log,
 				numAccepted: b.numAcceptedVts,
 				numDropped:  b.numDroppedVts,
+				syncedVM:    b.syncedVM,
 				vtx:         vtx,
 			})
 			if err != nil {
@@ -435,6 +442,17 @@ func (b *bootstrapper) process(ctx context.Context, vtxs ...avalanche.Vertex) er
 				continue
 			}
 
+			syncedEdge, err := isSyncedEdge(ctx, b.syncedVM, vtx)
+			if err != nil {
+				return err
+			}
+			if syncedEdge {
+				// The transactions of [vtx] were already applied by state
+				// sync, so there is no need to traverse into its parents.
+				b.numFetchedVts.Inc()
+				continue
+			}
+
 			txs, err := vtx.Txs(ctx)
 			if err != nil {
 				return err
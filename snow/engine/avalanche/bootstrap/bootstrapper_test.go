@@ -89,7 +89,7 @@ func newConfig(t *testing.T) (Config, ids.NodeID, *common.SenderTest, *vertex.Te
 		SharedCfg:                      &common.SharedConfig{},
 	}
 
-	avaGetHandler, err := getter.New(manager, commonConfig)
+	avaGetHandler, err := getter.New(manager, vm, commonConfig)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -27,6 +27,7 @@ type vtxParser struct {
 	log                     logging.Logger
 	numAccepted, numDropped prometheus.Counter
 	manager                 vertex.Manager
+	syncedVM                vertex.SyncedVM // can be nil
 }
 
 func (p *vtxParser) Parse(ctx context.Context, vtxBytes []byte) (queue.Job, error) {
@@ -38,6 +39,7 @@ func (p *vtxParser) Parse(ctx context.Context, vtxBytes []byte) (queue.Job, erro
 		log:         p.log,
 		numAccepted: p.numAccepted,
 		numDropped:  p.numDropped,
+		syncedVM:    p.syncedVM,
 		vtx:         vtx,
 	}, nil
 }
@@ -45,6 +47,7 @@ func (p *vtxParser) Parse(ctx context.Context, vtxBytes []byte) (queue.Job, erro
 type vertexJob struct {
 	log                     logging.Logger
 	numAccepted, numDropped prometheus.Counter
+	syncedVM                vertex.SyncedVM // can be nil
 	vtx                     avalanche.Vertex
 }
 
@@ -52,8 +55,11 @@ func (v *vertexJob) ID() ids.ID {
 	return v.vtx.ID()
 }
 
-func (v *vertexJob) MissingDependencies(context.Context) (set.Set[ids.ID], error) {
+func (v *vertexJob) MissingDependencies(ctx context.Context) (set.Set[ids.ID], error) {
 	missing := set.Set[ids.ID]{}
+	if syncedEdge, err := isSyncedEdge(ctx, v.syncedVM, v.vtx); err != nil || syncedEdge {
+		return missing, err
+	}
 	parents, err := v.vtx.Parents()
 	if err != nil {
 		return missing, err
@@ -67,7 +73,10 @@ func (v *vertexJob) MissingDependencies(context.Context) (set.Set[ids.ID], error
 }
 
 // Returns true if this vertex job has at least 1 missing dependency
-func (v *vertexJob) HasMissingDependencies(context.Context) (bool, error) {
+func (v *vertexJob) HasMissingDependencies(ctx context.Context) (bool, error) {
+	if syncedEdge, err := isSyncedEdge(ctx, v.syncedVM, v.vtx); err != nil || syncedEdge {
+		return false, err
+	}
 	parents, err := v.vtx.Parents()
 	if err != nil {
 		return false, err
@@ -120,3 +129,28 @@ func (v *vertexJob) Execute(ctx context.Context) error {
 func (v *vertexJob) Bytes() []byte {
 	return v.vtx.Bytes()
 }
+
+// isSyncedEdge returns true if the state of [vm] was synced from a state
+// summary that already includes every transaction of [vtx]. Such a vertex can
+// be accepted without accepting its ancestors first, so they don't need to be
+// fetched.
+func isSyncedEdge(ctx context.Context, vm vertex.SyncedVM, vtx avalanche.Vertex) (bool, error) {
+	if vm == nil {
+		return false, nil
+	}
+	synced, err := vm.StateSynced(ctx)
+	if err != nil || !synced {
+		return false, err
+	}
+
+	txs, err := vtx.Txs(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, tx := range txs {
+		if tx.Status() != choices.Accepted {
+			return false, nil
+		}
+	}
+	return true, nil
+}
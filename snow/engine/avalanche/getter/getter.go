@@ -10,11 +10,11 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/lasthyphen/dijetsnodego/ids"
-	"github.com/lasthyphen/dijetsnodego/message"
 	"github.com/lasthyphen/dijetsnodego/snow/choices"
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/avalanche"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/avalanche/vertex"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/snowman/block"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/metric"
@@ -25,9 +25,15 @@ import (
 // Get requests are always served, regardless node state (bootstrapping or normal operations).
 var _ common.AllGetsServer = (*getter)(nil)
 
-func New(storage vertex.Storage, commonCfg common.Config) (common.AllGetsServer, error) {
+func New(
+	storage vertex.Storage,
+	vm common.VM,
+	commonCfg common.Config,
+) (common.AllGetsServer, error) {
+	ssVM, _ := vm.(block.StateSyncableVM)
 	gh := &getter{
 		storage: storage,
+		ssVM:    ssVM,
 		sender:  commonCfg.Sender,
 		cfg:     commonCfg,
 		log:     commonCfg.Ctx.Log,
@@ -45,6 +51,7 @@ func New(storage vertex.Storage, commonCfg common.Config) (common.AllGetsServer,
 
 type getter struct {
 	storage vertex.Storage
+	ssVM    block.StateSyncableVM // can be nil
 	sender  common.Sender
 	cfg     common.Config
 
@@ -52,23 +59,73 @@ type getter struct {
 	getAncestorsVtxs metric.Averager
 }
 
-func (gh *getter) GetStateSummaryFrontier(_ context.Context, nodeID ids.NodeID, requestID uint32) error {
-	gh.log.Debug("dropping request",
-		zap.String("reason", "unhandled by this gear"),
-		zap.Stringer("messageOp", message.GetStateSummaryFrontierOp),
-		zap.Stringer("nodeID", nodeID),
-		zap.Uint32("requestID", requestID),
-	)
+func (gh *getter) GetStateSummaryFrontier(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	// Note: we do not check if gh.ssVM.StateSyncEnabled since we want all
+	// nodes, including those disabling state sync to serve state summaries if
+	// these are available
+	if gh.ssVM == nil {
+		gh.log.Debug("dropping GetStateSummaryFrontier message",
+			zap.String("reason", "state sync not supported"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+
+	summary, err := gh.ssVM.GetLastStateSummary(ctx)
+	if err != nil {
+		gh.log.Debug("dropping GetStateSummaryFrontier message",
+			zap.String("reason", "couldn't get state summary frontier"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	gh.sender.SendStateSummaryFrontier(ctx, nodeID, requestID, summary.Bytes())
 	return nil
 }
 
-func (gh *getter) GetAcceptedStateSummary(_ context.Context, nodeID ids.NodeID, requestID uint32, _ []uint64) error {
-	gh.log.Debug("dropping request",
-		zap.String("reason", "unhandled by this gear"),
-		zap.Stringer("messageOp", message.GetAcceptedStateSummaryOp),
-		zap.Stringer("nodeID", nodeID),
-		zap.Uint32("requestID", requestID),
-	)
+func (gh *getter) GetAcceptedStateSummary(ctx context.Context, nodeID ids.NodeID, requestID uint32, heights []uint64) error {
+	// If there are no requested heights, then we can return the result
+	// immediately, regardless of if the underlying VM implements state sync.
+	if len(heights) == 0 {
+		gh.sender.SendAcceptedStateSummary(ctx, nodeID, requestID, nil)
+		return nil
+	}
+
+	if gh.ssVM == nil {
+		gh.log.Debug("dropping GetAcceptedStateSummary message",
+			zap.String("reason", "state sync not supported"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+
+	summaryIDs := make([]ids.ID, 0, len(heights))
+	for _, height := range heights {
+		summary, err := gh.ssVM.GetStateSummary(ctx, height)
+		if err == block.ErrStateSyncableVMNotImplemented {
+			gh.log.Debug("dropping GetAcceptedStateSummary message",
+				zap.String("reason", "state sync not supported"),
+				zap.Stringer("nodeID", nodeID),
+				zap.Uint32("requestID", requestID),
+			)
+			return nil
+		}
+		if err != nil {
+			gh.log.Debug("couldn't get state summary",
+				zap.Uint64("height", height),
+				zap.Error(err),
+			)
+			continue
+		}
+		summaryIDs = append(summaryIDs, summary.ID())
+	}
+
+	gh.sender.SendAcceptedStateSummary(ctx, nodeID, requestID, summaryIDs)
 	return nil
 }
 
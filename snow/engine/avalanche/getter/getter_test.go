@@ -69,7 +69,7 @@ func TestAcceptedFrontier(t *testing.T) {
 	vtxID1 := ids.GenerateTestID()
 	vtxID2 := ids.GenerateTestID()
 
-	bsIntf, err := New(manager, config)
+	bsIntf, err := New(manager, nil, config)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -126,7 +126,7 @@ func TestFilterAccepted(t *testing.T) {
 		StatusV: choices.Accepted,
 	}}
 
-	bsIntf, err := New(manager, config)
+	bsIntf, err := New(manager, nil, config)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -190,7 +190,8 @@ func (vtx *uniqueVertex) Accept(ctx context.Context) error {
 		vtx.serializer.edge.Remove(parent.ID())
 	}
 
-	if err := vtx.serializer.state.SetEdge(vtx.serializer.Edge(ctx)); err != nil {
+	edge := vtx.serializer.Edge(ctx)
+	if err := vtx.serializer.state.SetEdge(edge); err != nil {
 		return fmt.Errorf("failed to set edge while accepting vertex %s due to %w", vtx.id, err)
 	}
 
@@ -198,7 +199,15 @@ func (vtx *uniqueVertex) Accept(ctx context.Context) error {
 	// parents to be garbage collected
 	vtx.v.parents = nil
 
-	return vtx.serializer.versionDB.Commit()
+	if err := vtx.serializer.versionDB.Commit(); err != nil {
+		return err
+	}
+
+	edgeTracker, ok := vtx.serializer.VM.(vertex.EdgeTrackerVM)
+	if !ok {
+		return nil
+	}
+	return edgeTracker.VertexAccepted(ctx, vtx, edge)
 }
 
 func (vtx *uniqueVertex) Reject(context.Context) error {
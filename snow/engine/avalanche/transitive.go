@@ -18,13 +18,17 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow/engine/avalanche/vertex"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
 	"github.com/lasthyphen/dijetsnodego/snow/events"
+	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/sampler"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 	"github.com/lasthyphen/dijetsnodego/version"
 )
 
-var _ Engine = (*Transitive)(nil)
+var (
+	_ Engine                    = (*Transitive)(nil)
+	_ common.ProcessingExporter = (*Transitive)(nil)
+)
 
 func New(config Config) (Engine, error) {
 	return newTransitive(config)
@@ -389,6 +393,18 @@ func (t *Transitive) HealthCheck(ctx context.Context) (interface{}, error) {
 	return intf, fmt.Errorf("vm: %w ; consensus: %s", vmErr, consensusErr)
 }
 
+func (t *Transitive) ExportProcessing(ctx context.Context) (common.ProcessingExport, error) {
+	graph, err := t.Consensus.ProcessingGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	graph.NumPolls = t.polls.Len()
+	graph.Polls = t.polls.States()
+	graph.Pending = t.pending.List()
+	utils.Sort(graph.Pending)
+	return graph, nil
+}
+
 func (t *Transitive) GetVM() common.VM {
 	return t.VM
 }
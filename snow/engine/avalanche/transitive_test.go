@@ -1510,7 +1510,7 @@ func TestEngineGetVertex(t *testing.T) {
 	manager := vertex.NewTestManager(t)
 	manager.Default(true)
 	engCfg.Manager = manager
-	avaGetHandler, err := avagetter.New(manager, commonCfg)
+	avaGetHandler, err := avagetter.New(manager, engCfg.VM, commonCfg)
 	if err != nil {
 		t.Fatal(err)
 	}
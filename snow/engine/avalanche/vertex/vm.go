@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/avalanche"
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowstorm"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
 )
@@ -29,3 +30,21 @@ type Getter interface {
 	// Retrieve a transaction that was submitted previously
 	GetTx(ctx context.Context, txID ids.ID) (snowstorm.Tx, error)
 }
+
+// SyncedVM is implemented by DAG VMs whose state can be synced from a state
+// summary rather than by executing every transaction since genesis.
+type SyncedVM interface {
+	// StateSynced returns true if the state of the VM was synced from a state
+	// summary and the chain hasn't finished bootstrapping since. While true,
+	// bootstrapping doesn't need to fetch the ancestors of vertices whose
+	// transactions are all accepted.
+	StateSynced(ctx context.Context) (bool, error)
+}
+
+// EdgeTrackerVM is implemented by DAG VMs that follow the accepted frontier of
+// the DAG.
+type EdgeTrackerVM interface {
+	// VertexAccepted is called once [vtx] was accepted and persisted. [edge] is
+	// the accepted frontier of the DAG after [vtx] was accepted.
+	VertexAccepted(ctx context.Context, vtx avalanche.Vertex, edge []ids.ID) error
+}
@@ -8,7 +8,10 @@ import (
 	"sync/atomic"
 )
 
-var _ Haltable = (*Halter)(nil)
+var (
+	_ Haltable = (*Halter)(nil)
+	_ Pausable = (*Pauser)(nil)
+)
 
 type Haltable interface {
 	Halt(context.Context)
@@ -26,3 +29,27 @@ func (h *Halter) Halt(context.Context) {
 func (h *Halter) Halted() bool {
 	return atomic.LoadUint32(&h.halted) == 1
 }
+
+// Pausable is implemented by components whose processing can be temporarily
+// suspended. Unlike halting, pausing can be undone.
+type Pausable interface {
+	Pause()
+	Resume()
+	Paused() bool
+}
+
+type Pauser struct {
+	paused uint32
+}
+
+func (p *Pauser) Pause() {
+	atomic.StoreUint32(&p.paused, 1)
+}
+
+func (p *Pauser) Resume() {
+	atomic.StoreUint32(&p.paused, 0)
+}
+
+func (p *Pauser) Paused() bool {
+	return atomic.LoadUint32(&p.paused) == 1
+}
@@ -0,0 +1,25 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrProcessingExportUnsupported = errors.New("engine doesn't support exporting processing decisions")
+
+// ProcessingExporter is implemented by consensus engines that can export the
+// decisions they are currently processing. This is used to debug chains that
+// stopped finalizing.
+type ProcessingExporter interface {
+	ExportProcessing(context.Context) (ProcessingExport, error)
+}
+
+// ProcessingExport is a JSON marshallable snapshot of the processing decisions
+// of a consensus engine.
+type ProcessingExport interface {
+	// DOT returns the snapshot as a Graphviz DOT graph.
+	DOT() string
+}
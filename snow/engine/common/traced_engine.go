@@ -17,7 +17,10 @@ import (
 	"github.com/lasthyphen/dijetsnodego/version"
 )
 
-var _ Engine = (*tracedEngine)(nil)
+var (
+	_ Engine             = (*tracedEngine)(nil)
+	_ ProcessingExporter = (*tracedEngine)(nil)
+)
 
 type tracedEngine struct {
 	engine Engine
@@ -414,6 +417,18 @@ func (e *tracedEngine) HealthCheck(ctx context.Context) (interface{}, error) {
 	return e.engine.HealthCheck(ctx)
 }
 
+func (e *tracedEngine) ExportProcessing(ctx context.Context) (ProcessingExport, error) {
+	exporter, ok := e.engine.(ProcessingExporter)
+	if !ok {
+		return nil, ErrProcessingExportUnsupported
+	}
+
+	ctx, span := e.tracer.Start(ctx, "tracedEngine.ExportProcessing")
+	defer span.End()
+
+	return exporter.ExportProcessing(ctx)
+}
+
 func (e *tracedEngine) GetVM() VM {
 	return e.engine.GetVM()
 }
@@ -43,3 +43,15 @@ type StateSyncableVM interface {
 	// [summaryHeight].
 	GetStateSummary(ctx context.Context, summaryHeight uint64) (StateSummary, error)
 }
+
+// StateSyncProgressVM may optionally be implemented by a StateSyncableVM to
+// report how far its ongoing state sync has progressed. The engine persists
+// the reported marker alongside the summary being synced, so that operators
+// can tell how much work a restarted node is resuming.
+type StateSyncProgressVM interface {
+	// StateSyncProgress returns an opaque marker of the progress of the
+	// ongoing state sync.
+	//
+	// Returns database.ErrNotFound if there is no in-progress sync.
+	StateSyncProgress(context.Context) ([]byte, error)
+}
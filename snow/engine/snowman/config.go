@@ -7,6 +7,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow"
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowball"
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowman"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowman/poll"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/snowman/block"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
@@ -22,4 +23,7 @@ type Config struct {
 	Validators validators.Set
 	Params     snowball.Parameters
 	Consensus  snowman.Consensus
+	// PollAudit persists the record of every poll. It is nil if polls aren't
+	// audited.
+	PollAudit *poll.AuditLog
 }
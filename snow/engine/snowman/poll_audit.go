@@ -0,0 +1,63 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/choices"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowman/poll"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
+)
+
+var _ poll.Auditor = pollAuditor{}
+
+// pollAuditor queues the records of finished polls until their results are
+// applied to consensus.
+type pollAuditor struct {
+	t *Transitive
+}
+
+func (a pollAuditor) Finished(record *poll.Record) {
+	a.t.finishedPolls = append(a.t.finishedPolls, record)
+}
+
+// auditPoll completes the record of the oldest finished poll with the outcome
+// of applying its [result] to consensus and persists it.
+func (t *Transitive) auditPoll(ctx context.Context, result ids.Bag) {
+	if t.PollAudit == nil || len(t.finishedPolls) == 0 {
+		return
+	}
+
+	record := t.finishedPolls[0]
+	t.finishedPolls[0] = nil
+	t.finishedPolls = t.finishedPolls[1:]
+
+	for i := range record.Responses {
+		response := &record.Responses[i]
+		response.Weight = json.Uint64(t.Validators.GetWeight(response.NodeID))
+	}
+	for _, blkID := range result.List() {
+		status := choices.Unknown
+		if blk, err := t.GetBlock(ctx, blkID); err == nil {
+			status = blk.Status()
+		}
+		record.Results = append(record.Results, poll.BlockResult{
+			BlockID:  blkID,
+			NumVotes: uint32(result.Count(blkID)),
+			Status:   status,
+		})
+	}
+	record.Preference = t.Consensus.Preference()
+
+	if err := t.PollAudit.Put(record); err != nil {
+		t.Ctx.Log.Warn("failed to persist poll record",
+			zap.Uint32("requestID", record.RequestID),
+			zap.Error(err),
+		)
+	}
+}
@@ -4,9 +4,9 @@
 package syncer
 
 import (
+	"github.com/lasthyphen/dijetsnodego/database"
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
-	"github.com/lasthyphen/dijetsnodego/snow/engine/snowman/block"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
 )
 
@@ -27,14 +27,21 @@ type Config struct {
 	// state summaries.
 	StateSyncBeacons validators.Set
 
-	VM block.ChainVM
+	// VM is synced if it implements block.StateSyncableVM. Both linear and
+	// DAG chains may be state synced.
+	VM common.VM
+
+	// DB is used to persist the state sync that is in progress, so that it
+	// can be resumed after a restart. If nil, the progress isn't persisted.
+	DB database.Database
 }
 
 func NewConfig(
 	commonCfg common.Config,
 	stateSyncerIDs []ids.NodeID,
 	snowGetHandler common.AllGetsServer,
-	vm block.ChainVM,
+	vm common.VM,
+	db database.Database,
 ) (Config, error) {
 	// Initialize the default values that will be used if stateSyncerIDs is
 	// empty.
@@ -67,5 +74,6 @@ func NewConfig(
 		Alpha:            syncAlpha,
 		StateSyncBeacons: stateSyncBeacons,
 		VM:               vm,
+		DB:               db,
 	}, nil
 }
@@ -0,0 +1,102 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package syncer
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+)
+
+const nodeIDLen = len(ids.NodeID{})
+
+var (
+	summaryKey  = []byte("summary")
+	votersKey   = []byte("voters")
+	progressKey = []byte("progress")
+
+	errInvalidVoters = errors.New("invalid voters")
+)
+
+// ongoingSync is the state sync that was started by the engine and not yet
+// finished. It is persisted so that a restarted node can resume it.
+type ongoingSync struct {
+	// summaryBytes is the summary that was accepted
+	summaryBytes []byte
+	// voters are the beacons that voted for the summary
+	voters set.Set[ids.NodeID]
+	// progress is the marker last reported by the VM, or nil if the VM
+	// doesn't report its progress
+	progress []byte
+}
+
+// getOngoingSync returns the persisted ongoing sync.
+//
+// Returns database.ErrNotFound if there is no ongoing sync.
+func getOngoingSync(db database.KeyValueReader) (*ongoingSync, error) {
+	summaryBytes, err := db.Get(summaryKey)
+	if err != nil {
+		return nil, err
+	}
+	votersBytes, err := db.Get(votersKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(votersBytes)%nodeIDLen != 0 {
+		return nil, errInvalidVoters
+	}
+	voters := set.NewSet[ids.NodeID](len(votersBytes) / nodeIDLen)
+	for i := 0; i < len(votersBytes); i += nodeIDLen {
+		nodeID, err := ids.ToNodeID(votersBytes[i : i+nodeIDLen])
+		if err != nil {
+			return nil, err
+		}
+		voters.Add(nodeID)
+	}
+
+	progress, err := db.Get(progressKey)
+	if err == database.ErrNotFound {
+		progress = nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &ongoingSync{
+		summaryBytes: summaryBytes,
+		voters:       voters,
+		progress:     progress,
+	}, nil
+}
+
+func putOngoingSync(db database.KeyValueWriterDeleter, s *ongoingSync) error {
+	votersBytes := make([]byte, 0, s.voters.Len()*nodeIDLen)
+	for nodeID := range s.voters {
+		votersBytes = append(votersBytes, nodeID[:]...)
+	}
+	if err := db.Put(summaryKey, s.summaryBytes); err != nil {
+		return err
+	}
+	if err := db.Put(votersKey, votersBytes); err != nil {
+		return err
+	}
+	return putProgress(db, s.progress)
+}
+
+func putProgress(db database.KeyValueWriterDeleter, progress []byte) error {
+	if progress == nil {
+		return db.Delete(progressKey)
+	}
+	return db.Put(progressKey, progress)
+}
+
+func deleteOngoingSync(db database.KeyValueDeleter) error {
+	if err := db.Delete(summaryKey); err != nil {
+		return err
+	}
+	if err := db.Delete(votersKey); err != nil {
+		return err
+	}
+	return db.Delete(progressKey)
+}
@@ -29,6 +29,7 @@ var _ common.StateSyncer = (*stateSyncer)(nil)
 type weightedSummary struct {
 	summary block.StateSummary
 	weight  uint64
+	voters  set.Set[ids.NodeID]
 }
 
 type stateSyncer struct {
@@ -86,6 +87,14 @@ type stateSyncer struct {
 
 	// number of times the state sync has been attempted
 	attempts int
+
+	// true once the state sync persisted by a previous run was considered
+	resumeAttempted bool
+	// true while only the voters of a previously persisted state sync are
+	// being asked to confirm its summary
+	resuming bool
+	// true once the VM started syncing a summary
+	syncing bool
 }
 
 func New(
@@ -251,6 +260,7 @@ func (ss *stateSyncer) AcceptedStateSummary(ctx context.Context, nodeID ids.Node
 			newWeight = stdmath.MaxUint64
 		}
 		ws.weight = newWeight
+		ws.voters.Add(nodeID)
 	}
 
 	ss.sendGetAcceptedStateSummaries(ctx)
@@ -275,6 +285,14 @@ func (ss *stateSyncer) AcceptedStateSummary(ctx context.Context, nodeID ids.Node
 
 	// if we don't have enough weight for the state summary to be accepted then retry or fail the state sync
 	size := len(ss.weightedSummaries)
+	if size == 0 && ss.resuming {
+		// The previous voters no longer support the summary, so fall back to
+		// selecting a summary from the network's frontier.
+		ss.Ctx.Log.Info("couldn't resume state sync",
+			zap.String("reason", "summary no longer supported by its voters"),
+		)
+		return ss.startup(ctx)
+	}
 	if size == 0 {
 		// retry the state sync if the weight is not enough to state sync
 		failedBeaconWeight := ss.StateSyncBeacons.SubsetWeight(ss.failedVoters)
@@ -300,6 +318,9 @@ func (ss *stateSyncer) AcceptedStateSummary(ctx context.Context, nodeID ids.Node
 		)
 
 		// if we do not restart state sync, move on to bootstrapping.
+		if err := ss.deleteOngoingSync(); err != nil {
+			return err
+		}
 		return ss.onDoneStateSyncing(ctx, ss.requestID)
 	}
 
@@ -316,10 +337,14 @@ func (ss *stateSyncer) AcceptedStateSummary(ctx context.Context, nodeID ids.Node
 	if startedSyncing {
 		// summary was accepted and VM is state syncing.
 		// Engine will wait for notification of state sync done.
-		return nil
+		ss.syncing = true
+		return ss.putOngoingSync(ctx, ss.weightedSummaries[preferredStateSummary.ID()])
 	}
 
 	// VM did not accept the summary, move on to bootstrapping.
+	if err := ss.deleteOngoingSync(); err != nil {
+		return err
+	}
 	return ss.onDoneStateSyncing(ctx, ss.requestID)
 }
 
@@ -403,6 +428,15 @@ func (ss *stateSyncer) startup(ctx context.Context) error {
 	ss.targetVoters.Clear()
 	ss.pendingVoters.Clear()
 	ss.failedVoters.Clear()
+	ss.resuming = false
+
+	if !ss.resumeAttempted {
+		ss.resumeAttempted = true
+		resumed, err := ss.resume(ctx)
+		if err != nil || resumed {
+			return err
+		}
+	}
 
 	// sample K beacons to retrieve frontier from
 	beaconIDs, err := ss.StateSyncBeacons.Sample(ss.Config.SampleK)
@@ -461,6 +495,126 @@ func (ss *stateSyncer) startup(ctx context.Context) error {
 	return nil
 }
 
+// resume asks the beacons that voted for the summary of the persisted state
+// sync, if any, to confirm that they still accept it. Returns true if the
+// votes were requested.
+func (ss *stateSyncer) resume(ctx context.Context) (bool, error) {
+	if ss.DB == nil {
+		return false, nil
+	}
+	ongoing, err := getOngoingSync(ss.DB)
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// The VM must still be syncing the same summary, otherwise its progress
+	// was lost and there is nothing to resume.
+	localSummary, err := ss.stateSyncVM.GetOngoingSyncStateSummary(ctx)
+	if err == database.ErrNotFound {
+		ss.Ctx.Log.Info("discarding persisted state sync",
+			zap.String("reason", "VM has no ongoing sync"),
+		)
+		return false, ss.deleteOngoingSync()
+	}
+	if err != nil {
+		return false, err
+	}
+	summary, err := ss.stateSyncVM.ParseStateSummary(ctx, ongoing.summaryBytes)
+	if err != nil || summary.ID() != localSummary.ID() {
+		ss.Ctx.Log.Info("discarding persisted state sync",
+			zap.String("reason", "VM is syncing a different summary"),
+			zap.Error(err),
+		)
+		return false, ss.deleteOngoingSync()
+	}
+
+	// Only the voters that are still beacons can confirm the summary.
+	voters := set.NewSet[ids.NodeID](ongoing.voters.Len())
+	votersWeight := uint64(0)
+	for nodeID := range ongoing.voters {
+		weight := ss.StateSyncBeacons.GetWeight(nodeID)
+		if weight == 0 {
+			continue
+		}
+		voters.Add(nodeID)
+		votersWeight, err = math.Add64(votersWeight, weight)
+		if err != nil {
+			return false, err
+		}
+	}
+	if votersWeight < ss.Alpha {
+		ss.Ctx.Log.Info("couldn't resume state sync",
+			zap.String("reason", "not enough of its voters are beacons"),
+			zap.Uint64("votersWeight", votersWeight),
+			zap.Uint64("requiredWeight", ss.Alpha),
+		)
+		return false, nil
+	}
+
+	ss.Ctx.Log.Info("resuming state sync",
+		zap.Stringer("summaryID", localSummary.ID()),
+		zap.Uint64("height", localSummary.Height()),
+		zap.Int("numVoters", voters.Len()),
+		zap.Binary("progress", ongoing.progress),
+	)
+
+	ss.locallyAvailableSummary = localSummary
+	ss.weightedSummaries[localSummary.ID()] = &weightedSummary{
+		summary: localSummary,
+	}
+	height := localSummary.Height()
+	ss.summariesHeights.Add(height)
+	ss.uniqueSummariesHeights = append(ss.uniqueSummariesHeights, height)
+	ss.targetVoters = voters
+	ss.resuming = true
+
+	ss.attempts++
+	ss.requestID++
+	ss.sendGetAcceptedStateSummaries(ctx)
+	return true, nil
+}
+
+// putOngoingSync persists the summary that the VM started syncing, along with
+// the beacons that voted for it.
+func (ss *stateSyncer) putOngoingSync(ctx context.Context, ws *weightedSummary) error {
+	if ss.DB == nil {
+		return nil
+	}
+	progress, err := ss.getProgress(ctx)
+	if err != nil {
+		return err
+	}
+	return putOngoingSync(ss.DB, &ongoingSync{
+		summaryBytes: ws.summary.Bytes(),
+		voters:       ws.voters,
+		progress:     progress,
+	})
+}
+
+func (ss *stateSyncer) deleteOngoingSync() error {
+	if ss.DB == nil {
+		return nil
+	}
+	return deleteOngoingSync(ss.DB)
+}
+
+// getProgress returns the progress marker reported by the VM, or nil if the
+// VM doesn't report one.
+func (ss *stateSyncer) getProgress(ctx context.Context) ([]byte, error) {
+	progressVM, ok := ss.VM.(block.StateSyncProgressVM)
+	if !ok {
+		return nil, nil
+	}
+	progress, err := progressVM.StateSyncProgress(ctx)
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	return progress, err
+}
+
 func (ss *stateSyncer) restart(ctx context.Context) error {
 	if ss.attempts > 0 && ss.attempts%ss.RetryBootstrapWarnFrequency == 0 {
 		ss.Ctx.Log.Debug("check internet connection",
@@ -526,6 +680,11 @@ func (ss *stateSyncer) Notify(ctx context.Context, msg common.Message) error {
 		)
 		return nil
 	}
+
+	ss.syncing = false
+	if err := ss.deleteOngoingSync(); err != nil {
+		return err
+	}
 	return ss.onDoneStateSyncing(ctx, ss.requestID)
 }
 
@@ -560,6 +719,17 @@ func (*stateSyncer) Gossip(context.Context) error {
 
 func (ss *stateSyncer) Shutdown(ctx context.Context) error {
 	ss.Config.Ctx.Log.Info("shutting down state syncer")
+
+	// Record how far the VM got, so that it is reported when resuming.
+	if ss.syncing && ss.DB != nil {
+		progress, err := ss.getProgress(ctx)
+		if err != nil {
+			return err
+		}
+		if err := putProgress(ss.DB, progress); err != nil {
+			return err
+		}
+	}
 	return ss.VM.Shutdown(ctx)
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/snow"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
@@ -42,7 +43,7 @@ func TestStateSyncerIsEnabledIfVMSupportsStateSyncing(t *testing.T) {
 	dummyGetter, err := getter.New(nonStateSyncableVM, *commonCfg)
 	require.NoError(err)
 
-	cfg, err := NewConfig(*commonCfg, nil, dummyGetter, nonStateSyncableVM)
+	cfg, err := NewConfig(*commonCfg, nil, dummyGetter, nonStateSyncableVM, memdb.New())
 	require.NoError(err)
 	syncer := New(cfg, func(context.Context, uint32) error {
 		return nil
@@ -66,7 +67,7 @@ func TestStateSyncerIsEnabledIfVMSupportsStateSyncing(t *testing.T) {
 	dummyGetter, err = getter.New(fullVM, *commonCfg)
 	require.NoError(err)
 
-	cfg, err = NewConfig(*commonCfg, nil, dummyGetter, fullVM)
+	cfg, err = NewConfig(*commonCfg, nil, dummyGetter, fullVM, memdb.New())
 	require.NoError(err)
 	syncer = New(cfg, func(context.Context, uint32) error {
 		return nil
@@ -1338,3 +1339,175 @@ func TestStateSyncIsDoneOnceVMNotifies(t *testing.T) {
 	require.NoError(syncer.Notify(context.Background(), common.StateSyncDone))
 	require.True(stateSyncFullyDone)
 }
+
+func TestStateSyncIsResumedWithPersistedVoters(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := buildTestPeers(t)
+	startupAlpha := (3*vdrs.Weight() + 3) / 4
+
+	peers := tracker.NewPeers()
+	startup := tracker.NewStartup(peers, startupAlpha)
+	vdrs.RegisterCallbackListener(startup)
+
+	commonCfg := common.Config{
+		Ctx:            snow.DefaultConsensusContextTest(),
+		Beacons:        vdrs,
+		SampleK:        vdrs.Len(),
+		Alpha:          (vdrs.Weight() + 1) / 2,
+		StartupTracker: startup,
+	}
+	syncer, fullVM, sender := buildTestsObjects(t, &commonCfg)
+
+	summary := &block.TestStateSummary{
+		HeightV: key,
+		IDV:     summaryID,
+		BytesV:  summaryBytes,
+		T:       t,
+	}
+	fullVM.GetOngoingSyncStateSummaryF = func(context.Context) (block.StateSummary, error) {
+		return summary, nil
+	}
+	fullVM.CantParseStateSummary = true
+	fullVM.ParseStateSummaryF = func(_ context.Context, b []byte) (block.StateSummary, error) {
+		require.Equal(summaryBytes, b)
+		return summary, nil
+	}
+
+	// Persist a sync that was voted for by all the beacons but one, which is
+	// no longer a beacon.
+	previousVoters := set.Set[ids.NodeID]{}
+	for _, vdr := range vdrs.List() {
+		previousVoters.Add(vdr.NodeID)
+	}
+	previousVoters.Add(ids.GenerateTestNodeID())
+	require.NoError(putOngoingSync(syncer.DB, &ongoingSync{
+		summaryBytes: summaryBytes,
+		voters:       previousVoters,
+		progress:     []byte{1},
+	}))
+
+	// The frontier must not be requested when resuming.
+	sender.CantSendGetStateSummaryFrontier = true
+
+	contactedVoters := make(map[ids.NodeID]uint32) // nodeID -> reqID map
+	sender.CantSendGetAcceptedStateSummary = true
+	sender.SendGetAcceptedStateSummaryF = func(_ context.Context, ss set.Set[ids.NodeID], reqID uint32, heights []uint64) {
+		require.Equal([]uint64{key}, heights)
+		for nodeID := range ss {
+			contactedVoters[nodeID] = reqID
+		}
+	}
+
+	for _, vdr := range vdrs.List() {
+		require.NoError(syncer.Connected(context.Background(), vdr.NodeID, version.CurrentApp))
+	}
+	require.True(syncer.resuming)
+
+	summaryAccepted := false
+	summary.AcceptF = func(context.Context) (bool, error) {
+		summaryAccepted = true
+		return true, nil
+	}
+
+	for syncer.pendingVoters.Len() != 0 {
+		voterID, found := syncer.pendingVoters.Peek()
+		require.True(found)
+		require.NoError(syncer.AcceptedStateSummary(
+			context.Background(),
+			voterID,
+			contactedVoters[voterID],
+			[]ids.ID{summaryID},
+		))
+	}
+	require.True(summaryAccepted)
+	require.Len(contactedVoters, vdrs.Len())
+
+	// Only the voters that confirmed the summary are persisted.
+	ongoing, err := getOngoingSync(syncer.DB)
+	require.NoError(err)
+	require.Equal(summaryBytes, ongoing.summaryBytes)
+	require.Equal(vdrs.Len(), ongoing.voters.Len())
+
+	// Once the VM is done, the sync is no longer resumable.
+	require.NoError(syncer.Notify(context.Background(), common.StateSyncDone))
+	_, err = getOngoingSync(syncer.DB)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStateSyncFallsBackToFrontierIfResumedSummaryIsRejected(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := buildTestPeers(t)
+	startupAlpha := (3*vdrs.Weight() + 3) / 4
+
+	peers := tracker.NewPeers()
+	startup := tracker.NewStartup(peers, startupAlpha)
+	vdrs.RegisterCallbackListener(startup)
+
+	commonCfg := common.Config{
+		Ctx:            snow.DefaultConsensusContextTest(),
+		Beacons:        vdrs,
+		SampleK:        vdrs.Len(),
+		Alpha:          (vdrs.Weight() + 1) / 2,
+		StartupTracker: startup,
+	}
+	syncer, fullVM, sender := buildTestsObjects(t, &commonCfg)
+
+	summary := &block.TestStateSummary{
+		HeightV: key,
+		IDV:     summaryID,
+		BytesV:  summaryBytes,
+		T:       t,
+	}
+	fullVM.GetOngoingSyncStateSummaryF = func(context.Context) (block.StateSummary, error) {
+		return summary, nil
+	}
+	fullVM.CantParseStateSummary = true
+	fullVM.ParseStateSummaryF = func(context.Context, []byte) (block.StateSummary, error) {
+		return summary, nil
+	}
+
+	voters := set.Set[ids.NodeID]{}
+	for _, vdr := range vdrs.List() {
+		voters.Add(vdr.NodeID)
+	}
+	require.NoError(putOngoingSync(syncer.DB, &ongoingSync{
+		summaryBytes: summaryBytes,
+		voters:       voters,
+	}))
+
+	contactedFrontiersProviders := set.Set[ids.NodeID]{}
+	sender.CantSendGetStateSummaryFrontier = true
+	sender.SendGetStateSummaryFrontierF = func(_ context.Context, ss set.Set[ids.NodeID], _ uint32) {
+		contactedFrontiersProviders.Union(ss)
+	}
+	contactedVoters := make(map[ids.NodeID]uint32) // nodeID -> reqID map
+	sender.CantSendGetAcceptedStateSummary = true
+	sender.SendGetAcceptedStateSummaryF = func(_ context.Context, ss set.Set[ids.NodeID], reqID uint32, _ []uint64) {
+		for nodeID := range ss {
+			contactedVoters[nodeID] = reqID
+		}
+	}
+
+	for _, vdr := range vdrs.List() {
+		require.NoError(syncer.Connected(context.Background(), vdr.NodeID, version.CurrentApp))
+	}
+	require.True(syncer.resuming)
+	require.Zero(contactedFrontiersProviders.Len())
+
+	// No voter supports the summary anymore.
+	for syncer.pendingVoters.Len() != 0 {
+		voterID, found := syncer.pendingVoters.Peek()
+		require.True(found)
+		require.NoError(syncer.AcceptedStateSummary(
+			context.Background(),
+			voterID,
+			contactedVoters[voterID],
+			nil,
+		))
+	}
+
+	require.False(syncer.resuming)
+	require.NotZero(contactedFrontiersProviders.Len())
+}
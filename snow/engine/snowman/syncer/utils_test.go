@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/snowman/block"
@@ -84,7 +85,7 @@ func buildTestsObjects(t *testing.T, commonCfg *common.Config) (
 	dummyGetter, err := getter.New(fullVM, *commonCfg)
 	require.NoError(t, err)
 
-	cfg, err := NewConfig(*commonCfg, nil, dummyGetter, fullVM)
+	cfg, err := NewConfig(*commonCfg, nil, dummyGetter, fullVM, memdb.New())
 	require.NoError(t, err)
 	commonSyncer := New(cfg, func(context.Context, uint32) error {
 		return nil
@@ -10,6 +10,8 @@ import (
 
 	"go.uber.org/zap"
 
+	"golang.org/x/exp/maps"
+
 	"github.com/lasthyphen/dijetsnodego/cache"
 	"github.com/lasthyphen/dijetsnodego/cache/metercacher"
 	"github.com/lasthyphen/dijetsnodego/ids"
@@ -19,6 +21,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowman/poll"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
 	"github.com/lasthyphen/dijetsnodego/snow/events"
+	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 	"github.com/lasthyphen/dijetsnodego/version"
@@ -26,7 +29,10 @@ import (
 
 const nonVerifiedCacheSize = 128
 
-var _ Engine = (*Transitive)(nil)
+var (
+	_ Engine                    = (*Transitive)(nil)
+	_ common.ProcessingExporter = (*Transitive)(nil)
+)
 
 func New(config Config) (Engine, error) {
 	return newTransitive(config)
@@ -50,6 +56,10 @@ type Transitive struct {
 	// track outstanding preference requests
 	polls poll.Set
 
+	// records of the polls that finished, but haven't been applied to
+	// consensus yet. Only populated if polls are audited.
+	finishedPolls []*poll.Record
+
 	// blocks that have we have sent get requests for but haven't yet received
 	blkReqs common.Requests
 
@@ -100,11 +110,20 @@ func newTransitive(config Config) (*Transitive, error) {
 		pending:                     make(map[ids.ID]snowman.Block),
 		nonVerifieds:                NewAncestorTree(),
 		nonVerifiedCache:            nonVerifiedCache,
-		polls: poll.NewSet(factory,
+	}
+	if config.PollAudit != nil {
+		t.polls = poll.NewAuditedSet(factory,
 			config.Ctx.Log,
 			"",
 			config.Ctx.Registerer,
-		),
+			pollAuditor{t: t},
+		)
+	} else {
+		t.polls = poll.NewSet(factory,
+			config.Ctx.Log,
+			"",
+			config.Ctx.Registerer,
+		)
 	}
 
 	return t, t.metrics.Initialize("", config.Ctx.Registerer)
@@ -448,6 +467,15 @@ func (t *Transitive) HealthCheck(ctx context.Context) (interface{}, error) {
 	return intf, fmt.Errorf("vm: %w ; consensus: %s", vmErr, consensusErr)
 }
 
+func (t *Transitive) ExportProcessing(context.Context) (common.ProcessingExport, error) {
+	tree := t.Consensus.ProcessingTree()
+	tree.NumPolls = t.polls.Len()
+	tree.Polls = t.polls.States()
+	tree.Pending = maps.Keys(t.pending)
+	utils.Sort(tree.Pending)
+	return tree, nil
+}
+
 func (t *Transitive) GetVM() common.VM {
 	return t.VM
 }
@@ -67,6 +67,7 @@ func (v *voter) Update(ctx context.Context) {
 		if err := v.t.Consensus.RecordPoll(ctx, result); err != nil {
 			v.t.errs.Add(err)
 		}
+		v.t.auditPoll(ctx, result)
 	}
 
 	if v.t.errs.Errored() {
@@ -5,6 +5,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow/networking/worker"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
 	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/utils/timer/mockable"
 
 	p2ppb "github.com/lasthyphen/dijetsnodego/proto/pb/p2p"
@@ -38,6 +40,43 @@ const (
 	syncProcessingTimeWarnLimit = 30 * time.Second
 )
 
+var (
+	errPaused        = errors.New("chain is paused")
+	errHalted        = errors.New("chain is halted")
+	errNotProcessing = errors.New("chain isn't running consensus")
+
+	// pausedDroppedOps are the messages that are dropped while the chain is
+	// paused. Responses and failures that aren't deferred are still delivered
+	// so that the requests the engine already sent are resolved.
+	pausedDroppedOps = set.Set[message.Op]{
+		message.GetStateSummaryFrontierOp: struct{}{},
+		message.GetAcceptedStateSummaryOp: struct{}{},
+		message.GetAcceptedFrontierOp:     struct{}{},
+		message.GetAcceptedOp:             struct{}{},
+		message.GetAncestorsOp:            struct{}{},
+		message.GetOp:                     struct{}{},
+		message.PushQueryOp:               struct{}{},
+		message.PullQueryOp:               struct{}{},
+		message.AppRequestOp:              struct{}{},
+		message.AppGossipOp:               struct{}{},
+		message.CrossChainAppRequestOp:    struct{}{},
+		message.GossipRequestOp:           struct{}{},
+	}
+
+	// pausedDeferredOps are the responses and failures that are held while the
+	// chain is paused and delivered once it is resumed. Delivering them would
+	// let consensus record votes, accept blocks and repoll, while dropping them
+	// would leave the engine's polls and requests outstanding forever.
+	pausedDeferredOps = set.Set[message.Op]{
+		message.PutOp:                struct{}{},
+		message.GetFailedOp:          struct{}{},
+		message.AncestorsOp:          struct{}{},
+		message.GetAncestorsFailedOp: struct{}{},
+		message.ChitsOp:              struct{}{},
+		message.QueryFailedOp:        struct{}{},
+	}
+)
+
 var _ Handler = (*handler)(nil)
 
 type Handler interface {
@@ -61,6 +100,24 @@ type Handler interface {
 	Stop(ctx context.Context)
 	StopWithError(ctx context.Context, err error)
 	Stopped() chan struct{}
+
+	// Pause stops delivering new consensus requests, gossip, VM notifications
+	// and the responses to the engine's queries and block requests to the
+	// engine until Resume is called.
+	Pause(ctx context.Context)
+	// Resume undoes Pause. A VM notification that was dropped while paused and
+	// the responses that were held are delivered to the engine.
+	Resume(ctx context.Context) error
+	Paused() bool
+
+	// Halt permanently stops the chain. The engine is shut down as in Stop,
+	// but the chain can't be resumed and keeps reporting healthy.
+	Halt(ctx context.Context)
+	Halted() bool
+
+	// ExportProcessing returns a snapshot of the decisions that the consensus
+	// engine is currently processing.
+	ExportProcessing(ctx context.Context) (common.ProcessingExport, error)
 }
 
 // handler passes incoming messages from the network to the consensus engine.
@@ -106,6 +163,19 @@ type handler struct {
 	closed chan struct{}
 
 	subnetConnector validators.SubnetConnector
+
+	pauser common.Pauser
+	// droppedNotification is the last VM notification that was dropped
+	// because the chain was paused, or nil if none was dropped.
+	// [ctx.Lock] must be held while accessing [droppedNotification].
+	droppedNotification *common.Message
+	// deferredMsgs are the messages that were held because the chain was
+	// paused, in the order they were received.
+	// [ctx.Lock] must be held while accessing [deferredMsgs].
+	deferredMsgs []message.InboundMessage
+
+	// halted is set once the chain was permanently stopped by Halt.
+	halted utils.AtomicBool
 }
 
 // Initialize this consensus handler
@@ -257,14 +327,158 @@ func (h *handler) Start(ctx context.Context, recoverPanic bool) {
 }
 
 func (h *handler) HealthCheck(ctx context.Context) (interface{}, error) {
+	// The engine of a halted chain was shut down on purpose, so it's excluded
+	// from the node's health.
+	if h.halted.GetValue() {
+		return map[string]interface{}{
+			"halted": true,
+		}, nil
+	}
+
+	h.ctx.Lock.Lock()
+	defer h.ctx.Lock.Unlock()
+
+	engine, err := h.getEngine()
+	if err != nil {
+		return nil, err
+	}
+	intf, err := engine.HealthCheck(ctx)
+	if !h.pauser.Paused() {
+		return intf, err
+	}
+	details := map[string]interface{}{
+		"paused": true,
+		"engine": intf,
+	}
+	if err != nil {
+		return details, fmt.Errorf("%w: %v", errPaused, err)
+	}
+	return details, errPaused
+}
+
+func (h *handler) Pause(context.Context) {
+	h.pauser.Pause()
+	h.metrics.paused.Set(1)
+	h.ctx.Log.Info("paused chain")
+}
+
+func (h *handler) Resume(ctx context.Context) error {
+	if h.halted.GetValue() {
+		return errHalted
+	}
+
+	h.ctx.Lock.Lock()
+	defer h.ctx.Lock.Unlock()
+
+	h.pauser.Resume()
+	h.metrics.paused.Set(0)
+	h.ctx.Log.Info("resumed chain",
+		zap.Int("numDeferredMessages", len(h.deferredMsgs)),
+	)
+
+	// The deferred messages are handled after the messages that are already
+	// queued, which doesn't break any ordering the engine relies on since they
+	// are responses to different requests.
+	for _, msg := range h.deferredMsgs {
+		h.syncMessageQueue.Push(ctx, msg)
+	}
+	h.deferredMsgs = nil
+
+	if h.droppedNotification == nil {
+		return nil
+	}
+	notification := *h.droppedNotification
+	h.droppedNotification = nil
+
+	engine, err := h.getEngine()
+	if err != nil {
+		return err
+	}
+	if err := engine.Notify(ctx, notification); err != nil {
+		h.StopWithError(ctx, fmt.Errorf(
+			"%w while delivering notification %s after resuming",
+			err,
+			notification,
+		))
+		return err
+	}
+	return nil
+}
+
+func (h *handler) Paused() bool {
+	return h.pauser.Paused()
+}
+
+func (h *handler) Halt(ctx context.Context) {
+	h.halted.SetValue(true)
+	h.ctx.Log.Info("halting chain")
+	h.Stop(ctx)
+}
+
+func (h *handler) Halted() bool {
+	return h.halted.GetValue()
+}
+
+func (h *handler) ExportProcessing(ctx context.Context) (common.ProcessingExport, error) {
 	h.ctx.Lock.Lock()
 	defer h.ctx.Lock.Unlock()
 
+	if state := h.ctx.GetState(); state != snow.NormalOp {
+		return nil, fmt.Errorf("%w: %s", errNotProcessing, state)
+	}
 	engine, err := h.getEngine()
 	if err != nil {
 		return nil, err
 	}
-	return engine.HealthCheck(ctx)
+	exporter, ok := engine.(common.ProcessingExporter)
+	if !ok {
+		return nil, common.ErrProcessingExportUnsupported
+	}
+	return exporter.ExportProcessing(ctx)
+}
+
+// dropIfPaused returns true if [msg] must not be delivered to the engine
+// because the chain is paused.
+func (h *handler) dropIfPaused(msg message.InboundMessage) bool {
+	op := msg.Op()
+	if !h.pauser.Paused() || !pausedDroppedOps.Contains(op) {
+		return false
+	}
+
+	h.ctx.Log.Debug("dropping message",
+		zap.String("reason", "chain is paused"),
+		zap.Stringer("nodeID", msg.NodeID()),
+		zap.Stringer("messageOp", op),
+	)
+	h.metrics.pausedDrops.Inc()
+	msg.OnFinishedHandling()
+	return true
+}
+
+// deferIfPaused returns true if [msg] must be delivered to the engine once the
+// chain is resumed rather than now.
+func (h *handler) deferIfPaused(msg message.InboundMessage) bool {
+	op := msg.Op()
+	if !pausedDeferredOps.Contains(op) {
+		return false
+	}
+
+	// The lock is held so that the message isn't deferred after [Resume]
+	// delivered the deferred messages.
+	h.ctx.Lock.Lock()
+	defer h.ctx.Lock.Unlock()
+
+	if !h.pauser.Paused() {
+		return false
+	}
+
+	h.ctx.Log.Debug("deferring message",
+		zap.String("reason", "chain is paused"),
+		zap.Stringer("nodeID", msg.NodeID()),
+		zap.Stringer("messageOp", op),
+	)
+	h.deferredMsgs = append(h.deferredMsgs, msg)
+	return true
 }
 
 // Push the message onto the handler's queue
@@ -415,6 +629,10 @@ func (h *handler) dispatchChans(ctx context.Context) {
 
 // Any returned error is treated as fatal
 func (h *handler) handleSyncMsg(ctx context.Context, msg message.InboundMessage) error {
+	if h.dropIfPaused(msg) || h.deferIfPaused(msg) {
+		return nil
+	}
+
 	var (
 		nodeID    = msg.NodeID()
 		op        = msg.Op()
@@ -674,6 +892,10 @@ func (h *handler) handleAsyncMsg(ctx context.Context, msg message.InboundMessage
 
 // Any returned error is treated as fatal
 func (h *handler) executeAsyncMsg(ctx context.Context, msg message.InboundMessage) error {
+	if h.dropIfPaused(msg) {
+		return nil
+	}
+
 	var (
 		nodeID    = msg.NodeID()
 		op        = msg.Op()
@@ -756,6 +978,10 @@ func (h *handler) executeAsyncMsg(ctx context.Context, msg message.InboundMessag
 
 // Any returned error is treated as fatal
 func (h *handler) handleChanMsg(msg message.InboundMessage) error {
+	if h.dropIfPaused(msg) {
+		return nil
+	}
+
 	var (
 		op        = msg.Op()
 		startTime = h.clock.Time()
@@ -786,7 +1012,19 @@ func (h *handler) handleChanMsg(msg message.InboundMessage) error {
 
 	switch msg := msg.Message().(type) {
 	case *message.VMMessage:
-		return engine.Notify(context.TODO(), common.Message(msg.Notification))
+		notification := common.Message(msg.Notification)
+		// The end of state sync must always be delivered, as the engine would
+		// otherwise never leave state syncing.
+		if h.pauser.Paused() && notification != common.StateSyncDone {
+			h.ctx.Log.Debug("dropping VM notification",
+				zap.String("reason", "chain is paused"),
+				zap.Stringer("notification", notification),
+			)
+			h.metrics.pausedDrops.Inc()
+			h.droppedNotification = &notification
+			return nil
+		}
+		return engine.Notify(context.TODO(), notification)
 
 	case *message.GossipRequest:
 		return engine.Gossip(context.TODO())
@@ -858,6 +1096,11 @@ func (h *handler) closeDispatcher(ctx context.Context) {
 }
 
 func (h *handler) shutdown(ctx context.Context) {
+	for _, msg := range h.deferredMsgs {
+		msg.OnFinishedHandling()
+	}
+	h.deferredMsgs = nil
+
 	defer func() {
 		if h.onStopped != nil {
 			go h.onStopped()
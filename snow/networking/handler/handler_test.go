@@ -320,6 +320,187 @@ func TestHandlerDispatchInternal(t *testing.T) {
 	}
 }
 
+// Test that a paused handler drops requests and VM notifications, defers
+// responses, and delivers the dropped notification and the deferred responses
+// once it is resumed
+func TestHandlerPauseAndResume(t *testing.T) {
+	require := require.New(t)
+
+	calledNotify := make(chan struct{}, 1)
+	calledPullQuery := make(chan struct{}, 1)
+	calledChits := make(chan struct{}, 1)
+	ctx := snow.DefaultConsensusContextTest()
+	msgFromVMChan := make(chan common.Message)
+	vdrs := validators.NewSet()
+	err := vdrs.Add(ids.GenerateTestNodeID(), nil, ids.Empty, 1)
+	require.NoError(err)
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+	handler, err := New(
+		ctx,
+		vdrs,
+		msgFromVMChan,
+		nil,
+		time.Second,
+		resourceTracker,
+		validators.UnhandledSubnetConnector,
+	)
+	require.NoError(err)
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+	handler.SetBootstrapper(bootstrapper)
+
+	engine := &common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+	engine.NotifyF = func(context.Context, common.Message) error {
+		calledNotify <- struct{}{}
+		return nil
+	}
+	engine.PullQueryF = func(context.Context, ids.NodeID, uint32, ids.ID) error {
+		calledPullQuery <- struct{}{}
+		return nil
+	}
+	engine.ChitsF = func(context.Context, ids.NodeID, uint32, []ids.ID) error {
+		calledChits <- struct{}{}
+		return nil
+	}
+	handler.SetConsensus(engine)
+	ctx.SetState(snow.NormalOp) // assumed bootstrapping is done
+
+	bootstrapper.StartF = func(context.Context, uint32) error {
+		return nil
+	}
+
+	handler.Pause(context.Background())
+	require.True(handler.Paused())
+
+	_, err = handler.HealthCheck(context.Background())
+	require.ErrorIs(err, errPaused)
+
+	handler.Start(context.Background(), false)
+	msgFromVMChan <- common.PendingTxs
+	handler.Push(context.Background(), message.InboundPullQuery(ids.Empty, 1, time.Second, ids.Empty, ids.EmptyNodeID))
+	handler.Push(context.Background(), message.InboundChits(ids.Empty, 2, []ids.ID{ids.Empty}, ids.EmptyNodeID))
+
+	select {
+	case <-time.After(20 * time.Millisecond):
+	case <-calledNotify:
+		t.Fatalf("shouldn't have called notify while paused")
+	case <-calledPullQuery:
+		t.Fatalf("shouldn't have called pull query while paused")
+	case <-calledChits:
+		t.Fatalf("shouldn't have called chits while paused")
+	}
+
+	require.NoError(handler.Resume(context.Background()))
+	require.False(handler.Paused())
+
+	select {
+	case <-time.After(20 * time.Millisecond):
+		t.Fatalf("should have called notify after resuming")
+	case <-calledNotify:
+	}
+	select {
+	case <-time.After(time.Second):
+		t.Fatalf("should have called chits after resuming")
+	case <-calledChits:
+	}
+
+	_, err = handler.HealthCheck(context.Background())
+	require.NoError(err)
+}
+
+func TestHandlerHalt(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewSet()
+	err := vdrs.Add(ids.GenerateTestNodeID(), nil, ids.Empty, 1)
+	require.NoError(err)
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+	handler, err := New(
+		ctx,
+		vdrs,
+		nil,
+		nil,
+		time.Second,
+		resourceTracker,
+		validators.UnhandledSubnetConnector,
+	)
+	require.NoError(err)
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+	bootstrapper.HaltF = func(context.Context) {}
+	handler.SetBootstrapper(bootstrapper)
+
+	calledShutdown := false
+	engine := &common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+	engine.ShutdownF = func(context.Context) error {
+		calledShutdown = true
+		return nil
+	}
+	handler.SetConsensus(engine)
+	ctx.SetState(snow.NormalOp) // assumed bootstrapping is done
+
+	bootstrapper.StartF = func(context.Context, uint32) error {
+		return nil
+	}
+
+	handler.Start(context.Background(), false)
+	handler.Halt(context.Background())
+	require.True(handler.Halted())
+
+	select {
+	case <-handler.Stopped():
+	case <-time.After(time.Second):
+		t.Fatalf("should have stopped the halted chain")
+	}
+	require.True(calledShutdown)
+
+	// The shutdown of a halted chain is expected, so it's reported healthy.
+	_, err = handler.HealthCheck(context.Background())
+	require.NoError(err)
+
+	err = handler.Resume(context.Background())
+	require.ErrorIs(err, errHalted)
+}
+
 func TestHandlerSubnetConnector(t *testing.T) {
 	ctx := snow.DefaultConsensusContextTest()
 	vdrs := validators.NewSet()
@@ -16,6 +16,8 @@ import (
 type metrics struct {
 	expired      prometheus.Counter
 	asyncExpired prometheus.Counter
+	paused       prometheus.Gauge
+	pausedDrops  prometheus.Counter
 	messages     map[message.Op]metric.Averager
 }
 
@@ -32,9 +34,21 @@ func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
 		Name:      "async_expired",
 		Help:      "Incoming async messages dropped because the message deadline expired",
 	})
+	paused := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "paused",
+		Help:      "1 if the chain's consensus is paused, 0 otherwise",
+	})
+	pausedDrops := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "paused_drops",
+		Help:      "Incoming messages and VM notifications dropped because the chain was paused",
+	})
 	errs.Add(
 		reg.Register(expired),
 		reg.Register(asyncExpired),
+		reg.Register(paused),
+		reg.Register(pausedDrops),
 	)
 
 	messages := make(map[message.Op]metric.Averager, len(message.ConsensusOps))
@@ -52,6 +66,8 @@ func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
 	return &metrics{
 		expired:      expired,
 		asyncExpired: asyncExpired,
+		paused:       paused,
+		pausedDrops:  pausedDrops,
 		messages:     messages,
 	}, errs.Err
 }
@@ -262,3 +262,84 @@ func (mr *MockHandlerMockRecorder) Stopped() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stopped", reflect.TypeOf((*MockHandler)(nil).Stopped))
 }
+
+// Pause mocks base method.
+func (m *MockHandler) Pause(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Pause", arg0)
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockHandlerMockRecorder) Pause(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockHandler)(nil).Pause), arg0)
+}
+
+// Paused mocks base method.
+func (m *MockHandler) Paused() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Paused")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Paused indicates an expected call of Paused.
+func (mr *MockHandlerMockRecorder) Paused() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Paused", reflect.TypeOf((*MockHandler)(nil).Paused))
+}
+
+// Resume mocks base method.
+func (m *MockHandler) Resume(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockHandlerMockRecorder) Resume(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockHandler)(nil).Resume), arg0)
+}
+
+// Halt mocks base method.
+func (m *MockHandler) Halt(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Halt", arg0)
+}
+
+// Halt indicates an expected call of Halt.
+func (mr *MockHandlerMockRecorder) Halt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Halt", reflect.TypeOf((*MockHandler)(nil).Halt), arg0)
+}
+
+// Halted mocks base method.
+func (m *MockHandler) Halted() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Halted")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Halted indicates an expected call of Halted.
+func (mr *MockHandlerMockRecorder) Halted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Halted", reflect.TypeOf((*MockHandler)(nil).Halted))
+}
+
+// ExportProcessing mocks base method.
+func (m *MockHandler) ExportProcessing(arg0 context.Context) (common.ProcessingExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProcessing", arg0)
+	ret0, _ := ret[0].(common.ProcessingExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportProcessing indicates an expected call of ExportProcessing.
+func (mr *MockHandlerMockRecorder) ExportProcessing(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProcessing", reflect.TypeOf((*MockHandler)(nil).ExportProcessing), arg0)
+}
@@ -344,7 +344,7 @@ func (cr *ChainRouter) AddChain(ctx context.Context, chain handler.Handler) {
 		zap.Stringer("chainID", chainID),
 	)
 	chain.SetOnStopped(func() {
-		cr.removeChain(ctx, chainID)
+		cr.removeChain(ctx, chain)
 	})
 	cr.chains[chainID] = chain
 
@@ -535,11 +535,13 @@ func (cr *ChainRouter) HealthCheck(context.Context) (interface{}, error) {
 }
 
 // RemoveChain removes the specified chain so that incoming
-// messages can't be routed to it
-func (cr *ChainRouter) removeChain(ctx context.Context, chainID ids.ID) {
+// messages can't be routed to it. If the chain was restarted, and messages are
+// routed to a different handler, that handler is kept.
+func (cr *ChainRouter) removeChain(ctx context.Context, chain handler.Handler) {
+	chainID := chain.Context().ChainID
+
 	cr.lock.Lock()
-	chain, exists := cr.chains[chainID]
-	if !exists {
+	if registeredChain, exists := cr.chains[chainID]; !exists || registeredChain != chain {
 		cr.log.Debug("can't remove unknown chain",
 			zap.Stringer("chainID", chainID),
 		)
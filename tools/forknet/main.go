@@ -0,0 +1,251 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// forknet forks the state of a network, read from the database of one of its
+// nodes, into the genesis of a local network with newly generated validators.
+//
+// The output directory contains:
+//   - genesis.json: the genesis of the forked network
+//   - node-<i>/staker.crt and node-<i>/staker.key: the staking key pairs of
+//     the validators
+//   - funding.key: the key that owns the stake of the validators
+//   - subnets.json: the subnets and chains that must be re-created
+//   - outputs.json: the X-chain DJTX the funding key must send to the owners of
+//     multisig outputs
+//
+// Only DJTX is forked. The subnet chains start from their genesis once they
+// are re-created, so their state isn't forked. By default, the fork fails if
+// the network holds UTXOs that can't be forked.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/spf13/pflag"
+
+	"github.com/lasthyphen/dijetsnodego/database/manager"
+	"github.com/lasthyphen/dijetsnodego/genesis"
+	"github.com/lasthyphen/dijetsnodego/genesis/fork"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/formatting/address"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/perms"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+	"github.com/lasthyphen/dijetsnodego/version"
+)
+
+const (
+	dbDirKey             = "db-dir"
+	networkIDKey         = "network-id"
+	numNodesKey          = "nodes"
+	stakeAmountKey       = "stake-amount"
+	delegationFeeKey     = "delegation-fee"
+	stakeDurationKey     = "stake-duration"
+	cChainGenesisFileKey = "c-chain-genesis-file"
+	outputDirKey         = "output-dir"
+	skipUTXOsKey         = "skip-unsupported-utxos"
+
+	defaultNetworkID     = 1337
+	defaultNumNodes      = 5
+	defaultStakeAmount   = 2 * units.KiloDjtx
+	defaultDelegationFee = 20000
+	defaultStakeDuration = 365 * 24 * time.Hour
+	defaultOutputDir     = "forked-network"
+
+	genesisFileName     = "genesis.json"
+	subnetsFileName     = "subnets.json"
+	outputsFileName     = "outputs.json"
+	fundingKeyFileName  = "funding.key"
+	stakingCertFileName = "staker.crt"
+	stakingKeyFileName  = "staker.key"
+	nodeDirNameFormat   = "node-%d"
+	genesisMessage      = "forked"
+)
+
+var errNoDBDir = errors.New("--db-dir must be specified")
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Printf("couldn't fork network: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	fs := pflag.NewFlagSet("forknet", pflag.ContinueOnError)
+	dbDir := fs.String(dbDirKey, "", "Path to the database directory of a stopped node of the network to fork, including the network name")
+	networkID := fs.Uint32(networkIDKey, defaultNetworkID, "Network ID of the forked network")
+	numNodes := fs.Int(numNodesKey, defaultNumNodes, "Number of validators of the forked network")
+	stakeAmount := fs.Uint64(stakeAmountKey, defaultStakeAmount, "Amount of nDJTX staked by each validator")
+	delegationFee := fs.Uint32(delegationFeeKey, defaultDelegationFee, "Delegation fee of each validator, out of 1,000,000")
+	stakeDuration := fs.Duration(stakeDurationKey, defaultStakeDuration, "Staking duration of the validators")
+	cChainGenesisFile := fs.String(cChainGenesisFileKey, "", "Path to the C-chain genesis. Defaults to the C-chain genesis of the local network")
+	outputDir := fs.String(outputDirKey, defaultOutputDir, "Directory the forked network is written to")
+	skipUTXOs := fs.Bool(skipUTXOsKey, false, "Drop the UTXOs of assets other than DJTX rather than failing the fork")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if *dbDir == "" {
+		return errNoDBDir
+	}
+
+	cChainGenesis := genesis.LocalConfig.CChainGenesis
+	if *cChainGenesisFile != "" {
+		cChainGenesisBytes, err := os.ReadFile(*cChainGenesisFile)
+		if err != nil {
+			return err
+		}
+		cChainGenesis = string(cChainGenesisBytes)
+	}
+
+	dbManager, err := manager.NewLevelDB(*dbDir, nil, logging.NoLog{}, version.CurrentDatabase, "", prometheus.NewRegistry())
+	if err != nil {
+		return err
+	}
+	builder, height, err := fork.Read(dbManager.Current().Database)
+	if closeErr := dbManager.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	network, err := builder.Build(&fork.Config{
+		NetworkID:            *networkID,
+		NumNodes:             *numNodes,
+		StakeAmount:          *stakeAmount,
+		DelegationFee:        *delegationFee,
+		StartTime:            time.Now(),
+		InitialStakeDuration: *stakeDuration,
+		CChainGenesis:        cChainGenesis,
+		Message:              genesisMessage,
+		SkipUnsupportedUTXOs: *skipUTXOs,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := write(*outputDir, network); err != nil {
+		return err
+	}
+
+	fundingAddr, err := address.Format(
+		"P",
+		constants.GetHRP(*networkID),
+		network.FundingKey.PublicKey().Address().Bytes(),
+	)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("forked P-chain height %d into network %d\n", height, *networkID)
+	fmt.Printf("allocations: %d\n", len(network.Genesis.Allocations))
+	fmt.Printf("multisig outputs to re-create: %d\n", len(network.Outputs))
+	fmt.Printf("skipped UTXOs: %d\n", network.SkippedUTXOs)
+	fmt.Printf("subnets to re-create: %d\n", len(network.Subnets))
+	fmt.Printf("funding address: %s\n", fundingAddr)
+	for i, node := range network.Nodes {
+		fmt.Printf("node %d: %s\n", i, node.NodeID)
+	}
+	fmt.Printf("written to %s\n", *outputDir)
+	return nil
+}
+
+func write(dir string, network *fork.Network) error {
+	unparsedGenesis, err := network.Genesis.Unparse()
+	if err != nil {
+		return err
+	}
+	genesisBytes, err := json.MarshalIndent(unparsedGenesis, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	// The owners are marshalled with the addresses formatted for the chain
+	// they're used on.
+	pChainCtx, err := newCtx(network.Genesis.NetworkID, constants.PlatformChainID, "P")
+	if err != nil {
+		return err
+	}
+	for _, subnet := range network.Subnets {
+		if subnet.Owner != nil {
+			subnet.Owner.InitCtx(pChainCtx)
+		}
+	}
+	subnetsBytes, err := json.MarshalIndent(network.Subnets, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	parsedGenesis, _, err := genesis.FromConfig(network.Genesis)
+	if err != nil {
+		return err
+	}
+	xChainTx, err := genesis.VMGenesis(parsedGenesis, constants.AVMID)
+	if err != nil {
+		return err
+	}
+	xChainCtx, err := newCtx(network.Genesis.NetworkID, xChainTx.ID(), "X")
+	if err != nil {
+		return err
+	}
+	for _, output := range network.Outputs {
+		output.Owners.InitCtx(xChainCtx)
+	}
+	outputsBytes, err := json.MarshalIndent(network.Outputs, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, perms.ReadWriteExecute); err != nil {
+		return err
+	}
+	if err := perms.WriteFile(filepath.Join(dir, genesisFileName), genesisBytes, perms.ReadWrite); err != nil {
+		return err
+	}
+	if err := perms.WriteFile(filepath.Join(dir, subnetsFileName), subnetsBytes, perms.ReadWrite); err != nil {
+		return err
+	}
+	if err := perms.WriteFile(filepath.Join(dir, outputsFileName), outputsBytes, perms.ReadWrite); err != nil {
+		return err
+	}
+	if err := perms.WriteFile(filepath.Join(dir, fundingKeyFileName), []byte(network.FundingKey.String()), perms.ReadOnly); err != nil {
+		return err
+	}
+	for i, node := range network.Nodes {
+		nodeDir := filepath.Join(dir, fmt.Sprintf(nodeDirNameFormat, i))
+		if err := os.MkdirAll(nodeDir, perms.ReadWriteExecute); err != nil {
+			return err
+		}
+		if err := perms.WriteFile(filepath.Join(nodeDir, stakingCertFileName), node.StakingCert, perms.ReadOnly); err != nil {
+			return err
+		}
+		if err := perms.WriteFile(filepath.Join(nodeDir, stakingKeyFileName), node.StakingKey, perms.ReadOnly); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newCtx(networkID uint32, chainID ids.ID, alias string) (*snow.Context, error) {
+	aliaser := ids.NewAliaser()
+	if err := aliaser.Alias(chainID, alias); err != nil {
+		return nil, err
+	}
+	return &snow.Context{
+		NetworkID: networkID,
+		ChainID:   chainID,
+		BCLookup:  aliaser,
+	}, nil
+}
@@ -101,6 +101,62 @@ var (
 		constants.TahoeID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
 	}
 	XChainMigrationDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// FIXME: update this before release
+	StateRootTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.TahoeID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	StateRootDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// FIXME: update this before release
+	ExpiryTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.TahoeID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	ExpiryDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// FIXME: update this before release
+	HTLCFxTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.TahoeID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	HTLCFxDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// FIXME: update this before release
+	FreezeFxTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.TahoeID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	FreezeFxDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// FIXME: update this before release
+	SubnetOwnershipTransferTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.TahoeID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	SubnetOwnershipTransferDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// FIXME: update this before release
+	SubnetValidatorModificationTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.TahoeID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	SubnetValidatorModificationDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// FIXME: update this before release
+	BlockchainModificationTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.TahoeID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	BlockchainModificationDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// FIXME: update this before release
+	RestakeTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.TahoeID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	RestakeDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
 )
 
 func init() {
@@ -173,6 +229,62 @@ func GetXChainMigrationTime(networkID uint32) time.Time {
 	return XChainMigrationDefaultTime
 }
 
+func GetStateRootTime(networkID uint32) time.Time {
+	if upgradeTime, exists := StateRootTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return StateRootDefaultTime
+}
+
+func GetExpiryTime(networkID uint32) time.Time {
+	if upgradeTime, exists := ExpiryTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return ExpiryDefaultTime
+}
+
+func GetHTLCFxTime(networkID uint32) time.Time {
+	if upgradeTime, exists := HTLCFxTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return HTLCFxDefaultTime
+}
+
+func GetFreezeFxTime(networkID uint32) time.Time {
+	if upgradeTime, exists := FreezeFxTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return FreezeFxDefaultTime
+}
+
+func GetSubnetOwnershipTransferTime(networkID uint32) time.Time {
+	if upgradeTime, exists := SubnetOwnershipTransferTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return SubnetOwnershipTransferDefaultTime
+}
+
+func GetSubnetValidatorModificationTime(networkID uint32) time.Time {
+	if upgradeTime, exists := SubnetValidatorModificationTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return SubnetValidatorModificationDefaultTime
+}
+
+func GetBlockchainModificationTime(networkID uint32) time.Time {
+	if upgradeTime, exists := BlockchainModificationTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return BlockchainModificationDefaultTime
+}
+
+func GetRestakeTime(networkID uint32) time.Time {
+	if upgradeTime, exists := RestakeTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return RestakeDefaultTime
+}
+
 func GetCompatibility(networkID uint32) Compatibility {
 	return NewCompatibility(
 		CurrentApp,
@@ -33,6 +33,9 @@ type Client interface {
 	ConfirmTx(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (choices.Status, error)
 	// GetTx returns the byte representation of [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
+	// SimulateTx verifies [tx] against the current state without issuing it
+	// and returns its effects
+	SimulateTx(ctx context.Context, tx []byte, options ...rpc.Option) (*SimulateTxReply, error)
 	// IssueStopVertex issues a stop vertex.
 	IssueStopVertex(ctx context.Context, options ...rpc.Option) error
 	// GetUTXOs returns the byte representation of the UTXOs controlled by [addrs]
@@ -60,6 +63,9 @@ type Client interface {
 	// GetBalance returns the balance of [assetID] held by [addr].
 	// If [includePartial], balance includes partial owned (i.e. in a multisig) funds.
 	GetBalance(ctx context.Context, addr ids.ShortID, assetID string, includePartial bool, options ...rpc.Option) (*GetBalanceReply, error)
+	// GetBalanceAtHeight returns the balance of [assetID] held by [addr] as of
+	// [height].
+	GetBalanceAtHeight(ctx context.Context, addr ids.ShortID, assetID string, includePartial bool, height uint64, options ...rpc.Option) (*GetBalanceReply, error)
 	// GetAllBalances returns all asset balances for [addr]
 	GetAllBalances(ctx context.Context, addr ids.ShortID, includePartial bool, options ...rpc.Option) ([]Balance, error)
 	// CreateAsset creates a new asset and returns its assetID
@@ -200,6 +206,19 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) SimulateTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*SimulateTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &SimulateTxReply{}
+	err = c.requester.SendRequest(ctx, "avm.simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) IssueStopVertex(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "avm.issueStopVertex", &struct{}{}, &struct{}{}, options...)
 }
@@ -324,6 +343,25 @@ func (c *client) GetBalance(
 	return res, err
 }
 
+func (c *client) GetBalanceAtHeight(
+	ctx context.Context,
+	addr ids.ShortID,
+	assetID string,
+	includePartial bool,
+	height uint64,
+	options ...rpc.Option,
+) (*GetBalanceReply, error) {
+	jsonHeight := cjson.Uint64(height)
+	res := &GetBalanceReply{}
+	err := c.requester.SendRequest(ctx, "avm.getBalance", &GetBalanceArgs{
+		HistoricalArgs: api.HistoricalArgs{Height: &jsonHeight},
+		Address:        addr.String(),
+		AssetID:        assetID,
+		IncludePartial: includePartial,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetAllBalances(
 	ctx context.Context,
 	addr ids.ShortID,
@@ -569,9 +607,10 @@ func (c *client) SendMultiple(
 	outputs := make([]SendOutput, len(clientOutputs))
 	for i, clientOutput := range clientOutputs {
 		outputs[i] = SendOutput{
-			Amount:  cjson.Uint64(clientOutput.Amount),
-			AssetID: clientOutput.AssetID,
-			To:      clientOutput.To.String(),
+			Amount:   cjson.Uint64(clientOutput.Amount),
+			AssetID:  clientOutput.AssetID,
+			To:       clientOutput.To.String(),
+			Locktime: cjson.Uint64(clientOutput.Locktime),
 		}
 	}
 	err := c.requester.SendRequest(ctx, "avm.sendMultiple", &SendMultipleArgs{
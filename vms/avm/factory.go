@@ -4,6 +4,8 @@
 package avm
 
 import (
+	"time"
+
 	"github.com/lasthyphen/dijetsnodego/snow"
 	"github.com/lasthyphen/dijetsnodego/vms"
 )
@@ -13,6 +15,11 @@ var _ vms.Factory = (*Factory)(nil)
 type Factory struct {
 	TxFee            uint64
 	CreateAssetTxFee uint64
+
+	// Time of the network upgrade that enables the htlc fx
+	HTLCFxTime time.Time
+	// Time of the network upgrade that enables the freeze fx
+	FreezeFxTime time.Time
 }
 
 func (f *Factory) New(*snow.Context) (interface{}, error) {
@@ -8,6 +8,8 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/freezefx"
+	"github.com/lasthyphen/dijetsnodego/vms/htlcfx"
 	"github.com/lasthyphen/dijetsnodego/vms/nftfx"
 	"github.com/lasthyphen/dijetsnodego/vms/propertyfx"
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
@@ -17,6 +19,8 @@ var (
 	_ Fx = (*secp256k1fx.Fx)(nil)
 	_ Fx = (*nftfx.Fx)(nil)
 	_ Fx = (*propertyfx.Fx)(nil)
+	_ Fx = (*htlcfx.Fx)(nil)
+	_ Fx = (*freezefx.Fx)(nil)
 )
 
 type ParsedFx struct {
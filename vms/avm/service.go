@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"sort"
 
 	"go.uber.org/zap"
 
@@ -53,6 +54,7 @@ var (
 	errNoAddresses            = errors.New("no addresses provided")
 	errNoKeys                 = errors.New("from addresses have no keys or funds")
 	errMissingPrivateKey      = errors.New("argument 'privateKey' not given")
+	errHistoricalAtomicUTXOs  = errors.New("historical queries aren't supported for atomic UTXOs")
 )
 
 // Service defines the base service for the asset vm
@@ -82,6 +84,86 @@ func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, reply *api.JSO
 	return nil
 }
 
+// SimulateTxReply is the response from calling SimulateTx
+type SimulateTxReply struct {
+	TxID ids.ID `json:"txID"`
+	// True if the tx would currently be issued into consensus
+	Valid bool `json:"valid"`
+	// Reason the tx failed verification. Only non-empty if Valid is false
+	Error string `json:"error,omitempty"`
+	// The following fields are only set if Valid is true.
+
+	// Amount of the fee asset burned by the tx
+	Fee json.Uint64 `json:"fee"`
+	// IDs of the UTXOs consumed by the tx
+	ConsumedUTXOIDs []ids.ID `json:"consumedUTXOIDs"`
+	// UTXOs produced by the tx on this chain
+	ProducedUTXOs []string `json:"producedUTXOs"`
+	// Encoding of [ProducedUTXOs]
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// SimulateTx verifies a tx against the current state, without issuing it into
+// consensus, and reports its effects.
+func (s *Service) SimulateTx(_ *http.Request, args *api.FormattedTx, reply *SimulateTxReply) error {
+	s.vm.ctx.Log.Debug("AVM: SimulateTx called",
+		logging.UserString("tx", args.Tx),
+	)
+
+	if !s.vm.bootstrapped {
+		return errBootstrapping
+	}
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := s.vm.parser.Parse(txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+
+	// The effects of a tx are only reported if it is valid, since the fee of
+	// an invalid tx may not be defined.
+	reply.TxID = tx.ID()
+	if err := s.vm.verifyTx(tx); err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+
+	flow := &txFlow{}
+	if err := tx.Unsigned.Visit(flow); err != nil {
+		return err
+	}
+	fee, err := djtx.Burned(s.vm.feeAssetID, flow.ins, flow.outs)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate fee: %w", err)
+	}
+
+	reply.Valid = true
+	reply.Fee = json.Uint64(fee)
+	inputUTXOs := tx.Unsigned.InputUTXOs()
+	reply.ConsumedUTXOIDs = make([]ids.ID, len(inputUTXOs))
+	for i, utxoID := range inputUTXOs {
+		reply.ConsumedUTXOIDs[i] = utxoID.InputID()
+	}
+
+	utxos := tx.UTXOs()
+	reply.ProducedUTXOs = make([]string, len(utxos))
+	for i, utxo := range utxos {
+		utxoBytes, err := s.vm.parser.Codec().Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return fmt.Errorf("couldn't marshal UTXO %s: %w", utxo.InputID(), err)
+		}
+		reply.ProducedUTXOs[i], err = formatting.Encode(args.Encoding, utxoBytes)
+		if err != nil {
+			return fmt.Errorf("couldn't encode UTXO %s as string: %w", utxo.InputID(), err)
+		}
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
 func (s *Service) IssueStopVertex(_ *http.Request, _, _ *struct{}) error {
 	return s.vm.issueStopVertex()
 }
@@ -217,8 +299,16 @@ func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, reply *api.GetTxRe
 	return nil
 }
 
+// GetUTXOsReply defines the GetUTXOs replies returned from the API
+type GetUTXOsReply struct {
+	api.GetUTXOsReply
+	// Locktimes[i] is the unix time until which UTXOs[i] can't be spent, or 0
+	// if it isn't timelocked.
+	Locktimes []json.Uint64 `json:"locktimes"`
+}
+
 // GetUTXOs gets all utxos for passed in addresses
-func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, reply *api.GetUTXOsReply) error {
+func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, reply *GetUTXOsReply) error {
 	s.vm.ctx.Log.Debug("AVM: GetUTXOs called",
 		logging.UserStrings("addresses", args.Addresses),
 	)
@@ -269,14 +359,22 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, reply *api.G
 		limit = int(maxPageSize)
 	}
 	if sourceChain == s.vm.ctx.ChainID {
+		var reader djtx.UTXOReader
+		reader, _, err = s.vm.getUTXOReader(addrSet, &args.HistoricalArgs)
+		if err != nil {
+			return err
+		}
 		utxos, endAddr, endUTXOID, err = djtx.GetPaginatedUTXOs(
-			s.vm.state,
+			reader,
 			addrSet,
 			startAddr,
 			startUTXO,
 			limit,
 		)
 	} else {
+		if args.Height != nil || args.Timestamp != nil {
+			return errHistoricalAtomicUTXOs
+		}
 		utxos, endAddr, endUTXOID, err = s.vm.GetAtomicUTXOs(
 			sourceChain,
 			addrSet,
@@ -290,8 +388,10 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, reply *api.G
 	}
 
 	reply.UTXOs = make([]string, len(utxos))
+	reply.Locktimes = make([]json.Uint64, len(utxos))
 	codec := s.vm.parser.Codec()
 	for i, utxo := range utxos {
+		reply.Locktimes[i] = json.Uint64(locktimeOf(utxo.Out))
 		b, err := codec.Marshal(txs.CodecVersion, utxo)
 		if err != nil {
 			return fmt.Errorf("problem marshalling UTXO: %w", err)
@@ -314,6 +414,23 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, reply *api.G
 	return nil
 }
 
+// locktimeOf returns the unix time until which [out] can't be spent, or 0 if
+// it isn't timelocked.
+func locktimeOf(out verify.State) uint64 {
+	switch out := out.(type) {
+	case *secp256k1fx.TransferOutput:
+		return out.Locktime
+	case *secp256k1fx.MintOutput:
+		return out.Locktime
+	case *nftfx.TransferOutput:
+		return out.Locktime
+	case *nftfx.MintOutput:
+		return out.Locktime
+	default:
+		return 0
+	}
+}
+
 // GetAssetDescriptionArgs are arguments for passing into GetAssetDescription requests
 type GetAssetDescriptionArgs struct {
 	AssetID string `json:"assetID"`
@@ -360,6 +477,7 @@ func (s *Service) GetAssetDescription(_ *http.Request, args *GetAssetDescription
 
 // GetBalanceArgs are arguments for passing into GetBalance requests
 type GetBalanceArgs struct {
+	api.HistoricalArgs
 	Address        string `json:"address"`
 	AssetID        string `json:"assetID"`
 	IncludePartial bool   `json:"includePartial"`
@@ -369,6 +487,34 @@ type GetBalanceArgs struct {
 type GetBalanceReply struct {
 	Balance json.Uint64   `json:"balance"`
 	UTXOIDs []djtx.UTXOID `json:"utxoIDs"`
+	// Unlocked is the part of the balance that can be spent now.
+	Unlocked json.Uint64 `json:"unlocked"`
+	// Locked is the amount held by the address that can't be spent yet.
+	Locked json.Uint64 `json:"locked"`
+	// Locks splits [Locked] by the time it unlocks, in increasing order.
+	Locks []LockedBalance `json:"locks"`
+}
+
+// LockedBalance is an amount that can't be spent until [Locktime].
+type LockedBalance struct {
+	Amount   json.Uint64 `json:"amount"`
+	Locktime json.Uint64 `json:"locktime"`
+}
+
+// newLockedBalances returns the amounts of [locked], which is keyed by
+// locktime, in increasing locktime order.
+func newLockedBalances(locked map[uint64]uint64) []LockedBalance {
+	locks := make([]LockedBalance, 0, len(locked))
+	for locktime, amount := range locked {
+		locks = append(locks, LockedBalance{
+			Amount:   json.Uint64(amount),
+			Locktime: json.Uint64(locktime),
+		})
+	}
+	sort.Slice(locks, func(i, j int) bool {
+		return locks[i].Locktime < locks[j].Locktime
+	})
+	return locks
 }
 
 // GetBalance returns the balance of an asset held by an address.
@@ -376,6 +522,10 @@ type GetBalanceReply struct {
 // (1 out of 1 multisig) by the address and with a locktime in the past.
 // Otherwise, returned balance includes assets held only partially by the
 // address, and includes balances with locktime in the future.
+// Regardless of [args.IncludePartial], the balance that is still locked is
+// reported separately from the unlocked balance.
+// If [args.Height] or [args.Timestamp] is specified, returns the balance as of
+// that point.
 func (s *Service) GetBalance(_ *http.Request, args *GetBalanceArgs, reply *GetBalanceReply) error {
 	s.vm.ctx.Log.Debug("AVM: GetBalance called",
 		logging.UserString("address", args.Address),
@@ -395,13 +545,17 @@ func (s *Service) GetBalance(_ *http.Request, args *GetBalanceArgs, reply *GetBa
 	addrSet := set.Set[ids.ShortID]{}
 	addrSet.Add(addr)
 
-	utxos, err := djtx.GetAllUTXOs(s.vm.state, addrSet)
+	reader, now, err := s.vm.getUTXOReader(addrSet, &args.HistoricalArgs)
+	if err != nil {
+		return err
+	}
+	utxos, err := djtx.GetAllUTXOs(reader, addrSet)
 	if err != nil {
 		return fmt.Errorf("problem retrieving UTXOs: %w", err)
 	}
 
-	now := s.vm.clock.Unix()
 	reply.UTXOIDs = make([]djtx.UTXOID, 0, len(utxos))
+	locked := make(map[uint64]uint64) // locktime -> amount
 	for _, utxo := range utxos {
 		if utxo.AssetID() != assetID {
 			continue
@@ -412,9 +566,30 @@ func (s *Service) GetBalance(_ *http.Request, args *GetBalanceArgs, reply *GetBa
 			continue
 		}
 		owners := transferable.OutputOwners
-		if !args.IncludePartial && (len(owners.Addrs) != 1 || owners.Locktime > now) {
+		if !args.IncludePartial && len(owners.Addrs) != 1 {
 			continue
 		}
+		if owners.Locktime > now {
+			lockedAmt, err := safemath.Add64(transferable.Amount(), locked[owners.Locktime])
+			if err != nil {
+				return err
+			}
+			locked[owners.Locktime] = lockedAmt
+			totalLocked, err := safemath.Add64(transferable.Amount(), uint64(reply.Locked))
+			if err != nil {
+				return err
+			}
+			reply.Locked = json.Uint64(totalLocked)
+			if !args.IncludePartial {
+				continue
+			}
+		} else {
+			unlocked, err := safemath.Add64(transferable.Amount(), uint64(reply.Unlocked))
+			if err != nil {
+				return err
+			}
+			reply.Unlocked = json.Uint64(unlocked)
+		}
 		amt, err := safemath.Add64(transferable.Amount(), uint64(reply.Balance))
 		if err != nil {
 			return err
@@ -422,6 +597,7 @@ func (s *Service) GetBalance(_ *http.Request, args *GetBalanceArgs, reply *GetBa
 		reply.Balance = json.Uint64(amt)
 		reply.UTXOIDs = append(reply.UTXOIDs, utxo.UTXOID)
 	}
+	reply.Locks = newLockedBalances(locked)
 
 	return nil
 }
@@ -429,10 +605,17 @@ func (s *Service) GetBalance(_ *http.Request, args *GetBalanceArgs, reply *GetBa
 type Balance struct {
 	AssetID string      `json:"asset"`
 	Balance json.Uint64 `json:"balance"`
+	// Unlocked is the part of the balance that can be spent now.
+	Unlocked json.Uint64 `json:"unlocked"`
+	// Locked is the amount held by the address that can't be spent yet.
+	Locked json.Uint64 `json:"locked"`
+	// Locks splits [Locked] by the time it unlocks, in increasing order.
+	Locks []LockedBalance `json:"locks"`
 }
 
 type GetAllBalancesArgs struct {
 	api.JSONAddress
+	api.HistoricalArgs
 	IncludePartial bool `json:"includePartial"`
 }
 
@@ -447,6 +630,11 @@ type GetAllBalancesReply struct {
 // If ![args.IncludePartial], returns only unlocked balance/UTXOs with a 1-out-of-1 multisig.
 // Otherwise, returned balance/UTXOs includes assets held only partially by the
 // address, and includes balances with locktime in the future.
+// Regardless of [args.IncludePartial], the amount of each asset that is still
+// locked is reported separately from the unlocked balance, so assets that are
+// only held in locked outputs are returned with a zero balance.
+// If [args.Height] or [args.Timestamp] is specified, returns the balances as of
+// that point.
 func (s *Service) GetAllBalances(_ *http.Request, args *GetAllBalancesArgs, reply *GetAllBalancesReply) error {
 	s.vm.ctx.Log.Debug("AVM: GetAllBalances called",
 		logging.UserString("address", args.Address),
@@ -459,14 +647,28 @@ func (s *Service) GetAllBalances(_ *http.Request, args *GetAllBalancesArgs, repl
 	addrSet := set.Set[ids.ShortID]{}
 	addrSet.Add(address)
 
-	utxos, err := djtx.GetAllUTXOs(s.vm.state, addrSet)
+	reader, now, err := s.vm.getUTXOReader(addrSet, &args.HistoricalArgs)
+	if err != nil {
+		return err
+	}
+	utxos, err := djtx.GetAllUTXOs(reader, addrSet)
 	if err != nil {
 		return fmt.Errorf("couldn't get address's UTXOs: %w", err)
 	}
 
-	now := s.vm.clock.Unix()
+	// addCapped returns [a] + [b], or MaxUint64 if it overflows.
+	addCapped := func(a, b uint64) uint64 {
+		sum, err := safemath.Add64(a, b)
+		if err != nil {
+			return math.MaxUint64
+		}
+		return sum
+	}
+
 	assetIDs := set.Set[ids.ID]{}       // IDs of assets the address has a non-zero balance of
 	balances := make(map[ids.ID]uint64) // key: ID (as bytes). value: balance of that asset
+	unlocked := make(map[ids.ID]uint64)
+	locked := make(map[ids.ID]map[uint64]uint64) // asset ID -> locktime -> amount
 	for _, utxo := range utxos {
 		// TODO make this not specific to *secp256k1fx.TransferOutput
 		transferable, ok := utxo.Out.(*secp256k1fx.TransferOutput)
@@ -474,27 +676,41 @@ func (s *Service) GetAllBalances(_ *http.Request, args *GetAllBalancesArgs, repl
 			continue
 		}
 		owners := transferable.OutputOwners
-		if !args.IncludePartial && (len(owners.Addrs) != 1 || owners.Locktime > now) {
+		if !args.IncludePartial && len(owners.Addrs) != 1 {
 			continue
 		}
 		assetID := utxo.AssetID()
 		assetIDs.Add(assetID)
-		balance := balances[assetID] // 0 if key doesn't exist
-		balance, err := safemath.Add64(transferable.Amount(), balance)
-		if err != nil {
-			balances[assetID] = math.MaxUint64
+		if owners.Locktime > now {
+			assetLocked, ok := locked[assetID]
+			if !ok {
+				assetLocked = make(map[uint64]uint64)
+				locked[assetID] = assetLocked
+			}
+			assetLocked[owners.Locktime] = addCapped(transferable.Amount(), assetLocked[owners.Locktime])
+			if !args.IncludePartial {
+				continue
+			}
 		} else {
-			balances[assetID] = balance
+			unlocked[assetID] = addCapped(transferable.Amount(), unlocked[assetID])
 		}
+		balances[assetID] = addCapped(transferable.Amount(), balances[assetID])
 	}
 
 	reply.Balances = make([]Balance, assetIDs.Len())
 	i := 0
 	for assetID := range assetIDs {
 		alias := s.vm.PrimaryAliasOrDefault(assetID)
+		totalLocked := uint64(0)
+		for _, amount := range locked[assetID] {
+			totalLocked = addCapped(amount, totalLocked)
+		}
 		reply.Balances[i] = Balance{
-			AssetID: alias,
-			Balance: json.Uint64(balances[assetID]),
+			AssetID:  alias,
+			Balance:  json.Uint64(balances[assetID]),
+			Unlocked: json.Uint64(unlocked[assetID]),
+			Locked:   json.Uint64(totalLocked),
+			Locks:    newLockedBalances(locked[assetID]),
 		}
 		i++
 	}
@@ -935,6 +1151,10 @@ type SendOutput struct {
 
 	// Address of the recipient
 	To string `json:"to"`
+
+	// Unix time until which the sent funds can't be spent. If 0, the funds
+	// can be spent immediately.
+	Locktime json.Uint64 `json:"locktime"`
 }
 
 // SendArgs are arguments for passing into Send requests
@@ -1043,7 +1263,7 @@ func (s *Service) SendMultiple(_ *http.Request, args *SendMultipleArgs, reply *a
 			Out: &secp256k1fx.TransferOutput{
 				Amt: uint64(output.Amount),
 				OutputOwners: secp256k1fx.OutputOwners{
-					Locktime:  0,
+					Locktime:  uint64(output.Locktime),
 					Threshold: 1,
 					Addrs:     []ids.ShortID{to},
 				},
@@ -19,10 +19,13 @@ import (
 
 	"github.com/lasthyphen/dijetsnodego/api"
 	"github.com/lasthyphen/dijetsnodego/chains/atomic"
+	"github.com/lasthyphen/dijetsnodego/database"
 	"github.com/lasthyphen/dijetsnodego/database/manager"
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/snow"
 	"github.com/lasthyphen/dijetsnodego/snow/choices"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/avalanche"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowstorm"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto"
@@ -218,6 +221,52 @@ func TestServiceIssueTx(t *testing.T) {
 	}
 }
 
+func TestServiceSimulateTx(t *testing.T) {
+	require := require.New(t)
+	genesisBytes, vm, s, _, _ := setup(t, true)
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+
+	tx := NewTx(t, genesisBytes, vm)
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	reply := &SimulateTxReply{}
+	require.NoError(s.SimulateTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, reply))
+	require.True(reply.Valid, reply.Error)
+	require.Equal(tx.ID(), reply.TxID)
+	require.EqualValues(startBalance, reply.Fee)
+	require.Equal([]ids.ID{tx.Unsigned.InputUTXOs()[0].InputID()}, reply.ConsumedUTXOIDs)
+	require.Empty(reply.ProducedUTXOs)
+
+	// Simulating a tx must not issue it
+	require.Empty(vm.PendingTxs(context.Background()))
+	_, err = vm.state.GetTx(tx.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// A tx that spends a UTXO that doesn't exist fails verification
+	utx := tx.Unsigned.(*txs.BaseTx)
+	utx.Ins[0].UTXOID.TxID = ids.GenerateTestID()
+	require.NoError(tx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}))
+	txStr, err = formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	reply = &SimulateTxReply{}
+	require.NoError(s.SimulateTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, reply))
+	require.False(reply.Valid)
+	require.NotEmpty(reply.Error)
+	require.Zero(reply.Fee)
+	require.Empty(reply.ConsumedUTXOIDs)
+}
+
 func TestServiceGetTxStatus(t *testing.T) {
 	genesisBytes, vm, s, _, _ := setup(t, true)
 	defer func() {
@@ -468,6 +517,141 @@ func TestServiceGetTxs(t *testing.T) {
 	require.Equal(t, getTxsReply.TxIDs, testTxs[10:20])
 }
 
+func TestServiceGetBalanceLocked(t *testing.T) {
+	require := require.New(t)
+	_, vm, s, _, _ := setup(t, true)
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+
+	now := time.Unix(1000, 0)
+	vm.clock.Set(now)
+
+	assetID := ids.GenerateTestID()
+	addr := ids.GenerateTestShortID()
+	addrStr, err := vm.FormatLocalAddress(addr)
+	require.NoError(err)
+
+	newUTXO := func(amount, locktime uint64, otherAddrs ...ids.ShortID) *djtx.UTXO {
+		owners := secp256k1fx.OutputOwners{
+			Locktime:  locktime,
+			Threshold: 1,
+			Addrs:     append([]ids.ShortID{addr}, otherAddrs...),
+		}
+		owners.Sort()
+		return &djtx.UTXO{
+			UTXOID: djtx.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: djtx.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: owners,
+			},
+		}
+	}
+	for _, utxo := range []*djtx.UTXO{
+		newUTXO(1, 0),
+		newUTXO(2, uint64(now.Unix())),
+		newUTXO(10, 2000),
+		newUTXO(20, 2000),
+		newUTXO(100, 3000),
+		// Only counted if partially owned UTXOs are included
+		newUTXO(1000, 0, ids.GenerateTestShortID()),
+		newUTXO(10000, 3000, ids.GenerateTestShortID()),
+	} {
+		require.NoError(vm.state.PutUTXO(utxo))
+	}
+
+	balanceReply := &GetBalanceReply{}
+	require.NoError(s.GetBalance(nil, &GetBalanceArgs{
+		Address: addrStr,
+		AssetID: assetID.String(),
+	}, balanceReply))
+	require.Equal(json.Uint64(3), balanceReply.Balance)
+	require.Len(balanceReply.UTXOIDs, 2)
+	require.Equal(json.Uint64(3), balanceReply.Unlocked)
+	require.Equal(json.Uint64(130), balanceReply.Locked)
+	require.Equal([]LockedBalance{
+		{Amount: 30, Locktime: 2000},
+		{Amount: 100, Locktime: 3000},
+	}, balanceReply.Locks)
+
+	balanceReply = &GetBalanceReply{}
+	require.NoError(s.GetBalance(nil, &GetBalanceArgs{
+		Address:        addrStr,
+		AssetID:        assetID.String(),
+		IncludePartial: true,
+	}, balanceReply))
+	require.Equal(json.Uint64(11133), balanceReply.Balance)
+	require.Len(balanceReply.UTXOIDs, 7)
+	require.Equal(json.Uint64(1003), balanceReply.Unlocked)
+	require.Equal(json.Uint64(10130), balanceReply.Locked)
+	require.Equal([]LockedBalance{
+		{Amount: 30, Locktime: 2000},
+		{Amount: 10100, Locktime: 3000},
+	}, balanceReply.Locks)
+
+	allBalancesReply := &GetAllBalancesReply{}
+	require.NoError(s.GetAllBalances(nil, &GetAllBalancesArgs{
+		JSONAddress: api.JSONAddress{Address: addrStr},
+	}, allBalancesReply))
+	require.Equal([]Balance{{
+		AssetID:  assetID.String(),
+		Balance:  3,
+		Unlocked: 3,
+		Locked:   130,
+		Locks: []LockedBalance{
+			{Amount: 30, Locktime: 2000},
+			{Amount: 100, Locktime: 3000},
+		},
+	}}, allBalancesReply.Balances)
+}
+
+func TestServiceGetAllBalancesLockedOnly(t *testing.T) {
+	require := require.New(t)
+	_, vm, s, _, _ := setup(t, true)
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+
+	vm.clock.Set(time.Unix(1000, 0))
+
+	assetID := ids.GenerateTestID()
+	addr := ids.GenerateTestShortID()
+	addrStr, err := vm.FormatLocalAddress(addr)
+	require.NoError(err)
+
+	require.NoError(vm.state.PutUTXO(&djtx.UTXO{
+		UTXOID: djtx.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: djtx.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 50,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Locktime:  2000,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}))
+
+	reply := &GetAllBalancesReply{}
+	require.NoError(s.GetAllBalances(nil, &GetAllBalancesArgs{
+		JSONAddress: api.JSONAddress{Address: addrStr},
+	}, reply))
+	require.Equal([]Balance{{
+		AssetID: assetID.String(),
+		Locked:  50,
+		Locks: []LockedBalance{
+			{Amount: 50, Locktime: 2000},
+		},
+	}}, reply.Balances)
+}
+
 func TestServiceGetAllBalances(t *testing.T) {
 	_, vm, s, _, _ := setup(t, true)
 	defer func() {
@@ -610,8 +794,11 @@ func TestServiceGetAllBalances(t *testing.T) {
 	reply = &GetAllBalancesReply{}
 	err = s.GetAllBalances(nil, balanceArgs, reply)
 	require.NoError(t, err)
-	// The balance should not include the UTXO since it is only partly owned by [addr]
-	require.Len(t, reply.Balances, 0)
+	// The asset is listed because of the locked UTXO, but none of it is spendable
+	require.Len(t, reply.Balances, 1)
+	require.Equal(t, assetID.String(), reply.Balances[0].AssetID)
+	require.Zero(t, uint64(reply.Balances[0].Balance))
+	require.Equal(t, uint64(1337), uint64(reply.Balances[0].Locked))
 
 	// A UTXO for a different asset
 	otherAssetID := ids.GenerateTestID()
@@ -657,8 +844,12 @@ func TestServiceGetAllBalances(t *testing.T) {
 	reply = &GetAllBalancesReply{}
 	err = s.GetAllBalances(nil, balanceArgs, reply)
 	require.NoError(t, err)
-	// The balance should include the UTXO since it is partly owned by [addr]
-	require.Len(t, reply.Balances, 0)
+	// Only the locked UTXO is fully owned by [addr], so only its asset is
+	// listed, with a zero unlocked balance
+	require.Len(t, reply.Balances, 1)
+	require.Equal(t, assetID.String(), reply.Balances[0].AssetID)
+	require.Zero(t, uint64(reply.Balances[0].Balance))
+	require.Equal(t, uint64(1337), uint64(reply.Balances[0].Locked))
 }
 
 func TestServiceGetTx(t *testing.T) {
@@ -2050,7 +2241,7 @@ func TestServiceGetUTXOs(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.label, func(t *testing.T) {
-			reply := &api.GetUTXOsReply{}
+			reply := &GetUTXOsReply{}
 			err := s.GetUTXOs(nil, test.args, reply)
 			if err != nil {
 				if !test.shouldErr {
@@ -2124,6 +2315,67 @@ func TestGetBalance(t *testing.T) {
 	}
 }
 
+func TestGetBalanceAtHeight(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Historical queries are rejected until the journal is enabled.
+	height := json.Uint64(0)
+	args := &GetBalanceArgs{
+		HistoricalArgs: api.HistoricalArgs{Height: &height},
+		AssetID:        genesisTx.ID().String(),
+	}
+	var err error
+	args.Address, err = vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	require.NoError(err)
+	err = s.GetBalance(nil, args, &GetBalanceReply{})
+	require.ErrorIs(err, errUTXOJournalDisabled)
+
+	require.NoError(vm.initUTXOJournal(0))
+	acceptedHeight, err := vm.state.AcceptedHeight()
+	require.NoError(err)
+	height = json.Uint64(acceptedHeight)
+
+	// Spend the address's genesis UTXO.
+	tx, err := vm.ParseTx(context.Background(), NewTx(t, genesisBytes, vm).Bytes())
+	require.NoError(err)
+	require.NoError(tx.Verify(context.Background()))
+	require.NoError(tx.Accept(context.Background()))
+
+	vtx := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{IDV: ids.GenerateTestID()},
+		HeightV:       acceptedHeight + 1,
+		TxsV:          []snowstorm.Tx{tx},
+	}
+	require.NoError(vm.VertexAccepted(context.Background(), vtx, []ids.ID{vtx.ID()}))
+
+	reply := &GetBalanceReply{}
+	require.NoError(s.GetBalance(nil, args, reply))
+	require.EqualValues(startBalance, reply.Balance)
+	require.Len(reply.UTXOIDs, 1)
+
+	spentHeight := height + 1
+	args.Height = &spentHeight
+	reply = &GetBalanceReply{}
+	require.NoError(s.GetBalance(nil, args, reply))
+	require.Zero(reply.Balance)
+
+	args.Height = nil
+	reply = &GetBalanceReply{}
+	require.NoError(s.GetBalance(nil, args, reply))
+	require.Zero(reply.Balance)
+
+	nextHeight := height + 2
+	args.Height = &nextHeight
+	err = s.GetBalance(nil, args, &GetBalanceReply{})
+	require.ErrorIs(err, djtx.ErrHeightNotRetained)
+}
+
 func TestCreateFixedCapAsset(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2581,6 +2833,60 @@ func TestSend(t *testing.T) {
 	}
 }
 
+func TestSendLocked(t *testing.T) {
+	require := require.New(t)
+	_, vm, s, _, genesisTx := setupWithKeys(t, true)
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := genesisTx.ID()
+	to := ids.GenerateTestShortID()
+	toStr, err := vm.FormatLocalAddress(to)
+	require.NoError(err)
+	changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	require.NoError(err)
+	_, fromAddrsStr := sampleAddrs(t, vm, addrs)
+
+	args := &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs:  api.JSONFromAddrs{From: fromAddrsStr},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		},
+		SendOutput: SendOutput{
+			Amount:   500,
+			AssetID:  assetID.String(),
+			To:       toStr,
+			Locktime: 12345,
+		},
+	}
+	reply := &api.JSONTxIDChangeAddr{}
+	vm.timer.Cancel()
+	require.NoError(s.Send(nil, args, reply))
+
+	pendingTxs := vm.txs
+	require.Len(pendingTxs, 1)
+	tx := pendingTxs[0].(*UniqueTx)
+	var sentOut *secp256k1fx.TransferOutput
+	for _, out := range tx.Unsigned.(*txs.BaseTx).Outs {
+		out := out.Out.(*secp256k1fx.TransferOutput)
+		if out.Addrs[0] == to {
+			sentOut = out
+		} else {
+			// The change isn't locked
+			require.Zero(out.Locktime)
+		}
+	}
+	require.NotNil(sentOut)
+	require.Equal(uint64(500), sentOut.Amt)
+	require.Equal(uint64(12345), sentOut.Locktime)
+}
+
 func TestSendMultiple(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,504 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/choices"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/avalanche"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/avalanche/vertex"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/snowman/block"
+	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/version"
+	"github.com/lasthyphen/dijetsnodego/vms/avm/statesync"
+	"github.com/lasthyphen/dijetsnodego/vms/avm/txs"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+)
+
+var (
+	snapshotsPrefix = []byte("snapshots")
+	syncPrefix      = []byte("sync")
+
+	stateSyncedKey = []byte("stateSynced")
+
+	errWrongUTXOID       = errors.New("UTXO doesn't match its key")
+	errWrongTxID         = errors.New("transaction doesn't match its key")
+	errWrongFreeze       = errors.New("freeze doesn't match its key")
+	errUnknownEntry      = errors.New("unknown entry type")
+	errStateSyncDisabled = errors.New("state sync isn't enabled")
+
+	_ block.StateSyncableVM     = (*VM)(nil)
+	_ block.StateSyncProgressVM = (*VM)(nil)
+	_ vertex.SyncedVM           = (*VM)(nil)
+	_ vertex.EdgeTrackerVM      = (*VM)(nil)
+	_ block.StateSummary        = (*stateSummary)(nil)
+	_ statesync.Writer          = (*stateSyncWriter)(nil)
+)
+
+// stateSummary wraps a summary so that accepting it starts syncing the VM.
+type stateSummary struct {
+	summary *statesync.Summary
+	vm      *VM
+}
+
+func (s *stateSummary) ID() ids.ID {
+	return s.summary.ID()
+}
+
+func (s *stateSummary) Height() uint64 {
+	return s.summary.Height
+}
+
+func (s *stateSummary) Bytes() []byte {
+	return s.summary.Bytes()
+}
+
+func (s *stateSummary) Accept(ctx context.Context) (bool, error) {
+	return s.vm.acceptStateSummary(ctx, s.summary)
+}
+
+func (vm *VM) initStateSync() error {
+	vm.snapshots = statesync.NewSnapshots(prefixdb.New(snapshotsPrefix, vm.baseDB))
+	vm.syncServer = statesync.NewServer(vm.snapshots)
+	vm.syncDB = prefixdb.New(syncPrefix, vm.db)
+	vm.syncClient = statesync.NewClient(statesync.ClientConfig{
+		Log:    vm.ctx.Log,
+		Sender: vm.appSender,
+		Writer: &stateSyncWriter{vm: vm},
+		DB:     vm.syncDB,
+	})
+
+	summary, err := vm.snapshots.Last()
+	switch err {
+	case nil:
+		vm.summaryHeight = summary.Height
+		return nil
+	case database.ErrNotFound:
+		return nil
+	default:
+		return err
+	}
+}
+
+func (vm *VM) StateSyncEnabled(context.Context) (bool, error) {
+	return vm.stateSyncEnabled, nil
+}
+
+func (vm *VM) GetOngoingSyncStateSummary(context.Context) (block.StateSummary, error) {
+	summary, err := vm.syncClient.Ongoing()
+	if err != nil {
+		return nil, err
+	}
+	return &stateSummary{
+		summary: summary,
+		vm:      vm,
+	}, nil
+}
+
+func (vm *VM) GetLastStateSummary(context.Context) (block.StateSummary, error) {
+	summary, err := vm.snapshots.Last()
+	if err != nil {
+		return nil, err
+	}
+	return &stateSummary{
+		summary: summary,
+		vm:      vm,
+	}, nil
+}
+
+func (vm *VM) ParseStateSummary(_ context.Context, summaryBytes []byte) (block.StateSummary, error) {
+	summary, err := statesync.ParseSummary(summaryBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &stateSummary{
+		summary: summary,
+		vm:      vm,
+	}, nil
+}
+
+func (vm *VM) GetStateSummary(_ context.Context, height uint64) (block.StateSummary, error) {
+	summary, err := vm.snapshots.Get(height)
+	if err != nil {
+		return nil, err
+	}
+	return &stateSummary{
+		summary: summary,
+		vm:      vm,
+	}, nil
+}
+
+// StateSyncProgress reports the number of buckets of the ongoing sync that were
+// applied.
+func (vm *VM) StateSyncProgress(context.Context) ([]byte, error) {
+	nextBucket, err := vm.syncClient.Progress()
+	if err != nil {
+		return nil, err
+	}
+	return database.PackUInt64(nextBucket), nil
+}
+
+// StateSynced returns true if the state was synced from a summary and normal
+// operations haven't started since.
+func (vm *VM) StateSynced(context.Context) (bool, error) {
+	return vm.syncDB.Has(stateSyncedKey)
+}
+
+func (vm *VM) acceptStateSummary(ctx context.Context, summary *statesync.Summary) (bool, error) {
+	if !vm.stateSyncEnabled {
+		return false, errStateSyncDisabled
+	}
+
+	height, err := vm.state.AcceptedHeight()
+	if err != nil {
+		return false, err
+	}
+	if height >= summary.Height {
+		vm.ctx.Log.Info("skipping state sync",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Uint64("summaryHeight", summary.Height),
+			zap.Uint64("acceptedHeight", height),
+		)
+		return false, nil
+	}
+
+	vm.ctx.Log.Info("starting state sync",
+		zap.Stringer("summaryID", summary.ID()),
+		zap.Uint64("summaryHeight", summary.Height),
+		zap.Uint64("acceptedHeight", height),
+	)
+	return true, vm.syncClient.Start(ctx, summary)
+}
+
+// VertexAccepted records the acceptance of [vtx] and starts building a state
+// summary if [vtx] is a checkpoint of the DAG.
+//
+// A checkpoint is an accepted vertex that is the whole accepted frontier while
+// every accepted transaction is in it or in its ancestors. The state at a
+// checkpoint only depends on the vertices accepted by consensus, rather than
+// on the order that this node accepted transactions in, so every node that
+// builds a summary at the checkpoint builds the same one. Transactions are
+// expected to always be accepted in an accepted vertex, which bootstrapping
+// relies on as well.
+func (vm *VM) VertexAccepted(ctx context.Context, vtx avalanche.Vertex, edge []ids.ID) error {
+	height, err := vtx.Height()
+	if err != nil {
+		return err
+	}
+	vtxTxs, err := vtx.Txs(ctx)
+	if err != nil {
+		return err
+	}
+	txIDs := make([]ids.ID, len(vtxTxs))
+	for i, tx := range vtxTxs {
+		txIDs[i] = tx.ID()
+	}
+
+	if err := vm.state.AcceptVertex(height, txIDs); err != nil {
+		return err
+	}
+	if err := vm.journalAcceptedHeight(); err != nil {
+		return err
+	}
+	if err := vm.db.Commit(); err != nil {
+		return err
+	}
+
+	// Summaries are only built once bootstrapped to avoid snapshotting every
+	// historical state while catching up.
+	if !vm.bootstrapped || vm.buildingSummary.GetValue() {
+		return nil
+	}
+	if height/statesync.SummaryInterval <= vm.summaryHeight/statesync.SummaryInterval {
+		return nil
+	}
+	vtxID := vtx.ID()
+	if len(edge) != 1 || edge[0] != vtxID {
+		return nil
+	}
+	numPending, err := vm.state.NumPending()
+	if err != nil {
+		return err
+	}
+	if numPending != 0 {
+		return nil
+	}
+
+	// The iterators are snapshots of the state at [vtx], so the summary can be
+	// built without holding the context lock while new transactions are
+	// accepted.
+	utxoIter := vm.state.UTXOs()
+	freezeIter := vm.state.Freezes()
+
+	vm.summaryHeight = height
+	vm.buildingSummary.SetValue(true)
+	vm.summaryBuilds.Add(1)
+	go func() {
+		defer vm.summaryBuilds.Done()
+		defer vm.buildingSummary.SetValue(false)
+		defer utxoIter.Release()
+		defer freezeIter.Release()
+
+		err := vm.buildStateSummary(height, vtxID, txIDs, utxoIter, freezeIter)
+		if err != nil {
+			vm.ctx.Log.Error("failed to build state summary",
+				zap.Stringer("vtxID", vtxID),
+				zap.Uint64("height", height),
+				zap.Error(err),
+			)
+		}
+	}()
+	return nil
+}
+
+// buildStateSummary snapshots the state at the accepted vertex [frontier],
+// which contains [frontierTxIDs], so that it can be served to syncing peers.
+// [utxoIter] and [freezeIter] must iterate over the state at [frontier].
+func (vm *VM) buildStateSummary(
+	height uint64,
+	frontier ids.ID,
+	frontierTxIDs []ids.ID,
+	utxoIter database.Iterator,
+	freezeIter database.Iterator,
+) error {
+	builder, err := vm.snapshots.NewBuilder(height, frontier)
+	if err != nil {
+		return err
+	}
+
+	// The statuses are only needed for the transactions whose outputs may
+	// still be consumed, and for the transactions of the frontier so that
+	// bootstrapping can stop at it.
+	statusTxIDs := set.NewSet[ids.ID](len(frontierTxIDs))
+	statusTxIDs.Add(frontierTxIDs...)
+	assetIDs := set.Set[ids.ID]{}
+
+	for utxoIter.Next() {
+		utxoID, err := ids.ToID(utxoIter.Key())
+		if err != nil {
+			return err
+		}
+		utxoBytes := utxoIter.Value()
+		utxo := &djtx.UTXO{}
+		if _, err := vm.parser.Codec().Unmarshal(utxoBytes, utxo); err != nil {
+			return err
+		}
+
+		if err := builder.Put(statesync.Key(utxoID, statesync.UTXOEntry), utxoBytes); err != nil {
+			return err
+		}
+		statusTxIDs.Add(utxo.TxID)
+		assetIDs.Add(utxo.AssetID())
+	}
+	if err := utxoIter.Error(); err != nil {
+		return err
+	}
+
+	for assetID := range assetIDs {
+		tx, err := vm.state.GetTx(assetID)
+		if err != nil {
+			return fmt.Errorf("couldn't get asset %s: %w", assetID, err)
+		}
+		if err := builder.Put(statesync.Key(assetID, statesync.TxEntry), tx.Bytes()); err != nil {
+			return err
+		}
+		statusTxIDs.Add(assetID)
+	}
+
+	statusBytes := database.PackUInt32(uint32(choices.Accepted))
+	for txID := range statusTxIDs {
+		if err := builder.Put(statesync.Key(txID, statesync.StatusEntry), statusBytes); err != nil {
+			return err
+		}
+	}
+
+	for freezeIter.Next() {
+		freezeKey := freezeIter.Key()
+		entryKey := statesync.Key(hashing.ComputeHash256Array(freezeKey), statesync.FreezeEntry)
+		if err := builder.Put(entryKey, freezeKey); err != nil {
+			return err
+		}
+	}
+	if err := freezeIter.Error(); err != nil {
+		return err
+	}
+
+	summary, err := builder.Finish()
+	if err != nil {
+		return err
+	}
+
+	vm.ctx.Log.Info("built state summary",
+		zap.Stringer("summaryID", summary.ID()),
+		zap.Stringer("frontier", summary.Frontier),
+		zap.Uint64("height", summary.Height),
+		zap.Uint64("numEntries", summary.NumEntries),
+	)
+	return nil
+}
+
+// stateSyncWriter applies the entries downloaded by the state sync client to
+// the state of the VM.
+type stateSyncWriter struct {
+	vm *VM
+}
+
+func (w *stateSyncWriter) Reset() error {
+	iter := w.vm.state.UTXOs()
+	var utxoIDs []ids.ID
+	for iter.Next() {
+		utxoID, err := ids.ToID(iter.Key())
+		if err != nil {
+			iter.Release()
+			return err
+		}
+		utxoIDs = append(utxoIDs, utxoID)
+	}
+	err := iter.Error()
+	iter.Release()
+	if err != nil {
+		return err
+	}
+
+	for _, utxoID := range utxoIDs {
+		if err := w.vm.state.DeleteUTXO(utxoID); err != nil {
+			return err
+		}
+	}
+
+	freezeIter := w.vm.state.Freezes()
+	var freezeKeys [][]byte
+	for freezeIter.Next() {
+		freezeKeys = append(freezeKeys, utils.CopyBytes(freezeIter.Key()))
+	}
+	err = freezeIter.Error()
+	freezeIter.Release()
+	if err != nil {
+		return err
+	}
+
+	for _, freezeKey := range freezeKeys {
+		if err := w.vm.state.DeleteFreeze(freezeKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *stateSyncWriter) Put(key, value []byte) error {
+	id, entryType, err := statesync.ParseKey(key)
+	if err != nil {
+		return err
+	}
+
+	switch entryType {
+	case statesync.UTXOEntry:
+		utxo := &djtx.UTXO{}
+		if _, err := w.vm.parser.Codec().Unmarshal(value, utxo); err != nil {
+			return err
+		}
+		if utxo.InputID() != id {
+			return errWrongUTXOID
+		}
+		return w.vm.state.PutUTXO(utxo)
+	case statesync.StatusEntry:
+		status, err := database.ParseUInt32(value)
+		if err != nil {
+			return err
+		}
+		return w.vm.state.PutStatus(id, choices.Status(status))
+	case statesync.TxEntry:
+		tx, err := w.parseTx(value)
+		if err != nil {
+			return err
+		}
+		if tx.ID() != id {
+			return errWrongTxID
+		}
+		return w.vm.state.PutTx(id, tx)
+	case statesync.FreezeEntry:
+		if hashing.ComputeHash256Array(value) != id {
+			return errWrongFreeze
+		}
+		return w.vm.state.PutFreeze(value)
+	default:
+		return fmt.Errorf("%w: %d", errUnknownEntry, entryType)
+	}
+}
+
+// parseTx parses [txBytes], which may have been created by either a regular
+// or a genesis transaction.
+func (w *stateSyncWriter) parseTx(txBytes []byte) (*txs.Tx, error) {
+	tx, err := w.vm.parser.Parse(txBytes)
+	if err == nil {
+		return tx, nil
+	}
+	return w.vm.parser.ParseGenesis(txBytes)
+}
+
+func (w *stateSyncWriter) Commit() error {
+	return w.vm.db.Commit()
+}
+
+func (w *stateSyncWriter) Finish(_ context.Context, summary *statesync.Summary) error {
+	if err := w.vm.state.SetAcceptedHeight(summary.Height); err != nil {
+		return err
+	}
+	if err := w.vm.syncDB.Put(stateSyncedKey, nil); err != nil {
+		return err
+	}
+	if err := w.vm.db.Commit(); err != nil {
+		return err
+	}
+
+	// The engine may be holding the context lock while waiting to deliver a
+	// message, so the notification must not block.
+	go func() {
+		w.vm.toEngine <- common.StateSyncDone
+	}()
+	return nil
+}
+
+func (vm *VM) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, _ time.Time, request []byte) error {
+	response, err := vm.syncServer.HandleRequest(request)
+	if err != nil {
+		// The requester will time out the request.
+		vm.ctx.Log.Debug("dropping state sync request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return vm.appSender.SendAppResponse(ctx, nodeID, requestID, response)
+}
+
+func (vm *VM) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
+	return vm.syncClient.AppResponse(ctx, nodeID, requestID, response)
+}
+
+func (vm *VM) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	return vm.syncClient.AppRequestFailed(ctx, nodeID, requestID)
+}
+
+func (vm *VM) Connected(ctx context.Context, nodeID ids.NodeID, _ *version.Application) error {
+	return vm.syncClient.Connected(ctx, nodeID)
+}
+
+func (vm *VM) Disconnected(_ context.Context, nodeID ids.NodeID) error {
+	vm.syncClient.Disconnected(nodeID)
+	return nil
+}
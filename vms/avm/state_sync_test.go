@@ -0,0 +1,85 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/choices"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/avalanche"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowstorm"
+	"github.com/lasthyphen/dijetsnodego/vms/avm/statesync"
+)
+
+func TestVertexAcceptedBuildsSummaryAtCheckpoint(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		ctx.Lock.Unlock()
+	}()
+
+	tx, err := vm.ParseTx(context.Background(), NewTx(t, genesisBytes, vm).Bytes())
+	require.NoError(err)
+	require.NoError(tx.Verify(context.Background()))
+	require.NoError(tx.Accept(context.Background()))
+
+	emptyVtx := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{IDV: ids.GenerateTestID()},
+		HeightV:       statesync.SummaryInterval,
+	}
+	txVtx := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{IDV: ids.GenerateTestID()},
+		HeightV:       statesync.SummaryInterval + 1,
+		TxsV:          []snowstorm.Tx{tx},
+	}
+
+	// The accepted transaction isn't in an accepted vertex yet.
+	require.NoError(vm.VertexAccepted(context.Background(), emptyVtx, []ids.ID{emptyVtx.ID()}))
+	vm.summaryBuilds.Wait()
+	_, err = vm.snapshots.Last()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The accepted frontier isn't a single vertex.
+	edge := []ids.ID{emptyVtx.ID(), txVtx.ID()}
+	require.NoError(vm.VertexAccepted(context.Background(), txVtx, edge))
+	vm.summaryBuilds.Wait()
+	_, err = vm.snapshots.Last()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	checkpoint := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{IDV: ids.GenerateTestID()},
+		HeightV:       statesync.SummaryInterval + 2,
+	}
+	require.NoError(vm.VertexAccepted(context.Background(), checkpoint, []ids.ID{checkpoint.ID()}))
+	vm.summaryBuilds.Wait()
+
+	summary, err := vm.snapshots.Last()
+	require.NoError(err)
+	require.Equal(checkpoint.HeightV, summary.Height)
+	require.Equal(checkpoint.ID(), summary.Frontier)
+
+	height, err := vm.state.AcceptedHeight()
+	require.NoError(err)
+	require.Equal(checkpoint.HeightV, height)
+
+	// Only one summary is built per interval.
+	nextCheckpoint := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{IDV: ids.GenerateTestID()},
+		HeightV:       statesync.SummaryInterval + 3,
+	}
+	require.NoError(vm.VertexAccepted(context.Background(), nextCheckpoint, []ids.ID{nextCheckpoint.ID()}))
+	vm.summaryBuilds.Wait()
+
+	summary, err = vm.snapshots.Last()
+	require.NoError(err)
+	require.Equal(checkpoint.ID(), summary.Frontier)
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+)
+
+var (
+	_ AcceptedState = (*acceptedState)(nil)
+
+	acceptedHeightKey = []byte("height")
+	pendingPrefix     = []byte("pending")
+)
+
+// AcceptedState tracks the accepted transactions whose vertex hasn't been
+// accepted yet, and the height of the accepted vertices.
+type AcceptedState interface {
+	// AddAccepted records [txID] as accepted. [txID] is pending until a vertex
+	// containing it is accepted.
+	AddAccepted(txID ids.ID) error
+
+	// AcceptVertex records that a vertex at [height] containing [txIDs] was
+	// accepted.
+	AcceptVertex(height uint64, txIDs []ids.ID) error
+
+	// NumPending returns the number of accepted transactions that aren't
+	// contained in an accepted vertex yet.
+	NumPending() (int, error)
+
+	// AcceptedHeight returns the largest height of the accepted vertices.
+	AcceptedHeight() (uint64, error)
+
+	// SetAcceptedHeight overwrites the largest height of the accepted vertices
+	// and forgets the pending transactions.
+	SetAcceptedHeight(height uint64) error
+}
+
+type acceptedState struct {
+	// Database that the height of the accepted vertices is stored in, at
+	// [acceptedHeightKey].
+	acceptedDB database.Database
+
+	// Database that the pending transactions are stored in.
+	pendingDB database.Database
+
+	pending       set.Set[ids.ID]
+	pendingLoaded bool
+}
+
+func NewAcceptedState(acceptedDB database.Database) AcceptedState {
+	return &acceptedState{
+		acceptedDB: acceptedDB,
+		pendingDB:  prefixdb.New(pendingPrefix, acceptedDB),
+	}
+}
+
+func (s *acceptedState) AddAccepted(txID ids.ID) error {
+	if err := s.loadPending(); err != nil {
+		return err
+	}
+	if err := s.pendingDB.Put(txID[:], nil); err != nil {
+		return err
+	}
+	s.pending.Add(txID)
+	return nil
+}
+
+func (s *acceptedState) AcceptVertex(height uint64, txIDs []ids.ID) error {
+	if err := s.loadPending(); err != nil {
+		return err
+	}
+	for _, txID := range txIDs {
+		if !s.pending.Contains(txID) {
+			continue
+		}
+		if err := s.pendingDB.Delete(txID[:]); err != nil {
+			return err
+		}
+		s.pending.Remove(txID)
+	}
+
+	acceptedHeight, err := s.AcceptedHeight()
+	if err != nil {
+		return err
+	}
+	if height <= acceptedHeight {
+		return nil
+	}
+	return database.PutUInt64(s.acceptedDB, acceptedHeightKey, height)
+}
+
+func (s *acceptedState) NumPending() (int, error) {
+	if err := s.loadPending(); err != nil {
+		return 0, err
+	}
+	return s.pending.Len(), nil
+}
+
+func (s *acceptedState) AcceptedHeight() (uint64, error) {
+	height, err := database.GetUInt64(s.acceptedDB, acceptedHeightKey)
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return height, err
+}
+
+func (s *acceptedState) SetAcceptedHeight(height uint64) error {
+	if err := s.loadPending(); err != nil {
+		return err
+	}
+	for txID := range s.pending {
+		if err := s.pendingDB.Delete(txID[:]); err != nil {
+			return err
+		}
+	}
+	s.pending.Clear()
+	return database.PutUInt64(s.acceptedDB, acceptedHeightKey, height)
+}
+
+func (s *acceptedState) loadPending() error {
+	if s.pendingLoaded {
+		return nil
+	}
+
+	iter := s.pendingDB.NewIterator()
+	defer iter.Release()
+
+	pending := set.Set[ids.ID]{}
+	for iter.Next() {
+		txID, err := ids.ToID(iter.Key())
+		if err != nil {
+			return err
+		}
+		pending.Add(txID)
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	s.pending = pending
+	s.pendingLoaded = true
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+func TestAcceptedStatePending(t *testing.T) {
+	require := require.New(t)
+
+	acceptedDB := memdb.New()
+	s := NewAcceptedState(acceptedDB)
+
+	txIDs := []ids.ID{
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+	}
+	for _, txID := range txIDs {
+		require.NoError(s.AddAccepted(txID))
+	}
+
+	numPending, err := s.NumPending()
+	require.NoError(err)
+	require.Equal(len(txIDs), numPending)
+
+	require.NoError(s.AcceptVertex(5, txIDs[:2]))
+
+	numPending, err = s.NumPending()
+	require.NoError(err)
+	require.Equal(1, numPending)
+
+	height, err := s.AcceptedHeight()
+	require.NoError(err)
+	require.Equal(uint64(5), height)
+
+	// The pending transactions should be persisted.
+	reloaded := NewAcceptedState(acceptedDB)
+	numPending, err = reloaded.NumPending()
+	require.NoError(err)
+	require.Equal(1, numPending)
+
+	// The height never decreases when a vertex is accepted.
+	require.NoError(reloaded.AcceptVertex(3, txIDs[2:]))
+
+	numPending, err = reloaded.NumPending()
+	require.NoError(err)
+	require.Zero(numPending)
+
+	height, err = reloaded.AcceptedHeight()
+	require.NoError(err)
+	require.Equal(uint64(5), height)
+
+	require.NoError(reloaded.AddAccepted(ids.GenerateTestID()))
+	require.NoError(reloaded.SetAcceptedHeight(100))
+
+	numPending, err = reloaded.NumPending()
+	require.NoError(err)
+	require.Zero(numPending)
+
+	height, err = reloaded.AcceptedHeight()
+	require.NoError(err)
+	require.Equal(uint64(100), height)
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
+)
+
+var (
+	_ FreezeState = (*freezeState)(nil)
+
+	errInvalidFreezeKey = errors.New("invalid freeze key")
+)
+
+// FreezeState tracks the UTXOs and the addresses whose funds of an asset can't
+// be spent.
+//
+// A freeze is keyed by the asset ID followed by either the ID of the frozen
+// UTXO or the frozen address.
+type FreezeState interface {
+	IsUTXOFrozen(assetID, utxoID ids.ID) (bool, error)
+	IsAddressFrozen(assetID ids.ID, addr ids.ShortID) (bool, error)
+
+	SetUTXOFrozen(assetID, utxoID ids.ID, frozen bool) error
+	SetAddressFrozen(assetID ids.ID, addr ids.ShortID, frozen bool) error
+
+	// Freezes returns an iterator over the keys of all the freezes.
+	Freezes() database.Iterator
+
+	// PutFreeze records the freeze with [key], as returned by [Freezes].
+	PutFreeze(key []byte) error
+
+	// DeleteFreeze removes the freeze with [key], as returned by [Freezes].
+	DeleteFreeze(key []byte) error
+}
+
+type freezeState struct {
+	freezeDB database.Database
+}
+
+func NewFreezeState(freezeDB database.Database) FreezeState {
+	return &freezeState{
+		freezeDB: freezeDB,
+	}
+}
+
+func (s *freezeState) IsUTXOFrozen(assetID, utxoID ids.ID) (bool, error) {
+	return s.freezeDB.Has(freezeKey(assetID, utxoID[:]))
+}
+
+func (s *freezeState) IsAddressFrozen(assetID ids.ID, addr ids.ShortID) (bool, error) {
+	return s.freezeDB.Has(freezeKey(assetID, addr[:]))
+}
+
+func (s *freezeState) SetUTXOFrozen(assetID, utxoID ids.ID, frozen bool) error {
+	return s.set(freezeKey(assetID, utxoID[:]), frozen)
+}
+
+func (s *freezeState) SetAddressFrozen(assetID ids.ID, addr ids.ShortID, frozen bool) error {
+	return s.set(freezeKey(assetID, addr[:]), frozen)
+}
+
+func (s *freezeState) Freezes() database.Iterator {
+	return s.freezeDB.NewIterator()
+}
+
+func (s *freezeState) PutFreeze(key []byte) error {
+	switch len(key) {
+	case 2 * hashing.HashLen, hashing.HashLen + hashing.AddrLen:
+		return s.freezeDB.Put(key, nil)
+	default:
+		return errInvalidFreezeKey
+	}
+}
+
+func (s *freezeState) DeleteFreeze(key []byte) error {
+	return s.freezeDB.Delete(key)
+}
+
+func (s *freezeState) set(key []byte, frozen bool) error {
+	if frozen {
+		return s.freezeDB.Put(key, nil)
+	}
+	return s.freezeDB.Delete(key)
+}
+
+func freezeKey(assetID ids.ID, target []byte) []byte {
+	key := make([]byte, 0, hashing.HashLen+len(target))
+	key = append(key, assetID[:]...)
+	return append(key, target...)
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+func TestFreezeState(t *testing.T) {
+	require := require.New(t)
+
+	s := NewFreezeState(memdb.New())
+
+	assetID := ids.GenerateTestID()
+	otherAssetID := ids.GenerateTestID()
+	utxoID := ids.GenerateTestID()
+	addr := ids.GenerateTestShortID()
+
+	require.NoError(s.SetUTXOFrozen(assetID, utxoID, true))
+	require.NoError(s.SetAddressFrozen(assetID, addr, true))
+
+	frozen, err := s.IsUTXOFrozen(assetID, utxoID)
+	require.NoError(err)
+	require.True(frozen)
+	frozen, err = s.IsAddressFrozen(assetID, addr)
+	require.NoError(err)
+	require.True(frozen)
+
+	// Freezes only apply to their asset.
+	frozen, err = s.IsUTXOFrozen(otherAssetID, utxoID)
+	require.NoError(err)
+	require.False(frozen)
+	frozen, err = s.IsAddressFrozen(otherAssetID, addr)
+	require.NoError(err)
+	require.False(frozen)
+
+	require.NoError(s.SetUTXOFrozen(assetID, utxoID, false))
+	frozen, err = s.IsUTXOFrozen(assetID, utxoID)
+	require.NoError(err)
+	require.False(frozen)
+	frozen, err = s.IsAddressFrozen(assetID, addr)
+	require.NoError(err)
+	require.True(frozen)
+}
+
+func TestFreezeStateCopy(t *testing.T) {
+	require := require.New(t)
+
+	s := NewFreezeState(memdb.New())
+
+	assetID := ids.GenerateTestID()
+	utxoID := ids.GenerateTestID()
+	addr := ids.GenerateTestShortID()
+	require.NoError(s.SetUTXOFrozen(assetID, utxoID, true))
+	require.NoError(s.SetAddressFrozen(assetID, addr, true))
+
+	copied := NewFreezeState(memdb.New())
+	iter := s.Freezes()
+	defer iter.Release()
+
+	numFreezes := 0
+	for iter.Next() {
+		require.NoError(copied.PutFreeze(iter.Key()))
+		numFreezes++
+	}
+	require.NoError(iter.Error())
+	require.Equal(2, numFreezes)
+
+	frozen, err := copied.IsUTXOFrozen(assetID, utxoID)
+	require.NoError(err)
+	require.True(frozen)
+	frozen, err = copied.IsAddressFrozen(assetID, addr)
+	require.NoError(err)
+	require.True(frozen)
+
+	require.ErrorIs(copied.PutFreeze([]byte{1}), errInvalidFreezeKey)
+}
@@ -17,17 +17,25 @@ var (
 	statusPrefix    = []byte("status")
 	singletonPrefix = []byte("singleton")
 	txPrefix        = []byte("tx")
+	acceptedPrefix  = []byte("accepted")
+	freezePrefix    = []byte("freeze")
 
 	_ State = (*state)(nil)
 )
 
-// State persistently maintains a set of UTXOs, transaction, statuses, and
-// singletons.
+// State persistently maintains a set of UTXOs, transaction, statuses,
+// singletons, and freezes.
 type State interface {
 	djtx.UTXOState
 	djtx.StatusState
 	djtx.SingletonState
 	TxState
+	AcceptedState
+	FreezeState
+
+	// UTXOs returns an iterator over all the serialized UTXOs, ordered by
+	// UTXO ID.
+	UTXOs() database.Iterator
 }
 
 type state struct {
@@ -35,6 +43,10 @@ type state struct {
 	djtx.StatusState
 	djtx.SingletonState
 	TxState
+	AcceptedState
+	FreezeState
+
+	utxoDB database.Database
 }
 
 func New(db database.Database, parser txs.Parser, metrics prometheus.Registerer) (State, error) {
@@ -42,6 +54,8 @@ func New(db database.Database, parser txs.Parser, metrics prometheus.Registerer)
 	statusDB := prefixdb.New(statusPrefix, db)
 	singletonDB := prefixdb.New(singletonPrefix, db)
 	txDB := prefixdb.New(txPrefix, db)
+	acceptedDB := prefixdb.New(acceptedPrefix, db)
+	freezeDB := prefixdb.New(freezePrefix, db)
 
 	utxoState, err := djtx.NewMeteredUTXOState(utxoDB, parser.Codec(), metrics)
 	if err != nil {
@@ -59,5 +73,12 @@ func New(db database.Database, parser txs.Parser, metrics prometheus.Registerer)
 		StatusState:    statusState,
 		SingletonState: djtx.NewSingletonState(singletonDB),
 		TxState:        txState,
+		AcceptedState:  NewAcceptedState(acceptedDB),
+		FreezeState:    NewFreezeState(freezeDB),
+		utxoDB:         utxoDB,
 	}, err
 }
+
+func (s *state) UTXOs() database.Iterator {
+	return djtx.NewUTXOIterator(s.utxoDB)
+}
@@ -0,0 +1,468 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+)
+
+var (
+	progressPrefix = []byte("progress")
+	stagingPrefix  = []byte("staging")
+
+	summaryKey    = []byte("summary")
+	bucketsKey    = []byte("buckets")
+	nextBucketKey = []byte("nextBucket")
+	numEntriesKey = []byte("numEntries")
+
+	errWrongRoot          = errors.New("bucket hashes don't match the summary root")
+	errWrongBucketHash    = errors.New("entries don't match the bucket hash")
+	errUnorderedEntries   = errors.New("entries are not in increasing key order")
+	errEntryOutsideBucket = errors.New("entry is outside of the requested bucket")
+	errMismatchedEntries  = errors.New("mismatched number of keys and values")
+	errEmptyResponse      = errors.New("response has no entries but claims more")
+	errWrongNumEntries    = errors.New("wrong number of entries")
+)
+
+// Writer applies the entries of a summary to the state of the VM.
+type Writer interface {
+	// Reset removes the state that is going to be replaced by the entries of
+	// a summary.
+	Reset() error
+
+	// Put applies the entry [key] -> [value] to the state.
+	Put(key, value []byte) error
+
+	// Commit persists the state modifications, including the sync progress.
+	Commit() error
+
+	// Finish is called once all the entries of [summary] have been applied
+	// and verified.
+	Finish(ctx context.Context, summary *Summary) error
+}
+
+type ClientConfig struct {
+	Log    logging.Logger
+	Sender common.AppSender
+	Writer Writer
+
+	// DB is used to persist the sync progress. It must be committed by
+	// [Writer.Commit].
+	DB database.Database
+}
+
+// Client downloads the entries of a summary from peers and verifies them
+// bucket by bucket against the summary root. The progress is persisted after
+// every bucket, so that a restarted node resumes the sync of the same summary.
+type Client struct {
+	config     ClientConfig
+	progressDB database.Database
+	stagingDB  database.Database
+
+	peers       set.Set[ids.NodeID]
+	lastFailure ids.NodeID
+
+	// summary is the summary being synced, or nil if not syncing
+	summary *Summary
+	// buckets are the verified bucket hashes of [summary], or nil if they
+	// haven't been fetched yet
+	buckets    []ids.ID
+	nextBucket int
+	numEntries uint64
+
+	// start is the last key received for [nextBucket]
+	start  []byte
+	hasher *bucketHasher
+
+	requestID      uint32
+	requestPending bool
+	pendingNodeID  ids.NodeID
+}
+
+func NewClient(config ClientConfig) *Client {
+	return &Client{
+		config:     config,
+		progressDB: prefixdb.New(progressPrefix, config.DB),
+		stagingDB:  prefixdb.New(stagingPrefix, config.DB),
+		hasher:     newBucketHasher(),
+	}
+}
+
+// Ongoing returns the summary whose sync was started but not finished.
+//
+// Returns database.ErrNotFound if there is no ongoing sync.
+func (c *Client) Ongoing() (*Summary, error) {
+	summaryBytes, err := c.progressDB.Get(summaryKey)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSummary(summaryBytes)
+}
+
+// Progress returns the number of buckets of the ongoing sync that were
+// verified and applied.
+//
+// Returns database.ErrNotFound if there is no ongoing sync.
+func (c *Client) Progress() (uint64, error) {
+	if _, err := c.progressDB.Get(summaryKey); err != nil {
+		return 0, err
+	}
+	nextBucket, err := database.GetUInt64(c.progressDB, nextBucketKey)
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return nextBucket, err
+}
+
+// Syncing returns true if the client is downloading a summary.
+func (c *Client) Syncing() bool {
+	return c.summary != nil
+}
+
+// Start syncing [summary]. If the sync of [summary] was previously started,
+// it is resumed from the last verified bucket.
+func (c *Client) Start(ctx context.Context, summary *Summary) error {
+	if err := database.Clear(c.stagingDB, c.stagingDB); err != nil {
+		return err
+	}
+
+	ongoing, err := c.Ongoing()
+	switch {
+	case err == nil && ongoing.ID() == summary.ID():
+		if err := c.loadProgress(); err != nil {
+			return err
+		}
+		c.config.Log.Info("resuming state sync",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Uint64("height", summary.Height),
+			zap.Int("nextBucket", c.nextBucket),
+		)
+	case err == nil || err == database.ErrNotFound:
+		if err := database.Clear(c.progressDB, c.progressDB); err != nil {
+			return err
+		}
+		if err := c.progressDB.Put(summaryKey, summary.Bytes()); err != nil {
+			return err
+		}
+		if err := c.config.Writer.Reset(); err != nil {
+			return err
+		}
+		if err := c.config.Writer.Commit(); err != nil {
+			return err
+		}
+		c.buckets = nil
+		c.nextBucket = 0
+		c.numEntries = 0
+		c.config.Log.Info("starting state sync",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Uint64("height", summary.Height),
+		)
+	default:
+		return err
+	}
+
+	c.summary = summary
+	c.resetBucket()
+	if c.nextBucket >= NumBuckets {
+		return c.finish(ctx)
+	}
+	return c.sendRequest(ctx)
+}
+
+func (c *Client) Connected(ctx context.Context, nodeID ids.NodeID) error {
+	c.peers.Add(nodeID)
+	if c.summary == nil || c.requestPending {
+		return nil
+	}
+	return c.sendRequest(ctx)
+}
+
+func (c *Client) Disconnected(nodeID ids.NodeID) {
+	c.peers.Remove(nodeID)
+}
+
+func (c *Client) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	if !c.isPending(nodeID, requestID) {
+		return nil
+	}
+	c.requestPending = false
+	c.lastFailure = nodeID
+	return c.sendRequest(ctx)
+}
+
+func (c *Client) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
+	if !c.isPending(nodeID, requestID) {
+		c.config.Log.Debug("dropping unexpected state sync response",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+	c.requestPending = false
+
+	var (
+		bucketVerified bool
+		err            error
+	)
+	if c.buckets == nil {
+		err = c.handleBuckets(response)
+	} else {
+		bucketVerified, err = c.handleEntries(response)
+	}
+	if err != nil {
+		c.config.Log.Debug("dropping invalid state sync response",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Error(err),
+		)
+		c.lastFailure = nodeID
+		return c.sendRequest(ctx)
+	}
+
+	if bucketVerified {
+		// Failing to apply verified entries is fatal, as fetching them again
+		// would produce the same entries.
+		if err := c.applyBucket(); err != nil {
+			return err
+		}
+	}
+	if c.nextBucket < NumBuckets {
+		return c.sendRequest(ctx)
+	}
+	return c.finish(ctx)
+}
+
+func (c *Client) handleBuckets(response []byte) error {
+	r := BucketsResponse{}
+	if err := parseResponse(response, &r); err != nil {
+		return err
+	}
+	if len(r.Buckets) != NumBuckets {
+		return errWrongNumBuckets
+	}
+	if root(r.Buckets) != c.summary.Root {
+		return errWrongRoot
+	}
+
+	if err := c.progressDB.Put(bucketsKey, packBuckets(r.Buckets)); err != nil {
+		return err
+	}
+	if err := c.config.Writer.Commit(); err != nil {
+		return err
+	}
+	c.buckets = r.Buckets
+	return nil
+}
+
+// handleEntries stages the entries in [response] and returns true once all
+// the entries of the current bucket have been received and verified.
+func (c *Client) handleEntries(response []byte) (bool, error) {
+	r := EntriesResponse{}
+	if err := parseResponse(response, &r); err != nil {
+		return false, err
+	}
+	if len(r.Keys) != len(r.Values) {
+		return false, errMismatchedEntries
+	}
+	if r.More && len(r.Keys) == 0 {
+		return false, errEmptyResponse
+	}
+
+	bucket := byte(c.nextBucket)
+	prevKey := c.start
+	for _, key := range r.Keys {
+		if len(key) == 0 || bucketOf(key) != bucket {
+			return false, errEntryOutsideBucket
+		}
+		if bytes.Compare(prevKey, key) >= 0 {
+			return false, errUnorderedEntries
+		}
+		prevKey = key
+	}
+
+	for i, key := range r.Keys {
+		c.hasher.Add(key, r.Values[i])
+		if err := c.stagingDB.Put(key, r.Values[i]); err != nil {
+			return false, err
+		}
+	}
+	if len(r.Keys) > 0 {
+		c.start = r.Keys[len(r.Keys)-1]
+	}
+	if r.More {
+		return false, nil
+	}
+
+	if c.hasher.Sum() != c.buckets[bucket] {
+		// The bucket must be fetched again, as we can't tell which of the
+		// responses was invalid.
+		c.resetBucket()
+		if err := database.Clear(c.stagingDB, c.stagingDB); err != nil {
+			return false, err
+		}
+		return false, errWrongBucketHash
+	}
+	return true, nil
+}
+
+// applyBucket writes the verified entries of the current bucket to the state
+// and persists the progress.
+func (c *Client) applyBucket() error {
+	iter := c.stagingDB.NewIterator()
+	defer iter.Release()
+
+	numEntries := uint64(0)
+	for iter.Next() {
+		if err := c.config.Writer.Put(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+		numEntries++
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if err := database.Clear(c.stagingDB, c.stagingDB); err != nil {
+		return err
+	}
+
+	c.nextBucket++
+	c.numEntries += numEntries
+	c.resetBucket()
+
+	if err := database.PutUInt64(c.progressDB, nextBucketKey, uint64(c.nextBucket)); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(c.progressDB, numEntriesKey, c.numEntries); err != nil {
+		return err
+	}
+	return c.config.Writer.Commit()
+}
+
+func (c *Client) finish(ctx context.Context) error {
+	summary := c.summary
+	if c.numEntries != summary.NumEntries {
+		return fmt.Errorf("%w: expected %d but got %d", errWrongNumEntries, summary.NumEntries, c.numEntries)
+	}
+
+	c.summary = nil
+	c.buckets = nil
+	if err := database.Clear(c.progressDB, c.progressDB); err != nil {
+		return err
+	}
+
+	c.config.Log.Info("finished state sync",
+		zap.Stringer("summaryID", summary.ID()),
+		zap.Uint64("height", summary.Height),
+		zap.Uint64("numEntries", c.numEntries),
+	)
+	return c.config.Writer.Finish(ctx, summary)
+}
+
+func (c *Client) sendRequest(ctx context.Context) error {
+	if c.requestPending {
+		return nil
+	}
+
+	nodeID, ok := c.samplePeer()
+	if !ok {
+		c.config.Log.Debug("waiting for peers to continue state sync")
+		return nil
+	}
+
+	var request Request
+	if c.buckets == nil {
+		request = &BucketsRequest{
+			Height: c.summary.Height,
+		}
+	} else {
+		request = &EntriesRequest{
+			Height: c.summary.Height,
+			Bucket: byte(c.nextBucket),
+			Start:  c.start,
+			Limit:  MaxEntriesPerResponse,
+		}
+	}
+	requestBytes, err := BuildRequest(request)
+	if err != nil {
+		return err
+	}
+
+	c.requestID++
+	c.requestPending = true
+	c.pendingNodeID = nodeID
+	return c.config.Sender.SendAppRequest(ctx, set.Set[ids.NodeID]{nodeID: struct{}{}}, c.requestID, requestBytes)
+}
+
+// samplePeer returns a random connected peer, avoiding the peer that failed
+// last if possible.
+func (c *Client) samplePeer() (ids.NodeID, bool) {
+	peers := c.peers.List()
+	if len(peers) == 0 {
+		return ids.EmptyNodeID, false
+	}
+	if len(peers) > 1 {
+		for i, peer := range peers {
+			if peer == c.lastFailure {
+				peers[i] = peers[len(peers)-1]
+				peers = peers[:len(peers)-1]
+				break
+			}
+		}
+	}
+	return peers[rand.Intn(len(peers))], true // #nosec G404
+}
+
+func (c *Client) isPending(nodeID ids.NodeID, requestID uint32) bool {
+	return c.requestPending && c.pendingNodeID == nodeID && c.requestID == requestID
+}
+
+func (c *Client) resetBucket() {
+	c.start = nil
+	c.hasher = newBucketHasher()
+}
+
+func (c *Client) loadProgress() error {
+	bucketsBytes, err := c.progressDB.Get(bucketsKey)
+	switch err {
+	case nil:
+		c.buckets, err = parseBuckets(bucketsBytes)
+		if err != nil {
+			return err
+		}
+	case database.ErrNotFound:
+		c.buckets = nil
+	default:
+		return err
+	}
+
+	nextBucket, err := database.GetUInt64(c.progressDB, nextBucketKey)
+	switch err {
+	case nil:
+		c.nextBucket = int(nextBucket)
+	case database.ErrNotFound:
+		c.nextBucket = 0
+	default:
+		return err
+	}
+
+	c.numEntries, err = database.GetUInt64(c.progressDB, numEntriesKey)
+	if err == database.ErrNotFound {
+		c.numEntries = 0
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+)
+
+var _ Writer = (*testWriter)(nil)
+
+type testWriter struct {
+	entries  map[string][]byte
+	resets   int
+	finished *Summary
+}
+
+func (w *testWriter) Reset() error {
+	w.resets++
+	w.entries = make(map[string][]byte)
+	return nil
+}
+
+func (w *testWriter) Put(key, value []byte) error {
+	w.entries[string(key)] = value
+	return nil
+}
+
+func (*testWriter) Commit() error {
+	return nil
+}
+
+func (w *testWriter) Finish(_ context.Context, summary *Summary) error {
+	w.finished = summary
+	return nil
+}
+
+type request struct {
+	nodeID    ids.NodeID
+	requestID uint32
+	bytes     []byte
+}
+
+func newTestClient(t *testing.T, db database.Database, writer Writer) (*Client, *[]request) {
+	requests := []request{}
+	sender := &common.SenderTest{T: t}
+	sender.SendAppRequestF = func(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, requestBytes []byte) error {
+		require.Equal(t, 1, nodeIDs.Len())
+		requests = append(requests, request{
+			nodeID:    nodeIDs.List()[0],
+			requestID: requestID,
+			bytes:     requestBytes,
+		})
+		return nil
+	}
+	return NewClient(ClientConfig{
+		Log:    logging.NoLog{},
+		Sender: sender,
+		Writer: writer,
+		DB:     db,
+	}), &requests
+}
+
+// serve answers the pending requests of [client] with [server] until there
+// are no more requests or [maxResponses] responses were sent.
+func serve(t *testing.T, client *Client, requests *[]request, server *Server, maxResponses int) {
+	require := require.New(t)
+
+	for i := 0; i < maxResponses && len(*requests) > 0; i++ {
+		r := (*requests)[0]
+		*requests = (*requests)[1:]
+
+		response, err := server.HandleRequest(r.bytes)
+		require.NoError(err)
+		require.NoError(client.AppResponse(context.Background(), r.nodeID, r.requestID, response))
+	}
+}
+
+func TestClientSync(t *testing.T) {
+	require := require.New(t)
+
+	snapshots := NewSnapshots(memdb.New())
+	summary, entries := buildSnapshot(t, snapshots, SummaryInterval, 1000)
+	server := NewServer(snapshots)
+
+	writer := &testWriter{}
+	client, requests := newTestClient(t, memdb.New(), writer)
+
+	require.NoError(client.Start(context.Background(), summary))
+	require.True(client.Syncing())
+	require.Empty(*requests)
+
+	require.NoError(client.Connected(context.Background(), ids.GenerateTestNodeID()))
+	serve(t, client, requests, server, 1000)
+
+	require.False(client.Syncing())
+	require.Equal(summary, writer.finished)
+	require.Equal(entries, writer.entries)
+
+	_, err := client.Ongoing()
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestClientResume(t *testing.T) {
+	require := require.New(t)
+
+	snapshots := NewSnapshots(memdb.New())
+	summary, entries := buildSnapshot(t, snapshots, SummaryInterval, 1000)
+	server := NewServer(snapshots)
+	nodeID := ids.GenerateTestNodeID()
+
+	db := memdb.New()
+	writer := &testWriter{}
+	client, requests := newTestClient(t, db, writer)
+	require.NoError(client.Start(context.Background(), summary))
+	require.NoError(client.Connected(context.Background(), nodeID))
+
+	// Only download part of the buckets before "restarting".
+	serve(t, client, requests, server, 20)
+	require.True(client.Syncing())
+
+	ongoing, err := client.Ongoing()
+	require.NoError(err)
+	require.Equal(summary.ID(), ongoing.ID())
+
+	client, requests = newTestClient(t, db, writer)
+	require.NoError(client.Start(context.Background(), summary))
+	require.NoError(client.Connected(context.Background(), nodeID))
+	serve(t, client, requests, server, 1000)
+
+	// The state must only have been reset by the first attempt.
+	require.Equal(1, writer.resets)
+	require.Equal(summary, writer.finished)
+	require.Equal(entries, writer.entries)
+}
+
+func TestClientRejectsInvalidResponse(t *testing.T) {
+	require := require.New(t)
+
+	snapshots := NewSnapshots(memdb.New())
+	summary, _ := buildSnapshot(t, snapshots, SummaryInterval, 10)
+
+	// Serve a different snapshot at the same height.
+	otherSnapshots := NewSnapshots(memdb.New())
+	_, _ = buildSnapshot(t, otherSnapshots, SummaryInterval, 10)
+	otherServer := NewServer(otherSnapshots)
+
+	writer := &testWriter{}
+	client, requests := newTestClient(t, memdb.New(), writer)
+	require.NoError(client.Start(context.Background(), summary))
+
+	badNodeID := ids.GenerateTestNodeID()
+	goodNodeID := ids.GenerateTestNodeID()
+	require.NoError(client.Connected(context.Background(), badNodeID))
+	require.Len(*requests, 1)
+
+	require.NoError(client.Connected(context.Background(), goodNodeID))
+	require.Len(*requests, 1)
+
+	serve(t, client, requests, otherServer, 1)
+
+	// The root doesn't match, so the request is sent to the other peer.
+	require.Len(*requests, 1)
+	require.Equal(goodNodeID, (*requests)[0].nodeID)
+	require.Nil(writer.finished)
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"crypto/sha256"
+	"errors"
+	"hash"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
+)
+
+// NumBuckets is the number of buckets the entries of a summary are split into.
+const NumBuckets = 256
+
+const (
+	UTXOEntry EntryType = iota
+	StatusEntry
+	TxEntry
+	// FreezeEntry is keyed by the hash of the key of the freeze it contains.
+	FreezeEntry
+)
+
+var (
+	errInvalidKey      = errors.New("invalid entry key")
+	errWrongNumBuckets = errors.New("wrong number of buckets")
+)
+
+// EntryType describes what an entry of a summary contains.
+type EntryType byte
+
+// Key returns the key of the entry of type [entryType] for [id]. Keys are
+// prefixed by the ID so that the entries are uniformly spread over the
+// buckets.
+func Key(id ids.ID, entryType EntryType) []byte {
+	key := make([]byte, hashing.HashLen+1)
+	copy(key, id[:])
+	key[hashing.HashLen] = byte(entryType)
+	return key
+}
+
+// ParseKey returns the ID and the type of the entry with [key].
+func ParseKey(key []byte) (ids.ID, EntryType, error) {
+	if len(key) != hashing.HashLen+1 {
+		return ids.Empty, 0, errInvalidKey
+	}
+	id, err := ids.ToID(key[:hashing.HashLen])
+	return id, EntryType(key[hashing.HashLen]), err
+}
+
+// bucketOf returns the bucket that [key] belongs to.
+func bucketOf(key []byte) byte {
+	return key[0]
+}
+
+// bucketHasher computes the hash of the entries of a bucket. Entries must be
+// added in increasing key order.
+type bucketHasher struct {
+	h hash.Hash
+}
+
+func newBucketHasher() *bucketHasher {
+	return &bucketHasher{h: sha256.New()}
+}
+
+func (b *bucketHasher) Add(key, value []byte) {
+	_, _ = b.h.Write(database.PackUInt32(uint32(len(key))))
+	_, _ = b.h.Write(key)
+	_, _ = b.h.Write(database.PackUInt32(uint32(len(value))))
+	_, _ = b.h.Write(value)
+}
+
+func (b *bucketHasher) Sum() ids.ID {
+	var id ids.ID
+	copy(id[:], b.h.Sum(nil))
+	return id
+}
+
+// root returns the hash that commits to all the bucket hashes.
+func root(buckets []ids.ID) ids.ID {
+	return hashing.ComputeHash256Array(packBuckets(buckets))
+}
+
+func packBuckets(buckets []ids.ID) []byte {
+	bytes := make([]byte, 0, len(buckets)*hashing.HashLen)
+	for _, bucket := range buckets {
+		bytes = append(bytes, bucket[:]...)
+	}
+	return bytes
+}
+
+func parseBuckets(bytes []byte) ([]ids.ID, error) {
+	if len(bytes) != NumBuckets*hashing.HashLen {
+		return nil, errWrongNumBuckets
+	}
+	buckets := make([]ids.ID, NumBuckets)
+	for i := range buckets {
+		copy(buckets[i][:], bytes[i*hashing.HashLen:])
+	}
+	return buckets, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+var (
+	_ Request = (*BucketsRequest)(nil)
+	_ Request = (*EntriesRequest)(nil)
+)
+
+// Request is a message sent by a syncing node to fetch part of a summary.
+type Request interface {
+	// Height of the summary the request refers to.
+	SummaryHeight() uint64
+}
+
+// BucketsRequest requests the bucket hashes of the summary at [Height]. It is
+// answered with a BucketsResponse.
+type BucketsRequest struct {
+	Height uint64 `serialize:"true"`
+}
+
+func (r *BucketsRequest) SummaryHeight() uint64 {
+	return r.Height
+}
+
+// EntriesRequest requests up to [Limit] entries of bucket [Bucket] of the
+// summary at [Height], starting after the key [Start]. If [Start] is empty,
+// the entries are returned from the beginning of the bucket. It is answered
+// with an EntriesResponse.
+type EntriesRequest struct {
+	Height uint64 `serialize:"true"`
+	Bucket byte   `serialize:"true"`
+	Start  []byte `serialize:"true"`
+	Limit  uint32 `serialize:"true"`
+}
+
+func (r *EntriesRequest) SummaryHeight() uint64 {
+	return r.Height
+}
+
+type BucketsResponse struct {
+	Buckets []ids.ID `serialize:"true"`
+}
+
+// EntriesResponse contains the requested entries, ordered by key. [More] is
+// true if the bucket contains entries after the last returned one.
+type EntriesResponse struct {
+	Keys   [][]byte `serialize:"true"`
+	Values [][]byte `serialize:"true"`
+	More   bool     `serialize:"true"`
+}
+
+func BuildRequest(r Request) ([]byte, error) {
+	return c.Marshal(codecVersion, &r)
+}
+
+func ParseRequest(bytes []byte) (Request, error) {
+	var r Request
+	version, err := c.Unmarshal(bytes, &r)
+	if err != nil {
+		return nil, err
+	}
+	if version != codecVersion {
+		return nil, errUnexpectedCodecVersion
+	}
+	return r, nil
+}
+
+func buildResponse(r interface{}) ([]byte, error) {
+	return c.Marshal(codecVersion, r)
+}
+
+func parseResponse(bytes []byte, r interface{}) error {
+	version, err := c.Unmarshal(bytes, r)
+	if err != nil {
+		return err
+	}
+	if version != codecVersion {
+		return errUnexpectedCodecVersion
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+)
+
+const (
+	// MaxEntriesPerResponse is the maximum number of entries returned in a
+	// single EntriesResponse.
+	MaxEntriesPerResponse = 2048
+
+	maxResponseSize = units.MiB
+)
+
+var errUnknownRequest = errors.New("unknown request type")
+
+// Server answers the requests of syncing peers from the persisted snapshots.
+type Server struct {
+	snapshots *Snapshots
+}
+
+func NewServer(snapshots *Snapshots) *Server {
+	return &Server{snapshots: snapshots}
+}
+
+// HandleRequest returns the response to the serialized request in
+// [requestBytes].
+func (s *Server) HandleRequest(requestBytes []byte) ([]byte, error) {
+	request, err := ParseRequest(requestBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse request: %w", err)
+	}
+
+	switch r := request.(type) {
+	case *BucketsRequest:
+		buckets, err := s.snapshots.Buckets(r.Height)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get buckets at height %d: %w", r.Height, err)
+		}
+		return buildResponse(&BucketsResponse{
+			Buckets: buckets,
+		})
+	case *EntriesRequest:
+		limit := int(r.Limit)
+		if limit <= 0 || limit > MaxEntriesPerResponse {
+			limit = MaxEntriesPerResponse
+		}
+		keys, values, more, err := s.snapshots.Entries(r.Height, r.Bucket, r.Start, limit, maxResponseSize)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get entries at height %d: %w", r.Height, err)
+		}
+		return buildResponse(&EntriesResponse{
+			Keys:   keys,
+			Values: values,
+			More:   more,
+		})
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnknownRequest, request)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+)
+
+const (
+	// maxSnapshots is the number of snapshots that are kept around. Keeping
+	// the previous snapshot allows peers that started syncing it to finish.
+	maxSnapshots = 2
+
+	builderBatchSize = 256 * units.KiB
+)
+
+var (
+	summaryPrefix = []byte("summary")
+	bucketsPrefix = []byte("buckets")
+	entriesPrefix = []byte("entries")
+)
+
+// Snapshots persists copies of the state at the heights that summaries were
+// taken at, so that they can be served to syncing peers while the state keeps
+// changing.
+type Snapshots struct {
+	// height -> summary bytes
+	summaryDB database.Database
+	// height -> bucket hashes
+	bucketsDB database.Database
+	// height -> key -> value
+	entriesDB database.Database
+}
+
+func NewSnapshots(db database.Database) *Snapshots {
+	return &Snapshots{
+		summaryDB: prefixdb.New(summaryPrefix, db),
+		bucketsDB: prefixdb.New(bucketsPrefix, db),
+		entriesDB: prefixdb.New(entriesPrefix, db),
+	}
+}
+
+// Get returns the summary taken at [height].
+//
+// Returns database.ErrNotFound if there is no snapshot at [height].
+func (s *Snapshots) Get(height uint64) (*Summary, error) {
+	summaryBytes, err := s.summaryDB.Get(database.PackUInt64(height))
+	if err != nil {
+		return nil, err
+	}
+	return ParseSummary(summaryBytes)
+}
+
+// Last returns the most recent summary.
+//
+// Returns database.ErrNotFound if there are no snapshots.
+func (s *Snapshots) Last() (*Summary, error) {
+	heights, err := s.heights()
+	if err != nil {
+		return nil, err
+	}
+	if len(heights) == 0 {
+		return nil, database.ErrNotFound
+	}
+	return s.Get(heights[len(heights)-1])
+}
+
+// Buckets returns the bucket hashes of the summary taken at [height].
+func (s *Snapshots) Buckets(height uint64) ([]ids.ID, error) {
+	bucketsBytes, err := s.bucketsDB.Get(database.PackUInt64(height))
+	if err != nil {
+		return nil, err
+	}
+	return parseBuckets(bucketsBytes)
+}
+
+// Entries returns up to [limit] entries, with a total size of at most
+// [maxSize] bytes, of [bucket] in the snapshot taken at [height]. The entries
+// start after [start], or at the beginning of the bucket if [start] is empty.
+// The returned boolean is true if the bucket has more entries.
+func (s *Snapshots) Entries(
+	height uint64,
+	bucket byte,
+	start []byte,
+	limit int,
+	maxSize int,
+) ([][]byte, [][]byte, bool, error) {
+	if _, err := s.summaryDB.Get(database.PackUInt64(height)); err != nil {
+		return nil, nil, false, err
+	}
+
+	if len(start) == 0 {
+		start = []byte{bucket}
+	}
+	db := prefixdb.NewNested(database.PackUInt64(height), s.entriesDB)
+	iter := db.NewIteratorWithStart(start)
+	defer iter.Release()
+
+	var (
+		keys   [][]byte
+		values [][]byte
+		size   int
+	)
+	for iter.Next() {
+		key := iter.Key()
+		if bucketOf(key) != bucket {
+			return keys, values, false, iter.Error()
+		}
+		if len(keys) == 0 && len(start) > 1 && string(key) == string(start) {
+			// [start] was returned by a previous call.
+			continue
+		}
+
+		value := iter.Value()
+		size += len(key) + len(value)
+		if len(keys) >= limit || (len(keys) > 0 && size > maxSize) {
+			return keys, values, true, iter.Error()
+		}
+		keys = append(keys, utils.CopyBytes(key))
+		values = append(values, utils.CopyBytes(value))
+	}
+	return keys, values, false, iter.Error()
+}
+
+// NewBuilder returns a builder that persists a new snapshot of the state at
+// the accepted vertex [frontier], whose height is [height]. Any existing
+// snapshot at [height] is overwritten.
+func (s *Snapshots) NewBuilder(height uint64, frontier ids.ID) (*Builder, error) {
+	db := prefixdb.NewNested(database.PackUInt64(height), s.entriesDB)
+	if err := database.Clear(db, db); err != nil {
+		return nil, err
+	}
+	return &Builder{
+		snapshots: s,
+		height:    height,
+		frontier:  frontier,
+		db:        db,
+		batch:     db.NewBatch(),
+	}, nil
+}
+
+// Builder writes the entries of a snapshot. Entries may be written in any
+// order.
+type Builder struct {
+	snapshots *Snapshots
+	height    uint64
+	frontier  ids.ID
+	db        database.Database
+	batch     database.Batch
+}
+
+func (b *Builder) Put(key, value []byte) error {
+	if err := b.batch.Put(key, value); err != nil {
+		return err
+	}
+	if b.batch.Size() < builderBatchSize {
+		return nil
+	}
+	if err := b.batch.Write(); err != nil {
+		return err
+	}
+	b.batch.Reset()
+	return nil
+}
+
+// Finish computes the summary of the written entries, persists it and prunes
+// the snapshots that are no longer needed.
+func (b *Builder) Finish() (*Summary, error) {
+	if err := b.batch.Write(); err != nil {
+		return nil, err
+	}
+
+	buckets := make([]ids.ID, NumBuckets)
+	hashers := make([]*bucketHasher, NumBuckets)
+	for i := range hashers {
+		hashers[i] = newBucketHasher()
+	}
+
+	iter := b.db.NewIterator()
+	defer iter.Release()
+
+	numEntries := uint64(0)
+	for iter.Next() {
+		key := iter.Key()
+		hashers[bucketOf(key)].Add(key, iter.Value())
+		numEntries++
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	for i, hasher := range hashers {
+		buckets[i] = hasher.Sum()
+	}
+
+	summary, err := NewSummary(b.height, b.frontier, root(buckets), numEntries)
+	if err != nil {
+		return nil, err
+	}
+
+	heightKey := database.PackUInt64(b.height)
+	if err := b.snapshots.bucketsDB.Put(heightKey, packBuckets(buckets)); err != nil {
+		return nil, err
+	}
+	if err := b.snapshots.summaryDB.Put(heightKey, summary.Bytes()); err != nil {
+		return nil, err
+	}
+	return summary, b.snapshots.prune()
+}
+
+// prune removes the oldest snapshots until at most [maxSnapshots] remain.
+func (s *Snapshots) prune() error {
+	heights, err := s.heights()
+	if err != nil {
+		return err
+	}
+	for len(heights) > maxSnapshots {
+		heightKey := database.PackUInt64(heights[0])
+		heights = heights[1:]
+
+		if err := s.summaryDB.Delete(heightKey); err != nil {
+			return err
+		}
+		if err := s.bucketsDB.Delete(heightKey); err != nil {
+			return err
+		}
+		db := prefixdb.NewNested(heightKey, s.entriesDB)
+		if err := database.Clear(db, db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// heights returns the heights of the persisted snapshots in increasing order.
+func (s *Snapshots) heights() ([]uint64, error) {
+	iter := s.summaryDB.NewIterator()
+	defer iter.Release()
+
+	var heights []uint64
+	for iter.Next() {
+		height, err := database.ParseUInt64(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		heights = append(heights, height)
+	}
+	return heights, iter.Error()
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+// buildSnapshot persists a snapshot at [height] with [numEntries] random UTXO
+// entries and returns its summary and entries.
+func buildSnapshot(t *testing.T, s *Snapshots, height uint64, numEntries int) (*Summary, map[string][]byte) {
+	require := require.New(t)
+
+	builder, err := s.NewBuilder(height, ids.GenerateTestID())
+	require.NoError(err)
+
+	entries := make(map[string][]byte, numEntries)
+	for i := 0; i < numEntries; i++ {
+		key := Key(ids.GenerateTestID(), UTXOEntry)
+		value := []byte{byte(i), byte(i >> 8)}
+		require.NoError(builder.Put(key, value))
+		entries[string(key)] = value
+	}
+
+	summary, err := builder.Finish()
+	require.NoError(err)
+	return summary, entries
+}
+
+func TestSnapshotsLastAndPrune(t *testing.T) {
+	require := require.New(t)
+
+	s := NewSnapshots(memdb.New())
+
+	_, err := s.Last()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	for height := uint64(1); height <= maxSnapshots+1; height++ {
+		summary, entries := buildSnapshot(t, s, height*SummaryInterval, 10)
+		require.Equal(uint64(len(entries)), summary.NumEntries)
+
+		last, err := s.Last()
+		require.NoError(err)
+		require.Equal(summary.ID(), last.ID())
+	}
+
+	_, err = s.Get(SummaryInterval)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = s.Buckets(SummaryInterval)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	heights, err := s.heights()
+	require.NoError(err)
+	require.Len(heights, maxSnapshots)
+}
+
+func TestSnapshotsEntries(t *testing.T) {
+	require := require.New(t)
+
+	s := NewSnapshots(memdb.New())
+	summary, entries := buildSnapshot(t, s, SummaryInterval, 2048)
+
+	buckets, err := s.Buckets(summary.Height)
+	require.NoError(err)
+	require.Equal(summary.Root, root(buckets))
+
+	// Page through every bucket and recompute its hash.
+	numEntries := 0
+	for bucket := 0; bucket < NumBuckets; bucket++ {
+		hasher := newBucketHasher()
+		var start []byte
+		for {
+			keys, values, more, err := s.Entries(summary.Height, byte(bucket), start, 3, maxResponseSize)
+			require.NoError(err)
+			for i, key := range keys {
+				require.Equal(byte(bucket), bucketOf(key))
+				require.Equal(entries[string(key)], values[i])
+				hasher.Add(key, values[i])
+				start = key
+			}
+			numEntries += len(keys)
+			if !more {
+				break
+			}
+		}
+		require.Equal(buckets[bucket], hasher.Sum())
+	}
+	require.Equal(len(entries), numEntries)
+
+	_, _, _, err = s.Entries(summary.Height+1, 0, nil, 1, maxResponseSize)
+	require.ErrorIs(err, database.ErrNotFound)
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
+)
+
+// SummaryInterval is the number of vertex heights between two state
+// summaries.
+const SummaryInterval = 1 << 12
+
+var errUnexpectedCodecVersion = errors.New("unexpected codec version")
+
+// Summary commits to the X-chain state once the accepted frontier of the DAG
+// was the single vertex [Frontier] at [Height]. That state only contains the
+// transactions of [Frontier] and its ancestors.
+//
+// The state is made of the UTXO set, the statuses of the transactions that
+// created the UTXOs or that are in [Frontier], and the transactions that
+// created the assets of the UTXOs. The entries are split into [NumBuckets] buckets by the
+// first byte of their key, and [Root] is the hash of the bucket hashes.
+type Summary struct {
+	Height     uint64 `serialize:"true"`
+	Frontier   ids.ID `serialize:"true"`
+	Root       ids.ID `serialize:"true"`
+	NumEntries uint64 `serialize:"true"`
+
+	id    ids.ID
+	bytes []byte
+}
+
+func NewSummary(height uint64, frontier ids.ID, root ids.ID, numEntries uint64) (*Summary, error) {
+	s := &Summary{
+		Height:     height,
+		Frontier:   frontier,
+		Root:       root,
+		NumEntries: numEntries,
+	}
+	bytes, err := c.Marshal(codecVersion, s)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal summary: %w", err)
+	}
+	s.initialize(bytes)
+	return s, nil
+}
+
+func ParseSummary(bytes []byte) (*Summary, error) {
+	s := &Summary{}
+	version, err := c.Unmarshal(bytes, s)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse summary: %w", err)
+	}
+	if version != codecVersion {
+		return nil, errUnexpectedCodecVersion
+	}
+	s.initialize(bytes)
+	return s, nil
+}
+
+func (s *Summary) initialize(bytes []byte) {
+	s.id = hashing.ComputeHash256Array(bytes)
+	s.bytes = bytes
+}
+
+func (s *Summary) ID() ids.ID {
+	return s.id
+}
+
+func (s *Summary) Bytes() []byte {
+	return s.bytes
+}
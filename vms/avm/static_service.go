@@ -19,6 +19,8 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/avm/txs"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/freezefx"
+	"github.com/lasthyphen/dijetsnodego/vms/htlcfx"
 	"github.com/lasthyphen/dijetsnodego/vms/nftfx"
 	"github.com/lasthyphen/dijetsnodego/vms/propertyfx"
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
@@ -44,6 +46,15 @@ var (
 	_ fxs.FxOperation   = (*propertyfx.MintOperation)(nil)
 	_ fxs.FxOperation   = (*propertyfx.BurnOperation)(nil)
 	_ verify.Verifiable = (*propertyfx.Credential)(nil)
+
+	_ djtx.TransferableIn  = (*htlcfx.TransferInput)(nil)
+	_ djtx.TransferableOut = (*htlcfx.TransferOutput)(nil)
+	_ verify.Verifiable    = (*htlcfx.Credential)(nil)
+
+	_ verify.State      = (*freezefx.FreezeOutput)(nil)
+	_ fxs.FxOperation   = (*freezefx.FreezeOperation)(nil)
+	_ fxs.FxOperation   = (*freezefx.CheckOperation)(nil)
+	_ verify.Verifiable = (*freezefx.Credential)(nil)
 )
 
 // StaticService defines the base service for the asset vm
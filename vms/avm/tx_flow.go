@@ -0,0 +1,43 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"github.com/lasthyphen/dijetsnodego/vms/avm/txs"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+)
+
+var _ txs.Visitor = (*txFlow)(nil)
+
+// txFlow collects all the transferable inputs consumed and all the
+// transferable outputs produced by a tx, including imported inputs and
+// exported outputs.
+type txFlow struct {
+	ins  []*djtx.TransferableInput
+	outs []*djtx.TransferableOutput
+}
+
+func (f *txFlow) BaseTx(tx *txs.BaseTx) error {
+	f.ins = append(f.ins, tx.Ins...)
+	f.outs = append(f.outs, tx.Outs...)
+	return nil
+}
+
+func (f *txFlow) CreateAssetTx(tx *txs.CreateAssetTx) error {
+	return f.BaseTx(&tx.BaseTx)
+}
+
+func (f *txFlow) OperationTx(tx *txs.OperationTx) error {
+	return f.BaseTx(&tx.BaseTx)
+}
+
+func (f *txFlow) ImportTx(tx *txs.ImportTx) error {
+	f.ins = append(f.ins, tx.ImportedIns...)
+	return f.BaseTx(&tx.BaseTx)
+}
+
+func (f *txFlow) ExportTx(tx *txs.ExportTx) error {
+	f.outs = append(f.outs, tx.ExportedOuts...)
+	return f.BaseTx(&tx.BaseTx)
+}
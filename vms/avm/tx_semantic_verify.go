@@ -4,12 +4,25 @@
 package avm
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/vms/avm/txs"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/freezefx"
 )
 
-var _ txs.Visitor = (*txSemanticVerify)(nil)
+var (
+	_ txs.Visitor = (*txSemanticVerify)(nil)
+
+	errFrozenUTXO            = errors.New("UTXO is frozen")
+	errFrozenAddress         = errors.New("address is frozen")
+	errMissingFreezeCheck    = errors.New("spending a freezable asset requires a freeze check operation")
+	errUnorderedFreezeCheck  = errors.New("freeze check must spend an accepted freeze output or the output of a freeze check")
+	errMultipleFreezeOutputs = errors.New("asset can't have more than one freeze output")
+)
 
 // SemanticVerify that this transaction is well-formed.
 type txSemanticVerify struct {
@@ -22,7 +35,14 @@ func (t *txSemanticVerify) BaseTx(tx *txs.BaseTx) error {
 		// Note: Verification of the length of [t.tx.Creds] happens during
 		// syntactic verification, which happens before semantic verification.
 		cred := t.tx.Creds[i].Verifiable
-		if err := t.vm.verifyTransfer(t.tx.Unsigned, in, cred); err != nil {
+		utxo, err := t.vm.getUTXO(&in.UTXOID)
+		if err != nil {
+			return err
+		}
+		if err := t.verifyNotFrozen(utxo); err != nil {
+			return err
+		}
+		if err := t.vm.verifyTransferOfUTXO(t.tx.Unsigned, in, cred, utxo); err != nil {
 			return err
 		}
 	}
@@ -75,6 +95,9 @@ func (t *txSemanticVerify) ImportTx(tx *txs.ImportTx) error {
 		// Note: Verification of the length of [t.tx.Creds] happens during
 		// syntactic verification, which happens before semantic verification.
 		cred := t.tx.Creds[i+offset].Verifiable
+		if err := t.verifyNotFrozen(&utxo); err != nil {
+			return err
+		}
 		if err := t.vm.verifyTransferOfUTXO(tx, in, cred, &utxo); err != nil {
 			return err
 		}
@@ -117,10 +140,149 @@ func (t *txSemanticVerify) OperationTx(tx *txs.OperationTx) error {
 		if err := t.vm.verifyOperation(tx, op, cred); err != nil {
 			return err
 		}
+
+		// The freeze output of an asset is never frozen.
+		switch op.Op.(type) {
+		case *freezefx.FreezeOperation:
+			continue
+		case *freezefx.CheckOperation:
+			if err := t.verifyFreezeCheckInput(op.UTXOIDs[0]); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, utxoID := range op.UTXOIDs {
+			utxo, err := t.vm.getUTXO(utxoID)
+			if err != nil {
+				return err
+			}
+			if err := t.verifyNotFrozen(utxo); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
 
 func (t *txSemanticVerify) CreateAssetTx(tx *txs.CreateAssetTx) error {
+	numFreezeOutputs := 0
+	for _, state := range tx.States {
+		fxIndex := int(state.FxIndex)
+		if !t.vm.isFxActive(fxIndex) {
+			return fmt.Errorf("%w: %s", errFxNotActive, t.vm.fxs[fxIndex].ID)
+		}
+		for _, out := range state.Outs {
+			if _, ok := out.(*freezefx.FreezeOutput); ok {
+				numFreezeOutputs++
+			}
+		}
+	}
+	// Spends are only ordered against the freezes of the asset if they all
+	// spend the same freeze output.
+	if numFreezeOutputs > 1 {
+		return errMultipleFreezeOutputs
+	}
 	return t.BaseTx((&tx.BaseTx))
 }
+
+// verifyNotFrozen verifies that [utxo] isn't frozen, either directly or through
+// one of its owners. Only the assets that support the freeze fx can be frozen.
+//
+// The tx must spend the freeze output of the asset, so that it conflicts with
+// any freeze of the asset that is processing. Otherwise, the tx could be
+// accepted after a freeze of [utxo] without being verified against it.
+func (t *txSemanticVerify) verifyNotFrozen(utxo *djtx.UTXO) error {
+	assetID := utxo.AssetID()
+	if t.vm.freezeFxIndex < 0 || !t.vm.verifyFxUsage(t.vm.freezeFxIndex, assetID) {
+		return nil
+	}
+	if !t.hasFreezeCheck(assetID) {
+		return fmt.Errorf("%w: %s", errMissingFreezeCheck, assetID)
+	}
+
+	utxoID := utxo.InputID()
+	frozen, err := t.vm.state.IsUTXOFrozen(assetID, utxoID)
+	if err != nil {
+		return err
+	}
+	if frozen {
+		return fmt.Errorf("%w: %s", errFrozenUTXO, utxoID)
+	}
+
+	out, ok := utxo.Out.(djtx.Addressable)
+	if !ok {
+		return nil
+	}
+	for _, addrBytes := range out.Addresses() {
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			return err
+		}
+		frozen, err := t.vm.state.IsAddressFrozen(assetID, addr)
+		if err != nil {
+			return err
+		}
+		if frozen {
+			return fmt.Errorf("%w: %s", errFrozenAddress, addr)
+		}
+	}
+	return nil
+}
+
+// hasFreezeCheck returns true if the tx spends the freeze output of [assetID].
+func (t *txSemanticVerify) hasFreezeCheck(assetID ids.ID) bool {
+	tx, ok := t.tx.Unsigned.(*txs.OperationTx)
+	if !ok {
+		return false
+	}
+	for _, op := range tx.Ops {
+		if op.AssetID() != assetID {
+			continue
+		}
+		switch op.Op.(type) {
+		case *freezefx.CheckOperation, *freezefx.FreezeOperation:
+			return true
+		}
+	}
+	return false
+}
+
+// verifyFreezeCheckInput verifies that the freeze output spent by a freeze
+// check reflects the accepted freezes of its asset, which the spends of the tx
+// are verified against. That is the case if the freeze output was accepted, or
+// if it was created by a freeze check that is processing, since that freeze
+// check was verified the same way. A freeze output created by a freeze that is
+// processing can only be spent by a freeze check once that freeze is accepted.
+func (t *txSemanticVerify) verifyFreezeCheckInput(utxoID *djtx.UTXOID) error {
+	if _, err := t.vm.state.GetUTXO(utxoID.InputID()); err == nil {
+		return nil
+	}
+
+	// The freeze output was already fetched by verifyOperation, so the tx that
+	// created it is processing.
+	txID, outputIndex := utxoID.InputSource()
+	parent := &UniqueTx{
+		vm:   t.vm,
+		txID: txID,
+	}
+	if status := parent.Status(); !status.Fetched() {
+		return errMissingUTXO
+	}
+	parentTx, ok := parent.Unsigned.(*txs.OperationTx)
+	if !ok {
+		return errUnorderedFreezeCheck
+	}
+
+	index := uint32(len(parentTx.Outs))
+	for _, op := range parentTx.Ops {
+		numOuts := uint32(len(op.Op.Outs()))
+		if outputIndex < index+numOuts {
+			if _, ok := op.Op.(*freezefx.CheckOperation); ok {
+				return nil
+			}
+			break
+		}
+		index += numOuts
+	}
+	return errUnorderedFreezeCheck
+}
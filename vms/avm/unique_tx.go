@@ -17,6 +17,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/vms/avm/txs"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/freezefx"
 )
 
 var (
@@ -173,9 +174,27 @@ func (tx *UniqueTx) Accept(context.Context) error {
 		}
 	}
 
+	if err := tx.applyFreezes(); err != nil {
+		return fmt.Errorf("couldn't apply freezes of tx %s: %w", txID, err)
+	}
+
 	if err := tx.setStatus(choices.Accepted); err != nil {
 		return fmt.Errorf("couldn't set status of tx %s: %w", txID, err)
 	}
+	if err := tx.vm.state.AddAccepted(txID); err != nil {
+		return fmt.Errorf("couldn't record acceptance of tx %s: %w", txID, err)
+	}
+	if tx.vm.utxoJournal != nil {
+		// The vertex containing [tx] is accepted after [tx], so its changes
+		// are journaled at the height after the last accepted vertex.
+		acceptedHeight, err := tx.vm.state.AcceptedHeight()
+		if err != nil {
+			return err
+		}
+		if err := tx.vm.utxoJournal.Record(acceptedHeight+1, tx.vm.clock.Time(), inputUTXOs, outputUTXOs); err != nil {
+			return fmt.Errorf("couldn't journal UTXOs of tx %s: %w", txID, err)
+		}
+	}
 
 	commitBatch, err := tx.vm.db.CommitBatch()
 	if err != nil {
@@ -199,6 +218,34 @@ func (tx *UniqueTx) Accept(context.Context) error {
 	return nil
 }
 
+// applyFreezes records the freezes and unfreezes performed by the operations
+// of the tx.
+func (tx *UniqueTx) applyFreezes() error {
+	opTx, ok := tx.Tx.Unsigned.(*txs.OperationTx)
+	if !ok {
+		return nil
+	}
+	for _, op := range opTx.Ops {
+		freezeOp, ok := op.Op.(*freezefx.FreezeOperation)
+		if !ok {
+			continue
+		}
+
+		assetID := op.AssetID()
+		for _, utxoID := range freezeOp.TargetUTXOIDs {
+			if err := tx.vm.state.SetUTXOFrozen(assetID, utxoID, freezeOp.Frozen); err != nil {
+				return err
+			}
+		}
+		for _, addr := range freezeOp.TargetAddrs {
+			if err := tx.vm.state.SetAddressFrozen(assetID, addr, freezeOp.Frozen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Reject is called when the transaction was finalized as rejected by consensus
 func (tx *UniqueTx) Reject(context.Context) error {
 	defer tx.vm.db.Abort()
@@ -0,0 +1,126 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/api"
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+)
+
+var (
+	utxoJournalPrefix = []byte("utxoJournal")
+
+	errUTXOJournalDisabled = errors.New("historical queries require the UTXO journal to be enabled")
+	errUTXOJournalBehind   = errors.New("UTXO journal doesn't include the last accepted vertex")
+	errHeightAndTimestamp  = errors.New("only one of height and timestamp can be specified")
+)
+
+// initUTXOJournal creates the UTXO journal. The X-chain height is the height
+// of the last accepted vertex, so if the journal is empty the current height
+// is recorded to allow it to be queried.
+//
+// A transaction is journaled at the height after the last accepted vertex when
+// it is accepted. Transactions are normally accepted just before their vertex,
+// so a transaction whose vertex is accepted after a higher vertex is journaled
+// at the height after the higher vertex.
+func (vm *VM) initUTXOJournal(retention uint64) error {
+	vm.utxoJournal = djtx.NewUTXOJournal(
+		prefixdb.New(utxoJournalPrefix, vm.db),
+		vm.parser.Codec(),
+		retention,
+	)
+
+	_, err := vm.utxoJournal.LastHeight()
+	if err != database.ErrNotFound {
+		return err
+	}
+	height, err := vm.state.AcceptedHeight()
+	if err != nil {
+		return err
+	}
+	vm.ctx.Log.Info("initializing UTXO journal",
+		zap.Uint64("height", height),
+		zap.Uint64("retention", retention),
+	)
+	return vm.utxoJournal.Record(height, vm.clock.Time(), nil, nil)
+}
+
+// journalAcceptedHeight records an empty entry at the height of the last
+// accepted vertex if no transaction was journaled at it, so that every
+// accepted height can be queried.
+func (vm *VM) journalAcceptedHeight() error {
+	if vm.utxoJournal == nil {
+		return nil
+	}
+	lastHeight, err := vm.utxoJournal.LastHeight()
+	if err != nil {
+		return err
+	}
+	acceptedHeight, err := vm.state.AcceptedHeight()
+	if err != nil {
+		return err
+	}
+	if lastHeight >= acceptedHeight {
+		return nil
+	}
+	return vm.utxoJournal.Record(acceptedHeight, vm.clock.Time(), nil, nil)
+}
+
+// getUTXOReader returns the UTXOs of [addrs] as of the point requested by
+// [args], and the unix time that locktimes should be compared against.
+func (vm *VM) getUTXOReader(addrs set.Set[ids.ShortID], args *api.HistoricalArgs) (djtx.UTXOReader, uint64, error) {
+	if args.Height == nil && args.Timestamp == nil {
+		return vm.state, vm.clock.Unix(), nil
+	}
+	if args.Height != nil && args.Timestamp != nil {
+		return nil, 0, errHeightAndTimestamp
+	}
+	if vm.utxoJournal == nil {
+		return nil, 0, errUTXOJournalDisabled
+	}
+
+	lastHeight, err := vm.utxoJournal.LastHeight()
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %s", djtx.ErrHeightNotRetained, err)
+	}
+	acceptedHeight, err := vm.state.AcceptedHeight()
+	if err != nil {
+		return nil, 0, err
+	}
+	if lastHeight < acceptedHeight {
+		return nil, 0, errUTXOJournalBehind
+	}
+
+	var (
+		height uint64
+		now    uint64
+	)
+	if args.Timestamp != nil {
+		now = uint64(*args.Timestamp)
+		height, err = vm.utxoJournal.HeightAt(time.Unix(int64(now), 0))
+		if err != nil {
+			return nil, 0, err
+		}
+	} else {
+		height = uint64(*args.Height)
+		timestamp, err := vm.utxoJournal.Timestamp(height)
+		if err != nil {
+			return nil, 0, err
+		}
+		now = uint64(timestamp.Unix())
+	}
+
+	utxos, err := vm.utxoJournal.Rewind(vm.state, addrs, height)
+	return utxos, now, err
+}
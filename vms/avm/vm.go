@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	stdjson "encoding/json"
@@ -30,6 +31,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowstorm"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/avalanche/vertex"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
+	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto"
 	"github.com/lasthyphen/dijetsnodego/utils/json"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
@@ -37,11 +39,14 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/timer/mockable"
 	"github.com/lasthyphen/dijetsnodego/version"
 	"github.com/lasthyphen/dijetsnodego/vms/avm/states"
+	"github.com/lasthyphen/dijetsnodego/vms/avm/statesync"
 	"github.com/lasthyphen/dijetsnodego/vms/avm/txs"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/components/index"
 	"github.com/lasthyphen/dijetsnodego/vms/components/keystore"
 	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/freezefx"
+	"github.com/lasthyphen/dijetsnodego/vms/htlcfx"
 	"github.com/lasthyphen/dijetsnodego/vms/nftfx"
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
 
@@ -58,6 +63,7 @@ const (
 
 var (
 	errIncompatibleFx            = errors.New("incompatible feature extension")
+	errFxNotActive               = errors.New("feature extension isn't active yet")
 	errUnknownFx                 = errors.New("unknown feature extension")
 	errGenesisAssetMustHaveState = errors.New("genesis asset must have non-empty state")
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
@@ -106,20 +112,34 @@ type VM struct {
 
 	typeToFxIndex map[reflect.Type]int
 	fxs           []*extensions.ParsedFx
+	// Indices of the secp256k1 fx, the htlc fx, and the freeze fx in [fxs], or
+	// -1 if the chain doesn't run them.
+	secp256k1FxIndex int
+	htlcFxIndex      int
+	freezeFxIndex    int
 
 	walletService WalletService
 
 	addressTxsIndexer index.AddressTxsIndexer
 
-	uniqueTxs cache.Deduplicator
-}
+	// nil if historical queries are disabled
+	utxoJournal djtx.UTXOJournal
 
-func (*VM) Connected(context.Context, ids.NodeID, *version.Application) error {
-	return nil
-}
+	uniqueTxs cache.Deduplicator
 
-func (*VM) Disconnected(context.Context, ids.NodeID) error {
-	return nil
+	// State sync
+	appSender        common.AppSender
+	stateSyncEnabled bool
+	snapshots        *statesync.Snapshots
+	syncServer       *statesync.Server
+	syncClient       *statesync.Client
+	syncDB           database.Database
+
+	// Height of the last state summary that was started. Summaries are built
+	// in the background, at most one at a time.
+	summaryHeight   uint64
+	buildingSummary utils.AtomicBool
+	summaryBuilds   sync.WaitGroup
 }
 
 /*
@@ -131,6 +151,13 @@ func (*VM) Disconnected(context.Context, ids.NodeID) error {
 type Config struct {
 	IndexTransactions    bool `json:"index-transactions"`
 	IndexAllowIncomplete bool `json:"index-allow-incomplete"`
+	StateSyncEnabled     bool `json:"state-sync-enabled"`
+	// UTXOJournalEnabled enables queries of balances and UTXOs as of a past
+	// height.
+	UTXOJournalEnabled bool `json:"utxo-journal-enabled"`
+	// UTXOJournalRetention is the number of heights that can be queried. If
+	// 0, all the heights since the journal was enabled can be queried.
+	UTXOJournalRetention uint64 `json:"utxo-journal-retention"`
 }
 
 func (vm *VM) Initialize(
@@ -142,7 +169,7 @@ func (vm *VM) Initialize(
 	configBytes []byte,
 	toEngine chan<- common.Message,
 	fxs []*common.Fx,
-	_ common.AppSender,
+	appSender common.AppSender,
 ) error {
 	avmConfig := Config{}
 	if len(configBytes) > 0 {
@@ -169,14 +196,19 @@ func (vm *VM) Initialize(
 	db := dbManager.Current().Database
 	vm.ctx = ctx
 	vm.toEngine = toEngine
+	vm.appSender = appSender
 	vm.baseDB = db
 	vm.db = versiondb.New(db)
 	vm.assetToFxCache = &cache.LRU{Size: assetToFxCacheSize}
 
 	vm.pubsub = pubsub.New(ctx.Log)
 
+	fxs = withUpgradeFxs(fxs)
 	typedFxs := make([]extensions.Fx, len(fxs))
 	vm.fxs = make([]*extensions.ParsedFx, len(fxs))
+	vm.secp256k1FxIndex = -1
+	vm.htlcFxIndex = -1
+	vm.freezeFxIndex = -1
 	for i, fxContainer := range fxs {
 		if fxContainer == nil {
 			return errIncompatibleFx
@@ -190,6 +222,15 @@ func (vm *VM) Initialize(
 			ID: fxContainer.ID,
 			Fx: fx,
 		}
+
+		switch fx.(type) {
+		case *secp256k1fx.Fx:
+			vm.secp256k1FxIndex = i
+		case *htlcfx.Fx:
+			vm.htlcFxIndex = i
+		case *freezefx.Fx:
+			vm.freezeFxIndex = i
+		}
 	}
 
 	vm.typeToFxIndex = map[reflect.Type]int{}
@@ -211,6 +252,10 @@ func (vm *VM) Initialize(
 	}
 
 	vm.state = state
+	vm.stateSyncEnabled = avmConfig.StateSyncEnabled
+	if err := vm.initStateSync(); err != nil {
+		return err
+	}
 
 	if err := vm.initGenesis(genesisBytes); err != nil {
 		return err
@@ -246,6 +291,12 @@ func (vm *VM) Initialize(
 			return fmt.Errorf("failed to initialize disabled indexer: %w", err)
 		}
 	}
+
+	if avmConfig.UTXOJournalEnabled {
+		if err := vm.initUTXOJournal(avmConfig.UTXOJournalRetention); err != nil {
+			return fmt.Errorf("failed to initialize UTXO journal: %w", err)
+		}
+	}
 	return vm.db.Commit()
 }
 
@@ -266,11 +317,19 @@ func (vm *VM) onNormalOperationsStarted() error {
 		}
 	}
 	vm.bootstrapped = true
-	return nil
+
+	// Once normal operations start, the engine no longer needs to know that
+	// the state was synced.
+	if err := vm.syncDB.Delete(stateSyncedKey); err != nil {
+		return err
+	}
+	return vm.db.Commit()
 }
 
 func (vm *VM) SetState(_ context.Context, state snow.State) error {
 	switch state {
+	case snow.StateSyncing:
+		return nil
 	case snow.Bootstrapping:
 		return vm.onBootstrapStarted()
 	case snow.NormalOp:
@@ -291,6 +350,9 @@ func (vm *VM) Shutdown(context.Context) error {
 	vm.timer.Stop()
 	vm.ctx.Lock.Lock()
 
+	// The state summary being built reads from the database.
+	vm.summaryBuilds.Wait()
+
 	return vm.baseDB.Close()
 }
 
@@ -386,6 +448,26 @@ func (vm *VM) IssueTx(b []byte) (ids.ID, error) {
 	return tx.ID(), nil
 }
 
+// verifyTx verifies [tx] against the current state, including the processing
+// txs, without issuing or persisting it.
+func (vm *VM) verifyTx(tx *txs.Tx) error {
+	err := tx.SyntacticVerify(
+		vm.ctx,
+		vm.parser.Codec(),
+		vm.feeAssetID,
+		vm.TxFee,
+		vm.CreateAssetTxFee,
+		len(vm.fxs),
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Unsigned.Visit(&txSemanticVerify{
+		tx: tx,
+		vm: vm,
+	})
+}
+
 func (vm *VM) issueStopVertex() error {
 	select {
 	case vm.toEngine <- common.StopVertex:
@@ -564,9 +646,53 @@ func (vm *VM) getFx(val interface{}) (int, error) {
 	if !exists {
 		return 0, errUnknownFx
 	}
+	if !vm.isFxActive(fx) {
+		return 0, fmt.Errorf("%w: %s", errFxNotActive, vm.fxs[fx].ID)
+	}
 	return fx, nil
 }
 
+// isFxActive returns false if the fx at [fxIndex] was added by a network
+// upgrade that hasn't activated yet.
+func (vm *VM) isFxActive(fxIndex int) bool {
+	switch fxIndex {
+	case vm.htlcFxIndex:
+		return !vm.clock.Time().Before(vm.HTLCFxTime)
+	case vm.freezeFxIndex:
+		return !vm.clock.Time().Before(vm.FreezeFxTime)
+	default:
+		return true
+	}
+}
+
+// withUpgradeFxs returns [fxs] followed by the fxs that were added to every
+// chain by a network upgrade, rather than through the chain's genesis, so that
+// adding them doesn't change the genesis of existing chains. They are added
+// after the genesis fxs so that the codec IDs of the existing types don't
+// change. Fxs that the chain already runs aren't added again.
+func withUpgradeFxs(fxs []*common.Fx) []*common.Fx {
+	upgradeFxs := []*common.Fx{
+		{ID: htlcfx.ID, Fx: &htlcfx.Fx{}},
+		{ID: freezefx.ID, Fx: &freezefx.Fx{}},
+	}
+
+	allFxs := make([]*common.Fx, len(fxs), len(fxs)+len(upgradeFxs))
+	copy(allFxs, fxs)
+	for _, upgradeFx := range upgradeFxs {
+		exists := false
+		for _, fx := range fxs {
+			if fx != nil && reflect.TypeOf(fx.Fx) == reflect.TypeOf(upgradeFx.Fx) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			allFxs = append(allFxs, upgradeFx)
+		}
+	}
+	return allFxs
+}
+
 func (vm *VM) verifyFxUsage(fxID int, assetID ids.ID) bool {
 	// Check cache to see whether this asset supports this fx
 	fxIDsIntf, assetInCache := vm.assetToFxCache.Get(assetID)
@@ -589,10 +715,13 @@ func (vm *VM) verifyFxUsage(fxID int, assetID ids.ID) bool {
 	}
 	fxIDs := ids.BitSet64(0)
 	for _, state := range createAssetTx.States {
-		if state.FxIndex == uint32(fxID) {
-			// Cache that this asset supports this fx
-			fxIDs.Add(uint(fxID))
-		}
+		// Cache that this asset supports this fx
+		fxIDs.Add(uint(state.FxIndex))
+	}
+	// Hashed-timelock outputs lock the value of fungible assets, so every
+	// asset that supports the secp256k1 fx supports the htlc fx.
+	if vm.secp256k1FxIndex >= 0 && vm.htlcFxIndex >= 0 && fxIDs.Contains(uint(vm.secp256k1FxIndex)) {
+		fxIDs.Add(uint(vm.htlcFxIndex))
 	}
 	vm.assetToFxCache.Put(assetID, fxIDs)
 	return fxIDs.Contains(uint(fxID))
@@ -618,14 +747,6 @@ func (vm *VM) verifyTransferOfUTXO(utx txs.UnsignedTx, in *djtx.TransferableInpu
 	return fx.VerifyTransfer(utx, in.In, cred, utxo.Out)
 }
 
-func (vm *VM) verifyTransfer(tx txs.UnsignedTx, in *djtx.TransferableInput, cred verify.Verifiable) error {
-	utxo, err := vm.getUTXO(&in.UTXOID)
-	if err != nil {
-		return err
-	}
-	return vm.verifyTransferOfUTXO(tx, in, cred, utxo)
-}
-
 func (vm *VM) verifyOperation(tx *txs.OperationTx, op *txs.Operation, cred verify.Verifiable) error {
 	opAssetID := op.AssetID()
 
@@ -1061,21 +1182,6 @@ func (*VM) CrossChainAppResponse(context.Context, ids.ID, uint32, []byte) error
 	return nil
 }
 
-// This VM doesn't (currently) have any app-specific messages
-func (*VM) AppRequest(context.Context, ids.NodeID, uint32, time.Time, []byte) error {
-	return nil
-}
-
-// This VM doesn't (currently) have any app-specific messages
-func (*VM) AppResponse(context.Context, ids.NodeID, uint32, []byte) error {
-	return nil
-}
-
-// This VM doesn't (currently) have any app-specific messages
-func (*VM) AppRequestFailed(context.Context, ids.NodeID, uint32) error {
-	return nil
-}
-
 // This VM doesn't (currently) have any app-specific messages
 func (*VM) AppGossip(context.Context, ids.NodeID, []byte) error {
 	return nil
@@ -30,6 +30,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/crypto"
 	"github.com/lasthyphen/dijetsnodego/utils/formatting"
 	"github.com/lasthyphen/dijetsnodego/utils/formatting/address"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
 	"github.com/lasthyphen/dijetsnodego/utils/json"
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 	"github.com/lasthyphen/dijetsnodego/version"
@@ -38,6 +39,8 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/avm/txs"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/freezefx"
+	"github.com/lasthyphen/dijetsnodego/vms/htlcfx"
 	"github.com/lasthyphen/dijetsnodego/vms/nftfx"
 	"github.com/lasthyphen/dijetsnodego/vms/propertyfx"
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
@@ -918,6 +921,658 @@ func TestIssueProperty(t *testing.T) {
 	}
 }
 
+var (
+	htlcPreimage = []byte("secret")
+	htlcDeadline = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// setupHTLC issues a tx that locks the funds of keys[0] in a hashed-timelock
+// output that keys[1] can claim and keys[0] can be refunded.
+func setupHTLC(t *testing.T) (*VM, *txs.Tx) {
+	vm, genesisBytes := newHTLCTestVM(t)
+	lockTx := newHTLCLockTx(t, vm, genesisBytes)
+	_, err := vm.IssueTx(lockTx.Bytes())
+	require.NoError(t, err)
+	return vm, lockTx
+}
+
+// newHTLCTestVM returns a VM that runs the htlc fx, along with its genesis.
+func newHTLCTestVM(t *testing.T) (*VM, []byte) {
+	require := require.New(t)
+	vm := &VM{}
+	ctx := NewContext(t)
+	ctx.Lock.Lock()
+	t.Cleanup(func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		ctx.Lock.Unlock()
+	})
+
+	genesisBytes := BuildGenesisTest(t)
+	issuer := make(chan common.Message, 1)
+	err := vm.Initialize(
+		context.Background(),
+		ctx,
+		manager.NewMemDB(version.Semantic1_0_0),
+		genesisBytes,
+		nil,
+		nil,
+		issuer,
+		[]*common.Fx{
+			{
+				ID: ids.Empty.Prefix(0),
+				Fx: &secp256k1fx.Fx{},
+			},
+			{
+				ID: ids.Empty.Prefix(1),
+				Fx: &nftfx.Fx{},
+			},
+			{
+				ID: ids.Empty.Prefix(2),
+				Fx: &propertyfx.Fx{},
+			},
+			{
+				ID: ids.Empty.Prefix(3),
+				Fx: &htlcfx.Fx{},
+			},
+		},
+		nil,
+	)
+	require.NoError(err)
+	vm.batchTimeout = 0
+
+	require.NoError(vm.SetState(context.Background(), snow.Bootstrapping))
+	require.NoError(vm.SetState(context.Background(), snow.NormalOp))
+
+	vm.clock.Set(htlcDeadline.Add(-time.Hour))
+	return vm, genesisBytes
+}
+
+// newHTLCLockTx returns a tx that locks the funds of keys[0] in a
+// hashed-timelock output.
+func newHTLCLockTx(t *testing.T, vm *VM, genesisBytes []byte) *txs.Tx {
+	djtxTx := GetDJTXTxFromGenesisTest(genesisBytes, t)
+	lockTx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+		Ins: []*djtx.TransferableInput{{
+			UTXOID: djtx.UTXOID{
+				TxID:        djtxTx.ID(),
+				OutputIndex: 2,
+			},
+			Asset: djtx.Asset{ID: djtxTx.ID()},
+			In: &secp256k1fx.TransferInput{
+				Amt: startBalance,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+		Outs: []*djtx.TransferableOutput{{
+			Asset: djtx.Asset{ID: djtxTx.ID()},
+			Out: &htlcfx.TransferOutput{
+				Amt:      startBalance,
+				Hash:     hashing.ComputeHash256Array(htlcPreimage),
+				Deadline: uint64(htlcDeadline.Unix()),
+				Receiver: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{keys[1].PublicKey().Address()},
+				},
+				Refund: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+				},
+			},
+		}},
+	}}}
+	require.NoError(t, lockTx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}))
+	return lockTx
+}
+
+// newHTLCSpendTx returns a tx that spends the hashed-timelock output of
+// [lockTx] to [key]. The output is claimed if [preimage] is provided and
+// refunded otherwise.
+func newHTLCSpendTx(t *testing.T, vm *VM, lockTx *txs.Tx, preimage []byte, key *crypto.PrivateKeySECP256K1R) *txs.Tx {
+	assetID := lockTx.Unsigned.(*txs.BaseTx).Outs[0].AssetID()
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+		Ins: []*djtx.TransferableInput{{
+			UTXOID: djtx.UTXOID{
+				TxID:        lockTx.ID(),
+				OutputIndex: 0,
+			},
+			Asset: djtx.Asset{ID: assetID},
+			In: &htlcfx.TransferInput{
+				Amt:      startBalance,
+				Preimage: preimage,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+		Outs: []*djtx.TransferableOutput{{
+			Asset: djtx.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: startBalance,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{key.PublicKey().Address()},
+				},
+			},
+		}},
+	}}}
+	require.NoError(t, tx.SignHTLCFx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{key}}))
+	return tx
+}
+
+func TestIssueHTLCClaim(t *testing.T) {
+	require := require.New(t)
+	vm, lockTx := setupHTLC(t)
+
+	// The preimage must match the hash of the output
+	_, err := vm.IssueTx(newHTLCSpendTx(t, vm, lockTx, []byte("wrong secret"), keys[1]).Bytes())
+	require.Error(err)
+
+	// Only the receiver can claim the output
+	_, err = vm.IssueTx(newHTLCSpendTx(t, vm, lockTx, htlcPreimage, keys[0]).Bytes())
+	require.Error(err)
+
+	// The output can't be refunded before the deadline
+	_, err = vm.IssueTx(newHTLCSpendTx(t, vm, lockTx, nil, keys[0]).Bytes())
+	require.Error(err)
+
+	_, err = vm.IssueTx(newHTLCSpendTx(t, vm, lockTx, htlcPreimage, keys[1]).Bytes())
+	require.NoError(err)
+}
+
+func TestIssueHTLCRefund(t *testing.T) {
+	require := require.New(t)
+	vm, lockTx := setupHTLC(t)
+	vm.clock.Set(htlcDeadline)
+
+	// The output can't be claimed once the deadline is reached
+	_, err := vm.IssueTx(newHTLCSpendTx(t, vm, lockTx, htlcPreimage, keys[1]).Bytes())
+	require.Error(err)
+
+	// Only the refund owners can be refunded the output
+	_, err = vm.IssueTx(newHTLCSpendTx(t, vm, lockTx, nil, keys[1]).Bytes())
+	require.Error(err)
+
+	_, err = vm.IssueTx(newHTLCSpendTx(t, vm, lockTx, nil, keys[0]).Bytes())
+	require.NoError(err)
+}
+
+func TestIssueHTLCBeforeActivation(t *testing.T) {
+	require := require.New(t)
+	vm, genesisBytes := newHTLCTestVM(t)
+	activationTime := htlcDeadline.Add(-30 * time.Minute)
+	vm.HTLCFxTime = activationTime
+
+	lockTx := newHTLCLockTx(t, vm, genesisBytes)
+	_, err := vm.IssueTx(lockTx.Bytes())
+	require.ErrorIs(err, errFxNotActive)
+
+	vm.clock.Set(activationTime)
+	_, err = vm.IssueTx(lockTx.Bytes())
+	require.NoError(err)
+}
+
+func TestWithUpgradeFxs(t *testing.T) {
+	require := require.New(t)
+
+	genesisFxs := []*common.Fx{
+		{
+			ID: ids.Empty.Prefix(0),
+			Fx: &secp256k1fx.Fx{},
+		},
+	}
+	fxs := withUpgradeFxs(genesisFxs)
+	require.Len(genesisFxs, 1)
+	require.Len(fxs, 3)
+	require.Equal(genesisFxs[0], fxs[0])
+	require.Equal(htlcfx.ID, fxs[1].ID)
+	require.IsType(&htlcfx.Fx{}, fxs[1].Fx)
+	require.Equal(freezefx.ID, fxs[2].ID)
+	require.IsType(&freezefx.Fx{}, fxs[2].Fx)
+
+	// Fxs that are already run by the chain aren't added again
+	require.Equal(fxs, withUpgradeFxs(fxs))
+}
+
+// newFreezeTestVM returns a VM that runs the freeze fx.
+func newFreezeTestVM(t *testing.T) *VM {
+	require := require.New(t)
+	vm := &VM{}
+	ctx := NewContext(t)
+	ctx.Lock.Lock()
+	t.Cleanup(func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		ctx.Lock.Unlock()
+	})
+
+	err := vm.Initialize(
+		context.Background(),
+		ctx,
+		manager.NewMemDB(version.Semantic1_0_0),
+		BuildGenesisTest(t),
+		nil,
+		nil,
+		make(chan common.Message, 1),
+		[]*common.Fx{
+			{
+				ID: ids.Empty.Prefix(0),
+				Fx: &secp256k1fx.Fx{},
+			},
+			{
+				ID: ids.Empty.Prefix(1),
+				Fx: &nftfx.Fx{},
+			},
+			{
+				ID: ids.Empty.Prefix(2),
+				Fx: &propertyfx.Fx{},
+			},
+			{
+				ID: ids.Empty.Prefix(3),
+				Fx: &htlcfx.Fx{},
+			},
+			{
+				ID: ids.Empty.Prefix(4),
+				Fx: &freezefx.Fx{},
+			},
+		},
+		nil,
+	)
+	require.NoError(err)
+	vm.batchTimeout = 0
+
+	require.NoError(vm.SetState(context.Background(), snow.Bootstrapping))
+	require.NoError(vm.SetState(context.Background(), snow.NormalOp))
+	return vm
+}
+
+// issueAndAccept issues [tx] to [vm] and accepts it.
+func issueAndAccept(t *testing.T, vm *VM, tx *txs.Tx) error {
+	txID, err := vm.IssueTx(tx.Bytes())
+	if err != nil {
+		return err
+	}
+	parsedTx, err := vm.GetTx(context.Background(), txID)
+	require.NoError(t, err)
+	return parsedTx.Accept(context.Background())
+}
+
+// newFreezeSpendTx returns a tx that sends the [startBalance] held by [key] in
+// output [outputIndex] of [assetTx] to [to]. The tx spends the freeze output
+// [freezeUTXOID] of the asset, held by keys[2], with a freeze check.
+func newFreezeSpendTx(t *testing.T, vm *VM, assetTx *txs.Tx, outputIndex uint32, freezeUTXOID djtx.UTXOID, key *crypto.PrivateKeySECP256K1R, to ids.ShortID) *txs.Tx {
+	tx := &txs.Tx{Unsigned: &txs.OperationTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+			Ins: []*djtx.TransferableInput{{
+				UTXOID: djtx.UTXOID{
+					TxID:        assetTx.ID(),
+					OutputIndex: outputIndex,
+				},
+				Asset: djtx.Asset{ID: assetTx.ID()},
+				In: &secp256k1fx.TransferInput{
+					Amt: startBalance,
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+				},
+			}},
+			Outs: []*djtx.TransferableOutput{{
+				Asset: djtx.Asset{ID: assetTx.ID()},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: startBalance,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{to},
+					},
+				},
+			}},
+		}},
+		Ops: []*txs.Operation{{
+			Asset:   djtx.Asset{ID: assetTx.ID()},
+			UTXOIDs: []*djtx.UTXOID{&freezeUTXOID},
+			Op: &freezefx.CheckOperation{
+				FreezeOutput: freezefx.FreezeOutput{
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{keys[2].PublicKey().Address()},
+					},
+				},
+			},
+		}},
+	}}
+	codec := vm.parser.Codec()
+	require.NoError(t, tx.SignSECP256K1Fx(codec, [][]*crypto.PrivateKeySECP256K1R{{key}}))
+	require.NoError(t, tx.SignFreezeFx(codec, [][]*crypto.PrivateKeySECP256K1R{{}}))
+	return tx
+}
+
+// newFreezeTx returns a tx that spends the freeze output [authorityUTXOID] of
+// [assetID], held by keys[2], to freeze or unfreeze [utxoIDs] and [addrs].
+func newFreezeTx(t *testing.T, vm *VM, assetID ids.ID, authorityUTXOID djtx.UTXOID, frozen bool, utxoIDs []ids.ID, addrs []ids.ShortID) *txs.Tx {
+	tx := &txs.Tx{Unsigned: &txs.OperationTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		}},
+		Ops: []*txs.Operation{{
+			Asset:   djtx.Asset{ID: assetID},
+			UTXOIDs: []*djtx.UTXOID{&authorityUTXOID},
+			Op: &freezefx.FreezeOperation{
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+				FreezeOutput: freezefx.FreezeOutput{
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{keys[2].PublicKey().Address()},
+					},
+				},
+				Frozen:        frozen,
+				TargetUTXOIDs: utxoIDs,
+				TargetAddrs:   addrs,
+			},
+		}},
+	}}
+	require.NoError(t, tx.SignFreezeFx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[2]}}))
+	return tx
+}
+
+// newFreezableAsset issues and accepts a freezable asset with an output of
+// [startBalance] for each of keys[0] and keys[1], at the returned indices, and
+// a freeze output, at index 2, held by keys[2].
+func newFreezableAsset(t *testing.T, vm *VM) (*txs.Tx, uint32, uint32) {
+	require := require.New(t)
+
+	newTransferOutput := func(key *crypto.PrivateKeySECP256K1R) verify.State {
+		return &secp256k1fx.TransferOutput{
+			Amt: startBalance,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{key.PublicKey().Address()},
+			},
+		}
+	}
+	createAssetTx := &txs.Tx{Unsigned: &txs.CreateAssetTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		}},
+		Name:         "Regulated",
+		Symbol:       "REG",
+		Denomination: 0,
+		States: []*txs.InitialState{
+			{
+				FxIndex: 0,
+				Outs: []verify.State{
+					newTransferOutput(keys[0]),
+					newTransferOutput(keys[1]),
+				},
+			},
+			{
+				FxIndex: 4,
+				Outs: []verify.State{
+					&freezefx.FreezeOutput{
+						OutputOwners: secp256k1fx.OutputOwners{
+							Threshold: 1,
+							Addrs:     []ids.ShortID{keys[2].PublicKey().Address()},
+						},
+					},
+				},
+			},
+		},
+	}}
+	createAssetTx.Unsigned.(*txs.CreateAssetTx).States[0].Sort(vm.parser.Codec())
+	require.NoError(vm.parser.InitializeTx(createAssetTx))
+	require.NoError(issueAndAccept(t, vm, createAssetTx))
+
+	// The outputs of keys[0] and keys[1] were sorted.
+	key0Index, key1Index := uint32(0), uint32(1)
+	firstOut := createAssetTx.Unsigned.(*txs.CreateAssetTx).States[0].Outs[0].(*secp256k1fx.TransferOutput)
+	if firstOut.Addrs[0] != keys[0].PublicKey().Address() {
+		key0Index, key1Index = 1, 0
+	}
+	return createAssetTx, key0Index, key1Index
+}
+
+func TestIssueFreeze(t *testing.T) {
+	require := require.New(t)
+	vm := newFreezeTestVM(t)
+	createAssetTx, key0Index, key1Index := newFreezableAsset(t, vm)
+	assetID := createAssetTx.ID()
+
+	frozenUTXO := djtx.UTXOID{TxID: assetID, OutputIndex: key0Index}
+	frozenAddr := keys[1].PublicKey().Address()
+
+	freezeTx := newFreezeTx(
+		t,
+		vm,
+		assetID,
+		djtx.UTXOID{TxID: assetID, OutputIndex: 2},
+		true,
+		[]ids.ID{frozenUTXO.InputID()},
+		[]ids.ShortID{frozenAddr},
+	)
+	require.NoError(issueAndAccept(t, vm, freezeTx))
+	freezeUTXOID := djtx.UTXOID{TxID: freezeTx.ID(), OutputIndex: 0}
+
+	_, err := vm.IssueTx(newFreezeSpendTx(t, vm, createAssetTx, key0Index, freezeUTXOID, keys[0], keys[0].PublicKey().Address()).Bytes())
+	require.ErrorIs(err, errFrozenUTXO)
+	_, err = vm.IssueTx(newFreezeSpendTx(t, vm, createAssetTx, key1Index, freezeUTXOID, keys[1], keys[1].PublicKey().Address()).Bytes())
+	require.ErrorIs(err, errFrozenAddress)
+
+	unfreezeTx := newFreezeTx(
+		t,
+		vm,
+		assetID,
+		freezeUTXOID,
+		false,
+		[]ids.ID{frozenUTXO.InputID()},
+		nil,
+	)
+	require.NoError(issueAndAccept(t, vm, unfreezeTx))
+	freezeUTXOID = djtx.UTXOID{TxID: unfreezeTx.ID(), OutputIndex: 0}
+
+	_, err = vm.IssueTx(newFreezeSpendTx(t, vm, createAssetTx, key0Index, freezeUTXOID, keys[0], keys[1].PublicKey().Address()).Bytes())
+	require.NoError(err)
+	_, err = vm.IssueTx(newFreezeSpendTx(t, vm, createAssetTx, key1Index, freezeUTXOID, keys[1], keys[0].PublicKey().Address()).Bytes())
+	require.ErrorIs(err, errFrozenAddress)
+}
+
+func TestIssueFreezeMissingCheck(t *testing.T) {
+	require := require.New(t)
+	vm := newFreezeTestVM(t)
+	createAssetTx, key0Index, _ := newFreezableAsset(t, vm)
+	assetID := createAssetTx.ID()
+
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+		Ins: []*djtx.TransferableInput{{
+			UTXOID: djtx.UTXOID{
+				TxID:        assetID,
+				OutputIndex: key0Index,
+			},
+			Asset: djtx.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: startBalance,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+		Outs: []*djtx.TransferableOutput{{
+			Asset: djtx.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: startBalance,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{keys[1].PublicKey().Address()},
+				},
+			},
+		}},
+	}}}
+	require.NoError(tx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}))
+	_, err := vm.IssueTx(tx.Bytes())
+	require.ErrorIs(err, errMissingFreezeCheck)
+}
+
+func TestIssueFreezeCheckOrdering(t *testing.T) {
+	require := require.New(t)
+	vm := newFreezeTestVM(t)
+	createAssetTx, key0Index, key1Index := newFreezableAsset(t, vm)
+	assetID := createAssetTx.ID()
+	acceptedFreezeUTXOID := djtx.UTXOID{TxID: assetID, OutputIndex: 2}
+
+	freezeTx := newFreezeTx(
+		t,
+		vm,
+		assetID,
+		acceptedFreezeUTXOID,
+		true,
+		nil,
+		[]ids.ShortID{keys[1].PublicKey().Address()},
+	)
+	_, err := vm.IssueTx(freezeTx.Bytes())
+	require.NoError(err)
+
+	// The freeze isn't accepted yet, so its freeze output doesn't reflect the
+	// freezes that the spend is verified against.
+	processingFreezeUTXOID := djtx.UTXOID{TxID: freezeTx.ID(), OutputIndex: 0}
+	_, err = vm.IssueTx(newFreezeSpendTx(t, vm, createAssetTx, key0Index, processingFreezeUTXOID, keys[0], keys[0].PublicKey().Address()).Bytes())
+	require.ErrorIs(err, errUnorderedFreezeCheck)
+
+	// Spending the accepted freeze output conflicts with the freeze.
+	spendTx := newFreezeSpendTx(t, vm, createAssetTx, key0Index, acceptedFreezeUTXOID, keys[0], keys[0].PublicKey().Address())
+	_, err = vm.IssueTx(spendTx.Bytes())
+	require.NoError(err)
+	parsedSpendTx, err := vm.GetTx(context.Background(), spendTx.ID())
+	require.NoError(err)
+	parsedFreezeTx, err := vm.GetTx(context.Background(), freezeTx.ID())
+	require.NoError(err)
+	require.Contains(parsedSpendTx.InputIDs(), acceptedFreezeUTXOID.InputID())
+	require.Contains(parsedFreezeTx.InputIDs(), acceptedFreezeUTXOID.InputID())
+
+	// The freeze output re-created by a processing freeze check can be spent.
+	chainedFreezeUTXOID := djtx.UTXOID{TxID: spendTx.ID(), OutputIndex: 1}
+	_, err = vm.IssueTx(newFreezeSpendTx(t, vm, createAssetTx, key1Index, chainedFreezeUTXOID, keys[1], keys[1].PublicKey().Address()).Bytes())
+	require.NoError(err)
+}
+
+func TestIssueFreezeMultipleFreezeOutputs(t *testing.T) {
+	require := require.New(t)
+	vm := newFreezeTestVM(t)
+
+	newFreezeOutput := func(key *crypto.PrivateKeySECP256K1R) verify.State {
+		return &freezefx.FreezeOutput{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{key.PublicKey().Address()},
+			},
+		}
+	}
+	createAssetTx := &txs.Tx{Unsigned: &txs.CreateAssetTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		}},
+		Name:         "Regulated",
+		Symbol:       "REG",
+		Denomination: 0,
+		States: []*txs.InitialState{{
+			FxIndex: 4,
+			Outs: []verify.State{
+				newFreezeOutput(keys[0]),
+				newFreezeOutput(keys[1]),
+			},
+		}},
+	}}
+	createAssetTx.Unsigned.(*txs.CreateAssetTx).States[0].Sort(vm.parser.Codec())
+	require.NoError(vm.parser.InitializeTx(createAssetTx))
+	_, err := vm.IssueTx(createAssetTx.Bytes())
+	require.ErrorIs(err, errMultipleFreezeOutputs)
+}
+
+func TestIssueFreezeWrongAuthority(t *testing.T) {
+	require := require.New(t)
+	vm := newFreezeTestVM(t)
+
+	createAssetTx := &txs.Tx{Unsigned: &txs.CreateAssetTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		}},
+		Name:         "Regulated",
+		Symbol:       "REG",
+		Denomination: 0,
+		States: []*txs.InitialState{{
+			FxIndex: 4,
+			Outs: []verify.State{
+				&freezefx.FreezeOutput{
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+					},
+				},
+			},
+		}},
+	}}
+	require.NoError(vm.parser.InitializeTx(createAssetTx))
+	require.NoError(issueAndAccept(t, vm, createAssetTx))
+
+	freezeTx := newFreezeTx(
+		t,
+		vm,
+		createAssetTx.ID(),
+		djtx.UTXOID{TxID: createAssetTx.ID(), OutputIndex: 0},
+		true,
+		nil,
+		[]ids.ShortID{keys[1].PublicKey().Address()},
+	)
+	_, err := vm.IssueTx(freezeTx.Bytes())
+	require.Error(err)
+}
+
+func TestIssueFreezeBeforeActivation(t *testing.T) {
+	require := require.New(t)
+	vm := newFreezeTestVM(t)
+	activationTime := vm.clock.Time().Add(time.Hour)
+	vm.FreezeFxTime = activationTime
+
+	createAssetTx := &txs.Tx{Unsigned: &txs.CreateAssetTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		}},
+		Name:         "Regulated",
+		Symbol:       "REG",
+		Denomination: 0,
+		States: []*txs.InitialState{{
+			FxIndex: 4,
+			Outs: []verify.State{
+				&freezefx.FreezeOutput{
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+					},
+				},
+			},
+		}},
+	}}
+	require.NoError(vm.parser.InitializeTx(createAssetTx))
+	_, err := vm.IssueTx(createAssetTx.Bytes())
+	require.ErrorIs(err, errFxNotActive)
+
+	vm.clock.Set(activationTime)
+	_, err = vm.IssueTx(createAssetTx.Bytes())
+	require.NoError(err)
+}
+
 func setupTxFeeAssets(t *testing.T) ([]byte, chan common.Message, *VM, *atomic.Memory) {
 	addr0Str, _ := address.FormatBech32(testHRP, addrs[0].Bytes())
 	addr1Str, _ := address.FormatBech32(testHRP, addrs[1].Bytes())
@@ -86,6 +86,10 @@ type ClientSendOutput struct {
 
 	// Address of the recipient
 	To ids.ShortID
+
+	// Unix time until which the sent funds can't be spent. If 0, the funds
+	// can be spent immediately.
+	Locktime uint64
 }
 
 func (c *walletClient) Send(
@@ -131,6 +135,7 @@ func (c *walletClient) SendMultiple(
 		serviceOutputs[i].Amount = json.Uint64(output.Amount)
 		serviceOutputs[i].AssetID = output.AssetID
 		serviceOutputs[i].To = output.To.String()
+		serviceOutputs[i].Locktime = json.Uint64(output.Locktime)
 	}
 	err := c.requester.SendRequest(ctx, "wallet.sendMultiple", &SendMultipleArgs{
 		JSONSpendHeader: api.JSONSpendHeader{
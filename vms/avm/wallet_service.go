@@ -185,7 +185,7 @@ func (w *WalletService) SendMultiple(_ *http.Request, args *SendMultipleArgs, re
 			Out: &secp256k1fx.TransferOutput{
 				Amt: uint64(output.Amount),
 				OutputOwners: secp256k1fx.OutputOwners{
-					Locktime:  0,
+					Locktime:  uint64(output.Locktime),
 					Threshold: 1,
 					Addrs:     []ids.ShortID{to},
 				},
@@ -13,6 +13,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow"
 	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto"
+	"github.com/lasthyphen/dijetsnodego/utils/math"
 	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
 )
 
@@ -240,3 +241,32 @@ func VerifyTx(
 
 	return fc.Verify()
 }
+
+// Burned returns the amount of [assetID] that is consumed by [ins] but isn't
+// produced by [outs].
+func Burned(assetID ids.ID, ins []*TransferableInput, outs []*TransferableOutput) (uint64, error) {
+	var (
+		consumed uint64
+		produced uint64
+		err      error
+	)
+	for _, in := range ins {
+		if in.AssetID() != assetID {
+			continue
+		}
+		consumed, err = math.Add64(consumed, in.Input().Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	for _, out := range outs {
+		if out.AssetID() != assetID {
+			continue
+		}
+		produced, err = math.Add64(produced, out.Output().Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return math.Sub(consumed, produced)
+}
@@ -335,3 +335,45 @@ func TestTransferableInputSerialization(t *testing.T) {
 		)
 	}
 }
+
+func TestBurned(t *testing.T) {
+	assetID := ids.GenerateTestID()
+	otherAssetID := ids.GenerateTestID()
+	ins := []*TransferableInput{
+		{
+			Asset: Asset{ID: assetID},
+			In:    &secp256k1fx.TransferInput{Amt: 10},
+		},
+		{
+			Asset: Asset{ID: otherAssetID},
+			In:    &secp256k1fx.TransferInput{Amt: 5},
+		},
+	}
+	outs := []*TransferableOutput{
+		{
+			Asset: Asset{ID: assetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: 3},
+		},
+	}
+
+	burned, err := Burned(assetID, ins, outs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if burned != 7 {
+		t.Fatalf("Should have burned 7 but burned %d", burned)
+	}
+
+	burned, err = Burned(otherAssetID, ins, outs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if burned != 5 {
+		t.Fatalf("Should have burned 5 but burned %d", burned)
+	}
+
+	// Producing more than is consumed isn't a burn
+	if _, err := Burned(assetID, ins[1:], outs); err == nil {
+		t.Fatalf("Should have errored due to producing more than was consumed")
+	}
+}
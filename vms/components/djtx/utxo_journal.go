@@ -0,0 +1,390 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package djtx
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/lasthyphen/dijetsnodego/codec"
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+)
+
+const (
+	consumedEntry byte = iota
+	producedEntry
+)
+
+var (
+	journalEntryPrefix     = []byte("entry")
+	journalTimestampPrefix = []byte("timestamp")
+	journalMetadataPrefix  = []byte("metadata")
+
+	journalFirstHeightKey = []byte("first")
+	journalLastHeightKey  = []byte("last")
+
+	ErrHeightNotRetained = errors.New("height isn't retained by the UTXO journal")
+
+	_ UTXOJournal = (*utxoJournal)(nil)
+	_ UTXOReader  = (*historicalUTXOs)(nil)
+)
+
+// UTXOJournal records the UTXOs that were consumed and produced at each height
+// so that the UTXOs of addresses can be read as of a past height.
+type UTXOJournal interface {
+	// Record persists the UTXOs that were consumed and produced at [height].
+	// Recording at the last recorded height adds to the UTXOs recorded at it.
+	// If [height] doesn't directly follow the last recorded height, the
+	// previously recorded heights are dropped. Heights that fall out of the
+	// retention window are pruned.
+	Record(height uint64, timestamp time.Time, consumed, produced []*UTXO) error
+
+	// FirstHeight returns the oldest height that can be read. Returns
+	// database.ErrNotFound if no height was recorded.
+	FirstHeight() (uint64, error)
+
+	// LastHeight returns the last recorded height. Returns
+	// database.ErrNotFound if no height was recorded.
+	LastHeight() (uint64, error)
+
+	// Timestamp returns the timestamp that was recorded at [height].
+	Timestamp(height uint64) (time.Time, error)
+
+	// HeightAt returns the last height that was recorded no later than
+	// [timestamp].
+	HeightAt(timestamp time.Time) (uint64, error)
+
+	// Rewind returns the UTXOs of [addrs] as of [height]. [current] must hold
+	// the UTXOs as of the last recorded height.
+	Rewind(current UTXOReader, addrs set.Set[ids.ShortID], height uint64) (UTXOReader, error)
+}
+
+type utxoJournal struct {
+	codec codec.Manager
+	// retention is the number of heights that are kept. If 0, every height is
+	// kept.
+	retention uint64
+
+	// Height -> Entry type -> UTXO ID -> UTXO
+	entryDB database.Database
+	// Height -> Unix timestamp
+	timestampDB database.Database
+	metadataDB  database.Database
+}
+
+// NewUTXOJournal returns a journal that keeps the last [retention] heights. If
+// [retention] is 0, all the heights are kept.
+func NewUTXOJournal(db database.Database, codec codec.Manager, retention uint64) UTXOJournal {
+	return &utxoJournal{
+		codec:       codec,
+		retention:   retention,
+		entryDB:     prefixdb.New(journalEntryPrefix, db),
+		timestampDB: prefixdb.New(journalTimestampPrefix, db),
+		metadataDB:  prefixdb.New(journalMetadataPrefix, db),
+	}
+}
+
+func (j *utxoJournal) Record(height uint64, timestamp time.Time, consumed, produced []*UTXO) error {
+	first, err := j.FirstHeight()
+	switch {
+	case err == database.ErrNotFound:
+		first = height
+	case err != nil:
+		return err
+	default:
+		last, err := j.LastHeight()
+		if err != nil {
+			return err
+		}
+		if height != last && height != last+1 {
+			// The UTXO set changed without being journaled, so the recorded
+			// heights can't be rewound to anymore.
+			if err := j.deleteHeights(first, last); err != nil {
+				return err
+			}
+			first = height
+		}
+	}
+
+	heightBytes := database.PackUInt64(height)
+	for _, utxo := range consumed {
+		if err := j.putEntry(heightBytes, consumedEntry, utxo); err != nil {
+			return err
+		}
+	}
+	for _, utxo := range produced {
+		if err := j.putEntry(heightBytes, producedEntry, utxo); err != nil {
+			return err
+		}
+	}
+	if err := database.PutUInt64(j.timestampDB, heightBytes, uint64(timestamp.Unix())); err != nil {
+		return err
+	}
+
+	if j.retention > 0 && height-first >= j.retention {
+		newFirst := height - j.retention + 1
+		if err := j.deleteHeights(first, newFirst-1); err != nil {
+			return err
+		}
+		first = newFirst
+	}
+	if err := database.PutUInt64(j.metadataDB, journalFirstHeightKey, first); err != nil {
+		return err
+	}
+	return database.PutUInt64(j.metadataDB, journalLastHeightKey, height)
+}
+
+func (j *utxoJournal) putEntry(heightBytes []byte, entryType byte, utxo *UTXO) error {
+	utxoBytes, err := j.codec.Marshal(codecVersion, utxo)
+	if err != nil {
+		return err
+	}
+	utxoID := utxo.InputID()
+	key := make([]byte, 0, len(heightBytes)+1+len(utxoID))
+	key = append(key, heightBytes...)
+	key = append(key, entryType)
+	key = append(key, utxoID[:]...)
+	return j.entryDB.Put(key, utxoBytes)
+}
+
+// deleteHeights deletes the entries of the heights in [start, end].
+func (j *utxoJournal) deleteHeights(start, end uint64) error {
+	for height := start; height <= end; height++ {
+		heightBytes := database.PackUInt64(height)
+		iter := j.entryDB.NewIteratorWithPrefix(heightBytes)
+		var keys [][]byte
+		for iter.Next() {
+			keys = append(keys, iter.Key())
+		}
+		err := iter.Error()
+		iter.Release()
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if err := j.entryDB.Delete(key); err != nil {
+				return err
+			}
+		}
+		if err := j.timestampDB.Delete(heightBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *utxoJournal) FirstHeight() (uint64, error) {
+	return database.GetUInt64(j.metadataDB, journalFirstHeightKey)
+}
+
+func (j *utxoJournal) LastHeight() (uint64, error) {
+	return database.GetUInt64(j.metadataDB, journalLastHeightKey)
+}
+
+func (j *utxoJournal) Timestamp(height uint64) (time.Time, error) {
+	timestamp, err := database.GetUInt64(j.timestampDB, database.PackUInt64(height))
+	if err == database.ErrNotFound {
+		return time.Time{}, fmt.Errorf("%w: %d", ErrHeightNotRetained, height)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(timestamp), 0), nil
+}
+
+func (j *utxoJournal) HeightAt(timestamp time.Time) (uint64, error) {
+	first, last, err := j.heights()
+	if err != nil {
+		return 0, err
+	}
+	firstTimestamp, err := j.Timestamp(first)
+	if err != nil {
+		return 0, err
+	}
+	if timestamp.Before(firstTimestamp) {
+		return 0, fmt.Errorf("%w: %s is before %s", ErrHeightNotRetained, timestamp, firstTimestamp)
+	}
+
+	// Timestamps never decrease, so the last height recorded no later than
+	// [timestamp] is binary searched.
+	low, high := first, last
+	for low < high {
+		mid := low + (high-low+1)/2
+		midTimestamp, err := j.Timestamp(mid)
+		if err != nil {
+			return 0, err
+		}
+		if midTimestamp.After(timestamp) {
+			high = mid - 1
+		} else {
+			low = mid
+		}
+	}
+	return low, nil
+}
+
+func (j *utxoJournal) Rewind(current UTXOReader, addrs set.Set[ids.ShortID], height uint64) (UTXOReader, error) {
+	first, last, err := j.heights()
+	if err != nil {
+		return nil, err
+	}
+	if height < first || height > last {
+		return nil, fmt.Errorf("%w: %d isn't in [%d, %d]", ErrHeightNotRetained, height, first, last)
+	}
+
+	utxos := &historicalUTXOs{
+		current:  current,
+		restored: make(map[ids.ID]*UTXO),
+	}
+	// Undo the heights after [height], starting from the last one. Within a
+	// height, consumed entries are undone before produced entries so that a
+	// UTXO produced and consumed at the same height is never restored.
+	for h := last; h > height; h-- {
+		iter := j.entryDB.NewIteratorWithPrefix(database.PackUInt64(h))
+		for iter.Next() {
+			key := iter.Key()
+			entryType := key[wrappers.LongLen]
+
+			utxo := &UTXO{}
+			if _, err := j.codec.Unmarshal(iter.Value(), utxo); err != nil {
+				iter.Release()
+				return nil, err
+			}
+			if !isOwnedByAny(utxo, addrs) {
+				continue
+			}
+
+			utxoID := utxo.InputID()
+			switch entryType {
+			case consumedEntry:
+				utxos.restored[utxoID] = utxo
+			case producedEntry:
+				if _, ok := utxos.restored[utxoID]; ok {
+					delete(utxos.restored, utxoID)
+				} else {
+					utxos.hidden.Add(utxoID)
+				}
+			}
+		}
+		err := iter.Error()
+		iter.Release()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return utxos, nil
+}
+
+func (j *utxoJournal) heights() (uint64, uint64, error) {
+	first, err := j.FirstHeight()
+	if err == database.ErrNotFound {
+		return 0, 0, fmt.Errorf("%w: nothing was recorded", ErrHeightNotRetained)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	last, err := j.LastHeight()
+	return first, last, err
+}
+
+func isOwnedByAny(utxo *UTXO, addrs set.Set[ids.ShortID]) bool {
+	addressable, ok := utxo.Out.(Addressable)
+	if !ok {
+		return false
+	}
+	for _, addrBytes := range addressable.Addresses() {
+		addr, err := ids.ToShortID(addrBytes)
+		if err == nil && addrs.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// historicalUTXOs overlays the UTXOs that were consumed since a past height,
+// and hides the UTXOs that were produced since, on top of the current UTXOs.
+type historicalUTXOs struct {
+	current  UTXOReader
+	restored map[ids.ID]*UTXO
+	hidden   set.Set[ids.ID]
+}
+
+func (h *historicalUTXOs) GetUTXO(utxoID ids.ID) (*UTXO, error) {
+	if utxo, ok := h.restored[utxoID]; ok {
+		return utxo, nil
+	}
+	if h.hidden.Contains(utxoID) {
+		return nil, database.ErrNotFound
+	}
+	return h.current.GetUTXO(utxoID)
+}
+
+// UTXOIDs returns the current UTXO IDs of [addr], in the order of [current],
+// followed by the restored UTXO IDs of [addr], in increasing order. The IDs
+// start after [previous], or at the beginning if [previous] isn't known.
+func (h *historicalUTXOs) UTXOIDs(addr []byte, previous ids.ID, limit int) ([]ids.ID, error) {
+	var utxoIDs []ids.ID
+	if _, ok := h.restored[previous]; !ok {
+		// Only the current UTXOs after [previous] are read, skipping the ones
+		// that were produced after the height.
+		start := previous
+		for len(utxoIDs) < limit {
+			batchSize := limit - len(utxoIDs)
+			batch, err := h.current.UTXOIDs(addr, start, batchSize)
+			if err != nil {
+				return nil, err
+			}
+			for _, utxoID := range batch {
+				if !h.hidden.Contains(utxoID) {
+					utxoIDs = append(utxoIDs, utxoID)
+				}
+			}
+			if len(batch) < batchSize {
+				break
+			}
+			start = batch[len(batch)-1]
+		}
+		previous = ids.Empty
+	}
+
+	for _, utxoID := range h.restoredIDs(addr) {
+		if len(utxoIDs) >= limit {
+			break
+		}
+		if previous != ids.Empty && !previous.Less(utxoID) {
+			continue
+		}
+		utxoIDs = append(utxoIDs, utxoID)
+	}
+	return utxoIDs, nil
+}
+
+// restoredIDs returns the restored UTXO IDs of [addr], in increasing order.
+func (h *historicalUTXOs) restoredIDs(addr []byte) []ids.ID {
+	var utxoIDs []ids.ID
+	for utxoID, utxo := range h.restored {
+		addressable, ok := utxo.Out.(Addressable)
+		if !ok {
+			continue
+		}
+		for _, addrBytes := range addressable.Addresses() {
+			if string(addrBytes) == string(addr) {
+				utxoIDs = append(utxoIDs, utxoID)
+				break
+			}
+		}
+	}
+	slices.SortFunc(utxoIDs, func(a, b ids.ID) bool {
+		return a.Less(b)
+	})
+	return utxoIDs
+}
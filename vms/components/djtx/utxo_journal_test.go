@@ -0,0 +1,203 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package djtx
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/codec"
+	"github.com/lasthyphen/dijetsnodego/codec/linearcodec"
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func newJournalTestCodec(t *testing.T) codec.Manager {
+	c := linearcodec.NewDefault()
+	manager := codec.NewDefaultManager()
+	require.NoError(t, c.RegisterType(&secp256k1fx.TransferOutput{}))
+	require.NoError(t, manager.RegisterCodec(codecVersion, c))
+	return manager
+}
+
+func newJournalTestUTXO(addr ids.ShortID, amount uint64) *UTXO {
+	return &UTXO{
+		UTXOID: UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: Asset{ID: ids.Empty},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+}
+
+func TestUTXOJournalRewind(t *testing.T) {
+	require := require.New(t)
+
+	manager := newJournalTestCodec(t)
+	current := NewUTXOState(memdb.New(), manager)
+	journal := NewUTXOJournal(memdb.New(), manager, 0)
+
+	addr := ids.GenerateTestShortID()
+	otherAddr := ids.GenerateTestShortID()
+	addrs := set.Set[ids.ShortID]{}
+	addrs.Add(addr)
+
+	// Height 0 produces [a] and [other].
+	a := newJournalTestUTXO(addr, 1)
+	other := newJournalTestUTXO(otherAddr, 2)
+	require.NoError(current.PutUTXO(a))
+	require.NoError(current.PutUTXO(other))
+	require.NoError(journal.Record(0, time.Unix(100, 0), nil, []*UTXO{a, other}))
+
+	// Height 1 consumes [a] and produces [b].
+	b := newJournalTestUTXO(addr, 3)
+	require.NoError(current.DeleteUTXO(a.InputID()))
+	require.NoError(current.PutUTXO(b))
+	require.NoError(journal.Record(1, time.Unix(200, 0), []*UTXO{a}, []*UTXO{b}))
+
+	// Height 2 produces and consumes [c], and produces [d], over two records.
+	c := newJournalTestUTXO(addr, 4)
+	d := newJournalTestUTXO(addr, 5)
+	require.NoError(current.PutUTXO(d))
+	require.NoError(journal.Record(2, time.Unix(200, 0), nil, []*UTXO{c}))
+	require.NoError(journal.Record(2, time.Unix(200, 0), []*UTXO{c}, []*UTXO{d}))
+
+	tests := []struct {
+		height  uint64
+		balance uint64
+	}{
+		{height: 0, balance: 1},
+		{height: 1, balance: 3},
+		{height: 2, balance: 8},
+	}
+	for _, test := range tests {
+		reader, err := journal.Rewind(current, addrs, test.height)
+		require.NoError(err)
+		balance, err := GetBalance(reader, addrs)
+		require.NoError(err)
+		require.Equal(test.balance, balance, "height %d", test.height)
+	}
+
+	reader, err := journal.Rewind(current, addrs, 0)
+	require.NoError(err)
+	utxos, err := GetAllUTXOs(reader, addrs)
+	require.NoError(err)
+	require.Equal([]*UTXO{a}, utxos)
+
+	_, err = reader.GetUTXO(b.InputID())
+	require.Equal(database.ErrNotFound, err)
+
+	_, err = journal.Rewind(current, addrs, 3)
+	require.True(errors.Is(err, ErrHeightNotRetained))
+
+	height, err := journal.HeightAt(time.Unix(150, 0))
+	require.NoError(err)
+	require.EqualValues(0, height)
+
+	height, err = journal.HeightAt(time.Unix(300, 0))
+	require.NoError(err)
+	require.EqualValues(2, height)
+
+	_, err = journal.HeightAt(time.Unix(50, 0))
+	require.True(errors.Is(err, ErrHeightNotRetained))
+}
+
+func TestUTXOJournalRetention(t *testing.T) {
+	require := require.New(t)
+
+	manager := newJournalTestCodec(t)
+	journal := NewUTXOJournal(memdb.New(), manager, 2)
+
+	for height := uint64(0); height < 5; height++ {
+		require.NoError(journal.Record(height, time.Unix(int64(height), 0), nil, nil))
+	}
+
+	first, err := journal.FirstHeight()
+	require.NoError(err)
+	require.EqualValues(3, first)
+
+	last, err := journal.LastHeight()
+	require.NoError(err)
+	require.EqualValues(4, last)
+
+	_, err = journal.Timestamp(2)
+	require.True(errors.Is(err, ErrHeightNotRetained))
+
+	// Skipping a height drops the previously recorded heights.
+	require.NoError(journal.Record(10, time.Unix(10, 0), nil, nil))
+
+	first, err = journal.FirstHeight()
+	require.NoError(err)
+	require.EqualValues(10, first)
+}
+
+func TestUTXOJournalRewindUTXOIDsPagination(t *testing.T) {
+	require := require.New(t)
+
+	manager := newJournalTestCodec(t)
+	current := NewUTXOState(memdb.New(), manager)
+	journal := NewUTXOJournal(memdb.New(), manager, 0)
+
+	addr := ids.GenerateTestShortID()
+	addrs := set.Set[ids.ShortID]{}
+	addrs.Add(addr)
+
+	// Height 0 produces 5 UTXOs.
+	produced := make([]*UTXO, 5)
+	for i := range produced {
+		produced[i] = newJournalTestUTXO(addr, 1)
+		require.NoError(current.PutUTXO(produced[i]))
+	}
+	require.NoError(journal.Record(0, time.Unix(0, 0), nil, produced))
+
+	// Height 1 consumes 2 of them and produces 2 new UTXOs.
+	consumed := produced[:2]
+	for _, utxo := range consumed {
+		require.NoError(current.DeleteUTXO(utxo.InputID()))
+	}
+	newUTXOs := []*UTXO{
+		newJournalTestUTXO(addr, 1),
+		newJournalTestUTXO(addr, 1),
+	}
+	for _, utxo := range newUTXOs {
+		require.NoError(current.PutUTXO(utxo))
+	}
+	require.NoError(journal.Record(1, time.Unix(1, 0), consumed, newUTXOs))
+
+	reader, err := journal.Rewind(current, addrs, 0)
+	require.NoError(err)
+
+	var (
+		utxoIDs  []ids.ID
+		previous = ids.Empty
+	)
+	for {
+		page, err := reader.UTXOIDs(addr[:], previous, 2)
+		require.NoError(err)
+		if len(page) == 0 {
+			break
+		}
+		require.LessOrEqual(len(page), 2)
+		utxoIDs = append(utxoIDs, page...)
+		previous = page[len(page)-1]
+	}
+
+	expected := make([]ids.ID, len(produced))
+	for i, utxo := range produced {
+		expected[i] = utxo.InputID()
+	}
+	require.ElementsMatch(expected, utxoIDs)
+}
@@ -110,6 +110,18 @@ func NewMeteredUTXOState(db database.Database, codec codec.Manager, metrics prom
 	}, err
 }
 
+// NewUTXOIterator returns an iterator over the serialized UTXOs of a UTXOState
+// stored in [db], ordered by UTXO ID.
+func NewUTXOIterator(db database.Database) database.Iterator {
+	return prefixdb.New(utxoPrefix, db).NewIterator()
+}
+
+// NewUTXOIteratorWithStart returns an iterator over the serialized UTXOs of a
+// UTXOState stored in [db], ordered by UTXO ID, starting at [start].
+func NewUTXOIteratorWithStart(db database.Database, start []byte) database.Iterator {
+	return prefixdb.New(utxoPrefix, db).NewIteratorWithStart(start)
+}
+
 func (s *utxoState) GetUTXO(utxoID ids.ID) (*UTXO, error) {
 	if utxoIntf, found := s.utxoCache.Get(utxoID); found {
 		if utxoIntf == nil {
@@ -0,0 +1,41 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+)
+
+var errNilCheckOperation = errors.New("nil check operation")
+
+// CheckOperation spends the FreezeOutput of an asset and re-creates it
+// unchanged. Every tx that spends UTXOs of an asset that supports this fx must
+// include it, so that the tx conflicts with any FreezeOperation of the asset
+// that is processing and consensus orders the spend against the freeze. It
+// doesn't require the signature of the freeze authority.
+type CheckOperation struct {
+	FreezeOutput FreezeOutput `serialize:"true" json:"freezeOutput"`
+}
+
+func (op *CheckOperation) InitCtx(ctx *snow.Context) {
+	op.FreezeOutput.OutputOwners.InitCtx(ctx)
+}
+
+func (*CheckOperation) Cost() (uint64, error) {
+	return 0, nil
+}
+
+func (op *CheckOperation) Outs() []verify.State {
+	return []verify.State{&op.FreezeOutput}
+}
+
+func (op *CheckOperation) Verify() error {
+	if op == nil {
+		return errNilCheckOperation
+	}
+	return op.FreezeOutput.Verify()
+}
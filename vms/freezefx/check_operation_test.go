@@ -0,0 +1,55 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func newCheckOperation() *CheckOperation {
+	return &CheckOperation{
+		FreezeOutput: FreezeOutput{OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}},
+	}
+}
+
+func TestCheckOperationVerify(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(newCheckOperation().Verify())
+
+	var op *CheckOperation
+	require.ErrorIs(op.Verify(), errNilCheckOperation)
+
+	op = newCheckOperation()
+	op.FreezeOutput.Threshold = 2
+	require.Error(op.Verify())
+}
+
+func TestCheckOperationCost(t *testing.T) {
+	require := require.New(t)
+	cost, err := newCheckOperation().Cost()
+	require.NoError(err)
+	require.Zero(cost)
+}
+
+func TestCheckOperationOuts(t *testing.T) {
+	require := require.New(t)
+	op := newCheckOperation()
+	require.Equal([]verify.State{&op.FreezeOutput}, op.Outs())
+}
+
+func TestCheckOperationState(t *testing.T) {
+	intf := interface{}(&CheckOperation{})
+	_, ok := intf.(verify.State)
+	require.False(t, ok)
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+// MaxTargets is the maximum number of UTXOs and addresses that a single
+// operation can freeze or unfreeze.
+const MaxTargets = 256
+
+var (
+	errNilFreezeOperation = errors.New("nil freeze operation")
+	errNoTargets          = errors.New("no UTXOs or addresses to freeze")
+	errTooManyTargets     = errors.New("too many UTXOs and addresses to freeze")
+	errTargetsNotSorted   = errors.New("UTXOs or addresses to freeze not sorted and unique")
+)
+
+// FreezeOperation spends the FreezeOutput of an asset to freeze, or unfreeze,
+// the UTXOs of that asset with the IDs [TargetUTXOIDs] and its UTXOs owned by
+// any of [TargetAddrs]. Frozen UTXOs can't be spent until they are unfrozen.
+type FreezeOperation struct {
+	Input        secp256k1fx.Input `serialize:"true" json:"input"`
+	FreezeOutput FreezeOutput      `serialize:"true" json:"freezeOutput"`
+
+	// Frozen is true if the targets are frozen and false if they are unfrozen.
+	Frozen        bool          `serialize:"true" json:"frozen"`
+	TargetUTXOIDs []ids.ID      `serialize:"true" json:"targetUTXOIDs"`
+	TargetAddrs   []ids.ShortID `serialize:"true" json:"targetAddresses"`
+}
+
+func (op *FreezeOperation) InitCtx(ctx *snow.Context) {
+	op.FreezeOutput.OutputOwners.InitCtx(ctx)
+}
+
+func (op *FreezeOperation) Cost() (uint64, error) {
+	return op.Input.Cost()
+}
+
+func (op *FreezeOperation) Outs() []verify.State {
+	return []verify.State{&op.FreezeOutput}
+}
+
+func (op *FreezeOperation) Verify() error {
+	switch {
+	case op == nil:
+		return errNilFreezeOperation
+	case len(op.TargetUTXOIDs) == 0 && len(op.TargetAddrs) == 0:
+		return errNoTargets
+	case len(op.TargetUTXOIDs)+len(op.TargetAddrs) > MaxTargets:
+		return errTooManyTargets
+	case !utils.IsSortedAndUniqueSortable(op.TargetUTXOIDs),
+		!utils.IsSortedAndUniqueSortable(op.TargetAddrs):
+		return errTargetsNotSorted
+	default:
+		return verify.All(&op.Input, &op.FreezeOutput)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func newFreezeOperation() *FreezeOperation {
+	return &FreezeOperation{
+		Input: secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+		FreezeOutput: FreezeOutput{OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}},
+		Frozen:        true,
+		TargetUTXOIDs: []ids.ID{{1}, {2}},
+		TargetAddrs:   []ids.ShortID{{1}},
+	}
+}
+
+func TestFreezeOperationVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		op          func() *FreezeOperation
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			op:   newFreezeOperation,
+		},
+		{
+			name: "nil",
+			op: func() *FreezeOperation {
+				return nil
+			},
+			expectedErr: errNilFreezeOperation,
+		},
+		{
+			name: "no targets",
+			op: func() *FreezeOperation {
+				op := newFreezeOperation()
+				op.TargetUTXOIDs = nil
+				op.TargetAddrs = nil
+				return op
+			},
+			expectedErr: errNoTargets,
+		},
+		{
+			name: "only addresses",
+			op: func() *FreezeOperation {
+				op := newFreezeOperation()
+				op.TargetUTXOIDs = nil
+				return op
+			},
+		},
+		{
+			name: "too many targets",
+			op: func() *FreezeOperation {
+				op := newFreezeOperation()
+				op.TargetUTXOIDs = make([]ids.ID, MaxTargets)
+				for i := range op.TargetUTXOIDs {
+					op.TargetUTXOIDs[i] = ids.Empty.Prefix(uint64(i))
+				}
+				return op
+			},
+			expectedErr: errTooManyTargets,
+		},
+		{
+			name: "unsorted UTXOs",
+			op: func() *FreezeOperation {
+				op := newFreezeOperation()
+				op.TargetUTXOIDs = []ids.ID{{2}, {1}}
+				return op
+			},
+			expectedErr: errTargetsNotSorted,
+		},
+		{
+			name: "duplicate addresses",
+			op: func() *FreezeOperation {
+				op := newFreezeOperation()
+				op.TargetAddrs = []ids.ShortID{{1}, {1}}
+				return op
+			},
+			expectedErr: errTargetsNotSorted,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.op().Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestFreezeOperationVerifyInvalidOutput(t *testing.T) {
+	op := newFreezeOperation()
+	op.FreezeOutput.Threshold = 2
+	require.Error(t, op.Verify())
+}
+
+func TestFreezeOperationOuts(t *testing.T) {
+	require := require.New(t)
+	op := newFreezeOperation()
+	require.Equal([]verify.State{&op.FreezeOutput}, op.Outs())
+}
+
+func TestFreezeOperationState(t *testing.T) {
+	intf := interface{}(&FreezeOperation{})
+	_, ok := intf.(verify.State)
+	require.False(t, ok)
+}
@@ -0,0 +1,14 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+// FreezeOutput is held by the freeze authority of an asset. Spending it allows
+// the UTXOs and addresses holding the asset to be frozen and unfrozen.
+type FreezeOutput struct {
+	secp256k1fx.OutputOwners `serialize:"true"`
+}
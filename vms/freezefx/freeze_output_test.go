@@ -0,0 +1,18 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+)
+
+func TestFreezeOutputState(t *testing.T) {
+	intf := interface{}(&FreezeOutput{})
+	_, ok := intf.(verify.State)
+	require.True(t, ok)
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+var (
+	errWrongTxType         = errors.New("wrong tx type")
+	errWrongUTXOType       = errors.New("wrong utxo type")
+	errWrongOperationType  = errors.New("wrong operation type")
+	errWrongCredentialType = errors.New("wrong credential type")
+	errWrongNumberOfUTXOs  = errors.New("wrong number of UTXOs for the operation")
+	errWrongFreezeOutput   = errors.New("wrong freeze output provided")
+	errUnexpectedSigs      = errors.New("unexpected signatures")
+	errCantTransfer        = errors.New("cant transfer with this fx")
+)
+
+// Fx allows the creator of an asset to designate a freeze authority, which can
+// freeze and unfreeze the UTXOs and addresses holding the asset. The freezes
+// are recorded and enforced by the VM when the operations are accepted.
+//
+// An asset has a single FreezeOutput. Freezes spend it with a FreezeOperation
+// and the txs that spend UTXOs of the asset spend it with a CheckOperation, so
+// that consensus orders every spend of the asset against its freezes.
+type Fx struct{ secp256k1fx.Fx }
+
+func (fx *Fx) Initialize(vmIntf interface{}) error {
+	if err := fx.InitializeVM(vmIntf); err != nil {
+		return err
+	}
+
+	log := fx.VM.Logger()
+	log.Debug("initializing freeze fx")
+
+	c := fx.VM.CodecRegistry()
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&FreezeOutput{}),
+		c.RegisterType(&FreezeOperation{}),
+		c.RegisterType(&Credential{}),
+		c.RegisterType(&CheckOperation{}),
+	)
+	return errs.Err
+}
+
+func (fx *Fx) VerifyOperation(txIntf, opIntf, credIntf interface{}, utxosIntf []interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.UnsignedTx)
+	switch {
+	case !ok:
+		return errWrongTxType
+	case len(utxosIntf) != 1:
+		return errWrongNumberOfUTXOs
+	}
+
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return errWrongCredentialType
+	}
+
+	switch op := opIntf.(type) {
+	case *FreezeOperation:
+		return fx.VerifyFreezeOperation(tx, op, cred, utxosIntf[0])
+	case *CheckOperation:
+		return fx.VerifyCheckOperation(op, cred, utxosIntf[0])
+	default:
+		return errWrongOperationType
+	}
+}
+
+func (fx *Fx) VerifyFreezeOperation(tx secp256k1fx.UnsignedTx, op *FreezeOperation, cred *Credential, utxoIntf interface{}) error {
+	out, ok := utxoIntf.(*FreezeOutput)
+	if !ok {
+		return errWrongUTXOType
+	}
+
+	if err := verify.All(op, cred, out); err != nil {
+		return err
+	}
+
+	switch {
+	case !out.OutputOwners.Equals(&op.FreezeOutput.OutputOwners):
+		return errWrongFreezeOutput
+	default:
+		return fx.Fx.VerifyCredentials(tx, &op.Input, &cred.Credential, &out.OutputOwners)
+	}
+}
+
+func (*Fx) VerifyCheckOperation(op *CheckOperation, cred *Credential, utxoIntf interface{}) error {
+	out, ok := utxoIntf.(*FreezeOutput)
+	if !ok {
+		return errWrongUTXOType
+	}
+
+	if err := verify.All(op, cred, out); err != nil {
+		return err
+	}
+
+	switch {
+	case !out.OutputOwners.Equals(&op.FreezeOutput.OutputOwners):
+		return errWrongFreezeOutput
+	case len(cred.Sigs) != 0:
+		return errUnexpectedSigs
+	default:
+		return nil
+	}
+}
+
+func (*Fx) VerifyTransfer(_, _, _, _ interface{}) error {
+	return errCantTransfer
+}
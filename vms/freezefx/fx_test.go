@@ -0,0 +1,238 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/codec/linearcodec"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+var (
+	txBytes  = []byte{0, 1, 2, 3, 4, 5}
+	sigBytes = [crypto.SECP256K1RSigLen]byte{
+		0x0e, 0x33, 0x4e, 0xbc, 0x67, 0xa7, 0x3f, 0xe8,
+		0x24, 0x33, 0xac, 0xa3, 0x47, 0x88, 0xa6, 0x3d,
+		0x58, 0xe5, 0x8e, 0xf0, 0x3a, 0xd5, 0x84, 0xf1,
+		0xbc, 0xa3, 0xb2, 0xd2, 0x5d, 0x51, 0xd6, 0x9b,
+		0x0f, 0x28, 0x5d, 0xcd, 0x3f, 0x71, 0x17, 0x0a,
+		0xf9, 0xbf, 0x2d, 0xb1, 0x10, 0x26, 0x5c, 0xe9,
+		0xdc, 0xc3, 0x9d, 0x7a, 0x01, 0x50, 0x9d, 0xe8,
+		0x35, 0xbd, 0xcb, 0x29, 0x3a, 0xd1, 0x49, 0x32,
+		0x00,
+	}
+	addr = [hashing.AddrLen]byte{
+		0x01, 0x5c, 0xce, 0x6c, 0x55, 0xd6, 0xb5, 0x09,
+		0x84, 0x5c, 0x8c, 0x4e, 0x30, 0xbe, 0xd9, 0x8d,
+		0x39, 0x1a, 0xe7, 0xf0,
+	}
+)
+
+func newTestFx(t *testing.T) *Fx {
+	vm := &secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := &Fx{}
+	require.NoError(t, fx.Initialize(vm))
+	return fx
+}
+
+func TestFxInitialize(t *testing.T) {
+	newTestFx(t)
+}
+
+func TestFxInitializeInvalid(t *testing.T) {
+	fx := Fx{}
+	require.Error(t, fx.Initialize(nil))
+}
+
+func TestFxVerifyFreezeOperation(t *testing.T) {
+	tx := &secp256k1fx.TestTx{
+		UnsignedBytes: txBytes,
+	}
+	cred := &Credential{Credential: secp256k1fx.Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{
+			sigBytes,
+		},
+	}}
+	newUTXO := func() interface{} {
+		return &FreezeOutput{OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		tx          interface{}
+		op          interface{}
+		cred        interface{}
+		utxos       []interface{}
+		expectedErr error
+	}{
+		{
+			name:  "valid",
+			tx:    tx,
+			op:    newFreezeOperation(),
+			cred:  cred,
+			utxos: []interface{}{newUTXO()},
+		},
+		{
+			name:        "wrong tx type",
+			op:          newFreezeOperation(),
+			cred:        cred,
+			utxos:       []interface{}{newUTXO()},
+			expectedErr: errWrongTxType,
+		},
+		{
+			name:        "wrong number of UTXOs",
+			tx:          tx,
+			op:          newFreezeOperation(),
+			cred:        cred,
+			expectedErr: errWrongNumberOfUTXOs,
+		},
+		{
+			name:        "wrong credential type",
+			tx:          tx,
+			op:          newFreezeOperation(),
+			cred:        &secp256k1fx.Credential{},
+			utxos:       []interface{}{newUTXO()},
+			expectedErr: errWrongCredentialType,
+		},
+		{
+			name:        "wrong operation type",
+			tx:          tx,
+			op:          &secp256k1fx.MintOperation{},
+			cred:        cred,
+			utxos:       []interface{}{newUTXO()},
+			expectedErr: errWrongOperationType,
+		},
+		{
+			name: "wrong UTXO type",
+			tx:   tx,
+			op:   newFreezeOperation(),
+			cred: cred,
+			utxos: []interface{}{&secp256k1fx.MintOutput{OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			}}},
+			expectedErr: errWrongUTXOType,
+		},
+		{
+			name: "wrong freeze output",
+			tx:   tx,
+			op: func() *FreezeOperation {
+				op := newFreezeOperation()
+				op.FreezeOutput.Addrs = []ids.ShortID{ids.GenerateTestShortID()}
+				return op
+			}(),
+			cred:        cred,
+			utxos:       []interface{}{newUTXO()},
+			expectedErr: errWrongFreezeOutput,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fx := newTestFx(t)
+			err := fx.VerifyOperation(test.tx, test.op, test.cred, test.utxos)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestFxVerifyFreezeOperationWrongSignature(t *testing.T) {
+	fx := newTestFx(t)
+	tx := &secp256k1fx.TestTx{
+		UnsignedBytes: txBytes,
+	}
+	cred := &Credential{Credential: secp256k1fx.Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{
+			{},
+		},
+	}}
+	utxo := &FreezeOutput{OutputOwners: secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}}
+	require.NoError(t, fx.Bootstrapped())
+	err := fx.VerifyOperation(tx, newFreezeOperation(), cred, []interface{}{utxo})
+	require.Error(t, err)
+}
+
+func TestFxVerifyCheckOperation(t *testing.T) {
+	tx := &secp256k1fx.TestTx{
+		UnsignedBytes: txBytes,
+	}
+	newUTXO := func() interface{} {
+		return &FreezeOutput{OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		op          interface{}
+		cred        interface{}
+		utxos       []interface{}
+		expectedErr error
+	}{
+		{
+			name:  "valid",
+			op:    newCheckOperation(),
+			cred:  &Credential{},
+			utxos: []interface{}{newUTXO()},
+		},
+		{
+			name:        "wrong UTXO type",
+			op:          newCheckOperation(),
+			cred:        &Credential{},
+			utxos:       []interface{}{&secp256k1fx.MintOutput{}},
+			expectedErr: errWrongUTXOType,
+		},
+		{
+			name: "wrong freeze output",
+			op: func() *CheckOperation {
+				op := newCheckOperation()
+				op.FreezeOutput.Addrs = []ids.ShortID{ids.GenerateTestShortID()}
+				return op
+			}(),
+			cred:        &Credential{},
+			utxos:       []interface{}{newUTXO()},
+			expectedErr: errWrongFreezeOutput,
+		},
+		{
+			name: "unexpected signatures",
+			op:   newCheckOperation(),
+			cred: &Credential{Credential: secp256k1fx.Credential{
+				Sigs: [][crypto.SECP256K1RSigLen]byte{
+					sigBytes,
+				},
+			}},
+			utxos:       []interface{}{newUTXO()},
+			expectedErr: errUnexpectedSigs,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fx := newTestFx(t)
+			err := fx.VerifyOperation(tx, test.op, test.cred, test.utxos)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestFxVerifyTransfer(t *testing.T) {
+	fx := newTestFx(t)
+	err := fx.VerifyTransfer(nil, nil, nil, nil)
+	require.ErrorIs(t, err, errCantTransfer)
+}
@@ -0,0 +1,18 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+)
+
+func TestCredentialState(t *testing.T) {
+	intf := interface{}(&Credential{})
+	_, ok := intf.(verify.State)
+	require.False(t, ok)
+}
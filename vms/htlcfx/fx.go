@@ -0,0 +1,108 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+var (
+	errWrongTxType         = errors.New("wrong tx type")
+	errWrongUTXOType       = errors.New("wrong utxo type")
+	errWrongInputType      = errors.New("wrong input type")
+	errWrongCredentialType = errors.New("wrong credential type")
+	errWrongAmount         = errors.New("utxo amount and input amount should be same")
+	errWrongPreimage       = errors.New("preimage doesn't match the hash of the output")
+	errDeadlinePassed      = errors.New("output can't be claimed once its deadline has passed")
+	errDeadlineNotPassed   = errors.New("output can't be refunded before its deadline")
+	errCantOperate         = errors.New("cant perform operations with this fx")
+)
+
+// Fx describes the hashed-timelock feature extension
+type Fx struct {
+	secp256k1fx.Fx
+
+	bootstrapped bool
+}
+
+func (fx *Fx) Initialize(vmIntf interface{}) error {
+	if err := fx.InitializeVM(vmIntf); err != nil {
+		return err
+	}
+
+	log := fx.VM.Logger()
+	log.Debug("initializing htlc fx")
+
+	c := fx.VM.CodecRegistry()
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&TransferInput{}),
+		c.RegisterType(&TransferOutput{}),
+		c.RegisterType(&Credential{}),
+	)
+	return errs.Err
+}
+
+func (fx *Fx) Bootstrapped() error {
+	fx.bootstrapped = true
+	return fx.Fx.Bootstrapped()
+}
+
+func (*Fx) VerifyOperation(interface{}, interface{}, interface{}, []interface{}) error {
+	return errCantOperate
+}
+
+func (fx *Fx) VerifyTransfer(txIntf, inIntf, credIntf, utxoIntf interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.UnsignedTx)
+	if !ok {
+		return errWrongTxType
+	}
+	in, ok := inIntf.(*TransferInput)
+	if !ok {
+		return errWrongInputType
+	}
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return errWrongCredentialType
+	}
+	out, ok := utxoIntf.(*TransferOutput)
+	if !ok {
+		return errWrongUTXOType
+	}
+	return fx.VerifySpend(tx, in, cred, out)
+}
+
+// VerifySpend ensures that the utxo can be claimed or refunded by the input
+func (fx *Fx) VerifySpend(utx secp256k1fx.UnsignedTx, in *TransferInput, cred *Credential, utxo *TransferOutput) error {
+	if err := verify.All(utxo, in, cred); err != nil {
+		return err
+	}
+	if utxo.Amt != in.Amt {
+		return fmt.Errorf("%w: %d != %d", errWrongAmount, utxo.Amt, in.Amt)
+	}
+
+	// The deadline isn't enforced during bootstrapping because the transaction
+	// was accepted before the current time.
+	now := fx.VM.Clock().Unix()
+	if !in.IsClaim() {
+		if fx.bootstrapped && now < utxo.Deadline {
+			return errDeadlineNotPassed
+		}
+		return fx.VerifyCredentials(utx, &in.Input, &cred.Credential, &utxo.Refund)
+	}
+
+	if fx.bootstrapped && now >= utxo.Deadline {
+		return errDeadlinePassed
+	}
+	if hashing.ComputeHash256Array(in.Preimage) != utxo.Hash {
+		return errWrongPreimage
+	}
+	return fx.VerifyCredentials(utx, &in.Input, &cred.Credential, &utxo.Receiver)
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/codec/linearcodec"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+var (
+	txBytes  = []byte{0, 1, 2, 3, 4, 5}
+	preimage = []byte("secret")
+	deadline = time.Date(2019, time.January, 19, 16, 25, 17, 0, time.UTC)
+)
+
+type testFx struct {
+	fx           *Fx
+	vm           *secp256k1fx.TestVM
+	tx           *secp256k1fx.TestTx
+	receiverCred *Credential
+	refundCred   *Credential
+	out          *TransferOutput
+}
+
+func newTestFx(t *testing.T) *testFx {
+	require := require.New(t)
+
+	vm := &secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := &Fx{}
+	require.NoError(fx.Initialize(vm))
+	require.NoError(fx.Bootstrapping())
+	require.NoError(fx.Bootstrapped())
+
+	factory := crypto.FactorySECP256K1R{}
+	receiverKey, err := factory.NewPrivateKey()
+	require.NoError(err)
+	refundKey, err := factory.NewPrivateKey()
+	require.NoError(err)
+
+	newCred := func(key crypto.PrivateKey) *Credential {
+		sig, err := key.Sign(txBytes)
+		require.NoError(err)
+		cred := &Credential{Credential: secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, 1),
+		}}
+		copy(cred.Sigs[0][:], sig)
+		return cred
+	}
+
+	return &testFx{
+		fx:           fx,
+		vm:           vm,
+		tx:           &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+		receiverCred: newCred(receiverKey),
+		refundCred:   newCred(refundKey),
+		out: &TransferOutput{
+			Amt:      1,
+			Hash:     hashing.ComputeHash256Array(preimage),
+			Deadline: uint64(deadline.Unix()),
+			Receiver: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{receiverKey.PublicKey().Address()},
+			},
+			Refund: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{refundKey.PublicKey().Address()},
+			},
+		},
+	}
+}
+
+func TestFxInitializeInvalid(t *testing.T) {
+	fx := Fx{}
+	require.Error(t, fx.Initialize(nil))
+}
+
+func TestFxVerifyTransferClaim(t *testing.T) {
+	require := require.New(t)
+	test := newTestFx(t)
+	test.vm.Clk.Set(deadline.Add(-time.Second))
+
+	in := &TransferInput{
+		Amt:      1,
+		Preimage: preimage,
+		Input:    secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+	require.NoError(test.fx.VerifyTransfer(test.tx, in, test.receiverCred, test.out))
+
+	// Only the receiver can claim the output
+	require.Error(test.fx.VerifyTransfer(test.tx, in, test.refundCred, test.out))
+
+	// The preimage must match the hash
+	in.Preimage = []byte("wrong secret")
+	err := test.fx.VerifyTransfer(test.tx, in, test.receiverCred, test.out)
+	require.ErrorIs(err, errWrongPreimage)
+
+	// The output can't be claimed once the deadline is reached
+	in.Preimage = preimage
+	test.vm.Clk.Set(deadline)
+	err = test.fx.VerifyTransfer(test.tx, in, test.receiverCred, test.out)
+	require.ErrorIs(err, errDeadlinePassed)
+}
+
+func TestFxVerifyTransferRefund(t *testing.T) {
+	require := require.New(t)
+	test := newTestFx(t)
+	test.vm.Clk.Set(deadline)
+
+	in := &TransferInput{
+		Amt:   1,
+		Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+	require.NoError(test.fx.VerifyTransfer(test.tx, in, test.refundCred, test.out))
+
+	// Only the refund owners can refund the output
+	require.Error(test.fx.VerifyTransfer(test.tx, in, test.receiverCred, test.out))
+
+	// The output can't be refunded before the deadline
+	test.vm.Clk.Set(deadline.Add(-time.Second))
+	err := test.fx.VerifyTransfer(test.tx, in, test.refundCred, test.out)
+	require.ErrorIs(err, errDeadlineNotPassed)
+}
+
+func TestFxVerifyTransferWrongAmount(t *testing.T) {
+	require := require.New(t)
+	test := newTestFx(t)
+	test.vm.Clk.Set(deadline)
+
+	in := &TransferInput{
+		Amt:   2,
+		Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+	err := test.fx.VerifyTransfer(test.tx, in, test.refundCred, test.out)
+	require.ErrorIs(err, errWrongAmount)
+}
+
+func TestFxVerifyTransferWrongTypes(t *testing.T) {
+	require := require.New(t)
+	test := newTestFx(t)
+
+	in := &TransferInput{
+		Amt:   1,
+		Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+	require.ErrorIs(test.fx.VerifyTransfer(nil, in, test.refundCred, test.out), errWrongTxType)
+	require.ErrorIs(test.fx.VerifyTransfer(test.tx, nil, test.refundCred, test.out), errWrongInputType)
+	require.ErrorIs(test.fx.VerifyTransfer(test.tx, in, nil, test.out), errWrongCredentialType)
+	require.ErrorIs(test.fx.VerifyTransfer(test.tx, in, test.refundCred, nil), errWrongUTXOType)
+}
+
+func TestFxVerifyTransferBootstrapping(t *testing.T) {
+	require := require.New(t)
+	test := newTestFx(t)
+	test.fx.bootstrapped = false
+
+	// Accepted claims remain valid after the deadline
+	test.vm.Clk.Set(deadline.Add(time.Hour))
+	in := &TransferInput{
+		Amt:      1,
+		Preimage: preimage,
+		Input:    secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+	require.NoError(test.fx.VerifyTransfer(test.tx, in, test.receiverCred, test.out))
+}
+
+func TestFxVerifyOperation(t *testing.T) {
+	test := newTestFx(t)
+	err := test.fx.VerifyOperation(test.tx, nil, test.receiverCred, []interface{}{test.out})
+	require.ErrorIs(t, err, errCantOperate)
+}
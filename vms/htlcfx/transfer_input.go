@@ -0,0 +1,61 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+	"github.com/lasthyphen/dijetsnodego/vms/types"
+)
+
+// MaxPreimageSize is the maximum size of the preimage revealed to claim an
+// output
+const MaxPreimageSize = 256
+
+var (
+	_ djtx.TransferableIn = (*TransferInput)(nil)
+
+	errNilInput         = errors.New("nil input")
+	errNoValueInput     = errors.New("input has no value")
+	errPreimageTooLarge = errors.New("preimage too large")
+)
+
+// TransferInput spends a [TransferOutput]. If [Preimage] is provided, the
+// input claims the output on behalf of its receiver. Otherwise, the input
+// refunds the output.
+type TransferInput struct {
+	Amt               uint64              `serialize:"true" json:"amount"`
+	Preimage          types.JSONByteSlice `serialize:"true" json:"preimage"`
+	secp256k1fx.Input `serialize:"true"`
+}
+
+func (*TransferInput) InitCtx(*snow.Context) {}
+
+// Amount returns the quantity of the asset this input produces
+func (in *TransferInput) Amount() uint64 {
+	return in.Amt
+}
+
+// IsClaim returns true if the input claims the output rather than refunding
+// it.
+func (in *TransferInput) IsClaim() bool {
+	return len(in.Preimage) != 0
+}
+
+// Verify this input is syntactically valid
+func (in *TransferInput) Verify() error {
+	switch {
+	case in == nil:
+		return errNilInput
+	case in.Amt == 0:
+		return errNoValueInput
+	case len(in.Preimage) > MaxPreimageSize:
+		return errPreimageTooLarge
+	default:
+		return in.Input.Verify()
+	}
+}
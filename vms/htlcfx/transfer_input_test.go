@@ -0,0 +1,72 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func TestTransferInputVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          *TransferInput
+		expectedErr error
+	}{
+		{
+			name:        "nil",
+			in:          nil,
+			expectedErr: errNilInput,
+		},
+		{
+			name: "no value",
+			in: &TransferInput{
+				Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+			expectedErr: errNoValueInput,
+		},
+		{
+			name: "preimage too large",
+			in: &TransferInput{
+				Amt:      1,
+				Preimage: make([]byte, MaxPreimageSize+1),
+				Input:    secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+			expectedErr: errPreimageTooLarge,
+		},
+		{
+			name: "claim",
+			in: &TransferInput{
+				Amt:      1,
+				Preimage: make([]byte, MaxPreimageSize),
+				Input:    secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		},
+		{
+			name: "refund",
+			in: &TransferInput{
+				Amt:   1,
+				Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.in.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestTransferInputIsClaim(t *testing.T) {
+	require := require.New(t)
+	in := &TransferInput{Amt: 1}
+	require.False(in.IsClaim())
+	require.Equal(uint64(1), in.Amount())
+
+	in.Preimage = []byte{0}
+	require.True(in.IsClaim())
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+var (
+	_ verify.State         = (*TransferOutput)(nil)
+	_ djtx.Addressable     = (*TransferOutput)(nil)
+	_ djtx.TransferableOut = (*TransferOutput)(nil)
+
+	errNilOutput     = errors.New("nil output")
+	errNoValueOutput = errors.New("output has no value")
+	errNoDeadline    = errors.New("output has no deadline")
+)
+
+// TransferOutput is a hashed-timelock output. Before [Deadline], it can be
+// spent by [Receiver] by revealing the preimage of [Hash]. Once [Deadline] is
+// reached, it can only be spent by [Refund].
+type TransferOutput struct {
+	Amt uint64 `serialize:"true" json:"amount"`
+	// SHA-256 hash of the preimage that must be revealed to claim the output
+	Hash ids.ID `serialize:"true" json:"hash"`
+	// Unix time after which the output can no longer be claimed and can be
+	// refunded
+	Deadline uint64 `serialize:"true" json:"deadline"`
+	// Owners that can claim the output before [Deadline]
+	Receiver secp256k1fx.OutputOwners `serialize:"true" json:"receiver"`
+	// Owners that can spend the output once [Deadline] is reached
+	Refund secp256k1fx.OutputOwners `serialize:"true" json:"refund"`
+}
+
+// InitCtx allows the owners to be marshalled to JSON with human readable
+// addresses.
+func (out *TransferOutput) InitCtx(ctx *snow.Context) {
+	out.Receiver.InitCtx(ctx)
+	out.Refund.InitCtx(ctx)
+}
+
+// Amount returns the quantity of the asset this output consumes
+func (out *TransferOutput) Amount() uint64 {
+	return out.Amt
+}
+
+// Addresses returns the addresses of both the receiver and the refund owners,
+// so that the output is tracked for both parties of the swap.
+func (out *TransferOutput) Addresses() [][]byte {
+	addrs := set.NewSet[ids.ShortID](len(out.Receiver.Addrs) + len(out.Refund.Addrs))
+	addrs.Add(out.Receiver.Addrs...)
+	addrs.Add(out.Refund.Addrs...)
+
+	addrsBytes := make([][]byte, 0, addrs.Len())
+	for addr := range addrs {
+		addr := addr
+		addrsBytes = append(addrsBytes, addr[:])
+	}
+	return addrsBytes
+}
+
+func (out *TransferOutput) Verify() error {
+	switch {
+	case out == nil:
+		return errNilOutput
+	case out.Amt == 0:
+		return errNoValueOutput
+	case out.Deadline == 0:
+		return errNoDeadline
+	}
+	if err := out.Receiver.Verify(); err != nil {
+		return err
+	}
+	return out.Refund.Verify()
+}
+
+func (out *TransferOutput) VerifyState() error {
+	return out.Verify()
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package htlcfx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func TestTransferOutputVerify(t *testing.T) {
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+	tests := []struct {
+		name        string
+		out         *TransferOutput
+		expectedErr error
+	}{
+		{
+			name:        "nil",
+			out:         nil,
+			expectedErr: errNilOutput,
+		},
+		{
+			name: "no value",
+			out: &TransferOutput{
+				Deadline: 1,
+				Receiver: owners,
+				Refund:   owners,
+			},
+			expectedErr: errNoValueOutput,
+		},
+		{
+			name: "no deadline",
+			out: &TransferOutput{
+				Amt:      1,
+				Receiver: owners,
+				Refund:   owners,
+			},
+			expectedErr: errNoDeadline,
+		},
+		{
+			name: "valid",
+			out: &TransferOutput{
+				Amt:      1,
+				Deadline: 1,
+				Receiver: owners,
+				Refund:   owners,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.out.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestTransferOutputVerifyInvalidOwners(t *testing.T) {
+	require := require.New(t)
+	out := &TransferOutput{
+		Amt:      1,
+		Deadline: 1,
+		Receiver: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		},
+		Refund: secp256k1fx.OutputOwners{
+			Threshold: 1,
+		},
+	}
+	require.Error(out.Verify())
+}
+
+func TestTransferOutputAddresses(t *testing.T) {
+	require := require.New(t)
+	receiverAddr := ids.GenerateTestShortID()
+	refundAddr := ids.GenerateTestShortID()
+	out := &TransferOutput{
+		Amt:      1,
+		Deadline: 1,
+		Receiver: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{receiverAddr},
+		},
+		Refund: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{receiverAddr, refundAddr},
+		},
+	}
+	require.Equal(uint64(1), out.Amount())
+	require.ElementsMatch(
+		[][]byte{receiverAddr[:], refundAddr[:]},
+		out.Addresses(),
+	)
+}
@@ -6,6 +6,8 @@ package metervm
 import (
 	"context"
 
+	"github.com/lasthyphen/dijetsnodego/database"
+
 	"github.com/lasthyphen/dijetsnodego/snow/engine/snowman/block"
 )
 
@@ -78,3 +80,11 @@ func (vm *blockVM) GetStateSummary(ctx context.Context, height uint64) (block.St
 	vm.blockMetrics.getStateSummary.Observe(duration)
 	return summary, nil
 }
+
+func (vm *blockVM) StateSyncProgress(ctx context.Context) ([]byte, error) {
+	progressVM, ok := vm.ChainVM.(block.StateSyncProgressVM)
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return progressVM.StateSyncProgress(ctx)
+}
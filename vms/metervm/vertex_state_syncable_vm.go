@@ -0,0 +1,87 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metervm
+
+import (
+	"context"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/consensus/avalanche"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/avalanche/vertex"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/snowman/block"
+)
+
+var (
+	_ block.StateSyncableVM     = (*vertexVM)(nil)
+	_ block.StateSyncProgressVM = (*vertexVM)(nil)
+	_ vertex.SyncedVM           = (*vertexVM)(nil)
+	_ vertex.EdgeTrackerVM      = (*vertexVM)(nil)
+)
+
+func (vm *vertexVM) StateSyncEnabled(ctx context.Context) (bool, error) {
+	ssVM, ok := vm.DAGVM.(block.StateSyncableVM)
+	if !ok {
+		return false, nil
+	}
+	return ssVM.StateSyncEnabled(ctx)
+}
+
+func (vm *vertexVM) GetOngoingSyncStateSummary(ctx context.Context) (block.StateSummary, error) {
+	ssVM, ok := vm.DAGVM.(block.StateSyncableVM)
+	if !ok {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+	return ssVM.GetOngoingSyncStateSummary(ctx)
+}
+
+func (vm *vertexVM) GetLastStateSummary(ctx context.Context) (block.StateSummary, error) {
+	ssVM, ok := vm.DAGVM.(block.StateSyncableVM)
+	if !ok {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+	return ssVM.GetLastStateSummary(ctx)
+}
+
+func (vm *vertexVM) ParseStateSummary(ctx context.Context, summaryBytes []byte) (block.StateSummary, error) {
+	ssVM, ok := vm.DAGVM.(block.StateSyncableVM)
+	if !ok {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+	return ssVM.ParseStateSummary(ctx, summaryBytes)
+}
+
+func (vm *vertexVM) GetStateSummary(ctx context.Context, height uint64) (block.StateSummary, error) {
+	ssVM, ok := vm.DAGVM.(block.StateSyncableVM)
+	if !ok {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+	return ssVM.GetStateSummary(ctx, height)
+}
+
+func (vm *vertexVM) StateSynced(ctx context.Context) (bool, error) {
+	syncedVM, ok := vm.DAGVM.(vertex.SyncedVM)
+	if !ok {
+		return false, nil
+	}
+	return syncedVM.StateSynced(ctx)
+}
+
+func (vm *vertexVM) StateSyncProgress(ctx context.Context) ([]byte, error) {
+	progressVM, ok := vm.DAGVM.(block.StateSyncProgressVM)
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+
+	return progressVM.StateSyncProgress(ctx)
+}
+
+func (vm *vertexVM) VertexAccepted(ctx context.Context, vtx avalanche.Vertex, edge []ids.ID) error {
+	edgeTracker, ok := vm.DAGVM.(vertex.EdgeTrackerVM)
+	if !ok {
+		return nil
+	}
+
+	return edgeTracker.VertexAccepted(ctx, vtx, edge)
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/genesis"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+var errUnknownUTXO = errors.New("unknown UTXO")
+
+// acceptedUTXOs replays the UTXO changes of accepted blocks for the indices
+// that trail the last accepted block.
+type acceptedUTXOs struct {
+	log     logging.Logger
+	state   state.State
+	genesis *genesis.State
+}
+
+func newAcceptedUTXOs(log logging.Logger, s state.State, genesisBytes []byte) (*acceptedUTXOs, error) {
+	genesisState, err := genesis.ParseState(genesisBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &acceptedUTXOs{
+		log:     log,
+		state:   s,
+		genesis: genesisState,
+	}, nil
+}
+
+// lastAcceptedHeight returns the height of the last accepted block.
+func (a *acceptedUTXOs) lastAcceptedHeight() (uint64, error) {
+	lastAccepted, _, err := a.state.GetStatelessBlock(a.state.GetLastAccepted())
+	if err != nil {
+		return 0, err
+	}
+	return lastAccepted.Height(), nil
+}
+
+// appliedTxs returns the txs whose UTXO changes were applied by accepting
+// [blk]. The txs of a proposal block are applied by its accepted option.
+func (a *acceptedUTXOs) appliedTxs(blk blocks.Block) ([]*txs.Tx, error) {
+	if blk.Height() == 0 {
+		// The genesis txs aren't included in the genesis block.
+		genesisTxs := make([]*txs.Tx, 0, len(a.genesis.Validators)+len(a.genesis.Chains))
+		genesisTxs = append(genesisTxs, a.genesis.Validators...)
+		return append(genesisTxs, a.genesis.Chains...), nil
+	}
+
+	switch blk.(type) {
+	case *blocks.ApricotProposalBlock, *blocks.BanffProposalBlock:
+		return nil, nil
+	case *blocks.ApricotCommitBlock, *blocks.BanffCommitBlock,
+		*blocks.ApricotAbortBlock, *blocks.BanffAbortBlock:
+		proposal, _, err := a.state.GetStatelessBlock(blk.Parent())
+		if err != nil {
+			return nil, err
+		}
+
+		var applied []*txs.Tx
+		for _, tx := range proposal.Txs() {
+			_, txStatus, err := a.state.GetTx(tx.ID())
+			if err != nil {
+				return nil, err
+			}
+
+			_, isRewardTx := tx.Unsigned.(*txs.RewardValidatorTx)
+			// Staked tokens are returned even if the reward is aborted.
+			if txStatus == status.Committed || isRewardTx {
+				applied = append(applied, tx)
+			}
+		}
+		return applied, nil
+	default:
+		return blk.Txs(), nil
+	}
+}
+
+// timestamp returns the chain time after [blk] was accepted, or [previous]
+// if [blk] didn't change it.
+func (*acceptedUTXOs) timestamp(blk blocks.Block, appliedTxs []*txs.Tx, previous time.Time) time.Time {
+	if banffBlk, ok := blk.(blocks.BanffBlock); ok {
+		return banffBlk.Timestamp()
+	}
+	for _, tx := range appliedTxs {
+		if advanceTimeTx, ok := tx.Unsigned.(*txs.AdvanceTimeTx); ok {
+			return advanceTimeTx.Timestamp()
+		}
+	}
+	return previous
+}
+
+// txUTXOs returns the UTXOs that [tx] removed from and added to the UTXO set.
+// Imported UTXOs aren't returned because they were produced on another chain.
+func (a *acceptedUTXOs) txUTXOs(tx *txs.Tx) ([]*djtx.UTXO, []*djtx.UTXO, error) {
+	rewardTx, ok := tx.Unsigned.(*txs.RewardValidatorTx)
+	if !ok {
+		flow := &txFlow{}
+		if err := tx.Unsigned.Visit(flow); err != nil {
+			return nil, nil, err
+		}
+		consumed := make([]*djtx.UTXO, 0, len(flow.ins))
+		for _, in := range flow.ins {
+			utxo, err := a.getConsumedUTXO(&in.UTXOID)
+			if errors.Is(err, errUnknownUTXO) {
+				a.log.Verbo("skipping unknown UTXO",
+					zap.Stringer("utxoID", in.InputID()),
+				)
+				continue
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			consumed = append(consumed, utxo)
+		}
+		return consumed, tx.UTXOs(), nil
+	}
+
+	// The stake and the reward are paid out by the tx that added the staker.
+	stakerTx, _, err := a.state.GetTx(rewardTx.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var produced []*djtx.UTXO
+	if staker, ok := stakerTx.Unsigned.(txs.PermissionlessStaker); ok {
+		numOuts := len(stakerTx.Unsigned.Outputs())
+		for i, out := range staker.Stake() {
+			produced = append(produced, &djtx.UTXO{
+				UTXOID: djtx.UTXOID{
+					TxID:        rewardTx.TxID,
+					OutputIndex: uint32(numOuts + i),
+				},
+				Asset: out.Asset,
+				Out:   out.Output(),
+			})
+		}
+	}
+	rewardUTXOs, err := a.state.GetRewardUTXOs(rewardTx.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, append(produced, rewardUTXOs...), nil
+}
+
+// getConsumedUTXO returns the UTXO [utxoID], which may have already been
+// consumed, by looking it up in the tx that produced it.
+func (a *acceptedUTXOs) getConsumedUTXO(utxoID *djtx.UTXOID) (*djtx.UTXO, error) {
+	if utxoID.TxID == ids.Empty {
+		for _, utxo := range a.genesis.UTXOs {
+			if utxo.OutputIndex == utxoID.OutputIndex {
+				return utxo, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", errUnknownUTXO, utxoID.InputID())
+	}
+
+	tx, _, err := a.state.GetTx(utxoID.TxID)
+	if err == database.ErrNotFound {
+		return nil, fmt.Errorf("%w: %s", errUnknownUTXO, utxoID.InputID())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// UTXOs are produced in the order: outputs, returned stake, rewards.
+	outputIndex := int(utxoID.OutputIndex)
+	utxos := tx.UTXOs()
+	if outputIndex < len(utxos) {
+		return utxos[outputIndex], nil
+	}
+	if staker, ok := tx.Unsigned.(txs.PermissionlessStaker); ok {
+		stake := staker.Stake()
+		if stakeIndex := outputIndex - len(utxos); stakeIndex < len(stake) {
+			out := stake[stakeIndex]
+			return &djtx.UTXO{
+				UTXOID: *utxoID,
+				Asset:  out.Asset,
+				Out:    out.Output(),
+			}, nil
+		}
+	}
+
+	rewardUTXOs, err := a.state.GetRewardUTXOs(utxoID.TxID)
+	if err != nil {
+		return nil, err
+	}
+	inputID := utxoID.InputID()
+	for _, utxo := range rewardUTXOs {
+		if utxo.InputID() == inputID {
+			return utxo, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", errUnknownUTXO, inputID)
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
+	"github.com/lasthyphen/dijetsnodego/database/versiondb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/components/index"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+var (
+	addressIndexPrefix = []byte("addressIndex")
+	addressTxsPrefix   = []byte("txs")
+
+	nextIndexHeightKey = []byte("nextHeight")
+)
+
+// addressTxsIndex indexes the accepted transactions that changed the balances
+// of each address.
+//
+// Blocks are indexed as they are accepted, and enabling the index on an
+// existing node backfills it from genesis.
+type addressTxsIndex struct {
+	*blockFollower
+
+	db      *versiondb.Database
+	indexer index.AddressTxsIndexer
+}
+
+func newAddressTxsIndex(
+	db database.Database,
+	log logging.Logger,
+	lock sync.Locker,
+	registerer prometheus.Registerer,
+	utxos *acceptedUTXOs,
+) (*addressTxsIndex, error) {
+	versionDB := versiondb.New(prefixdb.New(addressIndexPrefix, db))
+	// Incomplete indices are allowed because the index is backfilled.
+	indexer, err := index.NewIndexer(
+		prefixdb.New(addressTxsPrefix, versionDB),
+		log,
+		"",
+		registerer,
+		true,
+	)
+	if err != nil {
+		return nil, err
+	}
+	nextHeight, err := database.GetUInt64(versionDB, nextIndexHeightKey)
+	if err == database.ErrNotFound {
+		nextHeight = 0
+	} else if err != nil {
+		return nil, err
+	}
+
+	i := &addressTxsIndex{
+		db:      versionDB,
+		indexer: indexer,
+	}
+	i.blockFollower = &blockFollower{
+		name:       "address transactions",
+		log:        log,
+		lock:       lock,
+		utxos:      utxos,
+		process:    i.index,
+		nextHeight: nextHeight,
+	}
+	return i, versionDB.Commit()
+}
+
+// Read returns the IDs of the transactions that changed [address]'s balance
+// of [assetID], in order of acceptance.
+func (i *addressTxsIndex) Read(address ids.ShortID, assetID ids.ID, cursor, pageSize uint64) ([]ids.ID, error) {
+	if i.backfillErr != nil {
+		return nil, fmt.Errorf("couldn't backfill the index: %w", i.backfillErr)
+	}
+	return i.indexer.Read(address[:], assetID, cursor, pageSize)
+}
+
+// index indexes [blk] and persists the height of the next block to index.
+func (i *addressTxsIndex) index(blk blocks.Block) error {
+	if err := i.indexBlock(blk); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(i.db, nextIndexHeightKey, blk.Height()+1); err != nil {
+		return err
+	}
+	return i.db.Commit()
+}
+
+func (i *addressTxsIndex) indexBlock(blk blocks.Block) error {
+	appliedTxs, err := i.utxos.appliedTxs(blk)
+	if err != nil {
+		return err
+	}
+	for _, tx := range appliedTxs {
+		if err := i.indexTx(tx); err != nil {
+			return fmt.Errorf("couldn't index tx %s: %w", tx.ID(), err)
+		}
+	}
+	return nil
+}
+
+func (i *addressTxsIndex) indexTx(tx *txs.Tx) error {
+	inputUTXOs, outputUTXOs, err := i.utxos.txUTXOs(tx)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.Unsigned.(*txs.RewardValidatorTx); !ok {
+		// Staked and exported outputs also change the balances of their
+		// owners.
+		flow := &txFlow{}
+		if err := tx.Unsigned.Visit(flow); err != nil {
+			return err
+		}
+		outputUTXOs = make([]*djtx.UTXO, len(flow.outs))
+		for j, out := range flow.outs {
+			outputUTXOs[j] = &djtx.UTXO{
+				Asset: out.Asset,
+				Out:   out.Output(),
+			}
+		}
+	}
+	return i.indexer.Accept(tx.ID(), inputUTXOs, outputUTXOs)
+}
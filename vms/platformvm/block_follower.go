@@ -0,0 +1,146 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
+	blockexecutor "github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks/executor"
+)
+
+// backfillBatchSize is the number of blocks that are backfilled each time the
+// context lock is held.
+const backfillBatchSize = 256
+
+var _ blockexecutor.AcceptListener = (*blockFollower)(nil)
+
+// blockFollower processes the accepted blocks in order of height for the
+// indices that are derived from them.
+//
+// Blocks are processed as they are accepted. The blocks that were accepted
+// before the index was enabled are backfilled in the background,
+// [backfillBatchSize] blocks at a time, and the blocks accepted while
+// backfilling are processed by the backfill.
+type blockFollower struct {
+	name  string
+	log   logging.Logger
+	lock  sync.Locker
+	utxos *acceptedUTXOs
+	// process processes [blk] and persists that it was processed.
+	process func(blk blocks.Block) error
+
+	// Height of the next block to process. Only accessed while holding
+	// [lock].
+	nextHeight uint64
+	// Error that stopped the backfill, if any. Only accessed while holding
+	// [lock].
+	backfillErr error
+
+	closed    utils.AtomicBool
+	backfills sync.WaitGroup
+}
+
+// Accepted processes [blk] unless the blocks before it are still being
+// backfilled.
+func (f *blockFollower) Accepted(blk blocks.Block) error {
+	if blk.Height() != f.nextHeight {
+		return nil
+	}
+	return f.processBlock(blk)
+}
+
+// startBackfill processes the accepted blocks that weren't processed yet in
+// the background.
+func (f *blockFollower) startBackfill() {
+	f.backfills.Add(1)
+	go func() {
+		defer f.backfills.Done()
+
+		for !f.closed.GetValue() {
+			f.lock.Lock()
+			done, err := f.backfillBatch()
+			if err != nil {
+				f.backfillErr = err
+			}
+			f.lock.Unlock()
+
+			if err != nil {
+				f.log.Error("failed to backfill",
+					zap.String("index", f.name),
+					zap.Error(err),
+				)
+				return
+			}
+			if done {
+				return
+			}
+		}
+	}()
+}
+
+// backfillBatch processes up to [backfillBatchSize] accepted blocks and
+// returns true once the last accepted block is processed.
+//
+// Assumes [lock] is held.
+func (f *blockFollower) backfillBatch() (bool, error) {
+	lastAcceptedHeight, err := f.utxos.lastAcceptedHeight()
+	if err != nil {
+		return false, err
+	}
+	if f.nextHeight > lastAcceptedHeight {
+		return true, nil
+	}
+
+	endHeight := lastAcceptedHeight
+	if maxHeight := f.nextHeight + backfillBatchSize - 1; endHeight > maxHeight {
+		endHeight = maxHeight
+	}
+	f.log.Info("backfilling",
+		zap.String("index", f.name),
+		zap.Uint64("startHeight", f.nextHeight),
+		zap.Uint64("endHeight", endHeight),
+		zap.Uint64("lastAcceptedHeight", lastAcceptedHeight),
+	)
+	for f.nextHeight <= endHeight {
+		blkID, err := f.utxos.state.GetBlockIDAtHeight(f.nextHeight)
+		if err != nil {
+			return false, fmt.Errorf("couldn't get block at height %d: %w", f.nextHeight, err)
+		}
+		blk, _, err := f.utxos.state.GetStatelessBlock(blkID)
+		if err != nil {
+			return false, err
+		}
+		if err := f.processBlock(blk); err != nil {
+			return false, err
+		}
+	}
+	return f.nextHeight > lastAcceptedHeight, nil
+}
+
+func (f *blockFollower) processBlock(blk blocks.Block) error {
+	if err := f.process(blk); err != nil {
+		return fmt.Errorf("couldn't process block %s: %w", blk.ID(), err)
+	}
+	f.nextHeight = blk.Height() + 1
+	return nil
+}
+
+// shutdown stops the backfill.
+//
+// Assumes [lock] is held.
+func (f *blockFollower) shutdown() {
+	f.closed.SetValue(true)
+
+	// The backfill may be waiting for the lock, so the lock must be released
+	// while waiting for the backfill to stop.
+	f.lock.Unlock()
+	f.backfills.Wait()
+	f.lock.Lock()
+}
@@ -33,13 +33,30 @@ func NewBanffAbortBlock(
 	timestamp time.Time,
 	parentID ids.ID,
 	height uint64,
+) (*BanffAbortBlock, error) {
+	return NewBanffAbortBlockWithStateRoot(
+		timestamp,
+		parentID,
+		height,
+		ids.Empty,
+	)
+}
+
+// NewBanffAbortBlockWithStateRoot returns a block that commits to
+// [parentStateRoot], the root of the state tree once [parentID] is accepted.
+func NewBanffAbortBlockWithStateRoot(
+	timestamp time.Time,
+	parentID ids.ID,
+	height uint64,
+	parentStateRoot ids.ID,
 ) (*BanffAbortBlock, error) {
 	blk := &BanffAbortBlock{
 		Time: uint64(timestamp.Unix()),
 		ApricotAbortBlock: ApricotAbortBlock{
 			CommonBlock: CommonBlock{
-				PrntID: parentID,
-				Hght:   height,
+				PrntID:        parentID,
+				Hght:          height,
+				PrntStateRoot: parentStateRoot,
 			},
 		},
 	}
@@ -4,6 +4,7 @@
 package blocks
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -20,6 +21,11 @@ type Block interface {
 	Bytes() []byte
 	Height() uint64
 
+	// ParentStateRoot returns the root of the state tree once the parent of
+	// this block is accepted, or [ids.Empty] if this block doesn't commit to
+	// a state root.
+	ParentStateRoot() ids.ID
+
 	// Txs returns list of transactions contained in the block
 	Txs() []*txs.Tx
 
@@ -31,6 +37,8 @@ type Block interface {
 	initialize(bytes []byte) error
 }
 
+var errWrongCodecVersion = errors.New("wrong codec version")
+
 type BanffBlock interface {
 	Block
 	Timestamp() time.Time
@@ -39,9 +47,23 @@ type BanffBlock interface {
 func initialize(blk Block) error {
 	// We serialize this block as a pointer so that it can be deserialized into
 	// a Block
-	bytes, err := Codec.Marshal(Version, &blk)
+	bytes, err := Codec.Marshal(codecVersion(blk), &blk)
 	if err != nil {
 		return fmt.Errorf("couldn't marshal block: %w", err)
 	}
 	return blk.initialize(bytes)
 }
+
+// codecVersion returns the codec version [blk] is serialized with, which is
+// the version required by its state root or by its txs.
+func codecVersion(blk Block) uint16 {
+	if blk.ParentStateRoot() != ids.Empty {
+		return StateRootVersion
+	}
+	for _, tx := range blk.Txs() {
+		if txs.CodecVersion(tx.Unsigned) == txs.V1Version {
+			return V1Version
+		}
+	}
+	return Version
+}
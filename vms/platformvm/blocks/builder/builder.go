@@ -27,9 +27,9 @@ import (
 	txexecutor "github.com/lasthyphen/dijetsnodego/vms/platformvm/txs/executor"
 )
 
-// targetBlockSize is maximum number of transaction bytes to place into a
+// TargetBlockSize is maximum number of transaction bytes to place into a
 // StandardBlock
-const targetBlockSize = 128 * units.KiB
+const TargetBlockSize = 128 * units.KiB
 
 var (
 	_ Builder = (*builder)(nil)
@@ -52,6 +52,10 @@ type Builder interface {
 	// AddUnverifiedTx verifier the tx before adding it to mempool
 	AddUnverifiedTx(tx *txs.Tx) error
 
+	// VerifyTx verifies the tx against the preferred state without adding it
+	// to the mempool
+	VerifyTx(tx *txs.Tx) error
+
 	// BuildBlock is called on timer clock to attempt to create
 	// next block
 	BuildBlock(context.Context) (snowman.Block, error)
@@ -131,13 +135,7 @@ func (b *builder) AddUnverifiedTx(tx *txs.Tx) error {
 		return nil
 	}
 
-	verifier := txexecutor.MempoolTxVerifier{
-		Backend:       b.txExecutorBackend,
-		ParentID:      b.preferredBlockID, // We want to build off of the preferred block
-		StateVersions: b.blkManager,
-		Tx:            tx,
-	}
-	if err := tx.Unsigned.Visit(&verifier); err != nil {
+	if err := b.VerifyTx(tx); err != nil {
 		b.MarkDropped(txID, err.Error())
 		return err
 	}
@@ -148,6 +146,16 @@ func (b *builder) AddUnverifiedTx(tx *txs.Tx) error {
 	return b.GossipTx(tx)
 }
 
+func (b *builder) VerifyTx(tx *txs.Tx) error {
+	verifier := txexecutor.MempoolTxVerifier{
+		Backend:       b.txExecutorBackend,
+		ParentID:      b.preferredBlockID, // We want to build off of the preferred block
+		StateVersions: b.blkManager,
+		Tx:            tx,
+	}
+	return tx.Unsigned.Visit(&verifier)
+}
+
 // BuildBlock builds a block to be added to consensus.
 // This method removes the transactions from the returned
 // blocks from the mempool.
@@ -210,6 +218,16 @@ func (b *builder) buildBlock() (blocks.Block, error) {
 	}
 	// [timestamp] = min(max(now, parentTime), nextStakerChangeTime)
 
+	// Blocks commit to the state root of their parent once state roots are
+	// activated.
+	preferredStateRoot := ids.Empty
+	if b.txExecutorBackend.Config.IsStateRootActivated(timestamp) {
+		preferredStateRoot, err = preferredState.GetStateRoot()
+		if err != nil {
+			return nil, fmt.Errorf("could not calculate state root: %w", err)
+		}
+	}
+
 	return buildBlock(
 		b,
 		preferredID,
@@ -217,6 +235,7 @@ func (b *builder) buildBlock() (blocks.Block, error) {
 		timestamp,
 		timeWasCapped,
 		preferredState,
+		preferredStateRoot,
 	)
 }
 
@@ -336,6 +355,7 @@ func buildBlock(
 	timestamp time.Time,
 	forceAdvanceTime bool,
 	parentState state.Chain,
+	parentStateRoot ids.ID,
 ) (blocks.Block, error) {
 	// Try rewarding stakers whose staking period ends at the new chain time.
 	// This is done first to prioritize advancing the timestamp as quickly as
@@ -350,16 +370,18 @@ func buildBlock(
 			return nil, fmt.Errorf("could not build tx to reward staker: %w", err)
 		}
 
-		return blocks.NewBanffProposalBlock(
+		return blocks.NewBanffProposalBlockWithStateRoot(
 			timestamp,
 			parentID,
 			height,
+			parentStateRoot,
 			rewardValidatorTx,
 		)
 	}
 
 	// Clean out the mempool's transactions with invalid timestamps.
 	builder.dropExpiredStakerTxs(timestamp)
+	builder.Mempool.RemoveExpiredTxs(timestamp)
 
 	// If there is no reason to build a block, don't.
 	if !builder.Mempool.HasTxs() && !forceAdvanceTime {
@@ -368,11 +390,12 @@ func buildBlock(
 	}
 
 	// Issue a block with as many transactions as possible.
-	return blocks.NewBanffStandardBlock(
+	return blocks.NewBanffStandardBlockWithStateRoot(
 		timestamp,
 		parentID,
 		height,
-		builder.Mempool.PeekTxs(targetBlockSize),
+		parentStateRoot,
+		builder.Mempool.PeekTxs(TargetBlockSize),
 	)
 }
 
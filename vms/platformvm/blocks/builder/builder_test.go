@@ -412,8 +412,9 @@ func TestBuildBlock(t *testing.T) {
 
 				// There are txs.
 				mempool.EXPECT().HasStakerTx().Return(false)
+				mempool.EXPECT().RemoveExpiredTxs(gomock.Any())
 				mempool.EXPECT().HasTxs().Return(true)
-				mempool.EXPECT().PeekTxs(targetBlockSize).Return(transactions)
+				mempool.EXPECT().PeekTxs(TargetBlockSize).Return(transactions)
 				return &builder{
 					Mempool: mempool,
 				}
@@ -459,6 +460,7 @@ func TestBuildBlock(t *testing.T) {
 
 				// There are no txs.
 				mempool.EXPECT().HasStakerTx().Return(false)
+				mempool.EXPECT().RemoveExpiredTxs(gomock.Any())
 				mempool.EXPECT().HasTxs().Return(false)
 
 				clk := &mockable.Clock{}
@@ -507,8 +509,9 @@ func TestBuildBlock(t *testing.T) {
 
 				// There are no txs.
 				mempool.EXPECT().HasStakerTx().Return(false)
+				mempool.EXPECT().RemoveExpiredTxs(gomock.Any())
 				mempool.EXPECT().HasTxs().Return(false)
-				mempool.EXPECT().PeekTxs(targetBlockSize).Return(nil)
+				mempool.EXPECT().PeekTxs(TargetBlockSize).Return(nil)
 
 				clk := &mockable.Clock{}
 				clk.Set(now)
@@ -562,8 +565,9 @@ func TestBuildBlock(t *testing.T) {
 
 				// There is a tx.
 				mempool.EXPECT().HasStakerTx().Return(false)
+				mempool.EXPECT().RemoveExpiredTxs(gomock.Any())
 				mempool.EXPECT().HasTxs().Return(true)
-				mempool.EXPECT().PeekTxs(targetBlockSize).Return([]*txs.Tx{transactions[0]})
+				mempool.EXPECT().PeekTxs(TargetBlockSize).Return([]*txs.Tx{transactions[0]})
 
 				clk := &mockable.Clock{}
 				clk.Set(now)
@@ -616,8 +620,9 @@ func TestBuildBlock(t *testing.T) {
 				// There are no decision txs
 				// There is a staker tx.
 				mempool.EXPECT().HasStakerTx().Return(false)
+				mempool.EXPECT().RemoveExpiredTxs(gomock.Any())
 				mempool.EXPECT().HasTxs().Return(true)
-				mempool.EXPECT().PeekTxs(targetBlockSize).Return([]*txs.Tx{transactions[0]})
+				mempool.EXPECT().PeekTxs(TargetBlockSize).Return([]*txs.Tx{transactions[0]})
 
 				clk := &mockable.Clock{}
 				clk.Set(now)
@@ -677,6 +682,7 @@ func TestBuildBlock(t *testing.T) {
 				tt.timestamp,
 				tt.forceAdvanceTime,
 				tt.parentStateF(ctrl),
+				ids.Empty,
 			)
 			if tt.expectedErr != nil {
 				require.ErrorIs(err, tt.expectedErr)
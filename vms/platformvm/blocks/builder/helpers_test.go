@@ -106,6 +106,10 @@ type environment struct {
 	backend        txexecutor.Backend
 }
 
+func (*environment) PriorityFee(*txs.Tx) (uint64, error) {
+	return 0, nil
+}
+
 // TODO snLookup currently duplicated in vm_test.go. Consider removing duplication
 type snLookup struct {
 	chainsToSubnet map[ids.ID]ids.ID
@@ -180,7 +184,7 @@ func newEnvironment(t *testing.T) *environment {
 		panic(fmt.Errorf("failed to create metrics: %w", err))
 	}
 
-	res.mempool, err = mempool.NewMempool("mempool", registerer, res)
+	res.mempool, err = mempool.NewMempool("mempool", registerer, res, res)
 	if err != nil {
 		panic(fmt.Errorf("failed to create mempool: %w", err))
 	}
@@ -257,6 +261,7 @@ func defaultState(
 		genesisBytes,
 		prometheus.NewRegistry(),
 		cfg,
+		&config.ExecutionConfig{},
 		ctx,
 		metrics.Noop,
 		rewards,
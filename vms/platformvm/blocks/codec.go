@@ -8,12 +8,31 @@ import (
 
 	"github.com/lasthyphen/dijetsnodego/codec"
 	"github.com/lasthyphen/dijetsnodego/codec/linearcodec"
+	"github.com/lasthyphen/dijetsnodego/codec/reflectcodec"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 )
 
-// Version is the current default codec version
-const Version = txs.Version
+const (
+	// Version is the current default codec version
+	Version = txs.Version
+
+	// V1Version is the codec version of blocks that contain txs serialized
+	// with [txs.V1Version]
+	V1Version = txs.V1Version
+
+	// StateRootVersion is the codec version of blocks that commit to the state
+	// root of their parent. In addition to the fields serialized by
+	// [V1Version], it serializes the fields tagged with [StateRootTagName].
+	StateRootVersion = V1Version + 1
+
+	// StateRootTagName is the tag of the fields only serialized by
+	// [StateRootVersion]
+	StateRootTagName = reflectcodec.DefaultTagName + "V2"
+
+	maxSliceLen = 256 * units.KiB
+)
 
 // GenesisCode allows blocks of larger than usual size to be parsed.
 // While this gives flexibility in accommodating large genesis blocks
@@ -25,13 +44,20 @@ var (
 )
 
 func init() {
+	v1TagNames := []string{reflectcodec.DefaultTagName, txs.V1TagName}
+	stateRootTagNames := []string{reflectcodec.DefaultTagName, txs.V1TagName, StateRootTagName}
+
 	c := linearcodec.NewDefault()
+	c1 := linearcodec.New(v1TagNames, maxSliceLen)
+	c2 := linearcodec.New(stateRootTagNames, maxSliceLen)
 	Codec = codec.NewDefaultManager()
 	gc := linearcodec.NewCustomMaxLength(math.MaxInt32)
+	gc1 := linearcodec.New(v1TagNames, math.MaxInt32)
+	gc2 := linearcodec.New(stateRootTagNames, math.MaxInt32)
 	GenesisCodec = codec.NewManager(math.MaxInt32)
 
 	errs := wrappers.Errs{}
-	for _, c := range []codec.Registry{c, gc} {
+	for _, c := range []codec.Registry{c, c1, c2, gc, gc1, gc2} {
 		errs.Add(
 			RegisterApricotBlockTypes(c),
 			txs.RegisterUnsignedTxsTypes(c),
@@ -40,7 +66,11 @@ func init() {
 	}
 	errs.Add(
 		Codec.RegisterCodec(Version, c),
+		Codec.RegisterCodec(V1Version, c1),
+		Codec.RegisterCodec(StateRootVersion, c2),
 		GenesisCodec.RegisterCodec(Version, gc),
+		GenesisCodec.RegisterCodec(V1Version, gc1),
+		GenesisCodec.RegisterCodec(StateRootVersion, gc2),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
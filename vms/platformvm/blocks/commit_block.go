@@ -33,13 +33,30 @@ func NewBanffCommitBlock(
 	timestamp time.Time,
 	parentID ids.ID,
 	height uint64,
+) (*BanffCommitBlock, error) {
+	return NewBanffCommitBlockWithStateRoot(
+		timestamp,
+		parentID,
+		height,
+		ids.Empty,
+	)
+}
+
+// NewBanffCommitBlockWithStateRoot returns a block that commits to
+// [parentStateRoot], the root of the state tree once [parentID] is accepted.
+func NewBanffCommitBlockWithStateRoot(
+	timestamp time.Time,
+	parentID ids.ID,
+	height uint64,
+	parentStateRoot ids.ID,
 ) (*BanffCommitBlock, error) {
 	blk := &BanffCommitBlock{
 		Time: uint64(timestamp.Unix()),
 		ApricotCommitBlock: ApricotCommitBlock{
 			CommonBlock: CommonBlock{
-				PrntID: parentID,
-				Hght:   height,
+				PrntID:        parentID,
+				Hght:          height,
+				PrntStateRoot: parentStateRoot,
 			},
 		},
 	}
@@ -16,6 +16,10 @@ type CommonBlock struct {
 	// This block's height. The genesis block is at height 0.
 	Hght uint64 `serialize:"true" json:"height"`
 
+	// Root of the state tree once the parent is accepted. Only serialized by
+	// [StateRootVersion].
+	PrntStateRoot ids.ID `serializeV2:"true" json:"parentStateRoot"`
+
 	id    ids.ID
 	bytes []byte
 }
@@ -40,3 +44,7 @@ func (b *CommonBlock) Bytes() []byte {
 func (b *CommonBlock) Height() uint64 {
 	return b.Hght
 }
+
+func (b *CommonBlock) ParentStateRoot() ids.ID {
+	return b.PrntStateRoot
+}
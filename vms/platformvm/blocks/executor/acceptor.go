@@ -19,6 +19,13 @@ import (
 
 var _ blocks.Visitor = (*acceptor)(nil)
 
+// AcceptListener is notified of the accepted blocks, in order of height, once
+// their changes were committed to the state. A proposal block is notified
+// with its accepted option.
+type AcceptListener interface {
+	Accepted(blk blocks.Block) error
+}
+
 // acceptor handles the logic for accepting a block.
 // All errors returned by this struct are fatal and should result in the chain
 // being shutdown.
@@ -27,6 +34,7 @@ type acceptor struct {
 	metrics          metrics.Metrics
 	recentlyAccepted window.Window[ids.ID]
 	bootstrapped     *utils.AtomicBool
+	listeners        []AcceptListener
 }
 
 func (a *acceptor) BanffAbortBlock(b *blocks.BanffAbortBlock) error {
@@ -170,7 +178,7 @@ func (a *acceptor) ApricotAtomicBlock(b *blocks.ApricotAtomicBlock) error {
 			err,
 		)
 	}
-	return nil
+	return a.notify(b)
 }
 
 func (a *acceptor) abortBlock(b blocks.Block) error {
@@ -234,7 +242,10 @@ func (a *acceptor) optionBlock(b, parent blocks.Block) error {
 		return fmt.Errorf("couldn't find state of block %s", blkID)
 	}
 	blkState.onAcceptState.Apply(a.state)
-	return a.state.Commit()
+	if err := a.state.Commit(); err != nil {
+		return err
+	}
+	return a.notify(parent, b)
 }
 
 func (a *acceptor) proposalBlock(b blocks.Block) {
@@ -291,7 +302,7 @@ func (a *acceptor) standardBlock(b blocks.Block) error {
 	if onAcceptFunc := blkState.onAcceptFunc; onAcceptFunc != nil {
 		onAcceptFunc()
 	}
-	return nil
+	return a.notify(b)
 }
 
 func (a *acceptor) commonAccept(b blocks.Block) error {
@@ -308,3 +319,15 @@ func (a *acceptor) commonAccept(b blocks.Block) error {
 	a.recentlyAccepted.Add(blkID)
 	return nil
 }
+
+// notify informs the listeners that [blks] were accepted, in order.
+func (a *acceptor) notify(blks ...blocks.Block) error {
+	for _, blk := range blks {
+		for _, listener := range a.listeners {
+			if err := listener.Accepted(blk); err != nil {
+				return fmt.Errorf("failed to notify the acceptance of block %s: %w", blk.ID(), err)
+			}
+		}
+	}
+	return nil
+}
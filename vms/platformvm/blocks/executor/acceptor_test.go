@@ -28,6 +28,15 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
 )
 
+type testAcceptListener struct {
+	accepted []blocks.Block
+}
+
+func (l *testAcceptListener) Accepted(blk blocks.Block) error {
+	l.accepted = append(l.accepted, blk)
+	return nil
+}
+
 func TestAcceptorVisitProposalBlock(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -173,6 +182,7 @@ func TestAcceptorVisitStandardBlock(t *testing.T) {
 
 	parentID := ids.GenerateTestID()
 	clk := &mockable.Clock{}
+	listener := &testAcceptListener{}
 	acceptor := &acceptor{
 		backend: &backend{
 			lastAccepted: parentID,
@@ -189,6 +199,7 @@ func TestAcceptorVisitStandardBlock(t *testing.T) {
 			MaxSize: 1,
 			TTL:     time.Hour,
 		}),
+		listeners: []AcceptListener{listener},
 	}
 
 	blk, err := blocks.NewBanffStandardBlock(
@@ -215,6 +226,7 @@ func TestAcceptorVisitStandardBlock(t *testing.T) {
 
 	err = acceptor.BanffStandardBlock(blk)
 	require.Error(err, "should fail because the block isn't in the state map")
+	require.Empty(listener.accepted)
 
 	// Set [blk]'s state in the map as though it had been verified.
 	onAcceptState := state.NewMockDiff(ctrl)
@@ -257,6 +269,7 @@ func TestAcceptorVisitStandardBlock(t *testing.T) {
 	require.NoError(err)
 	require.True(calledOnAcceptFunc)
 	require.Equal(blk.ID(), acceptor.backend.lastAccepted)
+	require.Equal([]blocks.Block{blk}, listener.accepted)
 }
 
 func TestAcceptorVisitCommitBlock(t *testing.T) {
@@ -128,6 +128,10 @@ func (*environment) ResetBlockTimer() {
 	// dummy call, do nothing for now
 }
 
+func (*environment) PriorityFee(*txs.Tx) (uint64, error) {
+	return 0, nil
+}
+
 // TODO snLookup currently duplicated in vm_test.go. Consider removing duplication
 type snLookup struct {
 	chainsToSubnet map[ids.ID]ids.ID
@@ -213,7 +217,7 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller) *environment {
 	metrics := metrics.Noop
 
 	var err error
-	res.mempool, err = mempool.NewMempool("mempool", registerer, res)
+	res.mempool, err = mempool.NewMempool("mempool", registerer, res, res)
 	if err != nil {
 		panic(fmt.Errorf("failed to create mempool: %w", err))
 	}
@@ -292,6 +296,7 @@ func defaultState(
 		genesisBytes,
 		prometheus.NewRegistry(),
 		cfg,
+		&config.ExecutionConfig{},
 		ctx,
 		metrics.Noop,
 		rewards,
@@ -361,6 +366,7 @@ func defaultConfig() *config.Config {
 		ApricotPhase3Time: defaultValidateEndTime,
 		ApricotPhase5Time: defaultValidateEndTime,
 		BanffTime:         mockable.MaxTime,
+		StateRootTime:     mockable.MaxTime,
 	}
 }
 
@@ -32,6 +32,7 @@ func NewManager(
 	s state.State,
 	txExecutorBackend *executor.Backend,
 	recentlyAccepted window.Window[ids.ID],
+	listeners ...AcceptListener,
 ) Manager {
 	backend := &backend{
 		Mempool:      mempool,
@@ -52,6 +53,7 @@ func NewManager(
 			metrics:          metrics,
 			recentlyAccepted: recentlyAccepted,
 			bootstrapped:     txExecutorBackend.Bootstrapped,
+			listeners:        listeners,
 		},
 		rejector: &rejector{backend: backend},
 	}
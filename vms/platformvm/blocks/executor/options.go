@@ -31,9 +31,12 @@ func (o *options) BanffProposalBlock(b *blocks.BanffProposalBlock) error {
 	timestamp := b.Timestamp()
 	blkID := b.ID()
 	nextHeight := b.Height() + 1
+	// Accepting [b] doesn't modify the state, so the options commit to the
+	// same state root as [b].
+	parentStateRoot := b.ParentStateRoot()
 
 	var err error
-	o.commitBlock, err = blocks.NewBanffCommitBlock(timestamp, blkID, nextHeight)
+	o.commitBlock, err = blocks.NewBanffCommitBlockWithStateRoot(timestamp, blkID, nextHeight, parentStateRoot)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to create commit block: %w",
@@ -41,7 +44,7 @@ func (o *options) BanffProposalBlock(b *blocks.BanffProposalBlock) error {
 		)
 	}
 
-	o.abortBlock, err = blocks.NewBanffAbortBlock(timestamp, blkID, nextHeight)
+	o.abortBlock, err = blocks.NewBanffAbortBlockWithStateRoot(timestamp, blkID, nextHeight, parentStateRoot)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to create abort block: %w",
@@ -27,6 +27,7 @@ var (
 	errConflictingBatchTxs                        = errors.New("block contains conflicting transactions")
 	errConflictingParentTxs                       = errors.New("block contains a transaction that conflicts with a transaction in a parent block")
 	errOptionBlockTimestampNotMatchingParent      = errors.New("option block proposed timestamp not matching parent block one")
+	errWrongParentStateRoot                       = errors.New("block commits to the wrong parent state root")
 )
 
 // verifier handles the logic for verifying a block.
@@ -241,7 +242,14 @@ func (v *verifier) banffOptionBlock(b blocks.BanffBlock) error {
 			blkTime,
 		)
 	}
-	return nil
+
+	// Accepting the BanffProposalBlock doesn't modify the state, so the option
+	// blocks commit to the same state root as their parent.
+	parent, err := v.GetBlock(parentID)
+	if err != nil {
+		return err
+	}
+	return verifyParentStateRoot(b, parent.ParentStateRoot())
 }
 
 func (v *verifier) banffNonOptionBlock(b blocks.BanffBlock) error {
@@ -266,6 +274,18 @@ func (v *verifier) banffNonOptionBlock(b blocks.BanffBlock) error {
 		)
 	}
 
+	expectedParentStateRoot := ids.Empty
+	if v.txExecutorBackend.Config.IsStateRootActivated(newChainTime) {
+		var err error
+		expectedParentStateRoot, err = parentState.GetStateRoot()
+		if err != nil {
+			return fmt.Errorf("could not calculate parent state root: %w", err)
+		}
+	}
+	if err := verifyParentStateRoot(b, expectedParentStateRoot); err != nil {
+		return err
+	}
+
 	nextStakerChangeTime, err := executor.GetNextStakerChangeTime(parentState)
 	if err != nil {
 		return fmt.Errorf("could not verify block timestamp: %w", err)
@@ -293,6 +313,9 @@ func (v *verifier) apricotCommonBlock(b blocks.Block) error {
 	if v.txExecutorBackend.Config.IsBanffActivated(timestamp) {
 		return fmt.Errorf("%w: timestamp = %s", errApricotBlockIssuedAfterFork, timestamp)
 	}
+	if err := verifyParentStateRoot(b, ids.Empty); err != nil {
+		return err
+	}
 	return v.commonBlock(b)
 }
 
@@ -315,6 +338,18 @@ func (v *verifier) commonBlock(b blocks.Block) error {
 	return nil
 }
 
+func verifyParentStateRoot(b blocks.Block, expectedParentStateRoot ids.ID) error {
+	if parentStateRoot := b.ParentStateRoot(); parentStateRoot != expectedParentStateRoot {
+		return fmt.Errorf(
+			"%w: expected %s but found %s",
+			errWrongParentStateRoot,
+			expectedParentStateRoot,
+			parentStateRoot,
+		)
+	}
+	return nil
+}
+
 // abortBlock populates the state of this block if [nil] is returned
 func (v *verifier) abortBlock(b blocks.Block) error {
 	parentID := b.Parent()
@@ -585,6 +585,7 @@ func TestBanffAbortBlockTimestampChecks(t *testing.T) {
 
 			// Set expectations for dependencies.
 			parentStatelessBlk.EXPECT().Height().Return(uint64(1)).Times(1)
+			parentStatelessBlk.EXPECT().ParentStateRoot().Return(ids.Empty).AnyTimes()
 
 			err = statelessAbortBlk.Visit(verifier)
 			require.ErrorIs(err, test.result)
@@ -679,6 +680,7 @@ func TestBanffCommitBlockTimestampChecks(t *testing.T) {
 
 			// Set expectations for dependencies.
 			parentStatelessBlk.EXPECT().Height().Return(uint64(1)).Times(1)
+			parentStatelessBlk.EXPECT().ParentStateRoot().Return(ids.Empty).AnyTimes()
 
 			err = statelessCommitBlk.Visit(verifier)
 			require.ErrorIs(err, test.result)
@@ -987,6 +989,7 @@ func TestVerifierVisitBanffCommitBlockUnexpectedParentState(t *testing.T) {
 
 	// Set expectations for dependencies.
 	parentStatelessBlk.EXPECT().Height().Return(uint64(1)).Times(1)
+	parentStatelessBlk.EXPECT().ParentStateRoot().Return(ids.Empty).Times(1)
 
 	// Verify the block.
 	err = verifier.BanffCommitBlock(blk)
@@ -1076,6 +1079,7 @@ func TestVerifierVisitBanffAbortBlockUnexpectedParentState(t *testing.T) {
 
 	// Set expectations for dependencies.
 	parentStatelessBlk.EXPECT().Height().Return(uint64(1)).Times(1)
+	parentStatelessBlk.EXPECT().ParentStateRoot().Return(ids.Empty).Times(1)
 
 	// Verify the block.
 	err = verifier.BanffAbortBlock(blk)
@@ -107,6 +107,20 @@ func (mr *MockBlockMockRecorder) Parent() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parent", reflect.TypeOf((*MockBlock)(nil).Parent))
 }
 
+// ParentStateRoot mocks base method.
+func (m *MockBlock) ParentStateRoot() ids.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParentStateRoot")
+	ret0, _ := ret[0].(ids.ID)
+	return ret0
+}
+
+// ParentStateRoot indicates an expected call of ParentStateRoot.
+func (mr *MockBlockMockRecorder) ParentStateRoot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParentStateRoot", reflect.TypeOf((*MockBlock)(nil).ParentStateRoot))
+}
+
 // Txs mocks base method.
 func (m *MockBlock) Txs() []*txs.Tx {
 	m.ctrl.T.Helper()
@@ -4,13 +4,25 @@
 package blocks
 
 import (
+	"fmt"
+
 	"github.com/lasthyphen/dijetsnodego/codec"
 )
 
 func Parse(c codec.Manager, b []byte) (Block, error) {
 	var blk Block
-	if _, err := c.Unmarshal(b, &blk); err != nil {
+	version, err := c.Unmarshal(b, &blk)
+	if err != nil {
 		return nil, err
 	}
+	// Blocks must be serialized with the only version that can represent
+	// them so that they have a unique ID.
+	if expectedVersion := codecVersion(blk); version != expectedVersion {
+		return nil, fmt.Errorf("%w: expected %d but got %d",
+			errWrongCodecVersion,
+			expectedVersion,
+			version,
+		)
+	}
 	return blk, blk.initialize(b)
 }
@@ -253,6 +253,80 @@ func TestAtomicBlock(t *testing.T) {
 	}
 }
 
+func TestExpiringTxsBlock(t *testing.T) {
+	require := require.New(t)
+	blkTimestamp := time.Now()
+	parentID := ids.ID{'p', 'a', 'r', 'e', 'n', 't', 'I', 'D'}
+	height := uint64(2022)
+	decisionTxs, err := testDecisionTxs()
+	require.NoError(err)
+
+	// A block without expiring txs keeps the default codec version.
+	var blk Block
+	blk, err = NewBanffStandardBlock(blkTimestamp, parentID, height, decisionTxs)
+	require.NoError(err)
+	wrongVersionBytes, err := Codec.Marshal(V1Version, &blk)
+	require.NoError(err)
+	_, err = Parse(Codec, wrongVersionBytes)
+	require.ErrorIs(err, errWrongCodecVersion)
+
+	utx := decisionTxs[0].Unsigned.(*txs.CreateChainTx)
+	utx.Expiry = uint64(blkTimestamp.Unix())
+	expiringTx, err := txs.NewSigned(utx, txs.Codec, nil)
+	require.NoError(err)
+	decisionTxs[0] = expiringTx
+
+	for _, cdc := range []codec.Manager{Codec, GenesisCodec} {
+		blk, err := NewBanffStandardBlock(blkTimestamp, parentID, height, decisionTxs)
+		require.NoError(err)
+
+		parsed, err := Parse(cdc, blk.Bytes())
+		require.NoError(err)
+		require.Equal(blk.ID(), parsed.ID())
+		require.Equal(blk.Bytes(), parsed.Bytes())
+		require.Equal(expiringTx.ID(), parsed.Txs()[0].ID())
+		require.Equal(utx.Expiry, txs.ExpiryOf(parsed.Txs()[0].Unsigned))
+	}
+}
+
+func TestStateRootBlock(t *testing.T) {
+	require := require.New(t)
+	blkTimestamp := time.Now()
+	parentID := ids.ID{'p', 'a', 'r', 'e', 'n', 't', 'I', 'D'}
+	parentStateRoot := ids.ID{'r', 'o', 'o', 't'}
+	height := uint64(2022)
+	decisionTxs, err := testDecisionTxs()
+	require.NoError(err)
+
+	// A block without a state root keeps the previous codec version.
+	var blk Block
+	blk, err = NewBanffStandardBlock(blkTimestamp, parentID, height, decisionTxs)
+	require.NoError(err)
+	require.Equal(ids.Empty, blk.ParentStateRoot())
+	wrongVersionBytes, err := Codec.Marshal(StateRootVersion, &blk)
+	require.NoError(err)
+	_, err = Parse(Codec, wrongVersionBytes)
+	require.ErrorIs(err, errWrongCodecVersion)
+
+	for _, cdc := range []codec.Manager{Codec, GenesisCodec} {
+		blk, err := NewBanffStandardBlockWithStateRoot(blkTimestamp, parentID, height, parentStateRoot, decisionTxs)
+		require.NoError(err)
+
+		parsed, err := Parse(cdc, blk.Bytes())
+		require.NoError(err)
+		require.Equal(blk.ID(), parsed.ID())
+		require.Equal(blk.Bytes(), parsed.Bytes())
+		require.Equal(parentStateRoot, parsed.ParentStateRoot())
+
+		// A block with a state root must use the state root codec version.
+		var wrongVersionBlk Block = blk
+		wrongVersionBytes, err := cdc.Marshal(V1Version, &wrongVersionBlk)
+		require.NoError(err)
+		_, err = Parse(cdc, wrongVersionBytes)
+		require.ErrorIs(err, errWrongCodecVersion)
+	}
+}
+
 func testAtomicTx() (*txs.Tx, error) {
 	utx := &txs.ImportTx{
 		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
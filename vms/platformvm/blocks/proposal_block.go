@@ -48,13 +48,32 @@ func NewBanffProposalBlock(
 	parentID ids.ID,
 	height uint64,
 	tx *txs.Tx,
+) (*BanffProposalBlock, error) {
+	return NewBanffProposalBlockWithStateRoot(
+		timestamp,
+		parentID,
+		height,
+		ids.Empty,
+		tx,
+	)
+}
+
+// NewBanffProposalBlockWithStateRoot returns a block that commits to
+// [parentStateRoot], the root of the state tree once [parentID] is accepted.
+func NewBanffProposalBlockWithStateRoot(
+	timestamp time.Time,
+	parentID ids.ID,
+	height uint64,
+	parentStateRoot ids.ID,
+	tx *txs.Tx,
 ) (*BanffProposalBlock, error) {
 	blk := &BanffProposalBlock{
 		Time: uint64(timestamp.Unix()),
 		ApricotProposalBlock: ApricotProposalBlock{
 			CommonBlock: CommonBlock{
-				PrntID: parentID,
-				Hght:   height,
+				PrntID:        parentID,
+				Hght:          height,
+				PrntStateRoot: parentStateRoot,
 			},
 			Tx: tx,
 		},
@@ -35,13 +35,32 @@ func NewBanffStandardBlock(
 	parentID ids.ID,
 	height uint64,
 	txs []*txs.Tx,
+) (*BanffStandardBlock, error) {
+	return NewBanffStandardBlockWithStateRoot(
+		timestamp,
+		parentID,
+		height,
+		ids.Empty,
+		txs,
+	)
+}
+
+// NewBanffStandardBlockWithStateRoot returns a block that commits to
+// [parentStateRoot], the root of the state tree once [parentID] is accepted.
+func NewBanffStandardBlockWithStateRoot(
+	timestamp time.Time,
+	parentID ids.ID,
+	height uint64,
+	parentStateRoot ids.ID,
+	txs []*txs.Tx,
 ) (*BanffStandardBlock, error) {
 	blk := &BanffStandardBlock{
 		Time: uint64(timestamp.Unix()),
 		ApricotStandardBlock: ApricotStandardBlock{
 			CommonBlock: CommonBlock{
-				PrntID: parentID,
-				Hght:   height,
+				PrntID:        parentID,
+				Hght:          height,
+				PrntStateRoot: parentStateRoot,
 			},
 			Transactions: txs,
 		},
@@ -0,0 +1,92 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+
+	blockexecutor "github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks/executor"
+)
+
+var _ blockexecutor.AcceptListener = (*chainUpgrader)(nil)
+
+// chainUpgrader restarts the blockchains whose VM was upgraded on their new VM
+// once an accepted block moves the chain time past the activation time of the
+// upgrade.
+type chainUpgrader struct {
+	vm *VM
+}
+
+func (c *chainUpgrader) Accepted(blk blocks.Block) error {
+	for _, tx := range blk.Txs() {
+		utx, ok := tx.Unsigned.(*txs.UpgradeBlockchainTx)
+		if !ok {
+			continue
+		}
+		metadata, err := c.vm.state.GetChainMetadata(utx.BlockchainID)
+		if err != nil {
+			return err
+		}
+		c.vm.upgradingChains[utx.BlockchainID] = metadata.VMID
+	}
+
+	if err := c.vm.upgradeChains(context.Background()); err != nil {
+		c.vm.ctx.Log.Warn("failed to upgrade blockchains",
+			zap.Uint64("height", blk.Height()),
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
+// upgradeChains restarts the blockchains whose VM upgrade activated as of the
+// last accepted chain time.
+func (vm *VM) upgradeChains(ctx context.Context) error {
+	timestamp := vm.state.GetTimestamp()
+	for chainID, runningVMID := range vm.upgradingChains {
+		metadata, err := vm.state.GetChainMetadata(chainID)
+		if err != nil {
+			return err
+		}
+		if metadata.Halted {
+			delete(vm.upgradingChains, chainID)
+			continue
+		}
+
+		vmID := metadata.VMIDAt(timestamp)
+		if vmID == runningVMID {
+			continue
+		}
+
+		if err := vm.restartChain(ctx, chainID, vmID); err != nil {
+			return err
+		}
+		delete(vm.upgradingChains, chainID)
+	}
+	return nil
+}
+
+func (vm *VM) restartChain(ctx context.Context, chainID ids.ID, vmID ids.ID) error {
+	chain, _, err := vm.state.GetTx(chainID)
+	if err != nil {
+		return err
+	}
+	tx, ok := chain.Unsigned.(*txs.CreateChainTx)
+	if !ok {
+		return fmt.Errorf("expected tx type *txs.CreateChainTx but got %T", chain.Unsigned)
+	}
+
+	vm.ctx.Log.Info("restarting blockchain on its upgraded VM",
+		zap.Stringer("blockchainID", chainID),
+		zap.Stringer("vmID", vmID),
+	)
+	return vm.Config.RestartChain(ctx, chainID, tx, vmID)
+}
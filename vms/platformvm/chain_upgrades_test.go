@@ -0,0 +1,106 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/chains"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+type restartRecorder struct {
+	chains.MockManager
+	restarted []chains.ChainParameters
+}
+
+func (r *restartRecorder) RestartChain(_ context.Context, chainParams chains.ChainParameters) error {
+	r.restarted = append(r.restarted, chainParams)
+	return nil
+}
+
+func TestChainRestartedOnUpgradeActivation(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+
+	recorder := &restartRecorder{}
+	vm.Config.Chains = recorder
+
+	acceptBlock := func(timestamp time.Time, tx *txs.Tx) {
+		preferred, err := vm.Builder.Preferred()
+		require.NoError(err)
+		statelessBlk, err := blocks.NewBanffStandardBlock(
+			timestamp,
+			preferred.ID(),
+			preferred.Height()+1,
+			[]*txs.Tx{tx},
+		)
+		require.NoError(err)
+		blk := vm.manager.NewBlock(statelessBlk)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(blk.Accept(context.Background()))
+		require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
+	}
+
+	subnetKeys := []*crypto.PrivateKeySECP256K1R{keys[0], keys[1]}
+	createChainTx, err := vm.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		constants.AVMID,
+		nil,
+		"chain",
+		subnetKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	chainTime := vm.state.GetTimestamp()
+	acceptBlock(chainTime, createChainTx)
+
+	newVMID := ids.GenerateTestID()
+	activationTime := chainTime.Add(time.Minute)
+	upgradeTx, err := vm.txBuilder.NewUpgradeBlockchainTx(
+		testSubnet1.ID(),
+		createChainTx.ID(),
+		newVMID,
+		uint64(activationTime.Unix()),
+		subnetKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	acceptBlock(chainTime, upgradeTx)
+
+	// The chain keeps running its VM until the upgrade activates.
+	require.Empty(recorder.restarted)
+
+	// Accepting a block that moves the chain time past the activation time
+	// restarts the chain on its new VM.
+	vm.clock.Set(activationTime)
+	createSubnetTx, err := vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	acceptBlock(activationTime, createSubnetTx)
+
+	require.Len(recorder.restarted, 1)
+	require.Equal(createChainTx.ID(), recorder.restarted[0].ID)
+	require.Equal(testSubnet1.ID(), recorder.restarted[0].SubnetID)
+	require.Equal(newVMID, recorder.restarted[0].VMID)
+	require.Empty(vm.upgradingChains)
+}
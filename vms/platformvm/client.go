@@ -5,6 +5,7 @@ package platformvm
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/lasthyphen/dijetsnodego/api"
@@ -15,6 +16,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/formatting/address"
 	"github.com/lasthyphen/dijetsnodego/utils/json"
 	"github.com/lasthyphen/dijetsnodego/utils/rpc"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/merkle"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 
 	platformapi "github.com/lasthyphen/dijetsnodego/vms/platformvm/api"
@@ -32,6 +34,9 @@ type Client interface {
 	ImportKey(ctx context.Context, user api.UserPass, privateKey *crypto.PrivateKeySECP256K1R, options ...rpc.Option) (ids.ShortID, error)
 	// GetBalance returns the balance of [addrs] on the P Chain
 	GetBalance(ctx context.Context, addrs []ids.ShortID, options ...rpc.Option) (*GetBalanceResponse, error)
+	// GetBalanceAtHeight returns the balance of [addrs] on the P Chain as of
+	// [height]
+	GetBalanceAtHeight(ctx context.Context, addrs []ids.ShortID, height uint64, options ...rpc.Option) (*GetBalanceResponse, error)
 	// CreateAddress creates a new address for [user]
 	CreateAddress(ctx context.Context, user api.UserPass, options ...rpc.Option) (ids.ShortID, error)
 	// ListAddresses returns an array of platform addresses controlled by [user]
@@ -63,10 +68,20 @@ type Client interface {
 	GetStakingAssetID(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (ids.ID, error)
 	// GetCurrentValidators returns the list of current validators for subnet with ID [subnetID]
 	GetCurrentValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]ClientPermissionlessValidator, error)
+	// GetDelegators returns up to [limit] of the current delegators of the
+	// validator with [nodeID] on the subnet with ID [subnetID], starting after
+	// [startIndex]. Returns the index to use to fetch the next page.
+	GetDelegators(ctx context.Context, subnetID ids.ID, nodeID ids.NodeID, startIndex StakerIndex, limit uint32, options ...rpc.Option) ([]ClientDelegator, StakerIndex, error)
 	// GetPendingValidators returns the list of pending validators for subnet with ID [subnetID]
 	GetPendingValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]interface{}, []interface{}, error)
 	// GetCurrentSupply returns an upper bound on the supply of DJTX in the system
 	GetCurrentSupply(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, error)
+	// GetOwnStakingStatus returns the uptime and reward eligibility of the
+	// node's validators
+	GetOwnStakingStatus(ctx context.Context, options ...rpc.Option) (*GetOwnStakingStatusReply, error)
+	// EstimateReward returns the projected reward for staking on a subnet
+	// along with, if requested, the reward status of an existing validator
+	EstimateReward(ctx context.Context, args *EstimateRewardArgs, options ...rpc.Option) (*EstimateRewardReply, error)
 	// SampleValidators returns the nodeIDs of a sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID]
 	SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16, options ...rpc.Option) ([]ids.NodeID, error)
 	// AddValidator issues a transaction to add a validator to the primary network
@@ -112,6 +127,20 @@ type Client interface {
 		endTime uint64,
 		options ...rpc.Option,
 	) (ids.ID, error)
+	// ModifySubnetValidator issues a transaction to set the weight of validator
+	// [nodeID] of subnet [subnetID] to [weight] and returns the txID. If
+	// [endTime] is non-zero, the end time of the validator is changed as well.
+	ModifySubnetValidator(
+		ctx context.Context,
+		user api.UserPass,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		subnetID ids.ID,
+		nodeID ids.NodeID,
+		weight,
+		endTime uint64,
+		options ...rpc.Option,
+	) (ids.ID, error)
 	// CreateSubnet issues a transaction to create [subnet] and returns the txID
 	CreateSubnet(
 		ctx context.Context,
@@ -122,6 +151,18 @@ type Client interface {
 		threshold uint32,
 		options ...rpc.Option,
 	) (ids.ID, error)
+	// TransferSubnetOwnership issues a transaction to replace the owner of
+	// [subnetID] and returns the txID
+	TransferSubnetOwnership(
+		ctx context.Context,
+		user api.UserPass,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		subnetID ids.ID,
+		controlKeys []ids.ShortID,
+		threshold uint32,
+		options ...rpc.Option,
+	) (ids.ID, error)
 	// ExportDJTX issues an ExportTx transaction and returns the txID
 	ExportDJTX(
 		ctx context.Context,
@@ -156,6 +197,29 @@ type Client interface {
 		genesisData []byte,
 		options ...rpc.Option,
 	) (ids.ID, error)
+	// HaltBlockchain issues a HaltBlockchain transaction and returns the txID
+	HaltBlockchain(
+		ctx context.Context,
+		user api.UserPass,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		subnetID ids.ID,
+		blockchainID ids.ID,
+		options ...rpc.Option,
+	) (ids.ID, error)
+	// UpgradeBlockchain issues an UpgradeBlockchain transaction and returns the
+	// txID
+	UpgradeBlockchain(
+		ctx context.Context,
+		user api.UserPass,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		subnetID ids.ID,
+		blockchainID ids.ID,
+		vmID string,
+		activationTime uint64,
+		options ...rpc.Option,
+	) (ids.ID, error)
 	// GetBlockchainStatus returns the current status of blockchain with ID: [blockchainID]
 	GetBlockchainStatus(ctx context.Context, blockchainID string, options ...rpc.Option) (status.BlockchainStatus, error)
 	// ValidatedBy returns the ID of the Subnet that validates [blockchainID]
@@ -166,6 +230,9 @@ type Client interface {
 	GetBlockchains(ctx context.Context, options ...rpc.Option) ([]APIBlockchain, error)
 	// IssueTx issues the transaction and returns its txID
 	IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
+	// SimulateTx verifies the transaction against the preferred state without
+	// issuing it and returns its effects
+	SimulateTx(ctx context.Context, tx []byte, options ...rpc.Option) (*SimulateTxReply, error)
 	// GetTx returns the byte representation of the transaction corresponding to [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetTxStatus returns the status of the transaction corresponding to [txID]
@@ -178,6 +245,18 @@ type Client interface {
 		freq time.Duration,
 		options ...rpc.Option,
 	) (*GetTxStatusResponse, error)
+	// GetMempool returns up to [limit] of the txs in the mempool, starting at
+	// the [startIndex]th tx added
+	GetMempool(ctx context.Context, startIndex uint64, limit uint32, options ...rpc.Option) (*GetMempoolReply, error)
+	// GetMempoolTx returns the description and the byte representation of the
+	// mempool tx corresponding to [txID]
+	GetMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) (*APIMempoolTx, []byte, error)
+	// GetDroppedTxs returns the txs that were recently dropped, most recently
+	// dropped first
+	GetDroppedTxs(ctx context.Context, options ...rpc.Option) ([]APIDroppedTx, error)
+	// GetFeeEstimate returns the static fees of the txs and the priority fee
+	// that a tx of [size] bytes should burn to be included in the next block
+	GetFeeEstimate(ctx context.Context, size uint64, options ...rpc.Option) (*GetFeeEstimateReply, error)
 	// GetStake returns the amount of nDJTX that [addrs] have cumulatively
 	// staked on the Primary Network.
 	GetStake(ctx context.Context, addrs []ids.ShortID, options ...rpc.Option) (map[ids.ID]uint64, [][]byte, error)
@@ -203,8 +282,26 @@ type Client interface {
 	// GetValidatorsAt returns the weights of the validator set of a provided subnet
 	// at the specified height.
 	GetValidatorsAt(ctx context.Context, subnetID ids.ID, height uint64, options ...rpc.Option) (map[ids.NodeID]uint64, error)
+	// GetValidatorSetChanges returns the changes made to the validator set of
+	// a subnet by the blocks in [startHeight, endHeight], and the last height
+	// that was inspected.
+	GetValidatorSetChanges(ctx context.Context, subnetID ids.ID, startHeight uint64, endHeight uint64, options ...rpc.Option) ([]APIValidatorSetChanges, uint64, error)
+	// GetAddressTxs returns the IDs of the accepted transactions that changed
+	// the balance of [assetID] of [addr], starting at [cursor], and the cursor
+	// of the next page.
+	GetAddressTxs(ctx context.Context, addr ids.ShortID, assetID ids.ID, cursor uint64, pageSize uint64, options ...rpc.Option) ([]ids.ID, uint64, error)
 	// GetBlock returns the block with the given id.
 	GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error)
+	// GetBlockByHeight returns the block accepted at [height].
+	GetBlockByHeight(ctx context.Context, height uint64, options ...rpc.Option) ([]byte, error)
+	// GetBlockRange returns up to [limit] of the blocks accepted at consecutive
+	// heights, starting at [startHeight].
+	GetBlockRange(ctx context.Context, startHeight uint64, limit uint32, options ...rpc.Option) ([][]byte, error)
+	// GetProof returns the proof of the value of [key] in the state tree and
+	// the state root committed to by the block accepted at [height]. If
+	// [height] is 0, the last accepted block is used. The proof should be
+	// verified against the state root of a block the caller trusts.
+	GetProof(ctx context.Context, key ids.ID, height uint64, options ...rpc.Option) (*merkle.Proof, ids.ID, error)
 }
 
 // Client implementation for interacting with the P Chain endpoint
@@ -254,6 +351,16 @@ func (c *client) GetBalance(ctx context.Context, addrs []ids.ShortID, options ..
 	return res, err
 }
 
+func (c *client) GetBalanceAtHeight(ctx context.Context, addrs []ids.ShortID, height uint64, options ...rpc.Option) (*GetBalanceResponse, error) {
+	jsonHeight := json.Uint64(height)
+	res := &GetBalanceResponse{}
+	err := c.requester.SendRequest(ctx, "platform.getBalance", &GetBalanceRequest{
+		HistoricalArgs: api.HistoricalArgs{Height: &jsonHeight},
+		Addresses:      ids.ShortIDsToStrings(addrs),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) CreateAddress(ctx context.Context, user api.UserPass, options ...rpc.Option) (ids.ShortID, error) {
 	res := &api.JSONAddress{}
 	err := c.requester.SendRequest(ctx, "platform.createAddress", &user, res, options...)
@@ -383,6 +490,28 @@ func (c *client) GetCurrentValidators(
 	return getClientPermissionlessValidators(res.Validators)
 }
 
+func (c *client) GetDelegators(
+	ctx context.Context,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	startIndex StakerIndex,
+	limit uint32,
+	options ...rpc.Option,
+) ([]ClientDelegator, StakerIndex, error) {
+	res := &GetDelegatorsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getDelegators", &GetDelegatorsArgs{
+		SubnetID:   subnetID,
+		NodeID:     nodeID,
+		StartIndex: startIndex,
+		Limit:      json.Uint32(limit),
+	}, res, options...)
+	if err != nil {
+		return nil, StakerIndex{}, err
+	}
+	delegators, err := getClientDelegators(res.Delegators)
+	return delegators, res.EndIndex, err
+}
+
 func (c *client) GetPendingValidators(
 	ctx context.Context,
 	subnetID ids.ID,
@@ -405,6 +534,18 @@ func (c *client) GetCurrentSupply(ctx context.Context, subnetID ids.ID, options
 	return uint64(res.Supply), err
 }
 
+func (c *client) GetOwnStakingStatus(ctx context.Context, options ...rpc.Option) (*GetOwnStakingStatusReply, error) {
+	res := &GetOwnStakingStatusReply{}
+	err := c.requester.SendRequest(ctx, "platform.getOwnStakingStatus", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) EstimateReward(ctx context.Context, args *EstimateRewardArgs, options ...rpc.Option) (*EstimateRewardReply, error) {
+	res := &EstimateRewardReply{}
+	err := c.requester.SendRequest(ctx, "platform.estimateReward", args, res, options...)
+	return res, err
+}
+
 func (c *client) SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16, options ...rpc.Option) ([]ids.NodeID, error) {
 	res := &SampleValidatorsReply{}
 	err := c.requester.SendRequest(ctx, "platform.sampleValidators", &SampleValidatorsArgs{
@@ -509,6 +650,32 @@ func (c *client) AddSubnetValidator(
 	return res.TxID, err
 }
 
+func (c *client) ModifySubnetValidator(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	weight,
+	endTime uint64,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "platform.modifySubnetValidator", &ModifySubnetValidatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		NodeID:   nodeID,
+		SubnetID: subnetID,
+		Weight:   json.Uint64(weight),
+		EndTime:  json.Uint64(endTime),
+	}, res, options...)
+	return res.TxID, err
+}
+
 func (c *client) CreateSubnet(
 	ctx context.Context,
 	user api.UserPass,
@@ -533,6 +700,32 @@ func (c *client) CreateSubnet(
 	return res.TxID, err
 }
 
+func (c *client) TransferSubnetOwnership(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	subnetID ids.ID,
+	controlKeys []ids.ShortID,
+	threshold uint32,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "platform.transferSubnetOwnership", &TransferSubnetOwnershipArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		SubnetID: subnetID,
+		APISubnet: APISubnet{
+			ControlKeys: ids.ShortIDsToStrings(controlKeys),
+			Threshold:   json.Uint32(threshold),
+		},
+	}, res, options...)
+	return res.TxID, err
+}
+
 func (c *client) ExportDJTX(
 	ctx context.Context,
 	user api.UserPass,
@@ -613,6 +806,54 @@ func (c *client) CreateBlockchain(
 	return res.TxID, err
 }
 
+func (c *client) HaltBlockchain(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	subnetID ids.ID,
+	blockchainID ids.ID,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "platform.haltBlockchain", &HaltBlockchainArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		SubnetID:     subnetID,
+		BlockchainID: blockchainID,
+	}, res, options...)
+	return res.TxID, err
+}
+
+func (c *client) UpgradeBlockchain(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	subnetID ids.ID,
+	blockchainID ids.ID,
+	vmID string,
+	activationTime uint64,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "platform.upgradeBlockchain", &UpgradeBlockchainArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		SubnetID:       subnetID,
+		BlockchainID:   blockchainID,
+		VMID:           vmID,
+		ActivationTime: json.Uint64(activationTime),
+	}, res, options...)
+	return res.TxID, err
+}
+
 func (c *client) GetBlockchainStatus(ctx context.Context, blockchainID string, options ...rpc.Option) (status.BlockchainStatus, error) {
 	res := &GetBlockchainStatusReply{}
 	err := c.requester.SendRequest(ctx, "platform.getBlockchainStatus", &GetBlockchainStatusArgs{
@@ -657,6 +898,20 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) SimulateTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*SimulateTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SimulateTxReply{}
+	err = c.requester.SendRequest(ctx, "platform.simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
 	err := c.requester.SendRequest(ctx, "platform.getTx", &api.GetTxArgs{
@@ -704,6 +959,46 @@ func (c *client) AwaitTxDecided(ctx context.Context, txID ids.ID, freq time.Dura
 	}
 }
 
+func (c *client) GetMempool(ctx context.Context, startIndex uint64, limit uint32, options ...rpc.Option) (*GetMempoolReply, error) {
+	res := &GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "platform.getMempool", &GetMempoolArgs{
+		StartIndex: json.Uint64(startIndex),
+		Limit:      json.Uint32(limit),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) (*APIMempoolTx, []byte, error) {
+	res := &GetMempoolTxReply{}
+	err := c.requester.SendRequest(ctx, "platform.getMempoolTx", &api.GetTxArgs{
+		TxID:     txID,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	txStr, ok := res.Tx.(string)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected tx to be a string but got %T", res.Tx)
+	}
+	txBytes, err := formatting.Decode(res.Encoding, txStr)
+	return &res.APIMempoolTx, txBytes, err
+}
+
+func (c *client) GetDroppedTxs(ctx context.Context, options ...rpc.Option) ([]APIDroppedTx, error) {
+	res := &GetDroppedTxsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getDroppedTxs", struct{}{}, res, options...)
+	return res.Txs, err
+}
+
+func (c *client) GetFeeEstimate(ctx context.Context, size uint64, options ...rpc.Option) (*GetFeeEstimateReply, error) {
+	res := &GetFeeEstimateReply{}
+	err := c.requester.SendRequest(ctx, "platform.getFeeEstimate", &GetFeeEstimateArgs{
+		Size: json.Uint64(size),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetStake(ctx context.Context, addrs []ids.ShortID, options ...rpc.Option) (map[ids.ID]uint64, [][]byte, error) {
 	res := new(GetStakeReply)
 	err := c.requester.SendRequest(ctx, "platform.getStake", &GetStakeArgs{
@@ -797,6 +1092,27 @@ func (c *client) GetValidatorsAt(ctx context.Context, subnetID ids.ID, height ui
 	return res.Validators, err
 }
 
+func (c *client) GetValidatorSetChanges(ctx context.Context, subnetID ids.ID, startHeight uint64, endHeight uint64, options ...rpc.Option) ([]APIValidatorSetChanges, uint64, error) {
+	res := &GetValidatorSetChangesReply{}
+	err := c.requester.SendRequest(ctx, "platform.getValidatorSetChanges", &GetValidatorSetChangesArgs{
+		SubnetID:    subnetID,
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+	}, res, options...)
+	return res.Changes, uint64(res.EndHeight), err
+}
+
+func (c *client) GetAddressTxs(ctx context.Context, addr ids.ShortID, assetID ids.ID, cursor uint64, pageSize uint64, options ...rpc.Option) ([]ids.ID, uint64, error) {
+	res := &GetAddressTxsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getAddressTxs", &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addr.String()},
+		Cursor:      json.Uint64(cursor),
+		PageSize:    json.Uint64(pageSize),
+		AssetID:     assetID,
+	}, res, options...)
+	return res.TxIDs, uint64(res.Cursor), err
+}
+
 func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error) {
 	response := &api.FormattedBlock{}
 	if err := c.requester.SendRequest(ctx, "platform.getBlock", &api.GetBlockArgs{
@@ -808,3 +1124,68 @@ func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Op
 
 	return formatting.Decode(response.Encoding, response.Block)
 }
+
+func (c *client) GetBlockByHeight(ctx context.Context, height uint64, options ...rpc.Option) ([]byte, error) {
+	response := &api.FormattedBlock{}
+	if err := c.requester.SendRequest(ctx, "platform.getBlockByHeight", &GetBlockByHeightArgs{
+		Height:   json.Uint64(height),
+		Encoding: formatting.Hex,
+	}, response, options...); err != nil {
+		return nil, err
+	}
+
+	return formatting.Decode(response.Encoding, response.Block)
+}
+
+// formattedBlocks is the response from calling GetBlockRange with a
+// non-JSON encoding.
+type formattedBlocks struct {
+	Blocks   []string            `json:"blocks"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+func (c *client) GetBlockRange(ctx context.Context, startHeight uint64, limit uint32, options ...rpc.Option) ([][]byte, error) {
+	response := &formattedBlocks{}
+	if err := c.requester.SendRequest(ctx, "platform.getBlockRange", &GetBlockRangeArgs{
+		StartHeight: json.Uint64(startHeight),
+		Limit:       json.Uint32(limit),
+		Encoding:    formatting.Hex,
+	}, response, options...); err != nil {
+		return nil, err
+	}
+
+	blocks := make([][]byte, len(response.Blocks))
+	for i, blockStr := range response.Blocks {
+		blockBytes, err := formatting.Decode(response.Encoding, blockStr)
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = blockBytes
+	}
+	return blocks, nil
+}
+
+func (c *client) GetProof(ctx context.Context, key ids.ID, height uint64, options ...rpc.Option) (*merkle.Proof, ids.ID, error) {
+	res := &GetProofReply{}
+	if err := c.requester.SendRequest(ctx, "platform.getProof", &GetProofArgs{
+		Key:      key,
+		Height:   json.Uint64(height),
+		Encoding: formatting.Hex,
+	}, res, options...); err != nil {
+		return nil, ids.Empty, err
+	}
+
+	proof := &merkle.Proof{
+		Key:      res.Key,
+		Siblings: res.Siblings,
+		Other:    res.OtherLeaf,
+	}
+	if res.Value != "" {
+		var err error
+		proof.Value, err = formatting.Decode(res.Encoding, res.Value)
+		if err != nil {
+			return nil, ids.Empty, err
+		}
+	}
+	return proof, res.StateRoot, nil
+}
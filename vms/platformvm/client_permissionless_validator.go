@@ -105,18 +105,9 @@ func getClientPermissionlessValidators(validatorsSliceIntf []interface{}) ([]Cli
 			return nil, err
 		}
 
-		clientDelegators := make([]ClientDelegator, len(apiValidator.Delegators))
-		for j, apiDelegator := range apiValidator.Delegators {
-			rewardOwner, err := apiOwnerToClientOwner(apiDelegator.RewardOwner)
-			if err != nil {
-				return nil, err
-			}
-
-			clientDelegators[j] = ClientDelegator{
-				ClientStaker:    apiStakerToClientStaker(apiDelegator.Staker),
-				RewardOwner:     rewardOwner,
-				PotentialReward: (*uint64)(apiDelegator.PotentialReward),
-			}
+		clientDelegators, err := getClientDelegators(apiValidator.Delegators)
+		if err != nil {
+			return nil, err
 		}
 
 		clientValidators[i] = ClientPermissionlessValidator{
@@ -133,3 +124,20 @@ func getClientPermissionlessValidators(validatorsSliceIntf []interface{}) ([]Cli
 	}
 	return clientValidators, nil
 }
+
+func getClientDelegators(apiDelegators []api.PrimaryDelegator) ([]ClientDelegator, error) {
+	clientDelegators := make([]ClientDelegator, len(apiDelegators))
+	for i, apiDelegator := range apiDelegators {
+		rewardOwner, err := apiOwnerToClientOwner(apiDelegator.RewardOwner)
+		if err != nil {
+			return nil, err
+		}
+
+		clientDelegators[i] = ClientDelegator{
+			ClientStaker:    apiStakerToClientStaker(apiDelegator.Staker),
+			RewardOwner:     rewardOwner,
+			PotentialReward: (*uint64)(apiDelegator.PotentialReward),
+		}
+	}
+	return clientDelegators, nil
+}
@@ -4,6 +4,7 @@
 package config
 
 import (
+	"context"
 	"time"
 
 	"github.com/lasthyphen/dijetsnodego/chains"
@@ -98,6 +99,26 @@ type Config struct {
 	// Time of the Banff network upgrade
 	BanffTime time.Time
 
+	// Time after which blocks must commit to the state root of their parent
+	StateRootTime time.Time
+
+	// Time after which txs can specify an expiry, which requires them to be
+	// serialized with [txs.V1Version]
+	ExpiryTime time.Time
+
+	// Time after which subnet owners can issue TransferSubnetOwnershipTxs
+	SubnetOwnershipTransferTime time.Time
+
+	// Time after which subnet owners can issue ModifySubnetValidatorTxs
+	SubnetValidatorModificationTime time.Time
+
+	// Time after which subnet owners can issue HaltBlockchainTxs and
+	// UpgradeBlockchainTxs
+	BlockchainModificationTime time.Time
+
+	// Time after which permissionless stakers can opt in to being restaked
+	RestakeTime time.Time
+
 	// Subnet ID --> Minimum portion of the subnet's stake this node must be
 	// connected to in order to report healthy.
 	// [constants.PrimaryNetworkID] is always a key in this map.
@@ -129,6 +150,30 @@ func (c *Config) IsBanffActivated(timestamp time.Time) bool {
 	return !timestamp.Before(c.BanffTime)
 }
 
+func (c *Config) IsStateRootActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.StateRootTime)
+}
+
+func (c *Config) IsExpiryActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.ExpiryTime)
+}
+
+func (c *Config) IsSubnetOwnershipTransferActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.SubnetOwnershipTransferTime)
+}
+
+func (c *Config) IsSubnetValidatorModificationActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.SubnetValidatorModificationTime)
+}
+
+func (c *Config) IsBlockchainModificationActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.BlockchainModificationTime)
+}
+
+func (c *Config) IsRestakeActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.RestakeTime)
+}
+
 func (c *Config) GetCreateBlockchainTxFee(timestamp time.Time) uint64 {
 	if c.IsApricotPhase3Activated(timestamp) {
 		return c.CreateBlockchainTxFee
@@ -143,22 +188,49 @@ func (c *Config) GetCreateSubnetTxFee(timestamp time.Time) uint64 {
 	return c.CreateAssetTxFee
 }
 
-// Create the blockchain described in [tx], but only if this node is a member of
-// the subnet that validates the chain
-func (c *Config) CreateChain(chainID ids.ID, tx *txs.CreateChainTx) {
-	if c.StakingEnabled && // Staking is enabled, so nodes might not validate all chains
-		constants.PrimaryNetworkID != tx.SubnetID && // All nodes must validate the primary network
-		!c.WhitelistedSubnets.Contains(tx.SubnetID) { // This node doesn't validate this blockchain
+// Create the blockchain described in [tx], running the VM [vmID], but only if
+// this node is a member of the subnet that validates the chain
+func (c *Config) CreateChain(chainID ids.ID, tx *txs.CreateChainTx, vmID ids.ID) {
+	if !c.validatesSubnet(tx.SubnetID) {
 		return
 	}
 
-	chainParams := chains.ChainParameters{
+	c.Chains.QueueChainCreation(c.chainParameters(chainID, tx, vmID))
+}
+
+// Permanently shut down the blockchain with ID [chainID], but only if this node
+// is a member of the subnet [subnetID] that validates the chain
+func (c *Config) HaltChain(ctx context.Context, subnetID ids.ID, chainID ids.ID) error {
+	if !c.validatesSubnet(subnetID) {
+		return nil
+	}
+
+	return c.Chains.HaltChain(ctx, chainID)
+}
+
+// Shut down the blockchain described in [tx] and create it again running the
+// VM [vmID], but only if this node is a member of the subnet that validates the
+// chain
+func (c *Config) RestartChain(ctx context.Context, chainID ids.ID, tx *txs.CreateChainTx, vmID ids.ID) error {
+	if !c.validatesSubnet(tx.SubnetID) {
+		return nil
+	}
+
+	return c.Chains.RestartChain(ctx, c.chainParameters(chainID, tx, vmID))
+}
+
+func (c *Config) validatesSubnet(subnetID ids.ID) bool {
+	return !c.StakingEnabled || // Staking is disabled, so nodes validate all chains
+		constants.PrimaryNetworkID == subnetID || // All nodes must validate the primary network
+		c.WhitelistedSubnets.Contains(subnetID) // This node validates this blockchain
+}
+
+func (*Config) chainParameters(chainID ids.ID, tx *txs.CreateChainTx, vmID ids.ID) chains.ChainParameters {
+	return chains.ChainParameters{
 		ID:          chainID,
 		SubnetID:    tx.SubnetID,
 		GenesisData: tx.GenesisData,
-		VMID:        tx.VMID,
+		VMID:        vmID,
 		FxIDs:       tx.FxIDs,
 	}
-
-	c.Chains.QueueChainCreation(chainParams)
 }
@@ -0,0 +1,46 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"encoding/json"
+)
+
+// ExecutionConfig contains the options of the P-chain that are set in its
+// chain config rather than by the node.
+type ExecutionConfig struct {
+	// IndexTransactions enables the index of the transactions that changed
+	// the balances of each address.
+	IndexTransactions bool `json:"index-transactions"`
+	// UTXOJournalEnabled enables queries of balances and UTXOs as of a past
+	// height.
+	UTXOJournalEnabled bool `json:"utxo-journal-enabled"`
+	// UTXOJournalRetention is the number of heights that can be queried. If
+	// 0, all the heights are kept.
+	UTXOJournalRetention uint64 `json:"utxo-journal-retention"`
+	// StateProofRetention is the number of accepted heights whose state can
+	// be proven. The nodes of older state trees are deleted. If 0, only the
+	// nodes of the last state tree are kept.
+	StateProofRetention uint64 `json:"state-proof-retention"`
+	// StateProofArchival keeps the nodes of the state tree of every height,
+	// so that the state of every height can be proven. The database then
+	// grows with every accepted block. StateProofRetention is ignored.
+	StateProofArchival bool `json:"state-proof-archival"`
+}
+
+// DefaultStateProofRetention is the number of accepted heights whose state can
+// be proven if it isn't set in the chain config.
+const DefaultStateProofRetention = 1024
+
+// GetExecutionConfig parses [b] into an ExecutionConfig. If [b] is empty, the
+// default config is returned.
+func GetExecutionConfig(b []byte) (*ExecutionConfig, error) {
+	config := &ExecutionConfig{
+		StateProofRetention: DefaultStateProofRetention,
+	}
+	if len(b) == 0 {
+		return config, nil
+	}
+	return config, json.Unmarshal(b, config)
+}
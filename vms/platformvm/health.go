@@ -18,7 +18,10 @@ import (
 
 const fallbackMinPercentConnected = 0.8
 
-var errNotEnoughStake = errors.New("not connected to enough stake")
+var (
+	errNotEnoughStake = errors.New("not connected to enough stake")
+	errLowUptime      = errors.New("uptime projected below the reward requirement")
+)
 
 func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
 	// Returns nil if this node is connected to > alpha percent of the Primary Network's stake
@@ -27,7 +30,7 @@ func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
 		return nil, fmt.Errorf("couldn't get percent connected: %w", err)
 	}
 	vm.metrics.SetPercentConnected(primaryPercentConnected)
-	details := map[string]float64{
+	details := map[string]interface{}{
 		"primary-percentConnected": primaryPercentConnected,
 	}
 
@@ -104,11 +107,49 @@ func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
 		}
 	}
 
-	if len(errorReasons) == 0 {
+	// Uptimes are only tracked once the chain is bootstrapped. A validator that
+	// isn't on track to be rewarded degrades the node while it can still
+	// recover.
+	var uptimeReasons []string
+	if vm.bootstrapped.GetValue() {
+		stakingStatuses, err := vm.getStakingStatuses()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get staking statuses: %w", err)
+		}
+		for _, status := range stakingStatuses {
+			prefix := status.subnetID.String()
+			if status.subnetID == constants.PrimaryNetworkID {
+				prefix = "primary"
+			}
+			details[prefix+"-uptime"] = status.uptime
+			details[prefix+"-onTrack"] = status.onTrack()
+
+			if !status.onTrack() {
+				uptimeReasons = append(uptimeReasons,
+					fmt.Sprintf("uptime on %q is projected to be %f%%; should be at least %f%% to be rewarded, at most %f%% is achievable in the remaining %s",
+						status.subnetID,
+						status.projectedUptime*100,
+						status.requiredUptime*100,
+						status.maxUptime*100,
+						status.timeRemaining,
+					),
+				)
+			}
+		}
+	}
+
+	switch {
+	case len(errorReasons) != 0:
+		return details, fmt.Errorf("platform layer is unhealthy err: %w, details: %s",
+			errNotEnoughStake,
+			strings.Join(errorReasons, ", "),
+		)
+	case len(uptimeReasons) != 0:
+		return details, fmt.Errorf("platform layer is degraded err: %w, details: %s",
+			errLowUptime,
+			strings.Join(uptimeReasons, ", "),
+		)
+	default:
 		return details, nil
 	}
-	return details, fmt.Errorf("platform layer is unhealthy err: %w, details: %s",
-		errNotEnoughStake,
-		strings.Join(errorReasons, ", "),
-	)
 }
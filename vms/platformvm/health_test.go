@@ -7,10 +7,12 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/uptime"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
 	"github.com/lasthyphen/dijetsnodego/version"
 )
@@ -108,3 +110,41 @@ func TestHealthCheckSubnet(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthCheckLowUptime(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+	vm.UptimePercentage = .8
+
+	genesisState, _ := defaultGenesis()
+	for _, validator := range genesisState.Validators[1:] {
+		err := vm.Connected(context.Background(), validator.NodeID, version.CurrentApp)
+		require.NoError(err)
+	}
+
+	// Before the node is a validator, its uptime isn't reported.
+	details, err := vm.HealthCheck(context.Background())
+	require.NoError(err)
+	require.NotContains(details, "primary-uptime")
+
+	// The node was assumed to be online while the uptimes weren't tracked.
+	vm.ctx.NodeID = genesisState.Validators[0].NodeID
+	details, err = vm.HealthCheck(context.Background())
+	require.NoError(err)
+	require.Contains(details, "primary-uptime")
+	require.Equal(true, details.(map[string]interface{})["primary-onTrack"])
+
+	// After being offline for a long time, the node's projected uptime is
+	// below the requirement, which degrades the node.
+	uptimeManager := vm.uptimeManager.(uptime.TestManager)
+	uptimeManager.SetTime(time.Now().Add(100 * 365 * 24 * time.Hour))
+	details, err = vm.HealthCheck(context.Background())
+	require.ErrorIs(err, errLowUptime)
+	require.Equal(false, details.(map[string]interface{})["primary-onTrack"])
+}
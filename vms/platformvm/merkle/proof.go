@@ -0,0 +1,117 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkle
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
+)
+
+var (
+	ErrInvalidProof = errors.New("invalid proof")
+
+	errTooDeep = errors.New("path is deeper than the maximum depth")
+)
+
+// Leaf is a leaf whose value is only known by its hash.
+type Leaf struct {
+	Key       ids.ID `json:"key"`
+	ValueHash ids.ID `json:"valueHash"`
+}
+
+// Proof proves the value of a key in the tree with a given root.
+type Proof struct {
+	Key ids.ID `json:"key"`
+	// Value of [Key] in the tree, or nil if [Key] isn't in the tree.
+	Value []byte `json:"value"`
+	// Siblings of the nodes on the path of [Key], starting at the root.
+	Siblings []ids.ID `json:"siblings"`
+	// Other is the leaf that was found on the path of [Key] if [Key] isn't in
+	// the tree. Nil if the path of [Key] ends in an empty subtree.
+	Other *Leaf `json:"other"`
+}
+
+// Prove returns the proof of the value of [key] in the tree with root [root].
+func Prove(r Reader, root ids.ID, key ids.ID) (*Proof, error) {
+	b := &batch{
+		reader:   r,
+		newNodes: make(map[ids.ID][]byte),
+	}
+	proof := &Proof{
+		Key: key,
+	}
+	nodeID := root
+	for depth := 0; nodeID != ids.Empty; depth++ {
+		n, err := b.getNode(nodeID)
+		if err != nil {
+			return nil, err
+		}
+		if n.isLeaf {
+			if n.key == key {
+				proof.Value = n.value
+			} else {
+				proof.Other = &Leaf{
+					Key:       n.key,
+					ValueHash: hashing.ComputeHash256Array(n.value),
+				}
+			}
+			return proof, nil
+		}
+		if depth >= MaxDepth {
+			return nil, errTooDeep
+		}
+
+		if bit(key, depth) == 0 {
+			proof.Siblings = append(proof.Siblings, n.right)
+			nodeID = n.left
+		} else {
+			proof.Siblings = append(proof.Siblings, n.left)
+			nodeID = n.right
+		}
+	}
+	return proof, nil
+}
+
+// Verify returns nil if [p] proves the value of [p.Key] in the tree with root
+// [root].
+func (p *Proof) Verify(root ids.ID) error {
+	depth := len(p.Siblings)
+	if depth > MaxDepth {
+		return fmt.Errorf("%w: %d siblings", ErrInvalidProof, depth)
+	}
+
+	var nodeID ids.ID
+	switch {
+	case p.Value != nil:
+		if p.Other != nil {
+			return fmt.Errorf("%w: unexpected leaf of %s", ErrInvalidProof, p.Other.Key)
+		}
+		nodeID = LeafID(p.Key, hashing.ComputeHash256Array(p.Value))
+	case p.Other != nil:
+		if p.Other.Key == p.Key {
+			return fmt.Errorf("%w: missing value", ErrInvalidProof)
+		}
+		for i := 0; i < depth; i++ {
+			if bit(p.Other.Key, i) != bit(p.Key, i) {
+				return fmt.Errorf("%w: leaf of %s isn't on the path", ErrInvalidProof, p.Other.Key)
+			}
+		}
+		nodeID = LeafID(p.Other.Key, p.Other.ValueHash)
+	}
+
+	for i := depth - 1; i >= 0; i-- {
+		if bit(p.Key, i) == 0 {
+			nodeID = InternalID(nodeID, p.Siblings[i])
+		} else {
+			nodeID = InternalID(p.Siblings[i], nodeID)
+		}
+	}
+	if nodeID != root {
+		return fmt.Errorf("%w: expected root %s but got %s", ErrInvalidProof, root, nodeID)
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package merkle implements a sparse Merkle tree over 32 byte keys.
+//
+// The path of a key is its bits, starting at the most significant bit of its
+// first byte. A subtree containing a single key is represented by the leaf of
+// that key, so the depth of a leaf is the length of the shortest prefix that
+// distinguishes its key from every other key in the tree. The empty tree has
+// the root [ids.Empty].
+//
+// Nodes are content addressed and never modified, so the root of any version
+// of the tree can be used to read it as long as its nodes are kept. The nodes
+// that an update removes from the tree are reported so that older versions can
+// be pruned.
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
+)
+
+const (
+	leafPrefix byte = iota
+	internalPrefix
+
+	// MaxDepth is the maximum number of siblings on the path of a key
+	MaxDepth = len(ids.Empty) * 8
+
+	leafHeaderLen = 1 + len(ids.Empty)
+	internalLen   = 1 + 2*len(ids.Empty)
+)
+
+var errInvalidNode = errors.New("invalid node")
+
+// Reader returns the nodes of a tree.
+type Reader interface {
+	// GetMerkleNode returns the bytes of the node with ID [nodeID], or
+	// database.ErrNotFound if the node is unknown.
+	GetMerkleNode(nodeID ids.ID) ([]byte, error)
+}
+
+type node struct {
+	// Only set for leaves
+	isLeaf bool
+	key    ids.ID
+	value  []byte
+
+	// Only set for internal nodes
+	left  ids.ID
+	right ids.ID
+}
+
+func parseNode(nodeBytes []byte) (*node, error) {
+	switch {
+	case len(nodeBytes) >= leafHeaderLen && nodeBytes[0] == leafPrefix:
+		n := &node{
+			isLeaf: true,
+			value:  nodeBytes[leafHeaderLen:],
+		}
+		copy(n.key[:], nodeBytes[1:leafHeaderLen])
+		return n, nil
+	case len(nodeBytes) == internalLen && nodeBytes[0] == internalPrefix:
+		n := &node{}
+		copy(n.left[:], nodeBytes[1:leafHeaderLen])
+		copy(n.right[:], nodeBytes[leafHeaderLen:])
+		return n, nil
+	default:
+		return nil, errInvalidNode
+	}
+}
+
+// LeafID returns the ID of the leaf of [key] whose value hashes to
+// [valueHash].
+func LeafID(key ids.ID, valueHash ids.ID) ids.ID {
+	leaf := make([]byte, 0, internalLen)
+	leaf = append(leaf, leafPrefix)
+	leaf = append(leaf, key[:]...)
+	leaf = append(leaf, valueHash[:]...)
+	return hashing.ComputeHash256Array(leaf)
+}
+
+// InternalID returns the ID of the internal node with the children [left] and
+// [right].
+func InternalID(left ids.ID, right ids.ID) ids.ID {
+	return hashing.ComputeHash256Array(internalBytes(left, right))
+}
+
+func internalBytes(left ids.ID, right ids.ID) []byte {
+	internal := make([]byte, 0, internalLen)
+	internal = append(internal, internalPrefix)
+	internal = append(internal, left[:]...)
+	return append(internal, right[:]...)
+}
+
+// bit returns the bit of [key] at [depth].
+func bit(key ids.ID, depth int) byte {
+	return (key[depth/8] >> (7 - depth%8)) & 1
+}
+
+type change struct {
+	key ids.ID
+	// nil if [key] is removed
+	value []byte
+}
+
+// Update returns the root of the tree that results from applying [changes] to
+// the tree with root [root]. A nil value removes its key from the tree.
+//
+// The nodes of the new tree that weren't read from [r] are returned, mapped by
+// ID. They must be made readable for the new root to be used. The IDs of the
+// nodes of the tree with root [root] that aren't in the new tree are returned
+// as well. They are only needed to read [root].
+func Update(
+	r Reader,
+	root ids.ID,
+	changes map[ids.ID][]byte,
+) (ids.ID, map[ids.ID][]byte, []ids.ID, error) {
+	sortedChanges := make([]change, 0, len(changes))
+	for key, value := range changes {
+		sortedChanges = append(sortedChanges, change{
+			key:   key,
+			value: value,
+		})
+	}
+	sort.Slice(sortedChanges, func(i, j int) bool {
+		return bytes.Compare(sortedChanges[i].key[:], sortedChanges[j].key[:]) < 0
+	})
+
+	b := &batch{
+		reader:   r,
+		newNodes: make(map[ids.ID][]byte),
+	}
+	newRoot, err := b.update(root, 0, sortedChanges)
+	if err != nil {
+		return ids.Empty, nil, nil, err
+	}
+
+	// Every node of the new tree that is on the path of a change is in
+	// [newNodes], so the visited nodes that aren't were removed.
+	removedNodes := make([]ids.ID, 0, len(b.visited))
+	for _, nodeID := range b.visited {
+		if _, ok := b.newNodes[nodeID]; !ok {
+			removedNodes = append(removedNodes, nodeID)
+		}
+	}
+	return newRoot, b.newNodes, removedNodes, nil
+}
+
+type batch struct {
+	reader   Reader
+	newNodes map[ids.ID][]byte
+	// Nodes of the original tree that are on the path of a change
+	visited []ids.ID
+}
+
+func (b *batch) getNode(nodeID ids.ID) (*node, error) {
+	nodeBytes, ok := b.newNodes[nodeID]
+	if !ok {
+		var err error
+		nodeBytes, err = b.reader.GetMerkleNode(nodeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get node %s: %w", nodeID, err)
+		}
+	}
+	return parseNode(nodeBytes)
+}
+
+// update applies [changes] to the subtree with root [nodeID] at [depth].
+//
+// Invariant: [changes] are sorted and all of their keys share the path to
+// [nodeID].
+func (b *batch) update(nodeID ids.ID, depth int, changes []change) (ids.ID, error) {
+	if len(changes) == 0 {
+		return nodeID, nil
+	}
+	if nodeID == ids.Empty {
+		return b.build(depth, changes)
+	}
+	b.visited = append(b.visited, nodeID)
+
+	n, err := b.getNode(nodeID)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if n.isLeaf {
+		// The subtree is rebuilt from the existing leaf and the changes, unless
+		// the leaf is changed.
+		i := sort.Search(len(changes), func(i int) bool {
+			return bytes.Compare(changes[i].key[:], n.key[:]) >= 0
+		})
+		if i == len(changes) || changes[i].key != n.key {
+			merged := make([]change, 0, len(changes)+1)
+			merged = append(merged, changes[:i]...)
+			merged = append(merged, change{
+				key:   n.key,
+				value: n.value,
+			})
+			changes = append(merged, changes[i:]...)
+		}
+		return b.build(depth, changes)
+	}
+
+	split := splitIndex(changes, depth)
+	left, err := b.update(n.left, depth+1, changes[:split])
+	if err != nil {
+		return ids.Empty, err
+	}
+	right, err := b.update(n.right, depth+1, changes[split:])
+	if err != nil {
+		return ids.Empty, err
+	}
+	return b.join(left, right)
+}
+
+// build returns the root of the subtree at [depth] containing the keys added by
+// [changes].
+//
+// Invariant: [changes] are sorted and all of their keys share the path to the
+// subtree.
+func (b *batch) build(depth int, changes []change) (ids.ID, error) {
+	added := make([]change, 0, len(changes))
+	for _, c := range changes {
+		if c.value != nil {
+			added = append(added, c)
+		}
+	}
+
+	switch len(added) {
+	case 0:
+		return ids.Empty, nil
+	case 1:
+		leaf := make([]byte, 0, leafHeaderLen+len(added[0].value))
+		leaf = append(leaf, leafPrefix)
+		leaf = append(leaf, added[0].key[:]...)
+		leaf = append(leaf, added[0].value...)
+
+		leafID := LeafID(added[0].key, hashing.ComputeHash256Array(added[0].value))
+		b.newNodes[leafID] = leaf
+		return leafID, nil
+	}
+
+	split := splitIndex(added, depth)
+	left, err := b.build(depth+1, added[:split])
+	if err != nil {
+		return ids.Empty, err
+	}
+	right, err := b.build(depth+1, added[split:])
+	if err != nil {
+		return ids.Empty, err
+	}
+	return b.join(left, right)
+}
+
+// join returns the root of the subtree with the children [left] and [right].
+// A subtree containing a single leaf is replaced by the leaf.
+func (b *batch) join(left ids.ID, right ids.ID) (ids.ID, error) {
+	if left == ids.Empty || right == ids.Empty {
+		child := left
+		if child == ids.Empty {
+			child = right
+		}
+		if child == ids.Empty {
+			return ids.Empty, nil
+		}
+
+		n, err := b.getNode(child)
+		if err != nil {
+			return ids.Empty, err
+		}
+		if n.isLeaf {
+			return child, nil
+		}
+	}
+
+	internalID := InternalID(left, right)
+	b.newNodes[internalID] = internalBytes(left, right)
+	return internalID, nil
+}
+
+// splitIndex returns the index of the first change whose key has a 1 at
+// [depth].
+func splitIndex(changes []change, depth int) int {
+	return sort.Search(len(changes), func(i int) bool {
+		return bit(changes[i].key, depth) == 1
+	})
+}
@@ -0,0 +1,259 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+type nodes map[ids.ID][]byte
+
+func (n nodes) GetMerkleNode(nodeID ids.ID) ([]byte, error) {
+	nodeBytes, ok := n[nodeID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return nodeBytes, nil
+}
+
+func (n nodes) update(t *testing.T, root ids.ID, changes map[ids.ID][]byte) ids.ID {
+	newRoot, newNodes, _, err := Update(n, root, changes)
+	require.NoError(t, err)
+	for nodeID, nodeBytes := range newNodes {
+		n[nodeID] = nodeBytes
+	}
+	return newRoot
+}
+
+func TestUpdateOrderIndependence(t *testing.T) {
+	require := require.New(t)
+
+	keys := make([]ids.ID, 64)
+	for i := range keys {
+		keys[i] = ids.GenerateTestID()
+	}
+
+	// Add every key at once
+	all := make(nodes)
+	changes := make(map[ids.ID][]byte, len(keys))
+	for i, key := range keys {
+		changes[key] = []byte{byte(i)}
+	}
+	expectedRoot := all.update(t, ids.Empty, changes)
+	require.NotEqual(ids.Empty, expectedRoot)
+
+	// Add every key one by one
+	oneByOne := make(nodes)
+	root := ids.Empty
+	for i := len(keys) - 1; i >= 0; i-- {
+		root = oneByOne.update(t, root, map[ids.ID][]byte{
+			keys[i]: {byte(i)},
+		})
+	}
+	require.Equal(expectedRoot, root)
+
+	// Adding and removing a key is a no-op
+	extraKey := ids.GenerateTestID()
+	root = oneByOne.update(t, root, map[ids.ID][]byte{
+		extraKey: {0},
+	})
+	require.NotEqual(expectedRoot, root)
+	root = oneByOne.update(t, root, map[ids.ID][]byte{
+		extraKey: nil,
+	})
+	require.Equal(expectedRoot, root)
+
+	// Removing every key results in the empty tree
+	for _, key := range keys {
+		changes[key] = nil
+	}
+	root = all.update(t, expectedRoot, changes)
+	require.Equal(ids.Empty, root)
+}
+
+func TestUpdateCollapsesLeaves(t *testing.T) {
+	require := require.New(t)
+
+	// The keys share the first 15 bits
+	key0 := ids.ID{0x00, 0x00}
+	key1 := ids.ID{0x00, 0x01}
+	key2 := ids.ID{0x80}
+
+	n := make(nodes)
+	root := n.update(t, ids.Empty, map[ids.ID][]byte{
+		key0: {0},
+		key1: {1},
+		key2: {2},
+	})
+
+	proof, err := Prove(n, root, key0)
+	require.NoError(err)
+	require.Len(proof.Siblings, 16)
+
+	// Once [key1] is removed, [key0] is the only key in the left subtree of
+	// the root
+	root = n.update(t, root, map[ids.ID][]byte{
+		key1: nil,
+	})
+	proof, err = Prove(n, root, key0)
+	require.NoError(err)
+	require.Len(proof.Siblings, 1)
+	require.Equal([]byte{0}, proof.Value)
+	require.NoError(proof.Verify(root))
+}
+
+func TestUpdateRemovedNodes(t *testing.T) {
+	require := require.New(t)
+
+	n := make(nodes)
+	changes := make(map[ids.ID][]byte)
+	for i := 0; i < 32; i++ {
+		changes[ids.GenerateTestID()] = []byte{byte(i)}
+	}
+	root := n.update(t, ids.Empty, changes)
+
+	// Modify, remove and add keys
+	newChanges := make(map[ids.ID][]byte)
+	i := 0
+	for key := range changes {
+		switch i % 3 {
+		case 0:
+			newChanges[key] = []byte{byte(i), 1}
+		case 1:
+			newChanges[key] = nil
+		}
+		i++
+	}
+	newChanges[ids.GenerateTestID()] = []byte{0}
+
+	newRoot, newNodes, removedNodes, err := Update(n, root, newChanges)
+	require.NoError(err)
+	require.NotEmpty(removedNodes)
+	for nodeID, nodeBytes := range newNodes {
+		n[nodeID] = nodeBytes
+	}
+	for _, nodeID := range removedNodes {
+		delete(n, nodeID)
+	}
+
+	// Only the nodes of the new tree are left
+	require.Len(n, n.count(t, newRoot))
+	for key, value := range changes {
+		if newValue, ok := newChanges[key]; ok {
+			value = newValue
+		}
+		proof, err := Prove(n, newRoot, key)
+		require.NoError(err)
+		require.Equal(value, proof.Value)
+		require.NoError(proof.Verify(newRoot))
+	}
+}
+
+// count returns the number of nodes of the tree with root [nodeID].
+func (n nodes) count(t *testing.T, nodeID ids.ID) int {
+	if nodeID == ids.Empty {
+		return 0
+	}
+	nodeBytes, err := n.GetMerkleNode(nodeID)
+	require.NoError(t, err)
+	node, err := parseNode(nodeBytes)
+	require.NoError(t, err)
+	if node.isLeaf {
+		return 1
+	}
+	return 1 + n.count(t, node.left) + n.count(t, node.right)
+}
+
+func TestProve(t *testing.T) {
+	require := require.New(t)
+
+	n := make(nodes)
+	changes := make(map[ids.ID][]byte)
+	for i := 0; i < 32; i++ {
+		changes[ids.GenerateTestID()] = []byte{byte(i), 1, 2, 3}
+	}
+	root := n.update(t, ids.Empty, changes)
+
+	for key, value := range changes {
+		proof, err := Prove(n, root, key)
+		require.NoError(err)
+		require.Equal(value, proof.Value)
+		require.Nil(proof.Other)
+		require.NoError(proof.Verify(root))
+
+		// The proof must not verify a different value
+		proof.Value = []byte{0}
+		require.ErrorIs(proof.Verify(root), ErrInvalidProof)
+	}
+
+	// Prove keys that aren't in the tree
+	for i := 0; i < 32; i++ {
+		key := ids.GenerateTestID()
+		proof, err := Prove(n, root, key)
+		require.NoError(err)
+		require.Nil(proof.Value)
+		require.NoError(proof.Verify(root))
+
+		// The proof must not verify the inclusion of the key
+		proof.Value = []byte{0}
+		proof.Other = nil
+		require.ErrorIs(proof.Verify(root), ErrInvalidProof)
+	}
+}
+
+func TestProveEmpty(t *testing.T) {
+	require := require.New(t)
+
+	proof, err := Prove(make(nodes), ids.Empty, ids.GenerateTestID())
+	require.NoError(err)
+	require.Nil(proof.Value)
+	require.Empty(proof.Siblings)
+	require.NoError(proof.Verify(ids.Empty))
+}
+
+func TestVerifyInvalidProof(t *testing.T) {
+	require := require.New(t)
+
+	key := ids.ID{0x00}
+	otherKey := ids.ID{0x80}
+
+	n := make(nodes)
+	root := n.update(t, ids.Empty, map[ids.ID][]byte{
+		key:      {0},
+		otherKey: {1},
+	})
+
+	proof, err := Prove(n, root, key)
+	require.NoError(err)
+	require.NoError(proof.Verify(root))
+
+	// Tampered sibling
+	proof.Siblings[0] = ids.GenerateTestID()
+	require.ErrorIs(proof.Verify(root), ErrInvalidProof)
+
+	// A key can't be proven absent by a leaf that isn't on its path
+	proof, err = Prove(n, root, otherKey)
+	require.NoError(err)
+	proof = &Proof{
+		Key:      key,
+		Siblings: []ids.ID{proof.Siblings[0]},
+		Other: &Leaf{
+			Key:       otherKey,
+			ValueHash: ids.GenerateTestID(),
+		},
+	}
+	require.ErrorIs(proof.Verify(root), ErrInvalidProof)
+
+	// Too many siblings
+	proof = &Proof{
+		Key:      key,
+		Siblings: make([]ids.ID, MaxDepth+1),
+	}
+	require.ErrorIs(proof.Verify(root), ErrInvalidProof)
+}
@@ -27,7 +27,11 @@ type txMetrics struct {
 	numRemoveSubnetValidatorTxs,
 	numTransformSubnetTxs,
 	numAddPermissionlessValidatorTxs,
-	numAddPermissionlessDelegatorTxs prometheus.Counter
+	numAddPermissionlessDelegatorTxs,
+	numTransferSubnetOwnershipTxs,
+	numModifySubnetValidatorTxs,
+	numHaltBlockchainTxs,
+	numUpgradeBlockchainTxs prometheus.Counter
 }
 
 func newTxMetrics(
@@ -49,6 +53,10 @@ func newTxMetrics(
 		numTransformSubnetTxs:            newTxMetric(namespace, "transform_subnet", registerer, &errs),
 		numAddPermissionlessValidatorTxs: newTxMetric(namespace, "add_permissionless_validator", registerer, &errs),
 		numAddPermissionlessDelegatorTxs: newTxMetric(namespace, "add_permissionless_delegator", registerer, &errs),
+		numTransferSubnetOwnershipTxs:    newTxMetric(namespace, "transfer_subnet_ownership", registerer, &errs),
+		numModifySubnetValidatorTxs:      newTxMetric(namespace, "modify_subnet_validator", registerer, &errs),
+		numHaltBlockchainTxs:             newTxMetric(namespace, "halt_blockchain", registerer, &errs),
+		numUpgradeBlockchainTxs:          newTxMetric(namespace, "upgrade_blockchain", registerer, &errs),
 	}
 	return m, errs.Err
 }
@@ -132,3 +140,23 @@ func (m *txMetrics) AddPermissionlessDelegatorTx(*txs.AddPermissionlessDelegator
 	m.numAddPermissionlessDelegatorTxs.Inc()
 	return nil
 }
+
+func (m *txMetrics) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	m.numTransferSubnetOwnershipTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) ModifySubnetValidatorTx(*txs.ModifySubnetValidatorTx) error {
+	m.numModifySubnetValidatorTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) HaltBlockchainTx(*txs.HaltBlockchainTx) error {
+	m.numHaltBlockchainTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) UpgradeBlockchainTx(*txs.UpgradeBlockchainTx) error {
+	m.numUpgradeBlockchainTxs.Inc()
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs/mempool"
+
+	txexecutor "github.com/lasthyphen/dijetsnodego/vms/platformvm/txs/executor"
+)
+
+var _ mempool.PriorityFeeCalculator = (*VM)(nil)
+
+// PriorityFee returns the amount of DJTX that [tx] burns in excess of the
+// static fee it must pay at the current chain time.
+func (vm *VM) PriorityFee(tx *txs.Tx) (uint64, error) {
+	flow := &txFlow{}
+	if err := tx.Unsigned.Visit(flow); err != nil {
+		return 0, err
+	}
+	burned, err := djtx.Burned(vm.ctx.DJTXAssetID, flow.ins, flow.outs)
+	if err != nil {
+		return 0, err
+	}
+
+	feeCalculator := &txexecutor.StaticFeeCalculator{
+		Config:    &vm.Config,
+		ChainTime: vm.state.GetTimestamp(),
+	}
+	if err := tx.Unsigned.Visit(feeCalculator); err != nil {
+		return 0, err
+	}
+	if burned < feeCalculator.Fee {
+		// The tx will fail verification, so it doesn't pay a priority fee.
+		return 0, nil
+	}
+	return burned - feeCalculator.Fee, nil
+}
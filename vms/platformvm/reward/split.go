@@ -0,0 +1,22 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reward
+
+import "github.com/lasthyphen/dijetsnodego/utils/math"
+
+// Split [totalAmount] into 2 amounts based on the [shares] the validator
+// charges its delegators. The first returned value is the amount owed to the
+// delegator and the second is the amount owed to the validator.
+//
+// Assumes [shares] <= PercentDenominator.
+func Split(totalAmount uint64, shares uint32) (uint64, uint64) {
+	delegatorShares := PercentDenominator - uint64(shares)                  // shares <= PercentDenominator so no underflow
+	delegatorAmount := delegatorShares * (totalAmount / PercentDenominator) // delegatorShares <= PercentDenominator so no overflow
+	// Delay rounding as long as possible for small numbers
+	if optimisticReward, err := math.Mul64(delegatorShares, totalAmount); err == nil {
+		delegatorAmount = optimisticReward / PercentDenominator
+	}
+	validatorAmount := totalAmount - delegatorAmount // delegatorAmount <= totalAmount so no underflow
+	return delegatorAmount, validatorAmount
+}
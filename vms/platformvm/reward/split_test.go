@@ -0,0 +1,58 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reward
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		amount            uint64
+		shares            uint32
+		expectedDelegator uint64
+		expectedValidator uint64
+	}{
+		{
+			amount:            1000,
+			shares:            PercentDenominator / 10,
+			expectedDelegator: 900,
+			expectedValidator: 100,
+		},
+		{
+			amount:            1,
+			shares:            PercentDenominator,
+			expectedDelegator: 0,
+			expectedValidator: 1,
+		},
+		{
+			amount:            1,
+			shares:            0,
+			expectedDelegator: 1,
+			expectedValidator: 0,
+		},
+		{
+			amount:            9,
+			shares:            PercentDenominator / 10,
+			expectedDelegator: 8,
+			expectedValidator: 1,
+		},
+		{
+			amount:            math.MaxUint64,
+			shares:            PercentDenominator / 10,
+			expectedDelegator: 16_602_069_666_338_100_000,
+			expectedValidator: 1_844_674_407_371_451_615,
+		},
+	}
+	for _, test := range tests {
+		require := require.New(t)
+
+		delegatorAmount, validatorAmount := Split(test.amount, test.shares)
+		require.Equal(test.expectedDelegator, delegatorAmount)
+		require.Equal(test.expectedValidator, validatorAmount)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"time"
 
 	stdmath "math"
@@ -28,6 +29,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/components/keystore"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/merkle"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/signer"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/stakeable"
@@ -36,9 +38,11 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs/builder"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs/executor"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs/mempool"
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
 
 	platformapi "github.com/lasthyphen/dijetsnodego/vms/platformvm/api"
+	blockbuilder "github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks/builder"
 )
 
 const (
@@ -70,6 +74,20 @@ var (
 	errMissingPrivateKey        = errors.New("argument 'privateKey' not given")
 	errStartAfterEndTime        = errors.New("start time must be before end time")
 	errStartTimeInThePast       = errors.New("start time in the past")
+	errTxNotInMempool           = errors.New("tx not in mempool")
+	errNoStakeAmount            = errors.New("argument 'stakeAmount' must be > 0")
+	errInvalidStakeDuration     = errors.New("invalid stake duration")
+	errStartAfterEndHeight      = errors.New("start height must not be after end height")
+	errHeightNotAccepted        = errors.New("height not accepted")
+	errAddressIndexDisabled     = errors.New("address transaction indexing is disabled")
+	errHistoricalAtomicUTXOs    = errors.New("historical queries aren't supported for atomic UTXOs")
+	errTransferPrimaryNetwork   = errors.New("can't transfer the ownership of the primary network")
+	errModifyPrimaryNetwork     = errors.New("can't modify a primary network validator with modifySubnetValidator")
+	errNotStakerTx              = errors.New("tx didn't add a staker")
+	errInvalidEndTimeRange      = errors.New("argument 'minEndTime' must not be after 'maxEndTime'")
+	errNoStateRoot              = errors.New("block doesn't commit to a state root")
+	errStateRootPruned          = errors.New("state tree was pruned")
+	errBootstrapping            = errors.New("chain is currently bootstrapping")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -174,6 +192,7 @@ func (s *Service) ImportKey(_ *http.Request, args *ImportKeyArgs, reply *api.JSO
  */
 
 type GetBalanceRequest struct {
+	api.HistoricalArgs
 	// TODO: remove Address
 	Address   *string  `json:"address,omitempty"`
 	Addresses []string `json:"addresses"`
@@ -194,7 +213,8 @@ type GetBalanceResponse struct {
 	UTXOIDs             []*djtx.UTXOID         `json:"utxoIDs"`
 }
 
-// GetBalance gets the balance of an address
+// GetBalance gets the balance of an address. If [args.Height] or
+// [args.Timestamp] is specified, the balance as of that point is returned.
 func (s *Service) GetBalance(_ *http.Request, args *GetBalanceRequest, response *GetBalanceResponse) error {
 	if args.Address != nil {
 		args.Addresses = append(args.Addresses, *args.Address)
@@ -210,12 +230,16 @@ func (s *Service) GetBalance(_ *http.Request, args *GetBalanceRequest, response
 		return err
 	}
 
-	utxos, err := djtx.GetAllUTXOs(s.vm.state, addrs)
+	reader, now, err := s.vm.getUTXOReader(addrs, &args.HistoricalArgs)
+	if err != nil {
+		return err
+	}
+	utxos, err := djtx.GetAllUTXOs(reader, addrs)
 	if err != nil {
 		return fmt.Errorf("couldn't get UTXO set of %v: %w", args.Addresses, err)
 	}
 
-	currentTime := s.vm.clock.Unix()
+	currentTime := uint64(now.Unix())
 
 	unlockeds := map[ids.ID]uint64{}
 	lockedStakeables := map[ids.ID]uint64{}
@@ -422,14 +446,22 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *ap
 		limit = builder.MaxPageSize
 	}
 	if sourceChain == s.vm.ctx.ChainID {
+		var reader djtx.UTXOReader
+		reader, _, err = s.vm.getUTXOReader(addrSet, &args.HistoricalArgs)
+		if err != nil {
+			return err
+		}
 		utxos, endAddr, endUTXOID, err = djtx.GetPaginatedUTXOs(
-			s.vm.state,
+			reader,
 			addrSet,
 			startAddr,
 			startUTXO,
 			limit,
 		)
 	} else {
+		if args.Height != nil || args.Timestamp != nil {
+			return errHistoricalAtomicUTXOs
+		}
 		utxos, endAddr, endUTXOID, err = s.vm.atomicUtxosManager.GetAtomicUTXOs(
 			sourceChain,
 			addrSet,
@@ -522,8 +554,11 @@ func (s *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, response *Ge
 				continue
 			}
 
-			unsignedTx := subnet.Unsigned.(*txs.CreateSubnetTx)
-			owner := unsignedTx.Owner.(*secp256k1fx.OutputOwners)
+			subnetOwner, err := s.vm.state.GetSubnetOwner(subnetID)
+			if err != nil {
+				return err
+			}
+			owner := subnetOwner.(*secp256k1fx.OutputOwners)
 			controlAddrs := []string{}
 			for _, controlKeyID := range owner.Addrs {
 				addr, err := s.addrManager.FormatLocalAddress(controlKeyID)
@@ -582,13 +617,16 @@ func (s *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, response *Ge
 			return err
 		}
 
-		subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
-		if !ok {
+		if _, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx); !ok {
 			return fmt.Errorf("expected tx type *txs.CreateSubnetTx but got %T", subnetTx.Unsigned)
 		}
-		owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+		subnetOwner, err := s.vm.state.GetSubnetOwner(subnetID)
+		if err != nil {
+			return err
+		}
+		owner, ok := subnetOwner.(*secp256k1fx.OutputOwners)
 		if !ok {
-			return fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", subnet.Owner)
+			return fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", subnetOwner)
 		}
 
 		controlAddrs := make([]string, len(owner.Addrs))
@@ -655,6 +693,15 @@ func (s *Service) GetStakingAssetID(_ *http.Request, args *GetStakingAssetIDArgs
  ******************************************************
  */
 
+// StakerIndex marks the position of a current staker in the order stakers are
+// removed from the current staker set. Used for pagination.
+type StakerIndex struct {
+	// Unix time, in seconds, at which the staker stops staking
+	EndTime json.Uint64 `json:"endTime"`
+	// ID of the tx that added the staker
+	TxID ids.ID `json:"txID"`
+}
+
 // GetCurrentValidatorsArgs are the arguments for calling GetCurrentValidators
 type GetCurrentValidatorsArgs struct {
 	// Subnet we're listing the validators of
@@ -665,28 +712,71 @@ type GetCurrentValidatorsArgs struct {
 	// some nodeIDs are not currently validators, they
 	// will be omitted from the response.
 	NodeIDs []ids.NodeID `json:"nodeIDs"`
+	// Only the validators after [StartIndex] are returned. If omitted, the
+	// validators are returned from the first one to stop staking.
+	StartIndex StakerIndex `json:"startIndex"`
+	// Maximum number of validators to return. If 0, all the validators are
+	// returned.
+	Limit json.Uint32 `json:"limit"`
+	// If non-zero, only the validators staking at least [MinStake] are
+	// returned.
+	MinStake json.Uint64 `json:"minStake"`
+	// If non-zero, only the validators that stop staking at or after
+	// [MinEndTime] are returned.
+	MinEndTime json.Uint64 `json:"minEndTime"`
+	// If non-zero, only the validators that stop staking at or before
+	// [MaxEndTime] are returned.
+	MaxEndTime json.Uint64 `json:"maxEndTime"`
+	// If provided, only the validators whose validation or delegation rewards
+	// are sent to [RewardOwner] are returned.
+	RewardOwner string `json:"rewardOwner"`
+	// If true, only the validators this node is connected to are returned.
+	ConnectedOnly bool `json:"connectedOnly"`
+	// If true, the delegators of the validators aren't returned. They can be
+	// fetched with GetDelegators.
+	OmitDelegators bool `json:"omitDelegators"`
 }
 
 // GetCurrentValidatorsReply are the results from calling GetCurrentValidators.
 // Each validator contains a list of delegators to itself.
 type GetCurrentValidatorsReply struct {
 	Validators []interface{} `json:"validators"`
+	// Number of validators returned
+	NumFetched json.Uint64 `json:"numFetched"`
+	// Index to pass as [StartIndex] to fetch the next page
+	EndIndex StakerIndex `json:"endIndex"`
 }
 
 // GetCurrentValidators returns current validators and delegators
 func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidatorsArgs, reply *GetCurrentValidatorsReply) error {
 	s.vm.ctx.Log.Debug("Platform: GetCurrentValidators called")
 
-	reply.Validators = []interface{}{}
+	if args.MinEndTime != 0 && args.MaxEndTime != 0 && args.MinEndTime > args.MaxEndTime {
+		return errInvalidEndTimeRange
+	}
 
-	// Validator's node ID as string --> Delegators to them
-	vdrToDelegators := map[ids.NodeID][]platformapi.PrimaryDelegator{}
+	reply.Validators = []interface{}{}
+	reply.EndIndex = args.StartIndex
 
 	// Create set of nodeIDs
 	nodeIDs := set.Set[ids.NodeID]{}
 	nodeIDs.Add(args.NodeIDs...)
 	includeAllNodes := nodeIDs.Len() == 0
 
+	var rewardOwner ids.ShortID
+	if args.RewardOwner != "" {
+		var err error
+		rewardOwner, err = djtx.ParseServiceAddress(s.addrManager, args.RewardOwner)
+		if err != nil {
+			return fmt.Errorf("couldn't parse reward owner %q: %w", args.RewardOwner, err)
+		}
+	}
+
+	startIndex, err := s.getStakerIndex(args.StartIndex)
+	if err != nil {
+		return err
+	}
+
 	currentStakerIterator, err := s.vm.state.GetCurrentStakerIterator()
 	if err != nil {
 		return err
@@ -696,24 +786,39 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 	// TODO: do not iterate over all stakers when nodeIDs given. Use currentValidators.ValidatorSet for iteration
 	for currentStakerIterator.Next() { // Iterates in order of increasing stop time
 		currentStaker := currentStakerIterator.Value()
+		endTime := json.Uint64(currentStaker.EndTime.Unix())
+		if args.MaxEndTime != 0 && endTime > args.MaxEndTime {
+			break
+		}
+		if startIndex != nil && !startIndex.Less(currentStaker) {
+			continue
+		}
 		if args.SubnetID != currentStaker.SubnetID {
 			continue
 		}
 		if !includeAllNodes && !nodeIDs.Contains(currentStaker.NodeID) {
 			continue
 		}
+		if endTime < args.MinEndTime || currentStaker.Weight < uint64(args.MinStake) {
+			continue
+		}
+
+		nodeID := currentStaker.NodeID
+		connected := s.vm.uptimeManager.IsConnected(nodeID, args.SubnetID)
+		if args.ConnectedOnly && !connected {
+			continue
+		}
 
 		tx, _, err := s.vm.state.GetTx(currentStaker.TxID)
 		if err != nil {
 			return err
 		}
 
-		nodeID := currentStaker.NodeID
 		weight := json.Uint64(currentStaker.Weight)
 		apiStaker := platformapi.Staker{
 			TxID:        currentStaker.TxID,
 			StartTime:   json.Uint64(currentStaker.StartTime.Unix()),
-			EndTime:     json.Uint64(currentStaker.EndTime.Unix()),
+			EndTime:     endTime,
 			StakeAmount: &weight,
 			NodeID:      nodeID,
 		}
@@ -721,6 +826,14 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 		potentialReward := json.Uint64(currentStaker.PotentialReward)
 		switch staker := tx.Unsigned.(type) {
 		case txs.ValidatorTx:
+			validationOwner, isValidationOwner := staker.ValidationRewardsOwner().(*secp256k1fx.OutputOwners)
+			delegationOwner, isDelegationOwner := staker.DelegationRewardsOwner().(*secp256k1fx.OutputOwners)
+			if args.RewardOwner != "" &&
+				!(isValidationOwner && hasAddress(validationOwner, rewardOwner)) &&
+				!(isDelegationOwner && hasAddress(delegationOwner, rewardOwner)) {
+				continue
+			}
+
 			shares := staker.Shares()
 			delegationFee := json.Float32(100 * float32(shares) / float32(reward.PercentDenominator))
 
@@ -729,20 +842,17 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 				return err
 			}
 
-			connected := s.vm.uptimeManager.IsConnected(nodeID, args.SubnetID)
 			var (
 				validationRewardOwner *platformapi.Owner
 				delegationRewardOwner *platformapi.Owner
 			)
-			validationOwner, ok := staker.ValidationRewardsOwner().(*secp256k1fx.OutputOwners)
-			if ok {
+			if isValidationOwner {
 				validationRewardOwner, err = s.getAPIOwner(validationOwner)
 				if err != nil {
 					return err
 				}
 			}
-			delegationOwner, ok := staker.DelegationRewardsOwner().(*secp256k1fx.OutputOwners)
-			if ok {
+			if isDelegationOwner {
 				delegationRewardOwner, err = s.getAPIOwner(delegationOwner)
 				if err != nil {
 					return err
@@ -766,30 +876,28 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 				}
 			}
 
-			reply.Validators = append(reply.Validators, vdr)
-
-		case txs.DelegatorTx:
-			var rewardOwner *platformapi.Owner
-			owner, ok := staker.RewardsOwner().(*secp256k1fx.OutputOwners)
-			if ok {
-				rewardOwner, err = s.getAPIOwner(owner)
+			if !args.OmitDelegators {
+				vdr.Delegators, err = s.getAPIDelegators(args.SubnetID, nodeID, nil, 0)
 				if err != nil {
 					return err
 				}
 			}
 
-			delegator := platformapi.PrimaryDelegator{
-				Staker:          apiStaker,
-				RewardOwner:     rewardOwner,
-				PotentialReward: &potentialReward,
-			}
-			vdrToDelegators[delegator.NodeID] = append(vdrToDelegators[delegator.NodeID], delegator)
+			reply.Validators = append(reply.Validators, vdr)
+
+		case txs.DelegatorTx:
+			// Delegators are returned with their validator.
+			continue
 		case *txs.AddSubnetValidatorTx:
+			if args.RewardOwner != "" {
+				// Permissioned validators aren't rewarded.
+				continue
+			}
+
 			uptime, err := s.getAPIUptime(currentStaker)
 			if err != nil {
 				return err
 			}
-			connected := s.vm.uptimeManager.IsConnected(nodeID, args.SubnetID)
 			reply.Validators = append(reply.Validators, platformapi.PermissionedValidator{
 				Staker:    apiStaker,
 				Connected: connected,
@@ -798,18 +906,169 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 		default:
 			return fmt.Errorf("expected validator but got %T", tx.Unsigned)
 		}
+
+		reply.EndIndex = StakerIndex{
+			EndTime: endTime,
+			TxID:    currentStaker.TxID,
+		}
+		if args.Limit != 0 && len(reply.Validators) >= int(args.Limit) {
+			break
+		}
 	}
 
-	for i, vdrIntf := range reply.Validators {
-		vdr, ok := vdrIntf.(platformapi.PermissionlessValidator)
-		if !ok {
+	reply.NumFetched = json.Uint64(len(reply.Validators))
+	return nil
+}
+
+// GetDelegatorsArgs are the arguments for calling GetDelegators
+type GetDelegatorsArgs struct {
+	// Subnet the validator is validating
+	// If omitted, defaults to primary network
+	SubnetID ids.ID `json:"subnetID"`
+	// NodeID of the validator the delegators are delegating to
+	NodeID ids.NodeID `json:"nodeID"`
+	// Only the delegators after [StartIndex] are returned. If omitted, the
+	// delegators are returned from the first one to stop staking.
+	StartIndex StakerIndex `json:"startIndex"`
+	// Maximum number of delegators to return. If 0, MaxPageSize is used.
+	Limit json.Uint32 `json:"limit"`
+}
+
+// GetDelegatorsReply are the results from calling GetDelegators
+type GetDelegatorsReply struct {
+	Delegators []platformapi.PrimaryDelegator `json:"delegators"`
+	// Number of delegators returned
+	NumFetched json.Uint64 `json:"numFetched"`
+	// Index to pass as [StartIndex] to fetch the next page
+	EndIndex StakerIndex `json:"endIndex"`
+}
+
+// GetDelegators returns a page of the current delegators of a validator, in
+// the order they stop staking.
+func (s *Service) GetDelegators(_ *http.Request, args *GetDelegatorsArgs, reply *GetDelegatorsReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetDelegators called",
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	limit := int(args.Limit)
+	if limit <= 0 || builder.MaxPageSize < limit {
+		limit = builder.MaxPageSize
+	}
+
+	startIndex, err := s.getStakerIndex(args.StartIndex)
+	if err != nil {
+		return err
+	}
+
+	reply.Delegators, err = s.getAPIDelegators(args.SubnetID, args.NodeID, startIndex, limit)
+	if err != nil {
+		return err
+	}
+
+	reply.NumFetched = json.Uint64(len(reply.Delegators))
+	reply.EndIndex = args.StartIndex
+	if len(reply.Delegators) > 0 {
+		lastDelegator := reply.Delegators[len(reply.Delegators)-1]
+		reply.EndIndex = StakerIndex{
+			EndTime: lastDelegator.EndTime,
+			TxID:    lastDelegator.TxID,
+		}
+	}
+	return nil
+}
+
+// getAPIDelegators returns up to [limit] current delegators of the validator
+// with [nodeID] on [subnetID], that stop staking after [startIndex]. If
+// [startIndex] is nil, the delegators are returned from the first one to stop
+// staking. If [limit] is 0, all the delegators are returned.
+func (s *Service) getAPIDelegators(
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	startIndex *state.Staker,
+	limit int,
+) ([]platformapi.PrimaryDelegator, error) {
+	delegatorIterator, err := s.vm.state.GetCurrentDelegatorIterator(subnetID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer delegatorIterator.Release()
+
+	var delegators []platformapi.PrimaryDelegator
+	for delegatorIterator.Next() {
+		delegator := delegatorIterator.Value()
+		if startIndex != nil && !startIndex.Less(delegator) {
 			continue
 		}
-		vdr.Delegators = vdrToDelegators[vdr.NodeID]
-		reply.Validators[i] = vdr
+
+		tx, _, err := s.vm.state.GetTx(delegator.TxID)
+		if err != nil {
+			return nil, err
+		}
+		delegatorTx, ok := tx.Unsigned.(txs.DelegatorTx)
+		if !ok {
+			return nil, fmt.Errorf("expected delegator but got %T", tx.Unsigned)
+		}
+
+		var rewardOwner *platformapi.Owner
+		owner, ok := delegatorTx.RewardsOwner().(*secp256k1fx.OutputOwners)
+		if ok {
+			rewardOwner, err = s.getAPIOwner(owner)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		weight := json.Uint64(delegator.Weight)
+		potentialReward := json.Uint64(delegator.PotentialReward)
+		delegators = append(delegators, platformapi.PrimaryDelegator{
+			Staker: platformapi.Staker{
+				TxID:        delegator.TxID,
+				StartTime:   json.Uint64(delegator.StartTime.Unix()),
+				EndTime:     json.Uint64(delegator.EndTime.Unix()),
+				StakeAmount: &weight,
+				NodeID:      nodeID,
+			},
+			RewardOwner:     rewardOwner,
+			PotentialReward: &potentialReward,
+		})
+		if limit > 0 && len(delegators) >= limit {
+			break
+		}
 	}
+	return delegators, nil
+}
 
-	return nil
+// getStakerIndex returns a staker ordered like the current staker marked by
+// [index], so that the stakers after [index] are greater than it. Returns nil
+// if [index] is empty.
+func (s *Service) getStakerIndex(index StakerIndex) (*state.Staker, error) {
+	if index.TxID == ids.Empty {
+		return nil, nil
+	}
+
+	tx, _, err := s.vm.state.GetTx(index.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get start index tx %s: %w", index.TxID, err)
+	}
+	staker, ok := tx.Unsigned.(txs.Staker)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errNotStakerTx, index.TxID)
+	}
+	return &state.Staker{
+		TxID:     index.TxID,
+		NextTime: time.Unix(int64(index.EndTime), 0),
+		Priority: staker.CurrentPriority(),
+	}, nil
+}
+
+func hasAddress(owner *secp256k1fx.OutputOwners, addr ids.ShortID) bool {
+	for _, ownerAddr := range owner.Addrs {
+		if ownerAddr == addr {
+			return true
+		}
+	}
+	return false
 }
 
 // GetPendingValidatorsArgs are the arguments for calling GetPendingValidators
@@ -928,6 +1187,209 @@ func (s *Service) GetCurrentSupply(_ *http.Request, args *GetCurrentSupplyArgs,
 	return err
 }
 
+// EstimateRewardArgs are the arguments for calling EstimateReward
+type EstimateRewardArgs struct {
+	// ID of the subnet to stake on. If omitted, defaults to the primary
+	// network.
+	SubnetID ids.ID `json:"subnetID"`
+	// Amount of tokens to stake
+	StakeAmount json.Uint64 `json:"stakeAmount"`
+	// Number of seconds to stake for
+	Duration json.Uint64 `json:"duration"`
+	// Fee, as a percentage, charged by the validator to its delegators. If
+	// provided, the reward is split between the delegator and the validator.
+	DelegationFeeRate *json.Float32 `json:"delegationFeeRate"`
+	// If provided, the reward status of this node's current validator is
+	// also reported.
+	NodeID *ids.NodeID `json:"nodeID"`
+}
+
+// EstimatedValidatorReward is the reward status of a current validator
+type EstimatedValidatorReward struct {
+	TxID ids.ID `json:"txID"`
+	// Reward the validator will receive if it is eligible
+	PotentialReward json.Uint64 `json:"potentialReward"`
+	// Uptime of the validator, between 0 and 1
+	Uptime json.Float32 `json:"uptime"`
+	// Uptime the validator must have to be rewarded, between 0 and 1
+	RequiredUptime json.Float32 `json:"requiredUptime"`
+	// True if the validator would currently be rewarded
+	Eligible bool `json:"eligible"`
+}
+
+// EstimateRewardReply are the results from calling EstimateReward
+type EstimateRewardReply struct {
+	// Supply of the staked asset used to compute the reward
+	CurrentSupply json.Uint64 `json:"currentSupply"`
+	// Total projected reward
+	Reward json.Uint64 `json:"reward"`
+	// Portion of [Reward] owed to the delegator. Only set if a delegation fee
+	// rate was provided.
+	DelegatorReward *json.Uint64 `json:"delegatorReward,omitempty"`
+	// Portion of [Reward] owed to the validator. Only set if a delegation fee
+	// rate was provided.
+	ValidatorFee *json.Uint64 `json:"validatorFee,omitempty"`
+	// Reward status of the validator of the provided node, if any
+	Validator *EstimatedValidatorReward `json:"validator,omitempty"`
+}
+
+// EstimateReward returns the reward a staker would receive for staking
+// [args.StakeAmount] for [args.Duration] given the current supply.
+func (s *Service) EstimateReward(_ *http.Request, args *EstimateRewardArgs, reply *EstimateRewardReply) error {
+	s.vm.ctx.Log.Debug("Platform: EstimateReward called",
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	if args.StakeAmount == 0 {
+		return errNoStakeAmount
+	}
+
+	minStakeDuration, maxStakeDuration, uptimeRequirement, err := s.getStakingRules(args.SubnetID)
+	if err != nil {
+		return err
+	}
+	duration := time.Duration(args.Duration) * time.Second
+	if duration < minStakeDuration || duration > maxStakeDuration {
+		return fmt.Errorf("%w: duration %s must be between %s and %s",
+			errInvalidStakeDuration,
+			duration,
+			minStakeDuration,
+			maxStakeDuration,
+		)
+	}
+
+	rewards, err := executor.GetRewardsCalculator(s.vm.txExecutorBackend, s.vm.state, args.SubnetID)
+	if err != nil {
+		return err
+	}
+	currentSupply, err := s.vm.state.GetCurrentSupply(args.SubnetID)
+	if err != nil {
+		return err
+	}
+
+	potentialReward := rewards.Calculate(duration, uint64(args.StakeAmount), currentSupply)
+	reply.CurrentSupply = json.Uint64(currentSupply)
+	reply.Reward = json.Uint64(potentialReward)
+
+	if args.DelegationFeeRate != nil {
+		if *args.DelegationFeeRate < 0 || *args.DelegationFeeRate > 100 {
+			return errInvalidDelegationRate
+		}
+		shares := uint32(10000 * *args.DelegationFeeRate)
+		delegatorReward, validatorFee := reward.Split(potentialReward, shares)
+		reply.DelegatorReward = (*json.Uint64)(&delegatorReward)
+		reply.ValidatorFee = (*json.Uint64)(&validatorFee)
+	}
+
+	if args.NodeID == nil {
+		return nil
+	}
+
+	validator, err := s.vm.state.GetCurrentValidator(args.SubnetID, *args.NodeID)
+	if err != nil {
+		return fmt.Errorf("couldn't get validator %s of subnet %s: %w", *args.NodeID, args.SubnetID, err)
+	}
+	// Rewards are given based on the uptime of the primary network validator.
+	primaryNetworkValidator, err := s.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, *args.NodeID)
+	if err != nil {
+		return fmt.Errorf("couldn't get primary network validator %s: %w", *args.NodeID, err)
+	}
+	uptime, err := s.vm.uptimeManager.CalculateUptimePercentFrom(
+		primaryNetworkValidator.NodeID,
+		constants.PrimaryNetworkID,
+		primaryNetworkValidator.StartTime,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate uptime: %w", err)
+	}
+
+	reply.Validator = &EstimatedValidatorReward{
+		TxID:            validator.TxID,
+		PotentialReward: json.Uint64(validator.PotentialReward),
+		Uptime:          json.Float32(uptime),
+		RequiredUptime:  json.Float32(uptimeRequirement),
+		Eligible:        uptime >= uptimeRequirement,
+	}
+	return nil
+}
+
+// getStakingRules returns the minimum and maximum staking durations and the
+// uptime requirement of [subnetID].
+func (s *Service) getStakingRules(subnetID ids.ID) (time.Duration, time.Duration, float64, error) {
+	if subnetID == constants.PrimaryNetworkID {
+		return s.vm.MinStakeDuration, s.vm.MaxStakeDuration, s.vm.UptimePercentage, nil
+	}
+
+	transformSubnetIntf, err := s.vm.state.GetSubnetTransformation(subnetID)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("couldn't get transformation of subnet %s: %w", subnetID, err)
+	}
+	transformSubnet, ok := transformSubnetIntf.Unsigned.(*txs.TransformSubnetTx)
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("expected TransformSubnetTx but got %T", transformSubnetIntf.Unsigned)
+	}
+	return time.Duration(transformSubnet.MinStakeDuration) * time.Second,
+		time.Duration(transformSubnet.MaxStakeDuration) * time.Second,
+		float64(transformSubnet.UptimeRequirement) / reward.PercentDenominator,
+		nil
+}
+
+// APIStakingStatus is the reward eligibility of this node's validator on a
+// subnet
+type APIStakingStatus struct {
+	SubnetID  ids.ID      `json:"subnetID"`
+	TxID      ids.ID      `json:"txID"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	// Observed uptime, between 0 and 1
+	Uptime json.Float32 `json:"uptime"`
+	// Uptime required to be rewarded, between 0 and 1
+	RequiredUptime json.Float32 `json:"requiredUptime"`
+	// Number of seconds left in the staking period
+	TimeRemaining json.Uint64 `json:"timeRemaining"`
+	// Uptime at the end of the staking period if the node stays up for all of
+	// the remaining time
+	MaxUptime json.Float32 `json:"maxUptime"`
+	// True if the validator will be rewarded if it stays up at the rate
+	// observed so far
+	OnTrack bool `json:"onTrack"`
+}
+
+// GetOwnStakingStatusReply is the response from calling GetOwnStakingStatus
+type GetOwnStakingStatusReply struct {
+	NodeID          ids.NodeID         `json:"nodeID"`
+	StakingStatuses []APIStakingStatus `json:"stakingStatuses"`
+}
+
+// GetOwnStakingStatus returns the uptime and reward eligibility of this node
+// on the primary network and on each tracked subnet that rewards its
+// validators.
+func (s *Service) GetOwnStakingStatus(_ *http.Request, _ *struct{}, reply *GetOwnStakingStatusReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetOwnStakingStatus called")
+
+	statuses, err := s.vm.getStakingStatuses()
+	if err != nil {
+		return err
+	}
+
+	reply.NodeID = s.vm.ctx.NodeID
+	reply.StakingStatuses = make([]APIStakingStatus, len(statuses))
+	for i, status := range statuses {
+		reply.StakingStatuses[i] = APIStakingStatus{
+			SubnetID:       status.subnetID,
+			TxID:           status.staker.TxID,
+			StartTime:      json.Uint64(status.staker.StartTime.Unix()),
+			EndTime:        json.Uint64(status.staker.EndTime.Unix()),
+			Uptime:         json.Float32(status.uptime),
+			RequiredUptime: json.Float32(status.requiredUptime),
+			TimeRemaining:  json.Uint64(status.timeRemaining / time.Second),
+			MaxUptime:      json.Float32(status.maxUptime),
+			OnTrack:        status.onTrack(),
+		}
+	}
+	return nil
+}
+
 // SampleValidatorsArgs are the arguments for calling SampleValidators
 type SampleValidatorsArgs struct {
 	// Number of validators in the sample
@@ -1282,23 +1744,28 @@ func (s *Service) AddSubnetValidator(_ *http.Request, args *AddSubnetValidatorAr
 	return errs.Err
 }
 
-// CreateSubnetArgs are the arguments to CreateSubnet
-type CreateSubnetArgs struct {
+// ModifySubnetValidatorArgs are the arguments to ModifySubnetValidator
+type ModifySubnetValidatorArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
-	// The ID member of APISubnet is ignored
-	APISubnet
+	// ID of the node whose validation is being modified
+	NodeID ids.NodeID `json:"nodeID"`
+	// ID of the subnet the node is validating
+	SubnetID ids.ID `json:"subnetID"`
+	// The new weight of the validator
+	Weight json.Uint64 `json:"weight"`
+	// The new end time of the validator. If 0, the end time is unchanged.
+	EndTime json.Uint64 `json:"endTime"`
 }
 
-// CreateSubnet creates and signs and issues a transaction to create a new
-// subnet
-func (s *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, response *api.JSONTxIDChangeAddr) error {
-	s.vm.ctx.Log.Debug("Platform: CreateSubnet called")
+// ModifySubnetValidator creates and signs and issues a transaction to change
+// the weight, and optionally the end time, of a current validator of a subnet
+// without removing it from the validator set
+func (s *Service) ModifySubnetValidator(_ *http.Request, args *ModifySubnetValidatorArgs, response *api.JSONTxIDChangeAddr) error {
+	s.vm.ctx.Log.Debug("Platform: ModifySubnetValidator called")
 
-	// Parse the control keys
-	controlKeys, err := djtx.ParseServiceAddresses(s.addrManager, args.ControlKeys)
-	if err != nil {
-		return err
+	if args.SubnetID == constants.PrimaryNetworkID {
+		return errModifyPrimaryNetwork
 	}
 
 	// Parse the from addresses
@@ -1313,17 +1780,16 @@ func (s *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, response
 	}
 	defer user.Close()
 
-	privKeys, err := keystore.GetKeychain(user, fromAddrs)
+	keys, err := keystore.GetKeychain(user, fromAddrs)
 	if err != nil {
 		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
 	}
 
-	// Parse the change address. Assumes that if the user has no keys,
-	// this operation will fail so the change address can be anything.
-	if len(privKeys.Keys) == 0 {
+	// Parse the change address.
+	if len(keys.Keys) == 0 {
 		return errNoKeys
 	}
-	changeAddr := privKeys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	changeAddr := keys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
 	if args.ChangeAddr != "" {
 		changeAddr, err = djtx.ParseServiceAddress(s.addrManager, args.ChangeAddr)
 		if err != nil {
@@ -1332,10 +1798,12 @@ func (s *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, response
 	}
 
 	// Create the transaction
-	tx, err := s.vm.txBuilder.NewCreateSubnetTx(
-		uint32(args.Threshold), // Threshold
-		controlKeys.List(),     // Control Addresses
-		privKeys.Keys,          // Private keys
+	tx, err := s.vm.txBuilder.NewModifySubnetValidatorTx(
+		uint64(args.Weight),  // Weight
+		uint64(args.EndTime), // End time
+		args.NodeID,          // Node ID
+		args.SubnetID,        // Subnet ID
+		keys.Keys,
 		changeAddr,
 	)
 	if err != nil {
@@ -1354,42 +1822,23 @@ func (s *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, response
 	return errs.Err
 }
 
-// ExportDJTXArgs are the arguments to ExportDJTX
-type ExportDJTXArgs struct {
+// CreateSubnetArgs are the arguments to CreateSubnet
+type CreateSubnetArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
-
-	// Amount of DJTX to send
-	Amount json.Uint64 `json:"amount"`
-
-	// Chain the funds are going to. Optional. Used if To address does not include the chainID.
-	TargetChain string `json:"targetChain"`
-
-	// ID of the address that will receive the DJTX. This address may include the
-	// chainID, which is used to determine what the destination chain is.
-	To string `json:"to"`
+	// The ID member of APISubnet is ignored
+	APISubnet
 }
 
-// ExportDJTX exports DJTX from the P-Chain to the X-Chain
-// It must be imported on the X-Chain to complete the transfer
-func (s *Service) ExportDJTX(_ *http.Request, args *ExportDJTXArgs, response *api.JSONTxIDChangeAddr) error {
-	s.vm.ctx.Log.Debug("Platform: ExportDJTX called")
-
-	if args.Amount == 0 {
-		return errNoAmount
-	}
+// CreateSubnet creates and signs and issues a transaction to create a new
+// subnet
+func (s *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, response *api.JSONTxIDChangeAddr) error {
+	s.vm.ctx.Log.Debug("Platform: CreateSubnet called")
 
-	// Get the chainID and parse the to address
-	chainID, to, err := s.addrManager.ParseAddress(args.To)
+	// Parse the control keys
+	controlKeys, err := djtx.ParseServiceAddresses(s.addrManager, args.ControlKeys)
 	if err != nil {
-		chainID, err = s.vm.ctx.BCLookup.Lookup(args.TargetChain)
-		if err != nil {
-			return err
-		}
-		to, err = ids.ShortFromString(args.To)
-		if err != nil {
-			return err
-		}
+		return err
 	}
 
 	// Parse the from addresses
@@ -1423,12 +1872,184 @@ func (s *Service) ExportDJTX(_ *http.Request, args *ExportDJTXArgs, response *ap
 	}
 
 	// Create the transaction
-	tx, err := s.vm.txBuilder.NewExportTx(
-		uint64(args.Amount), // Amount
-		chainID,             // ID of the chain to send the funds to
-		to,                  // Address
-		privKeys.Keys,       // Private keys
-		changeAddr,          // Change address
+	tx, err := s.vm.txBuilder.NewCreateSubnetTx(
+		uint32(args.Threshold), // Threshold
+		controlKeys.List(),     // Control Addresses
+		privKeys.Keys,          // Private keys
+		changeAddr,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = s.addrManager.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		s.vm.Builder.AddUnverifiedTx(tx),
+		user.Close(),
+	)
+	return errs.Err
+}
+
+// TransferSubnetOwnershipArgs are the arguments to TransferSubnetOwnership
+type TransferSubnetOwnershipArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// ID of the subnet whose owner is being replaced
+	SubnetID ids.ID `json:"subnetID"`
+	// The ID member of APISubnet is ignored. ControlKeys and Threshold specify
+	// the new owner of the subnet.
+	APISubnet
+}
+
+// TransferSubnetOwnership creates and signs and issues a transaction to
+// replace the owner of a subnet. The transaction is authorized by the current
+// owner of the subnet.
+func (s *Service) TransferSubnetOwnership(_ *http.Request, args *TransferSubnetOwnershipArgs, response *api.JSONTxIDChangeAddr) error {
+	s.vm.ctx.Log.Debug("Platform: TransferSubnetOwnership called")
+
+	if args.SubnetID == constants.PrimaryNetworkID {
+		return errTransferPrimaryNetwork
+	}
+
+	// Parse the control keys
+	controlKeys, err := djtx.ParseServiceAddresses(s.addrManager, args.ControlKeys)
+	if err != nil {
+		return err
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := djtx.ParseServiceAddresses(s.addrManager, args.From)
+	if err != nil {
+		return err
+	}
+
+	user, err := keystore.NewUserFromKeystore(s.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	privKeys, err := keystore.GetKeychain(user, fromAddrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address. Assumes that if the user has no keys,
+	// this operation will fail so the change address can be anything.
+	if len(privKeys.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := privKeys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = djtx.ParseServiceAddress(s.addrManager, args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := s.vm.txBuilder.NewTransferSubnetOwnershipTx(
+		args.SubnetID,
+		uint32(args.Threshold), // Threshold
+		controlKeys.List(),     // Control Addresses
+		privKeys.Keys,          // Private keys
+		changeAddr,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = s.addrManager.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		s.vm.Builder.AddUnverifiedTx(tx),
+		user.Close(),
+	)
+	return errs.Err
+}
+
+// ExportDJTXArgs are the arguments to ExportDJTX
+type ExportDJTXArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+
+	// Amount of DJTX to send
+	Amount json.Uint64 `json:"amount"`
+
+	// Chain the funds are going to. Optional. Used if To address does not include the chainID.
+	TargetChain string `json:"targetChain"`
+
+	// ID of the address that will receive the DJTX. This address may include the
+	// chainID, which is used to determine what the destination chain is.
+	To string `json:"to"`
+}
+
+// ExportDJTX exports DJTX from the P-Chain to the X-Chain
+// It must be imported on the X-Chain to complete the transfer
+func (s *Service) ExportDJTX(_ *http.Request, args *ExportDJTXArgs, response *api.JSONTxIDChangeAddr) error {
+	s.vm.ctx.Log.Debug("Platform: ExportDJTX called")
+
+	if args.Amount == 0 {
+		return errNoAmount
+	}
+
+	// Get the chainID and parse the to address
+	chainID, to, err := s.addrManager.ParseAddress(args.To)
+	if err != nil {
+		chainID, err = s.vm.ctx.BCLookup.Lookup(args.TargetChain)
+		if err != nil {
+			return err
+		}
+		to, err = ids.ShortFromString(args.To)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := djtx.ParseServiceAddresses(s.addrManager, args.From)
+	if err != nil {
+		return err
+	}
+
+	user, err := keystore.NewUserFromKeystore(s.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	privKeys, err := keystore.GetKeychain(user, fromAddrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address. Assumes that if the user has no keys,
+	// this operation will fail so the change address can be anything.
+	if len(privKeys.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := privKeys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = djtx.ParseServiceAddress(s.addrManager, args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := s.vm.txBuilder.NewExportTx(
+		uint64(args.Amount), // Amount
+		chainID,             // ID of the chain to send the funds to
+		to,                  // Address
+		privKeys.Keys,       // Private keys
+		changeAddr,          // Change address
 	)
 	if err != nil {
 		return fmt.Errorf("couldn't create tx: %w", err)
@@ -1551,43 +2172,205 @@ type CreateBlockchainArgs struct {
 	Encoding formatting.Encoding `json:"encoding"`
 }
 
-// CreateBlockchain issues a transaction to create a new blockchain
-func (s *Service) CreateBlockchain(_ *http.Request, args *CreateBlockchainArgs, response *api.JSONTxIDChangeAddr) error {
-	s.vm.ctx.Log.Debug("Platform: CreateBlockchain called")
+// CreateBlockchain issues a transaction to create a new blockchain
+func (s *Service) CreateBlockchain(_ *http.Request, args *CreateBlockchainArgs, response *api.JSONTxIDChangeAddr) error {
+	s.vm.ctx.Log.Debug("Platform: CreateBlockchain called")
+
+	switch {
+	case args.Name == "":
+		return errMissingName
+	case args.VMID == "":
+		return errMissingVMID
+	}
+
+	genesisBytes, err := formatting.Decode(args.Encoding, args.GenesisData)
+	if err != nil {
+		return fmt.Errorf("problem parsing genesis data: %w", err)
+	}
+
+	vmID, err := s.vm.Chains.LookupVM(args.VMID)
+	if err != nil {
+		return fmt.Errorf("no VM with ID '%s' found", args.VMID)
+	}
+
+	fxIDs := []ids.ID(nil)
+	for _, fxIDStr := range args.FxIDs {
+		fxID, err := s.vm.Chains.LookupVM(fxIDStr)
+		if err != nil {
+			return fmt.Errorf("no FX with ID '%s' found", fxIDStr)
+		}
+		fxIDs = append(fxIDs, fxID)
+	}
+	// If creating AVM instance, use secp256k1fx
+	// TODO: Document FXs and have user specify them in API call
+	fxIDsSet := set.Set[ids.ID]{}
+	fxIDsSet.Add(fxIDs...)
+	if vmID == constants.AVMID && !fxIDsSet.Contains(secp256k1fx.ID) {
+		fxIDs = append(fxIDs, secp256k1fx.ID)
+	}
+
+	if args.SubnetID == constants.PrimaryNetworkID {
+		return txs.ErrCantValidatePrimaryNetwork
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := djtx.ParseServiceAddresses(s.addrManager, args.From)
+	if err != nil {
+		return err
+	}
+
+	user, err := keystore.NewUserFromKeystore(s.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	keys, err := keystore.GetKeychain(user, fromAddrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address. Assumes that if the user has no keys,
+	// this operation will fail so the change address can be anything.
+	if len(keys.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := keys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = djtx.ParseServiceAddress(s.addrManager, args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := s.vm.txBuilder.NewCreateChainTx(
+		args.SubnetID,
+		genesisBytes,
+		vmID,
+		fxIDs,
+		args.Name,
+		keys.Keys,
+		changeAddr, // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = s.addrManager.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		s.vm.Builder.AddUnverifiedTx(tx),
+		user.Close(),
+	)
+	return errs.Err
+}
+
+// HaltBlockchainArgs is the arguments for calling HaltBlockchain
+type HaltBlockchainArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// ID of Subnet that validates the blockchain
+	SubnetID ids.ID `json:"subnetID"`
+	// ID of the blockchain to halt
+	BlockchainID ids.ID `json:"blockchainID"`
+}
+
+// HaltBlockchain issues a transaction to halt a blockchain. Once halted, nodes
+// stop running the blockchain and never create it again.
+func (s *Service) HaltBlockchain(_ *http.Request, args *HaltBlockchainArgs, response *api.JSONTxIDChangeAddr) error {
+	s.vm.ctx.Log.Debug("Platform: HaltBlockchain called")
+
+	if args.SubnetID == constants.PrimaryNetworkID {
+		return txs.ErrCantValidatePrimaryNetwork
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := djtx.ParseServiceAddresses(s.addrManager, args.From)
+	if err != nil {
+		return err
+	}
+
+	user, err := keystore.NewUserFromKeystore(s.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	keys, err := keystore.GetKeychain(user, fromAddrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address. Assumes that if the user has no keys,
+	// this operation will fail so the change address can be anything.
+	if len(keys.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := keys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = djtx.ParseServiceAddress(s.addrManager, args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := s.vm.txBuilder.NewHaltBlockchainTx(
+		args.SubnetID,
+		args.BlockchainID,
+		keys.Keys,
+		changeAddr, // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = s.addrManager.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		s.vm.Builder.AddUnverifiedTx(tx),
+		user.Close(),
+	)
+	return errs.Err
+}
+
+// UpgradeBlockchainArgs is the arguments for calling UpgradeBlockchain
+type UpgradeBlockchainArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// ID of Subnet that validates the blockchain
+	SubnetID ids.ID `json:"subnetID"`
+	// ID of the blockchain to upgrade
+	BlockchainID ids.ID `json:"blockchainID"`
+	// ID of the VM the blockchain runs once the upgrade is activated
+	VMID string `json:"vmID"`
+	// Unix time the blockchain starts running [VMID]
+	ActivationTime json.Uint64 `json:"activationTime"`
+}
+
+// UpgradeBlockchain issues a transaction to schedule a blockchain to switch to
+// a different VM at the provided activation time.
+// Nodes that are running the blockchain restart it on the new VM once they
+// accept a block that moves the chain time past the activation time.
+func (s *Service) UpgradeBlockchain(_ *http.Request, args *UpgradeBlockchainArgs, response *api.JSONTxIDChangeAddr) error {
+	s.vm.ctx.Log.Debug("Platform: UpgradeBlockchain called")
 
-	switch {
-	case args.Name == "":
-		return errMissingName
-	case args.VMID == "":
+	if args.VMID == "" {
 		return errMissingVMID
 	}
 
-	genesisBytes, err := formatting.Decode(args.Encoding, args.GenesisData)
-	if err != nil {
-		return fmt.Errorf("problem parsing genesis data: %w", err)
-	}
-
 	vmID, err := s.vm.Chains.LookupVM(args.VMID)
 	if err != nil {
 		return fmt.Errorf("no VM with ID '%s' found", args.VMID)
 	}
 
-	fxIDs := []ids.ID(nil)
-	for _, fxIDStr := range args.FxIDs {
-		fxID, err := s.vm.Chains.LookupVM(fxIDStr)
-		if err != nil {
-			return fmt.Errorf("no FX with ID '%s' found", fxIDStr)
-		}
-		fxIDs = append(fxIDs, fxID)
-	}
-	// If creating AVM instance, use secp256k1fx
-	// TODO: Document FXs and have user specify them in API call
-	fxIDsSet := set.Set[ids.ID]{}
-	fxIDsSet.Add(fxIDs...)
-	if vmID == constants.AVMID && !fxIDsSet.Contains(secp256k1fx.ID) {
-		fxIDs = append(fxIDs, secp256k1fx.ID)
-	}
-
 	if args.SubnetID == constants.PrimaryNetworkID {
 		return txs.ErrCantValidatePrimaryNetwork
 	}
@@ -1623,12 +2406,11 @@ func (s *Service) CreateBlockchain(_ *http.Request, args *CreateBlockchainArgs,
 	}
 
 	// Create the transaction
-	tx, err := s.vm.txBuilder.NewCreateChainTx(
+	tx, err := s.vm.txBuilder.NewUpgradeBlockchainTx(
 		args.SubnetID,
-		genesisBytes,
+		args.BlockchainID,
 		vmID,
-		fxIDs,
-		args.Name,
+		uint64(args.ActivationTime),
 		keys.Keys,
 		changeAddr, // Change address
 	)
@@ -1670,6 +2452,11 @@ func (s *Service) GetBlockchainStatus(r *http.Request, args *GetBlockchainStatus
 
 	// if its aliased then vm created this chain.
 	if aliasedID, err := s.vm.Chains.Lookup(args.BlockchainID); err == nil {
+		if chainStatus, modified := s.modifiedChainStatus(aliasedID); modified {
+			reply.Status = chainStatus
+			return nil
+		}
+
 		if s.nodeValidates(aliasedID) {
 			reply.Status = status.Validating
 			return nil
@@ -1695,6 +2482,11 @@ func (s *Service) GetBlockchainStatus(r *http.Request, args *GetBlockchainStatus
 		return fmt.Errorf("problem looking up blockchain: %w", err)
 	}
 	if exists {
+		if chainStatus, modified := s.modifiedChainStatus(blockchainID); modified {
+			reply.Status = chainStatus
+			return nil
+		}
+
 		reply.Status = status.Created
 		return nil
 	}
@@ -1715,6 +2507,25 @@ func (s *Service) GetBlockchainStatus(r *http.Request, args *GetBlockchainStatus
 	return nil
 }
 
+// modifiedChainStatus returns the status of the blockchain if it was halted, or
+// if it is scheduled to switch to a different VM, as of the last accepted
+// state.
+func (s *Service) modifiedChainStatus(blockchainID ids.ID) (status.BlockchainStatus, bool) {
+	metadata, err := s.vm.state.GetChainMetadata(blockchainID)
+	if err != nil {
+		return status.UnknownChain, false
+	}
+
+	switch {
+	case metadata.Halted:
+		return status.Halted, true
+	case metadata.UpgradePending(s.vm.state.GetTimestamp()):
+		return status.Upgrading, true
+	default:
+		return status.UnknownChain, false
+	}
+}
+
 func (s *Service) nodeValidates(blockchainID ids.ID) bool {
 	chainTx, _, err := s.vm.state.GetTx(blockchainID)
 	if err != nil {
@@ -1954,6 +2765,81 @@ func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetT
 	return nil
 }
 
+// SimulateTxReply is the response from calling SimulateTx
+type SimulateTxReply struct {
+	TxID ids.ID `json:"txID"`
+	// True if the tx would currently be accepted into the mempool
+	Valid bool `json:"valid"`
+	// Reason the tx failed verification. Only non-empty if Valid is false
+	Error string `json:"error,omitempty"`
+	// The following fields are only set if Valid is true.
+
+	// Amount of nDJTX burned by the tx
+	Fee json.Uint64 `json:"fee"`
+	// IDs of the UTXOs consumed by the tx
+	ConsumedUTXOIDs []ids.ID `json:"consumedUTXOIDs"`
+	// UTXOs produced by the tx on this chain
+	ProducedUTXOs []string `json:"producedUTXOs"`
+	// Encoding of [ProducedUTXOs]
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// SimulateTx verifies a tx against the preferred state, without adding it to
+// the mempool or gossiping it, and reports its effects.
+func (s *Service) SimulateTx(_ *http.Request, args *api.FormattedTx, reply *SimulateTxReply) error {
+	s.vm.ctx.Log.Debug("Platform: SimulateTx called")
+
+	if !s.vm.bootstrapped.GetValue() {
+		return errBootstrapping
+	}
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+
+	// The effects of a tx are only reported if it is valid, since the fee of
+	// an invalid tx may not be defined.
+	reply.TxID = tx.ID()
+	if err := s.vm.Builder.VerifyTx(tx); err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+
+	flow := &txFlow{}
+	if err := tx.Unsigned.Visit(flow); err != nil {
+		return err
+	}
+	fee, err := djtx.Burned(s.vm.ctx.DJTXAssetID, flow.ins, flow.outs)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate fee: %w", err)
+	}
+
+	reply.Valid = true
+	reply.Fee = json.Uint64(fee)
+	reply.ConsumedUTXOIDs = tx.Unsigned.InputIDs().List()
+	utils.Sort(reply.ConsumedUTXOIDs)
+
+	utxos := tx.UTXOs()
+	reply.ProducedUTXOs = make([]string, len(utxos))
+	for i, utxo := range utxos {
+		utxoBytes, err := txs.Codec.Marshal(txs.Version, utxo)
+		if err != nil {
+			return fmt.Errorf("couldn't marshal UTXO %s: %w", utxo.InputID(), err)
+		}
+		reply.ProducedUTXOs[i], err = formatting.Encode(args.Encoding, utxoBytes)
+		if err != nil {
+			return fmt.Errorf("couldn't encode UTXO %s as string: %w", utxo.InputID(), err)
+		}
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
 type GetTxStatusArgs struct {
 	TxID ids.ID `json:"txID"`
 	// Returns a response that looks like this:
@@ -2034,6 +2920,194 @@ func (s *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, response *
 	return nil
 }
 
+// GetMempoolArgs are the arguments for calling GetMempool
+type GetMempoolArgs struct {
+	// Index of the first tx to return, in the order txs were added
+	StartIndex json.Uint64 `json:"startIndex"`
+	// Maximum number of txs to return. If 0, MaxPageSize is used.
+	Limit json.Uint32 `json:"limit"`
+}
+
+// APIMempoolTx describes a tx in the mempool
+type APIMempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// Name of the unsigned tx type, e.g. AddValidatorTx
+	Type string      `json:"type"`
+	Size json.Uint64 `json:"size"`
+	// Unix time, in seconds, at which the tx was added to the mempool
+	Added json.Uint64 `json:"added"`
+	// Amount of nDJTX burned by the tx in excess of its static fee
+	PriorityFee json.Uint64 `json:"priorityFee"`
+}
+
+// GetMempoolReply is the response from calling GetMempool
+type GetMempoolReply struct {
+	// Total number of txs in the mempool
+	NumTxs json.Uint64    `json:"numTxs"`
+	Txs    []APIMempoolTx `json:"txs"`
+	// Index to pass as [StartIndex] to fetch the next page
+	EndIndex json.Uint64 `json:"endIndex"`
+}
+
+// GetMempool returns a page of the txs in the mempool, in the order they were
+// added.
+func (s *Service) GetMempool(_ *http.Request, args *GetMempoolArgs, reply *GetMempoolReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetMempool called",
+		zap.Uint64("startIndex", uint64(args.StartIndex)),
+		zap.Uint32("limit", uint32(args.Limit)),
+	)
+
+	limit := int(args.Limit)
+	if limit <= 0 || builder.MaxPageSize < limit {
+		limit = builder.MaxPageSize
+	}
+
+	entries := s.vm.Builder.Entries()
+	start := len(entries)
+	if args.StartIndex < json.Uint64(start) {
+		start = int(args.StartIndex)
+	}
+	end := start + limit
+	if end > len(entries) {
+		end = len(entries)
+	}
+
+	reply.NumTxs = json.Uint64(len(entries))
+	reply.Txs = make([]APIMempoolTx, 0, end-start)
+	for _, entry := range entries[start:end] {
+		reply.Txs = append(reply.Txs, newAPIMempoolTx(entry))
+	}
+	reply.EndIndex = json.Uint64(end)
+	return nil
+}
+
+// GetMempoolTxReply is the response from calling GetMempoolTx
+type GetMempoolTxReply struct {
+	APIMempoolTx
+	Tx       interface{}         `json:"tx"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetMempoolTx returns a tx that is currently in the mempool
+func (s *Service) GetMempoolTx(_ *http.Request, args *api.GetTxArgs, reply *GetMempoolTxReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetMempoolTx called",
+		zap.Stringer("txID", args.TxID),
+	)
+
+	entry, ok := s.vm.Builder.GetEntry(args.TxID)
+	if !ok {
+		return fmt.Errorf("%w: %s", errTxNotInMempool, args.TxID)
+	}
+
+	reply.APIMempoolTx = newAPIMempoolTx(entry)
+	reply.Encoding = args.Encoding
+	if args.Encoding == formatting.JSON {
+		entry.Tx.Unsigned.InitCtx(s.vm.ctx)
+		reply.Tx = entry.Tx
+		return nil
+	}
+
+	var err error
+	reply.Tx, err = formatting.Encode(args.Encoding, entry.Tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode tx as a string: %w", err)
+	}
+	return nil
+}
+
+// APIDroppedTx describes a tx that was recently dropped
+type APIDroppedTx struct {
+	TxID   ids.ID `json:"txID"`
+	Reason string `json:"reason"`
+	// Unix time, in seconds, at which the tx was dropped
+	Dropped json.Uint64 `json:"dropped"`
+}
+
+// GetDroppedTxsReply is the response from calling GetDroppedTxs
+type GetDroppedTxsReply struct {
+	Txs []APIDroppedTx `json:"txs"`
+}
+
+// GetDroppedTxs returns the txs that were recently dropped, along with the
+// reason they were dropped. The most recently dropped tx is returned first.
+func (s *Service) GetDroppedTxs(_ *http.Request, _ *struct{}, reply *GetDroppedTxsReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetDroppedTxs called")
+
+	droppedTxs := s.vm.Builder.DroppedTxs()
+	reply.Txs = make([]APIDroppedTx, len(droppedTxs))
+	for i, droppedTx := range droppedTxs {
+		reply.Txs[i] = APIDroppedTx{
+			TxID:    droppedTx.TxID,
+			Reason:  droppedTx.Reason,
+			Dropped: json.Uint64(droppedTx.Dropped.Unix()),
+		}
+	}
+	return nil
+}
+
+func newAPIMempoolTx(entry mempool.Entry) APIMempoolTx {
+	return APIMempoolTx{
+		TxID:        entry.Tx.ID(),
+		Type:        reflect.TypeOf(entry.Tx.Unsigned).Elem().Name(),
+		Size:        json.Uint64(len(entry.Tx.Bytes())),
+		Added:       json.Uint64(entry.Added.Unix()),
+		PriorityFee: json.Uint64(entry.PriorityFee),
+	}
+}
+
+// GetFeeEstimateArgs are the arguments for calling GetFeeEstimate
+type GetFeeEstimateArgs struct {
+	// Size, in bytes, of the tx to estimate the priority fee of. If 0, only
+	// the priority fee per byte is estimated.
+	Size json.Uint64 `json:"size"`
+}
+
+// GetFeeEstimateReply is the response from calling GetFeeEstimate
+type GetFeeEstimateReply struct {
+	// Static fees, in nDJTX, that txs must burn at the current chain time
+	TxFee                         json.Uint64 `json:"txFee"`
+	CreateSubnetTxFee             json.Uint64 `json:"createSubnetTxFee"`
+	CreateBlockchainTxFee         json.Uint64 `json:"createBlockchainTxFee"`
+	TransformSubnetTxFee          json.Uint64 `json:"transformSubnetTxFee"`
+	AddPrimaryNetworkValidatorFee json.Uint64 `json:"addPrimaryNetworkValidatorFee"`
+	AddPrimaryNetworkDelegatorFee json.Uint64 `json:"addPrimaryNetworkDelegatorFee"`
+	AddSubnetValidatorFee         json.Uint64 `json:"addSubnetValidatorFee"`
+	AddSubnetDelegatorFee         json.Uint64 `json:"addSubnetDelegatorFee"`
+	// Priority fee per byte, in nDJTX, that a tx should burn in addition to
+	// its static fee to be included in the next block
+	PriorityFeeRate json.Uint64 `json:"priorityFeeRate"`
+	// Priority fee, in nDJTX, that a tx of the requested size should burn
+	PriorityFee json.Uint64 `json:"priorityFee"`
+}
+
+// GetFeeEstimate returns the static fees of the txs and an estimate of the
+// priority fee needed to be included in the next block, given the txs
+// currently in the mempool.
+func (s *Service) GetFeeEstimate(_ *http.Request, args *GetFeeEstimateArgs, reply *GetFeeEstimateReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetFeeEstimate called",
+		zap.Uint64("size", uint64(args.Size)),
+	)
+
+	chainTime := s.vm.state.GetTimestamp()
+	reply.TxFee = json.Uint64(s.vm.TxFee)
+	reply.CreateSubnetTxFee = json.Uint64(s.vm.GetCreateSubnetTxFee(chainTime))
+	reply.CreateBlockchainTxFee = json.Uint64(s.vm.GetCreateBlockchainTxFee(chainTime))
+	reply.TransformSubnetTxFee = json.Uint64(s.vm.TransformSubnetTxFee)
+	reply.AddPrimaryNetworkValidatorFee = json.Uint64(s.vm.AddPrimaryNetworkValidatorFee)
+	reply.AddPrimaryNetworkDelegatorFee = json.Uint64(s.vm.AddPrimaryNetworkDelegatorFee)
+	reply.AddSubnetValidatorFee = json.Uint64(s.vm.AddSubnetValidatorFee)
+	reply.AddSubnetDelegatorFee = json.Uint64(s.vm.AddSubnetDelegatorFee)
+
+	priorityFeeRate := s.vm.Builder.EstimatePriorityFeeRate(blockbuilder.TargetBlockSize)
+	priorityFee, err := math.Mul64(priorityFeeRate, uint64(args.Size))
+	if err != nil {
+		return err
+	}
+	reply.PriorityFeeRate = json.Uint64(priorityFeeRate)
+	reply.PriorityFee = json.Uint64(priorityFee)
+	return nil
+}
+
 type GetStakeArgs struct {
 	api.JSONAddresses
 	Encoding formatting.Encoding `json:"encoding"`
@@ -2329,32 +3403,350 @@ func (s *Service) GetValidatorsAt(r *http.Request, args *GetValidatorsAtArgs, re
 	return nil
 }
 
+// GetValidatorSetChangesArgs are the arguments for GetValidatorSetChanges
+type GetValidatorSetChangesArgs struct {
+	SubnetID    ids.ID      `json:"subnetID"`
+	StartHeight json.Uint64 `json:"startHeight"`
+	// If EndHeight is beyond the last accepted block, the changes up to the
+	// last accepted block are returned.
+	EndHeight json.Uint64 `json:"endHeight"`
+}
+
+// APIValidatorChange is the change of the weight of a validator. The validator
+// was added if OldWeight is 0 and removed if NewWeight is 0.
+type APIValidatorChange struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Hex encoded BLS public key of the validator, if it registered one.
+	PublicKey *string     `json:"publicKey,omitempty"`
+	OldWeight json.Uint64 `json:"oldWeight"`
+	NewWeight json.Uint64 `json:"newWeight"`
+}
+
+// APIValidatorSetChanges are the changes made to the validator set of a subnet
+// by the block at Height.
+type APIValidatorSetChanges struct {
+	Height   json.Uint64          `json:"height"`
+	SubnetID ids.ID               `json:"subnetID"`
+	Changes  []APIValidatorChange `json:"changes"`
+}
+
+// GetValidatorSetChangesReply is the response from GetValidatorSetChanges
+type GetValidatorSetChangesReply struct {
+	// Changes of the heights that modified the validator set, in increasing
+	// height order.
+	Changes []APIValidatorSetChanges `json:"changes"`
+	// The last height that was inspected. If it is lower than the requested
+	// end height, the next page starts at EndHeight+1.
+	EndHeight json.Uint64 `json:"endHeight"`
+}
+
+// GetValidatorSetChanges returns the changes made to the validator set of a
+// subnet by the blocks in [StartHeight, EndHeight]. At most [MaxPageSize]
+// heights are inspected per call. The changes made by newly accepted blocks are
+// also published on the /events websocket to the connections subscribed to the
+// subnet or to the validators with the addIDs command.
+func (s *Service) GetValidatorSetChanges(r *http.Request, args *GetValidatorSetChangesArgs, reply *GetValidatorSetChangesReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetValidatorSetChanges called",
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+		zap.Uint64("endHeight", uint64(args.EndHeight)),
+	)
+
+	ctx := r.Context()
+	startHeight := uint64(args.StartHeight)
+	endHeight := uint64(args.EndHeight)
+	if startHeight > endHeight {
+		return errStartAfterEndHeight
+	}
+
+	lastAcceptedHeight, err := s.vm.GetCurrentHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't get last accepted height: %w", err)
+	}
+	if startHeight > lastAcceptedHeight {
+		return fmt.Errorf("%w: start height %d is after last accepted height %d",
+			errHeightNotAccepted,
+			startHeight,
+			lastAcceptedHeight,
+		)
+	}
+	if endHeight > lastAcceptedHeight {
+		endHeight = lastAcceptedHeight
+	}
+	if maxEndHeight := startHeight + builder.MaxPageSize - 1; endHeight > maxEndHeight {
+		endHeight = maxEndHeight
+	}
+
+	setChanges, err := s.vm.getValidatorSetChanges(ctx, args.SubnetID, startHeight, endHeight)
+	if err != nil {
+		return fmt.Errorf("couldn't get validator set changes: %w", err)
+	}
+	reply.Changes = make([]APIValidatorSetChanges, len(setChanges))
+	for i, changes := range setChanges {
+		reply.Changes[i] = changes.toAPI()
+	}
+	reply.EndHeight = json.Uint64(endHeight)
+	return nil
+}
+
+// GetAddressTxsArgs are the arguments for GetAddressTxs
+type GetAddressTxsArgs struct {
+	api.JSONAddress
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize json.Uint64 `json:"pageSize"`
+	// AssetID defaulted to DJTX if omitted or left blank
+	AssetID ids.ID `json:"assetID"`
+}
+
+// GetAddressTxsReply is the response from GetAddressTxs
+type GetAddressTxsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+}
+
+// GetAddressTxs returns the IDs of the accepted transactions that changed the
+// balance of an address, in order of acceptance.
+func (s *Service) GetAddressTxs(_ *http.Request, args *GetAddressTxsArgs, reply *GetAddressTxsReply) error {
+	cursor := uint64(args.Cursor)
+	pageSize := uint64(args.PageSize)
+	s.vm.ctx.Log.Debug("Platform: GetAddressTxs called",
+		logging.UserString("address", args.Address),
+		zap.Stringer("assetID", args.AssetID),
+		zap.Uint64("cursor", cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+
+	if s.vm.addressTxsIndex == nil {
+		return errAddressIndexDisabled
+	}
+	if pageSize == 0 || pageSize > builder.MaxPageSize {
+		pageSize = builder.MaxPageSize
+	}
+
+	address, err := djtx.ParseServiceAddress(s.addrManager, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+	}
+	assetID := args.AssetID
+	if assetID == ids.Empty {
+		assetID = s.vm.ctx.DJTXAssetID
+	}
+
+	reply.TxIDs, err = s.vm.addressTxsIndex.Read(address, assetID, cursor, pageSize)
+	if err != nil {
+		return fmt.Errorf("couldn't read address transactions: %w", err)
+	}
+
+	// To get the next set of tx IDs, the user should provide this cursor.
+	reply.Cursor = json.Uint64(cursor + uint64(len(reply.TxIDs)))
+	return nil
+}
+
 func (s *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *api.GetBlockResponse) error {
 	s.vm.ctx.Log.Debug("Platform: GetBlock called",
 		zap.Stringer("blkID", args.BlockID),
 		zap.Stringer("encoding", args.Encoding),
 	)
 
-	block, err := s.vm.manager.GetStatelessBlock(args.BlockID)
+	var err error
+	response.Block, err = s.getAPIBlock(args.BlockID, args.Encoding)
+	response.Encoding = args.Encoding
+	return err
+}
+
+// GetBlockByHeightArgs are the arguments for calling GetBlockByHeight
+type GetBlockByHeightArgs struct {
+	// Height of the accepted block to return
+	Height   json.Uint64         `json:"height"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetBlockByHeight returns the block accepted at the given height.
+func (s *Service) GetBlockByHeight(_ *http.Request, args *GetBlockByHeightArgs, response *api.GetBlockResponse) error {
+	s.vm.ctx.Log.Debug("Platform: GetBlockByHeight called",
+		zap.Uint64("height", uint64(args.Height)),
+		zap.Stringer("encoding", args.Encoding),
+	)
+
+	blkID, err := s.vm.state.GetBlockIDAtHeight(uint64(args.Height))
 	if err != nil {
-		return fmt.Errorf("couldn't get block with id %s: %w", args.BlockID, err)
+		return fmt.Errorf("couldn't get block at height %d: %w", args.Height, err)
 	}
+
+	response.Block, err = s.getAPIBlock(blkID, args.Encoding)
 	response.Encoding = args.Encoding
+	return err
+}
 
-	if args.Encoding == formatting.JSON {
-		block.InitCtx(s.vm.ctx)
-		response.Block = block
-		return nil
+// GetBlockRangeArgs are the arguments for calling GetBlockRange
+type GetBlockRangeArgs struct {
+	// Height of the first accepted block to return
+	StartHeight json.Uint64 `json:"startHeight"`
+	// Maximum number of blocks to return. If 0, MaxPageSize is used.
+	Limit    json.Uint32         `json:"limit"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetBlockRangeReply is the response from calling GetBlockRange
+type GetBlockRangeReply struct {
+	// Blocks in order of increasing height. Each block is formatted like the
+	// block returned by GetBlock.
+	Blocks   []interface{}       `json:"blocks"`
+	Encoding formatting.Encoding `json:"encoding"`
+	// Number of blocks returned
+	NumFetched json.Uint64 `json:"numFetched"`
+}
+
+// GetBlockRange returns the blocks accepted at consecutive heights, starting at
+// [StartHeight]. Fewer than [Limit] blocks are returned if the last accepted
+// block is reached.
+func (s *Service) GetBlockRange(_ *http.Request, args *GetBlockRangeArgs, response *GetBlockRangeReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetBlockRange called",
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+		zap.Uint32("limit", uint32(args.Limit)),
+		zap.Stringer("encoding", args.Encoding),
+	)
+
+	limit := int(args.Limit)
+	if limit <= 0 || builder.MaxPageSize < limit {
+		limit = builder.MaxPageSize
+	}
+
+	response.Blocks = make([]interface{}, 0, limit)
+	response.Encoding = args.Encoding
+	for height := uint64(args.StartHeight); len(response.Blocks) < limit; height++ {
+		blkID, err := s.vm.state.GetBlockIDAtHeight(height)
+		if err == database.ErrNotFound {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't get block at height %d: %w", height, err)
+		}
+
+		block, err := s.getAPIBlock(blkID, args.Encoding)
+		if err != nil {
+			return err
+		}
+		response.Blocks = append(response.Blocks, block)
+	}
+
+	response.NumFetched = json.Uint64(len(response.Blocks))
+	return nil
+}
+
+// GetProofArgs are the arguments for calling GetProof
+type GetProofArgs struct {
+	// Key of the state tree to prove. See [state.UTXOMerkleKey],
+	// [state.ValidatorMerkleKey] and [state.SubnetOwnerMerkleKey].
+	Key ids.ID `json:"key"`
+	// Height of the accepted block whose state root the proof is verified
+	// against. If 0, the last accepted block is used.
+	Height   json.Uint64         `json:"height"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetProofReply is the response from calling GetProof
+type GetProofReply struct {
+	// Accepted block that commits to [StateRoot]
+	BlockID ids.ID      `json:"blockID"`
+	Height  json.Uint64 `json:"height"`
+	// Root of the state tree once the parent of the block was accepted
+	StateRoot ids.ID `json:"stateRoot"`
+	Key       ids.ID `json:"key"`
+	// Value of [Key] encoded with [Encoding], or the empty string if [Key]
+	// isn't in the state tree.
+	Value string `json:"value"`
+	// Siblings of the nodes on the path of [Key], starting at the root
+	Siblings []ids.ID `json:"siblings"`
+	// Leaf found on the path of [Key] if [Key] isn't in the state tree
+	OtherLeaf *merkle.Leaf        `json:"otherLeaf"`
+	Encoding  formatting.Encoding `json:"encoding"`
+}
+
+// GetProof returns the proof of the value of a key of the state tree against
+// the state root an accepted block commits to.
+func (s *Service) GetProof(_ *http.Request, args *GetProofArgs, response *GetProofReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetProof called",
+		zap.Stringer("key", args.Key),
+		zap.Uint64("height", uint64(args.Height)),
+		zap.Stringer("encoding", args.Encoding),
+	)
+
+	blkID := s.vm.manager.LastAccepted()
+	if args.Height != 0 {
+		var err error
+		blkID, err = s.vm.state.GetBlockIDAtHeight(uint64(args.Height))
+		if err != nil {
+			return fmt.Errorf("couldn't get block at height %d: %w", args.Height, err)
+		}
+	}
+	block, err := s.vm.manager.GetStatelessBlock(blkID)
+	if err != nil {
+		return fmt.Errorf("couldn't get block with id %s: %w", blkID, err)
+	}
+	stateRoot := block.ParentStateRoot()
+	if stateRoot == ids.Empty {
+		return fmt.Errorf("%w: %s", errNoStateRoot, blkID)
 	}
 
-	response.Block, err = formatting.Encode(args.Encoding, block.Bytes())
+	// Unless the node is archival, only the nodes of the state trees of the
+	// last [stateProofRetention] heights are kept, so the last accepted state
+	// can prove the value of a key in any of them.
+	if !s.vm.stateProofArchival {
+		retention := s.vm.stateProofRetention
+		lastAcceptedHeight, err := s.vm.GetCurrentHeight(context.Background())
+		if err != nil {
+			return fmt.Errorf("couldn't get last accepted height: %w", err)
+		}
+		if block.Height()+retention <= lastAcceptedHeight {
+			return fmt.Errorf("%w: only the last %d heights can be proven", errStateRootPruned, retention)
+		}
+	}
+	proof, err := merkle.Prove(s.vm.state, stateRoot, args.Key)
 	if err != nil {
-		return fmt.Errorf("couldn't encode block %s as string: %w", args.BlockID, err)
+		return fmt.Errorf("couldn't prove key %s: %w", args.Key, err)
 	}
 
+	response.BlockID = blkID
+	response.Height = json.Uint64(block.Height())
+	response.StateRoot = stateRoot
+	response.Key = proof.Key
+	if proof.Value != nil {
+		response.Value, err = formatting.Encode(args.Encoding, proof.Value)
+		if err != nil {
+			return fmt.Errorf("couldn't encode value as string: %w", err)
+		}
+	}
+	response.Siblings = proof.Siblings
+	response.OtherLeaf = proof.Other
+	response.Encoding = args.Encoding
 	return nil
 }
 
+// getAPIBlock returns the block with ID [blkID]. If [encoding] is JSON, the
+// decoded block is returned. Otherwise, the block bytes are returned encoded
+// with [encoding].
+func (s *Service) getAPIBlock(blkID ids.ID, encoding formatting.Encoding) (interface{}, error) {
+	block, err := s.vm.manager.GetStatelessBlock(blkID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get block with id %s: %w", blkID, err)
+	}
+
+	if encoding == formatting.JSON {
+		block.InitCtx(s.vm.ctx)
+		return block, nil
+	}
+
+	blockStr, err := formatting.Encode(encoding, block.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("couldn't encode block %s as string: %w", blkID, err)
+	}
+	return blockStr, nil
+}
+
 func (s *Service) getAPIUptime(staker *state.Staker) (*json.Float32, error) {
 	// Only report uptimes that we have been actively tracking.
 	if constants.PrimaryNetworkID != staker.SubnetID && !s.vm.WhitelistedSubnets.Contains(staker.SubnetID) {
@@ -8,29 +8,39 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
+	"net/http"
 	"testing"
 	"time"
 
 	stdjson "encoding/json"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/require"
 
 	"github.com/lasthyphen/dijetsnodego/api"
 	"github.com/lasthyphen/dijetsnodego/api/keystore"
 	"github.com/lasthyphen/dijetsnodego/chains/atomic"
+	"github.com/lasthyphen/dijetsnodego/database"
 	"github.com/lasthyphen/dijetsnodego/database/manager"
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
 	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/pubsub"
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowman"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto"
 	"github.com/lasthyphen/dijetsnodego/utils/formatting"
 	"github.com/lasthyphen/dijetsnodego/utils/json"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/version"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/merkle"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
@@ -417,6 +427,178 @@ func TestGetTx(t *testing.T) {
 }
 
 // Test method GetBalance
+func TestSimulateTx(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	key := keys[0]
+	addr := key.PublicKey().Address()
+	tx, err := service.vm.txBuilder.NewExportTx(
+		100,
+		service.vm.ctx.XChainID,
+		addr,
+		[]*crypto.PrivateKeySECP256K1R{key},
+		addr,
+	)
+	require.NoError(err)
+
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	reply := SimulateTxReply{}
+	require.NoError(service.SimulateTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.True(reply.Valid, reply.Error)
+	require.Equal(tx.ID(), reply.TxID)
+	require.EqualValues(service.vm.TxFee, reply.Fee)
+	require.Equal(tx.Unsigned.InputIDs().Len(), len(reply.ConsumedUTXOIDs))
+	require.Len(reply.ProducedUTXOs, len(tx.UTXOs()))
+
+	// Simulating a tx must not issue it
+	require.False(service.vm.Builder.Has(tx.ID()))
+
+	// A tx that spends a UTXO that doesn't exist fails verification
+	utx := tx.Unsigned.(*txs.ExportTx)
+	utx.Ins[0].UTXOID.TxID = ids.GenerateTestID()
+	txBytes, err := txs.Codec.Marshal(txs.Version, tx)
+	require.NoError(err)
+	txStr, err = formatting.Encode(formatting.Hex, txBytes)
+	require.NoError(err)
+
+	reply = SimulateTxReply{}
+	require.NoError(service.SimulateTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.False(reply.Valid)
+	require.NotEmpty(reply.Error)
+	require.Zero(reply.Fee)
+	require.Empty(reply.ConsumedUTXOIDs)
+	_, dropped := service.vm.Builder.GetDropReason(reply.TxID)
+	require.False(dropped)
+
+	// A tx that produces more than it consumes doesn't have a fee, so it is
+	// only reported as invalid
+	utx.ExportedOutputs[0].Out.(*secp256k1fx.TransferOutput).Amt = math.MaxUint64
+	txBytes, err = txs.Codec.Marshal(txs.Version, tx)
+	require.NoError(err)
+	txStr, err = formatting.Encode(formatting.Hex, txBytes)
+	require.NoError(err)
+
+	reply = SimulateTxReply{}
+	require.NoError(service.SimulateTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.False(reply.Valid)
+	require.NotEmpty(reply.Error)
+
+	// Txs can't be simulated while bootstrapping
+	service.vm.bootstrapped.SetValue(false)
+	err = service.SimulateTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &SimulateTxReply{})
+	require.ErrorIs(err, errBootstrapping)
+	service.vm.bootstrapped.SetValue(true)
+}
+
+func TestGetMempool(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	var txIDs []ids.ID
+	for _, key := range keys[:2] {
+		addr := key.PublicKey().Address()
+		tx, err := service.vm.txBuilder.NewCreateSubnetTx(
+			1,
+			[]ids.ShortID{addr},
+			[]*crypto.PrivateKeySECP256K1R{key},
+			addr,
+		)
+		require.NoError(err)
+		require.NoError(service.vm.Builder.Add(tx))
+		txIDs = append(txIDs, tx.ID())
+	}
+
+	reply := GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{Limit: 1}, &reply))
+	require.EqualValues(2, reply.NumTxs)
+	require.Len(reply.Txs, 1)
+	require.Equal(txIDs[0], reply.Txs[0].TxID)
+	require.Equal("CreateSubnetTx", reply.Txs[0].Type)
+	require.EqualValues(1, reply.EndIndex)
+
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{StartIndex: reply.EndIndex}, &reply))
+	require.Len(reply.Txs, 1)
+	require.Equal(txIDs[1], reply.Txs[0].TxID)
+	require.EqualValues(2, reply.EndIndex)
+
+	txReply := GetMempoolTxReply{}
+	require.NoError(service.GetMempoolTx(nil, &api.GetTxArgs{
+		TxID:     txIDs[1],
+		Encoding: formatting.Hex,
+	}, &txReply))
+	require.Equal(reply.Txs[0], txReply.APIMempoolTx)
+
+	err := service.GetMempoolTx(nil, &api.GetTxArgs{TxID: ids.GenerateTestID()}, &txReply)
+	require.ErrorIs(err, errTxNotInMempool)
+
+	service.vm.Builder.MarkDropped(txIDs[0], "reason")
+	droppedReply := GetDroppedTxsReply{}
+	require.NoError(service.GetDroppedTxs(nil, nil, &droppedReply))
+	require.Len(droppedReply.Txs, 1)
+	require.Equal(txIDs[0], droppedReply.Txs[0].TxID)
+	require.Equal("reason", droppedReply.Txs[0].Reason)
+}
+
+func TestGetFeeEstimate(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	key := keys[0]
+	addr := key.PublicKey().Address()
+	tx, err := service.vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{addr},
+		[]*crypto.PrivateKeySECP256K1R{key},
+		addr,
+	)
+	require.NoError(err)
+
+	// The builder only burns the static fee.
+	priorityFee, err := service.vm.PriorityFee(tx)
+	require.NoError(err)
+	require.Zero(priorityFee)
+	require.NoError(service.vm.Builder.Add(tx))
+
+	reply := GetFeeEstimateReply{}
+	require.NoError(service.GetFeeEstimate(nil, &GetFeeEstimateArgs{Size: 1000}, &reply))
+	require.EqualValues(service.vm.TxFee, reply.TxFee)
+	require.EqualValues(service.vm.GetCreateSubnetTxFee(service.vm.state.GetTimestamp()), reply.CreateSubnetTxFee)
+	require.EqualValues(service.vm.AddPrimaryNetworkValidatorFee, reply.AddPrimaryNetworkValidatorFee)
+	// The mempool doesn't fill a block, so no priority fee is needed.
+	require.Zero(reply.PriorityFeeRate)
+	require.Zero(reply.PriorityFee)
+}
+
 func TestGetBalance(t *testing.T) {
 	service, _ := defaultService(t)
 	defaultAddress(t, service)
@@ -754,6 +936,271 @@ func TestGetCurrentValidators(t *testing.T) {
 	}
 }
 
+func TestGetCurrentValidatorsPagination(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	genesis, _ := defaultGenesis()
+
+	// Fetch the validators two at a time
+	fetched := set.Set[ids.NodeID]{}
+	args := GetCurrentValidatorsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		Limit:    2,
+	}
+	for {
+		reply := GetCurrentValidatorsReply{}
+		require.NoError(service.GetCurrentValidators(nil, &args, &reply))
+		require.Len(reply.Validators, int(reply.NumFetched))
+		require.LessOrEqual(len(reply.Validators), 2)
+		if len(reply.Validators) == 0 {
+			require.Equal(args.StartIndex, reply.EndIndex)
+			break
+		}
+
+		for _, vdrIntf := range reply.Validators {
+			vdr, ok := vdrIntf.(pchainapi.PermissionlessValidator)
+			require.True(ok)
+			require.False(fetched.Contains(vdr.NodeID))
+			fetched.Add(vdr.NodeID)
+		}
+		args.StartIndex = reply.EndIndex
+	}
+	require.Equal(len(genesis.Validators), fetched.Len())
+
+	// Filter out every validator
+	args = GetCurrentValidatorsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		MinStake: json.Uint64(defaultWeight + 1),
+	}
+	reply := GetCurrentValidatorsReply{}
+	require.NoError(service.GetCurrentValidators(nil, &args, &reply))
+	require.Empty(reply.Validators)
+
+	args = GetCurrentValidatorsArgs{
+		SubnetID:      constants.PrimaryNetworkID,
+		ConnectedOnly: true,
+	}
+	require.NoError(service.GetCurrentValidators(nil, &args, &reply))
+	require.Empty(reply.Validators)
+
+	args = GetCurrentValidatorsArgs{
+		SubnetID:   constants.PrimaryNetworkID,
+		MaxEndTime: json.Uint64(defaultValidateEndTime.Unix() - 1),
+	}
+	require.NoError(service.GetCurrentValidators(nil, &args, &reply))
+	require.Empty(reply.Validators)
+
+	rewardOwner, err := service.addrManager.FormatLocalAddress(ids.GenerateTestShortID())
+	require.NoError(err)
+	args = GetCurrentValidatorsArgs{
+		SubnetID:    constants.PrimaryNetworkID,
+		RewardOwner: rewardOwner,
+	}
+	require.NoError(service.GetCurrentValidators(nil, &args, &reply))
+	require.Empty(reply.Validators)
+
+	// Genesis validators are rewarded to the address of their node ID
+	nodeID := ids.NodeID(keys[1].PublicKey().Address())
+	rewardOwner, err = service.addrManager.FormatLocalAddress(ids.ShortID(nodeID))
+	require.NoError(err)
+	args = GetCurrentValidatorsArgs{
+		SubnetID:    constants.PrimaryNetworkID,
+		RewardOwner: rewardOwner,
+	}
+	require.NoError(service.GetCurrentValidators(nil, &args, &reply))
+	require.Len(reply.Validators, 1)
+	vdr, ok := reply.Validators[0].(pchainapi.PermissionlessValidator)
+	require.True(ok)
+	require.Equal(nodeID, vdr.NodeID)
+
+	args = GetCurrentValidatorsArgs{
+		SubnetID:   constants.PrimaryNetworkID,
+		MinEndTime: json.Uint64(defaultValidateEndTime.Unix() + 1),
+		MaxEndTime: json.Uint64(defaultValidateEndTime.Unix()),
+	}
+	err = service.GetCurrentValidators(nil, &args, &reply)
+	require.ErrorIs(err, errInvalidEndTimeRange)
+}
+
+func TestGetDelegators(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defaultAddress(t, service)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	// Add delegators to a genesis validator
+	validatorNodeID := ids.NodeID(keys[1].PublicKey().Address())
+	delegatorStartTime := uint64(defaultValidateStartTime.Unix())
+	delegatorIDs := make([]ids.ID, 3)
+	for i := range delegatorIDs {
+		delegatorEndTime := uint64(defaultValidateStartTime.Add(defaultMinStakingDuration).Unix()) + uint64(i)
+		tx, err := service.vm.txBuilder.NewAddDelegatorTx(
+			service.vm.MinDelegatorStake,
+			delegatorStartTime,
+			delegatorEndTime,
+			validatorNodeID,
+			ids.GenerateTestShortID(),
+			[]*crypto.PrivateKeySECP256K1R{keys[0]},
+			keys[0].PublicKey().Address(), // change addr
+		)
+		require.NoError(err)
+
+		staker, err := state.NewCurrentStaker(
+			tx.ID(),
+			tx.Unsigned.(*txs.AddDelegatorTx),
+			0,
+		)
+		require.NoError(err)
+
+		service.vm.state.PutCurrentDelegator(staker)
+		service.vm.state.AddTx(tx, status.Committed)
+		delegatorIDs[i] = tx.ID()
+	}
+	require.NoError(service.vm.state.Commit())
+
+	// The delegators are omitted from the validators if requested
+	vdrArgs := GetCurrentValidatorsArgs{
+		SubnetID:       constants.PrimaryNetworkID,
+		NodeIDs:        []ids.NodeID{validatorNodeID},
+		OmitDelegators: true,
+	}
+	vdrReply := GetCurrentValidatorsReply{}
+	require.NoError(service.GetCurrentValidators(nil, &vdrArgs, &vdrReply))
+	require.Len(vdrReply.Validators, 1)
+	vdr, ok := vdrReply.Validators[0].(pchainapi.PermissionlessValidator)
+	require.True(ok)
+	require.Empty(vdr.Delegators)
+
+	// The delegators are paged in the order they stop staking
+	args := GetDelegatorsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		NodeID:   validatorNodeID,
+		Limit:    2,
+	}
+	reply := GetDelegatorsReply{}
+	require.NoError(service.GetDelegators(nil, &args, &reply))
+	require.Len(reply.Delegators, 2)
+	require.EqualValues(2, reply.NumFetched)
+	require.Equal(delegatorIDs[0], reply.Delegators[0].TxID)
+	require.Equal(delegatorIDs[1], reply.Delegators[1].TxID)
+	require.Equal(delegatorIDs[1], reply.EndIndex.TxID)
+	require.Equal(reply.Delegators[1].EndTime, reply.EndIndex.EndTime)
+
+	args.StartIndex = reply.EndIndex
+	reply = GetDelegatorsReply{}
+	require.NoError(service.GetDelegators(nil, &args, &reply))
+	require.Len(reply.Delegators, 1)
+	require.Equal(delegatorIDs[2], reply.Delegators[0].TxID)
+	require.Equal(validatorNodeID, reply.Delegators[0].NodeID)
+
+	args.StartIndex = reply.EndIndex
+	reply = GetDelegatorsReply{}
+	require.NoError(service.GetDelegators(nil, &args, &reply))
+	require.Empty(reply.Delegators)
+	require.Equal(args.StartIndex, reply.EndIndex)
+
+	// A tx that didn't add a staker isn't a valid index
+	args.StartIndex = StakerIndex{
+		TxID: service.vm.ctx.XChainID,
+	}
+	err := service.GetDelegators(nil, &args, &reply)
+	require.Error(err)
+}
+
+func TestEstimateReward(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	currentSupply, err := service.vm.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+
+	duration := defaultMinStakingDuration
+	expectedReward := service.vm.txExecutorBackend.Rewards.Calculate(duration, defaultWeight, currentSupply)
+
+	delegationFeeRate := json.Float32(10)
+	nodeID := ids.NodeID(keys[0].PublicKey().Address())
+	reply := EstimateRewardReply{}
+	require.NoError(service.EstimateReward(nil, &EstimateRewardArgs{
+		StakeAmount:       json.Uint64(defaultWeight),
+		Duration:          json.Uint64(duration / time.Second),
+		DelegationFeeRate: &delegationFeeRate,
+		NodeID:            &nodeID,
+	}, &reply))
+	require.EqualValues(currentSupply, reply.CurrentSupply)
+	require.EqualValues(expectedReward, reply.Reward)
+
+	expectedDelegatorReward, expectedValidatorFee := reward.Split(expectedReward, 100_000)
+	require.EqualValues(expectedDelegatorReward, *reply.DelegatorReward)
+	require.EqualValues(expectedValidatorFee, *reply.ValidatorFee)
+
+	validator, err := service.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	require.NotNil(reply.Validator)
+	require.Equal(validator.TxID, reply.Validator.TxID)
+	require.EqualValues(validator.PotentialReward, reply.Validator.PotentialReward)
+	require.EqualValues(service.vm.UptimePercentage, reply.Validator.RequiredUptime)
+	require.Equal(reply.Validator.Uptime >= reply.Validator.RequiredUptime, reply.Validator.Eligible)
+
+	err = service.EstimateReward(nil, &EstimateRewardArgs{
+		StakeAmount: json.Uint64(defaultWeight),
+		Duration:    json.Uint64((defaultMaxStakingDuration + time.Second) / time.Second),
+	}, &reply)
+	require.ErrorIs(err, errInvalidStakeDuration)
+
+	err = service.EstimateReward(nil, &EstimateRewardArgs{
+		Duration: json.Uint64(duration / time.Second),
+	}, &reply)
+	require.ErrorIs(err, errNoStakeAmount)
+}
+
+func TestGetOwnStakingStatus(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	// The node isn't a validator
+	reply := GetOwnStakingStatusReply{}
+	require.NoError(service.GetOwnStakingStatus(nil, nil, &reply))
+	require.Empty(reply.StakingStatuses)
+
+	nodeID := ids.NodeID(keys[0].PublicKey().Address())
+	service.vm.ctx.NodeID = nodeID
+	validator, err := service.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+
+	require.NoError(service.GetOwnStakingStatus(nil, nil, &reply))
+	require.Equal(nodeID, reply.NodeID)
+	require.Len(reply.StakingStatuses, 1)
+
+	status := reply.StakingStatuses[0]
+	require.Equal(constants.PrimaryNetworkID, status.SubnetID)
+	require.Equal(validator.TxID, status.TxID)
+	require.EqualValues(validator.EndTime.Unix(), status.EndTime)
+	require.EqualValues(service.vm.UptimePercentage, status.RequiredUptime)
+	require.EqualValues(validator.EndTime.Sub(service.vm.clock.Time())/time.Second, status.TimeRemaining)
+	require.GreaterOrEqual(status.MaxUptime, status.Uptime)
+	require.Equal(status.Uptime >= status.RequiredUptime, status.OnTrack)
+}
+
 func TestGetTimestamp(t *testing.T) {
 	require := require.New(t)
 	service, _ := defaultService(t)
@@ -849,3 +1296,559 @@ func TestGetBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlockByHeight(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding formatting.Encoding
+	}{
+		{
+			name:     "json",
+			encoding: formatting.JSON,
+		},
+		{
+			name:     "hex",
+			encoding: formatting.Hex,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			service, _ := defaultService(t)
+			service.vm.ctx.Lock.Lock()
+			defer service.vm.ctx.Lock.Unlock()
+
+			service.vm.Config.CreateAssetTxFee = 100 * defaultTxFee
+
+			// Make a block an accept it, then check we can get it by height.
+			tx, err := service.vm.txBuilder.NewCreateChainTx(
+				testSubnet1.ID(),
+				nil,
+				constants.AVMID,
+				nil,
+				"chain name",
+				[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+				keys[0].PublicKey().Address(), // change addr
+			)
+			require.NoError(err)
+
+			preferred, err := service.vm.Builder.Preferred()
+			require.NoError(err)
+
+			statelessBlock, err := blocks.NewBanffStandardBlock(
+				preferred.Timestamp(),
+				preferred.ID(),
+				preferred.Height()+1,
+				[]*txs.Tx{tx},
+			)
+			require.NoError(err)
+
+			block := service.vm.manager.NewBlock(statelessBlock)
+
+			require.NoError(block.Verify(context.Background()))
+			require.NoError(block.Accept(context.Background()))
+
+			args := GetBlockByHeightArgs{
+				Height:   json.Uint64(block.Height()),
+				Encoding: test.encoding,
+			}
+			response := api.GetBlockResponse{}
+			require.NoError(service.GetBlockByHeight(nil, &args, &response))
+			require.Equal(test.encoding, response.Encoding)
+
+			switch {
+			case test.encoding == formatting.JSON:
+				require.Equal(statelessBlock, response.Block)
+
+				_, err = stdjson.Marshal(response)
+				require.NoError(err)
+			default:
+				decoded, _ := formatting.Decode(response.Encoding, response.Block.(string))
+				require.Equal(block.Bytes(), decoded)
+			}
+
+			// No block was accepted after [block]
+			args.Height++
+			err = service.GetBlockByHeight(nil, &args, &response)
+			require.ErrorIs(err, database.ErrNotFound)
+
+			// Get every accepted block
+			rangeArgs := GetBlockRangeArgs{
+				Encoding: test.encoding,
+			}
+			rangeResponse := GetBlockRangeReply{}
+			require.NoError(service.GetBlockRange(nil, &rangeArgs, &rangeResponse))
+			require.Len(rangeResponse.Blocks, int(block.Height())+1)
+			require.EqualValues(len(rangeResponse.Blocks), rangeResponse.NumFetched)
+			require.Equal(test.encoding, rangeResponse.Encoding)
+
+			for height, blockIntf := range rangeResponse.Blocks {
+				var blockHeight uint64
+				switch {
+				case test.encoding == formatting.JSON:
+					blockHeight = blockIntf.(blocks.Block).Height()
+				default:
+					decoded, err := formatting.Decode(rangeResponse.Encoding, blockIntf.(string))
+					require.NoError(err)
+					parsed, err := blocks.Parse(blocks.Codec, decoded)
+					require.NoError(err)
+					blockHeight = parsed.Height()
+				}
+				require.EqualValues(height, blockHeight)
+			}
+
+			// Limit the number of blocks returned
+			rangeArgs = GetBlockRangeArgs{
+				StartHeight: json.Uint64(block.Height()),
+				Limit:       1,
+				Encoding:    test.encoding,
+			}
+			rangeResponse = GetBlockRangeReply{}
+			require.NoError(service.GetBlockRange(nil, &rangeArgs, &rangeResponse))
+			require.Len(rangeResponse.Blocks, 1)
+
+			rangeArgs.StartHeight++
+			rangeResponse = GetBlockRangeReply{}
+			require.NoError(service.GetBlockRange(nil, &rangeArgs, &rangeResponse))
+			require.Empty(rangeResponse.Blocks)
+		})
+	}
+}
+
+func TestGetValidatorSetChanges(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	vm := service.vm
+	startTime := vm.clock.Time().Add(txexecutor.SyncBound).Add(1 * time.Second)
+	endTime := startTime.Add(defaultMaxStakingDuration)
+	nodeID := ids.GenerateTestNodeID()
+
+	addValidatorTx, err := vm.txBuilder.NewAddValidatorTx(
+		vm.MaxValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		ids.GenerateTestShortID(),
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.GenerateTestShortID(),
+	)
+	require.NoError(err)
+
+	// Add the validator to the pending validator set at height 2
+	preferred, err := vm.Builder.Preferred()
+	require.NoError(err)
+	statelessBlk, err := blocks.NewBanffStandardBlock(
+		preferred.Timestamp(),
+		preferred.ID(),
+		preferred.Height()+1,
+		[]*txs.Tx{addValidatorTx},
+	)
+	require.NoError(err)
+	blk := vm.manager.NewBlock(statelessBlk)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
+
+	// Move the validator into the current validator set at height 3
+	vm.clock.Set(startTime)
+	preferred, err = vm.Builder.Preferred()
+	require.NoError(err)
+	statelessBlk, err = blocks.NewBanffStandardBlock(
+		startTime,
+		preferred.ID(),
+		preferred.Height()+1,
+		nil,
+	)
+	require.NoError(err)
+	blk = vm.manager.NewBlock(statelessBlk)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	// Without subscribers, the changes aren't computed but are considered
+	// published once the block is accepted.
+	require.Zero(vm.pubsub.NumSubscribers())
+	require.EqualValues(3, vm.publishedHeight)
+	require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
+
+	reply := GetValidatorSetChangesReply{}
+	require.NoError(service.GetValidatorSetChanges(&http.Request{}, &GetValidatorSetChangesArgs{
+		SubnetID:    constants.PrimaryNetworkID,
+		StartHeight: 2,
+		EndHeight:   100,
+	}, &reply))
+	require.EqualValues(3, reply.EndHeight)
+	require.Equal([]APIValidatorSetChanges{{
+		Height:   3,
+		SubnetID: constants.PrimaryNetworkID,
+		Changes: []APIValidatorChange{{
+			NodeID:    nodeID,
+			OldWeight: 0,
+			NewWeight: json.Uint64(vm.MaxValidatorStake),
+		}},
+	}}, reply.Changes)
+
+	// The validator set wasn't modified at height 2
+	require.NoError(service.GetValidatorSetChanges(&http.Request{}, &GetValidatorSetChangesArgs{
+		SubnetID:    constants.PrimaryNetworkID,
+		StartHeight: 2,
+		EndHeight:   2,
+	}, &reply))
+	require.EqualValues(2, reply.EndHeight)
+	require.Empty(reply.Changes)
+
+	err = service.GetValidatorSetChanges(&http.Request{}, &GetValidatorSetChangesArgs{
+		SubnetID:    constants.PrimaryNetworkID,
+		StartHeight: 3,
+		EndHeight:   2,
+	}, &reply)
+	require.ErrorIs(err, errStartAfterEndHeight)
+
+	err = service.GetValidatorSetChanges(&http.Request{}, &GetValidatorSetChangesArgs{
+		SubnetID:    constants.PrimaryNetworkID,
+		StartHeight: 4,
+		EndHeight:   5,
+	}, &reply)
+	require.ErrorIs(err, errHeightNotAccepted)
+}
+
+func TestValidatorSetChangesFilter(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	changes := &validatorSetChanges{
+		height:   5,
+		subnetID: subnetID,
+		changes: []*validatorChange{{
+			nodeID:    nodeID,
+			newWeight: 1,
+		}},
+	}
+
+	subnetFilter := pubsub.NewFilterParam()
+	require.NoError(subnetFilter.Add(subnetID[:]))
+	nodeFilter := pubsub.NewFilterParam()
+	require.NoError(nodeFilter.Add(nodeID[:]))
+	otherFilter := pubsub.NewFilterParam()
+	require.NoError(otherFilter.Add(ids.GenerateTestShortID().Bytes()))
+
+	matches, msg := changes.Filter([]pubsub.Filter{subnetFilter, nodeFilter, otherFilter})
+	require.Equal([]bool{true, true, false}, matches)
+	require.Equal(APIValidatorSetChanges{
+		Height:   5,
+		SubnetID: subnetID,
+		Changes: []APIValidatorChange{{
+			NodeID:    nodeID,
+			NewWeight: 1,
+		}},
+	}, msg)
+}
+
+func TestGetBalanceAtHeight(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	key := keys[0]
+	addr := key.PublicKey().Address()
+	height := json.Uint64(0)
+	args := &GetBalanceRequest{
+		HistoricalArgs: api.HistoricalArgs{Height: &height},
+		Addresses:      []string{addr.String()},
+	}
+	err := service.GetBalance(nil, args, &GetBalanceResponse{})
+	require.ErrorIs(err, errUTXOJournalDisabled)
+
+	// Enabling the journal backfills the accepted blocks
+	vm := service.vm
+	_, genesisBytes := defaultGenesis()
+	acceptedUTXOs, err := newAcceptedUTXOs(logging.NoLog{}, vm.state, genesisBytes)
+	require.NoError(err)
+	vm.utxoJournal, err = newUTXOJournal(memdb.New(), logging.NoLog{}, &vm.ctx.Lock, acceptedUTXOs, 0)
+	require.NoError(err)
+	done, err := vm.utxoJournal.backfillBatch()
+	require.NoError(err)
+	require.True(done)
+
+	args.Height = nil
+	before := GetBalanceResponse{}
+	require.NoError(service.GetBalance(nil, args, &before))
+
+	exportTx, err := vm.txBuilder.NewExportTx(
+		100,
+		vm.ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*crypto.PrivateKeySECP256K1R{key},
+		addr,
+	)
+	require.NoError(err)
+
+	preferred, err := vm.Builder.Preferred()
+	require.NoError(err)
+	height = json.Uint64(preferred.Height())
+	statelessBlk, err := blocks.NewBanffStandardBlock(
+		preferred.Timestamp(),
+		preferred.ID(),
+		preferred.Height()+1,
+		[]*txs.Tx{exportTx},
+	)
+	require.NoError(err)
+	blk := vm.manager.NewBlock(statelessBlk)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+
+	// The journal wasn't registered with the block manager, so it is notified
+	// of the accepted block directly.
+	require.NoError(vm.utxoJournal.Accepted(statelessBlk))
+
+	after := GetBalanceResponse{}
+	require.NoError(service.GetBalance(nil, args, &after))
+	require.Less(uint64(after.Balance), uint64(before.Balance))
+
+	// The balance before the export is returned at the previous height
+	args.Height = &height
+	historical := GetBalanceResponse{}
+	require.NoError(service.GetBalance(nil, args, &historical))
+	require.Equal(before.Balance, historical.Balance)
+	require.ElementsMatch(before.UTXOIDs, historical.UTXOIDs)
+
+	// The UTXOs at the previous height can be paginated
+	addrStr, err := service.addrManager.FormatLocalAddress(addr)
+	require.NoError(err)
+	utxosReply := api.GetUTXOsReply{}
+	require.NoError(service.GetUTXOs(nil, &api.GetUTXOsArgs{
+		HistoricalArgs: api.HistoricalArgs{Height: &height},
+		Addresses:      []string{addrStr},
+		Encoding:       formatting.Hex,
+	}, &utxosReply))
+	require.Len(utxosReply.UTXOs, len(before.UTXOIDs))
+
+	nextHeight := height + 2
+	args.Height = &nextHeight
+	err = service.GetBalance(nil, args, &GetBalanceResponse{})
+	require.ErrorIs(err, djtx.ErrHeightNotRetained)
+
+	timestamp := json.Uint64(1)
+	args.Timestamp = &timestamp
+	err = service.GetBalance(nil, args, &GetBalanceResponse{})
+	require.ErrorIs(err, errHeightAndTimestamp)
+}
+
+func TestGetAddressTxs(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	key := keys[0]
+	addr := key.PublicKey().Address()
+	args := &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addr.String()},
+	}
+	reply := GetAddressTxsReply{}
+	err := service.GetAddressTxs(nil, args, &reply)
+	require.ErrorIs(err, errAddressIndexDisabled)
+
+	// Enabling the index backfills the accepted blocks
+	vm := service.vm
+	_, genesisBytes := defaultGenesis()
+	acceptedUTXOs, err := newAcceptedUTXOs(logging.NoLog{}, vm.state, genesisBytes)
+	require.NoError(err)
+	vm.addressTxsIndex, err = newAddressTxsIndex(
+		memdb.New(),
+		logging.NoLog{},
+		&vm.ctx.Lock,
+		prometheus.NewRegistry(),
+		acceptedUTXOs,
+	)
+	require.NoError(err)
+	done, err := vm.addressTxsIndex.backfillBatch()
+	require.NoError(err)
+	require.True(done)
+
+	require.NoError(service.GetAddressTxs(nil, args, &reply))
+	require.NotEmpty(reply.TxIDs)
+	numBackfilledTxs := len(reply.TxIDs)
+
+	to := ids.GenerateTestShortID()
+	exportTx, err := vm.txBuilder.NewExportTx(
+		100,
+		vm.ctx.XChainID,
+		to,
+		[]*crypto.PrivateKeySECP256K1R{key},
+		addr,
+	)
+	require.NoError(err)
+
+	preferred, err := vm.Builder.Preferred()
+	require.NoError(err)
+	statelessBlk, err := blocks.NewBanffStandardBlock(
+		preferred.Timestamp(),
+		preferred.ID(),
+		preferred.Height()+1,
+		[]*txs.Tx{exportTx},
+	)
+	require.NoError(err)
+	blk := vm.manager.NewBlock(statelessBlk)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+
+	// The index wasn't registered with the block manager, so it is notified of
+	// the accepted block directly.
+	require.NoError(vm.addressTxsIndex.Accepted(statelessBlk))
+
+	// The sender's txs are paginated in order of acceptance
+	args.PageSize = json.Uint64(numBackfilledTxs)
+	require.NoError(service.GetAddressTxs(nil, args, &reply))
+	require.Len(reply.TxIDs, numBackfilledTxs)
+	require.EqualValues(numBackfilledTxs, reply.Cursor)
+
+	args.Cursor = reply.Cursor
+	require.NoError(service.GetAddressTxs(nil, args, &reply))
+	require.Equal([]ids.ID{exportTx.ID()}, reply.TxIDs)
+
+	// The recipient of the exported outputs is indexed
+	require.NoError(service.GetAddressTxs(nil, &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: to.String()},
+	}, &reply))
+	require.Equal([]ids.ID{exportTx.ID()}, reply.TxIDs)
+}
+
+func TestGetProof(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer service.vm.ctx.Lock.Unlock()
+
+	service.vm.Config.StateRootTime = time.Time{}
+	service.vm.Config.CreateAssetTxFee = 100 * defaultTxFee
+	service.vm.Config.CreateBlockchainTxFee = 100 * defaultTxFee
+
+	// The genesis block doesn't commit to a state root
+	err := service.GetProof(nil, &GetProofArgs{Encoding: formatting.Hex}, &GetProofReply{})
+	require.ErrorIs(err, errNoStateRoot)
+
+	tx, err := service.vm.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		constants.AVMID,
+		nil,
+		"chain name",
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+
+	preferred, err := service.vm.Builder.Preferred()
+	require.NoError(err)
+	parentStateRoot, err := service.vm.state.GetStateRoot()
+	require.NoError(err)
+
+	statelessBlock, err := blocks.NewBanffStandardBlockWithStateRoot(
+		preferred.Timestamp(),
+		preferred.ID(),
+		preferred.Height()+1,
+		parentStateRoot,
+		[]*txs.Tx{tx},
+	)
+	require.NoError(err)
+
+	block := service.vm.manager.NewBlock(statelessBlock)
+	require.NoError(block.Verify(context.Background()))
+	require.NoError(block.Accept(context.Background()))
+
+	// The consumed UTXO is in the state root committed to by the block
+	consumedUTXOID := tx.Unsigned.InputIDs().List()[0]
+	reply := GetProofReply{}
+	require.NoError(service.GetProof(nil, &GetProofArgs{
+		Key:      state.UTXOMerkleKey(consumedUTXOID),
+		Height:   json.Uint64(block.Height()),
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.Equal(block.ID(), reply.BlockID)
+	require.Equal(parentStateRoot, reply.StateRoot)
+	require.NotEmpty(reply.Value)
+
+	value, err := formatting.Decode(reply.Encoding, reply.Value)
+	require.NoError(err)
+	proof := merkle.Proof{
+		Key:      reply.Key,
+		Value:    value,
+		Siblings: reply.Siblings,
+		Other:    reply.OtherLeaf,
+	}
+	require.NoError(proof.Verify(reply.StateRoot))
+
+	// The produced UTXO isn't
+	producedUTXOID := tx.UTXOs()[0].InputID()
+	reply = GetProofReply{}
+	require.NoError(service.GetProof(nil, &GetProofArgs{
+		Key:      state.UTXOMerkleKey(producedUTXOID),
+		Height:   json.Uint64(block.Height()),
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.Empty(reply.Value)
+
+	proof = merkle.Proof{
+		Key:      reply.Key,
+		Siblings: reply.Siblings,
+		Other:    reply.OtherLeaf,
+	}
+	require.NoError(proof.Verify(reply.StateRoot))
+
+	// Once another block is accepted, the state tree the block commits to is
+	// pruned.
+	service.vm.stateProofRetention = 1
+	tx, err = service.vm.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		constants.AVMID,
+		nil,
+		"other chain name",
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+
+	parentStateRoot, err = service.vm.state.GetStateRoot()
+	require.NoError(err)
+	statelessBlock, err = blocks.NewBanffStandardBlockWithStateRoot(
+		block.Timestamp(),
+		block.ID(),
+		block.Height()+1,
+		parentStateRoot,
+		[]*txs.Tx{tx},
+	)
+	require.NoError(err)
+
+	nextBlock := service.vm.manager.NewBlock(statelessBlock)
+	require.NoError(nextBlock.Verify(context.Background()))
+	require.NoError(nextBlock.Accept(context.Background()))
+
+	err = service.GetProof(nil, &GetProofArgs{
+		Key:      state.UTXOMerkleKey(consumedUTXOID),
+		Height:   json.Uint64(block.Height()),
+		Encoding: formatting.Hex,
+	}, &GetProofReply{})
+	require.ErrorIs(err, errStateRootPruned)
+
+	require.NoError(service.GetProof(nil, &GetProofArgs{
+		Key:      state.UTXOMerkleKey(consumedUTXOID),
+		Height:   json.Uint64(nextBlock.Height()),
+		Encoding: formatting.Hex,
+	}, &GetProofReply{}))
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+// stakingStatus describes whether this node's validator on a subnet is on
+// track to be rewarded.
+type stakingStatus struct {
+	subnetID ids.ID
+	staker   *state.Staker
+
+	// uptime is the observed uptime of the validator, between 0 and 1.
+	uptime float64
+	// requiredUptime is the uptime the validator must have, between 0 and 1,
+	// at the end of the staking period to be rewarded.
+	requiredUptime float64
+	// timeRemaining is the amount of time left in the staking period.
+	timeRemaining time.Duration
+	// maxUptime is the uptime the validator will have at the end of the
+	// staking period if it is up for all of the remaining time.
+	maxUptime float64
+	// projectedUptime is the uptime the validator will have at the end of the
+	// staking period if it stays up at the rate observed so far.
+	projectedUptime float64
+}
+
+// onTrack returns true if the validator will be rewarded if it stays up at the
+// rate observed so far.
+func (s *stakingStatus) onTrack() bool {
+	return s.projectedUptime >= s.requiredUptime
+}
+
+// getStakingStatuses returns the staking status of this node's validators on
+// the primary network and on the tracked subnets that reward their stakers.
+// Subnets that this node doesn't validate are skipped.
+func (vm *VM) getStakingStatuses() ([]*stakingStatus, error) {
+	primaryNetworkValidator, err := vm.state.GetCurrentValidator(
+		constants.PrimaryNetworkID,
+		vm.ctx.NodeID,
+	)
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get current local validator: %w", err)
+	}
+
+	// Stakers are rewarded based on the uptime of their primary network
+	// validator.
+	uptime, err := vm.uptimeManager.CalculateUptimePercentFrom(
+		primaryNetworkValidator.NodeID,
+		constants.PrimaryNetworkID,
+		primaryNetworkValidator.StartTime,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't calculate uptime: %w", err)
+	}
+
+	statuses := []*stakingStatus{
+		vm.newStakingStatus(
+			constants.PrimaryNetworkID,
+			primaryNetworkValidator,
+			primaryNetworkValidator.StartTime,
+			uptime,
+			vm.UptimePercentage,
+		),
+	}
+	for subnetID := range vm.WhitelistedSubnets {
+		validator, err := vm.state.GetCurrentValidator(subnetID, vm.ctx.NodeID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get current subnet validator of %q: %w", subnetID, err)
+		}
+
+		transformSubnetIntf, err := vm.state.GetSubnetTransformation(subnetID)
+		if err == database.ErrNotFound {
+			// Validators of permissioned subnets aren't rewarded.
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get transformation of subnet %q: %w", subnetID, err)
+		}
+		transformSubnet, ok := transformSubnetIntf.Unsigned.(*txs.TransformSubnetTx)
+		if !ok {
+			return nil, fmt.Errorf("expected TransformSubnetTx but got %T", transformSubnetIntf.Unsigned)
+		}
+
+		requiredUptime := float64(transformSubnet.UptimeRequirement) / reward.PercentDenominator
+		statuses = append(statuses, vm.newStakingStatus(
+			subnetID,
+			validator,
+			primaryNetworkValidator.StartTime,
+			uptime,
+			requiredUptime,
+		))
+	}
+	return statuses, nil
+}
+
+// newStakingStatus assumes that [uptime] was measured from [uptimeStartTime]
+// until now.
+func (vm *VM) newStakingStatus(
+	subnetID ids.ID,
+	staker *state.Staker,
+	uptimeStartTime time.Time,
+	uptime float64,
+	requiredUptime float64,
+) *stakingStatus {
+	now := vm.clock.Time()
+	timeRemaining := staker.EndTime.Sub(now)
+	if timeRemaining < 0 {
+		timeRemaining = 0
+	}
+
+	maxUptime := 1.0
+	projectedUptime := 1.0
+	elapsed := now.Sub(uptimeStartTime)
+	if total := elapsed + timeRemaining; elapsed > 0 && total > 0 {
+		maxUptime = (uptime*float64(elapsed) + float64(timeRemaining)) / float64(total)
+		projectedUptime = (uptime*float64(elapsed) + uptime*float64(timeRemaining)) / float64(total)
+	}
+
+	return &stakingStatus{
+		subnetID:        subnetID,
+		staker:          staker,
+		uptime:          uptime,
+		requiredUptime:  requiredUptime,
+		timeRemaining:   timeRemaining,
+		maxUptime:       maxUptime,
+		projectedUptime: projectedUptime,
+	}
+}
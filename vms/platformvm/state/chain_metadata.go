@@ -0,0 +1,44 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+// ChainMetadata describes the modifications made to a blockchain after it was
+// created.
+type ChainMetadata struct {
+	// Halted is true if the blockchain was halted. Halted blockchains are never
+	// created again.
+	Halted bool `serialize:"true"`
+	// VMID is the ID of the VM the blockchain runs before [ActivationTime].
+	VMID ids.ID `serialize:"true"`
+	// UpgradeVMID is the ID of the VM the blockchain runs starting at
+	// [ActivationTime]. If empty, no upgrade was ever scheduled. Nodes that
+	// are running the blockchain restart it on [UpgradeVMID] once they accept
+	// a block that moves the chain time past [ActivationTime].
+	UpgradeVMID ids.ID `serialize:"true"`
+	// ActivationTime is the Unix time the blockchain starts running
+	// [UpgradeVMID].
+	ActivationTime uint64 `serialize:"true"`
+}
+
+// VMIDAt returns the ID of the VM the blockchain runs at [timestamp].
+func (m *ChainMetadata) VMIDAt(timestamp time.Time) ids.ID {
+	if m.UpgradeVMID == ids.Empty || timestamp.Before(time.Unix(int64(m.ActivationTime), 0)) {
+		return m.VMID
+	}
+	return m.UpgradeVMID
+}
+
+// UpgradePending returns true if the blockchain is scheduled to switch to a
+// different VM after [timestamp].
+func (m *ChainMetadata) UpgradePending(timestamp time.Time) bool {
+	return m.UpgradeVMID != ids.Empty &&
+		m.UpgradeVMID != m.VMID &&
+		m.VMIDAt(timestamp) == m.VMID
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+func TestChainMetadataVMIDAt(t *testing.T) {
+	require := require.New(t)
+
+	activationTime := time.Unix(1_000, 0)
+	metadata := &ChainMetadata{
+		VMID: ids.GenerateTestID(),
+	}
+
+	// Without an upgrade, the chain always runs the VM it was created with.
+	require.Equal(metadata.VMID, metadata.VMIDAt(activationTime))
+	require.False(metadata.UpgradePending(activationTime))
+
+	metadata.UpgradeVMID = ids.GenerateTestID()
+	metadata.ActivationTime = uint64(activationTime.Unix())
+
+	beforeActivation := activationTime.Add(-time.Second)
+	require.Equal(metadata.VMID, metadata.VMIDAt(beforeActivation))
+	require.True(metadata.UpgradePending(beforeActivation))
+
+	require.Equal(metadata.UpgradeVMID, metadata.VMIDAt(activationTime))
+	require.False(metadata.UpgradePending(activationTime))
+
+	// Upgrading to the VM that is already running is never pending.
+	metadata.UpgradeVMID = metadata.VMID
+	require.False(metadata.UpgradePending(beforeActivation))
+}
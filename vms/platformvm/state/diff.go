@@ -11,6 +11,8 @@ import (
 	"github.com/lasthyphen/dijetsnodego/database"
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/merkle"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 )
@@ -43,9 +45,13 @@ type diff struct {
 	// Subnet ID --> Tx that transforms the subnet
 	transformedSubnets map[ids.ID]*txs.Tx
 	cachedSubnets      []*txs.Tx
+	// Subnet ID --> Owner of the subnet
+	subnetOwners map[ids.ID]fx.Owner
 
 	addedChains  map[ids.ID][]*txs.Tx
 	cachedChains map[ids.ID][]*txs.Tx
+	// Chain ID --> Modifications made to the blockchain
+	chainMetadata map[ids.ID]*ChainMetadata
 
 	// map of txID -> []*UTXO
 	addedRewardUTXOs map[ids.ID][]*djtx.UTXO
@@ -55,6 +61,13 @@ type diff struct {
 
 	// map of modified UTXOID -> *UTXO if the UTXO is nil, it has been removed
 	modifiedUTXOs map[ids.ID]*utxoModification
+
+	// The state root is calculated the first time it is requested. The diff
+	// must not be modified afterwards.
+	stateRoot ids.ID
+	// map of nodeID -> node of the state tree that isn't in the parent state.
+	// nil if the state root hasn't been calculated.
+	merkleNodes map[ids.ID][]byte
 }
 
 type utxoModification struct {
@@ -136,6 +149,10 @@ func (d *diff) DeleteCurrentValidator(staker *Staker) {
 	d.currentStakerDiffs.DeleteValidator(staker)
 }
 
+func (d *diff) UpdateCurrentValidator(staker *Staker) {
+	d.currentStakerDiffs.UpdateValidator(staker)
+}
+
 func (d *diff) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
 	parentState, ok := d.stateVersions.GetState(d.parentID)
 	if !ok {
@@ -297,6 +314,28 @@ func (d *diff) AddSubnetTransformation(transformSubnetTxIntf *txs.Tx) {
 	}
 }
 
+func (d *diff) GetSubnetOwner(subnetID ids.ID) (fx.Owner, error) {
+	owner, exists := d.subnetOwners[subnetID]
+	if exists {
+		return owner, nil
+	}
+
+	// If the subnet's ownership wasn't transferred in this diff, ask the
+	// parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, ErrMissingParentState
+	}
+	return parentState.GetSubnetOwner(subnetID)
+}
+
+func (d *diff) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
+	if d.subnetOwners == nil {
+		d.subnetOwners = make(map[ids.ID]fx.Owner)
+	}
+	d.subnetOwners[subnetID] = owner
+}
+
 func (d *diff) GetChains(subnetID ids.ID) ([]*txs.Tx, error) {
 	addedChains := d.addedChains[subnetID]
 	if len(addedChains) == 0 {
@@ -356,6 +395,27 @@ func (d *diff) AddChain(createChainTx *txs.Tx) {
 	d.cachedChains[tx.SubnetID] = append(cachedChains, createChainTx)
 }
 
+func (d *diff) GetChainMetadata(chainID ids.ID) (*ChainMetadata, error) {
+	metadata, exists := d.chainMetadata[chainID]
+	if exists {
+		return metadata, nil
+	}
+
+	// If the blockchain wasn't modified in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, ErrMissingParentState
+	}
+	return parentState.GetChainMetadata(chainID)
+}
+
+func (d *diff) SetChainMetadata(chainID ids.ID, metadata *ChainMetadata) {
+	if d.chainMetadata == nil {
+		d.chainMetadata = make(map[ids.ID]*ChainMetadata)
+	}
+	d.chainMetadata[chainID] = metadata
+}
+
 func (d *diff) GetTx(txID ids.ID) (*txs.Tx, status.Status, error) {
 	if tx, exists := d.addedTxs[txID]; exists {
 		return tx.tx, tx.status, nil
@@ -444,6 +504,62 @@ func (d *diff) DeleteUTXO(utxoID ids.ID) {
 	}
 }
 
+func (d *diff) GetStateRoot() (ids.ID, error) {
+	if d.merkleNodes != nil {
+		return d.stateRoot, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return ids.Empty, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	parentStateRoot, err := parentState.GetStateRoot()
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	changes := make(merkleChanges)
+	for utxoID, utxo := range d.modifiedUTXOs {
+		if err := changes.putUTXO(utxoID, utxo.utxo); err != nil {
+			return ids.Empty, err
+		}
+	}
+	if err := changes.putValidators(d, d.currentStakerDiffs.validatorDiffs); err != nil {
+		return ids.Empty, err
+	}
+	for _, subnet := range d.addedSubnets {
+		createSubnetTx := subnet.Unsigned.(*txs.CreateSubnetTx)
+		if err := changes.putSubnetOwner(subnet.ID(), createSubnetTx.Owner); err != nil {
+			return ids.Empty, err
+		}
+	}
+	for subnetID, owner := range d.subnetOwners {
+		if err := changes.putSubnetOwner(subnetID, owner); err != nil {
+			return ids.Empty, err
+		}
+	}
+
+	stateRoot, merkleNodes, _, err := merkle.Update(parentState, parentStateRoot, changes)
+	if err != nil {
+		return ids.Empty, err
+	}
+	d.stateRoot = stateRoot
+	d.merkleNodes = merkleNodes
+	return stateRoot, nil
+}
+
+func (d *diff) GetMerkleNode(nodeID ids.ID) ([]byte, error) {
+	if node, ok := d.merkleNodes[nodeID]; ok {
+		return node, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetMerkleNode(nodeID)
+}
+
 func (d *diff) Apply(baseState State) {
 	baseState.SetTimestamp(d.timestamp)
 	for subnetID, supply := range d.currentSupply {
@@ -458,16 +574,21 @@ func (d *diff) Apply(baseState State) {
 					baseState.PutCurrentValidator(validatorDiff.validator)
 				}
 			}
+			if validatorDiff.validatorUpdated {
+				baseState.UpdateCurrentValidator(validatorDiff.validator)
+			}
+
+			// Restaked delegators are deleted before their new staking
+			// periods are added.
+			for _, delegator := range validatorDiff.deletedDelegators {
+				baseState.DeleteCurrentDelegator(delegator)
+			}
 
 			addedDelegatorIterator := NewTreeIterator(validatorDiff.addedDelegators)
 			for addedDelegatorIterator.Next() {
 				baseState.PutCurrentDelegator(addedDelegatorIterator.Value())
 			}
 			addedDelegatorIterator.Release()
-
-			for _, delegator := range validatorDiff.deletedDelegators {
-				baseState.DeleteCurrentDelegator(delegator)
-			}
 		}
 	}
 	for _, subnetValidatorDiffs := range d.pendingStakerDiffs.validatorDiffs {
@@ -497,11 +618,17 @@ func (d *diff) Apply(baseState State) {
 	for _, tx := range d.transformedSubnets {
 		baseState.AddSubnetTransformation(tx)
 	}
+	for subnetID, owner := range d.subnetOwners {
+		baseState.SetSubnetOwner(subnetID, owner)
+	}
 	for _, chains := range d.addedChains {
 		for _, chain := range chains {
 			baseState.AddChain(chain)
 		}
 	}
+	for chainID, metadata := range d.chainMetadata {
+		baseState.SetChainMetadata(chainID, metadata)
+	}
 	for _, tx := range d.addedTxs {
 		baseState.AddTx(tx.tx, tx.status)
 	}
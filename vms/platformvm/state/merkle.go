@@ -0,0 +1,151 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
+	"github.com/lasthyphen/dijetsnodego/utils/math"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+// The state root commits to the UTXOs, the current validators and the subnet
+// owners of the chain. Each of them is a leaf of a sparse Merkle tree whose key
+// is the hash of a type prefix and of the ID of the entry.
+const (
+	utxoMerklePrefix byte = iota
+	validatorMerklePrefix
+	subnetOwnerMerklePrefix
+)
+
+// MerkleValidator is the value of a current validator in the state tree.
+type MerkleValidator struct {
+	TxID            ids.ID `serialize:"true" json:"txID"`
+	StartTime       uint64 `serialize:"true" json:"startTime"`
+	EndTime         uint64 `serialize:"true" json:"endTime"`
+	Weight          uint64 `serialize:"true" json:"weight"`
+	DelegatorWeight uint64 `serialize:"true" json:"delegatorWeight"`
+	// Compressed BLS public key of the validator, or nil if it didn't register
+	// one.
+	PublicKey []byte `serialize:"true" json:"publicKey"`
+}
+
+// UTXOMerkleKey returns the key of the UTXO [utxoID] in the state tree.
+func UTXOMerkleKey(utxoID ids.ID) ids.ID {
+	return merkleKey(utxoMerklePrefix, utxoID[:])
+}
+
+// ValidatorMerkleKey returns the key of the current validator of [subnetID]
+// with [nodeID] in the state tree.
+func ValidatorMerkleKey(subnetID ids.ID, nodeID ids.NodeID) ids.ID {
+	key := make([]byte, 0, len(subnetID)+len(nodeID))
+	key = append(key, subnetID[:]...)
+	key = append(key, nodeID[:]...)
+	return merkleKey(validatorMerklePrefix, key)
+}
+
+// SubnetOwnerMerkleKey returns the key of the owner of [subnetID] in the state
+// tree.
+func SubnetOwnerMerkleKey(subnetID ids.ID) ids.ID {
+	return merkleKey(subnetOwnerMerklePrefix, subnetID[:])
+}
+
+func merkleKey(prefix byte, id []byte) ids.ID {
+	key := make([]byte, 0, 1+len(id))
+	key = append(key, prefix)
+	key = append(key, id...)
+	return hashing.ComputeHash256Array(key)
+}
+
+// merkleChanges maps the keys of the state tree that were modified to their
+// new value. A nil value removes the key from the tree.
+type merkleChanges map[ids.ID][]byte
+
+func (m merkleChanges) putUTXO(utxoID ids.ID, utxo *djtx.UTXO) error {
+	key := UTXOMerkleKey(utxoID)
+	if utxo == nil {
+		m[key] = nil
+		return nil
+	}
+	utxoBytes, err := txs.GenesisCodec.Marshal(txs.Version, utxo)
+	if err != nil {
+		return err
+	}
+	m[key] = utxoBytes
+	return nil
+}
+
+func (m merkleChanges) putSubnetOwner(subnetID ids.ID, owner fx.Owner) error {
+	ownerBytes, err := txs.GenesisCodec.Marshal(txs.Version, &owner)
+	if err != nil {
+		return err
+	}
+	m[SubnetOwnerMerkleKey(subnetID)] = ownerBytes
+	return nil
+}
+
+// putValidator sets the value of the current validator of [subnetID] with
+// [nodeID] to its value in [stakers].
+func (m merkleChanges) putValidator(stakers CurrentStakers, subnetID ids.ID, nodeID ids.NodeID) error {
+	key := ValidatorMerkleKey(subnetID, nodeID)
+	validator, err := stakers.GetCurrentValidator(subnetID, nodeID)
+	if err == database.ErrNotFound {
+		m[key] = nil
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	delegatorIterator, err := stakers.GetCurrentDelegatorIterator(subnetID, nodeID)
+	if err != nil {
+		return err
+	}
+	defer delegatorIterator.Release()
+
+	var delegatorWeight uint64
+	for delegatorIterator.Next() {
+		delegatorWeight, err = math.Add64(delegatorWeight, delegatorIterator.Value().Weight)
+		if err != nil {
+			return err
+		}
+	}
+
+	merkleValidator := MerkleValidator{
+		TxID:            validator.TxID,
+		StartTime:       uint64(validator.StartTime.Unix()),
+		EndTime:         uint64(validator.EndTime.Unix()),
+		Weight:          validator.Weight,
+		DelegatorWeight: delegatorWeight,
+	}
+	if validator.PublicKey != nil {
+		merkleValidator.PublicKey = bls.PublicKeyToBytes(validator.PublicKey)
+	}
+	validatorBytes, err := txs.GenesisCodec.Marshal(txs.Version, &merkleValidator)
+	if err != nil {
+		return err
+	}
+	m[key] = validatorBytes
+	return nil
+}
+
+// putValidators sets the value of every validator that was modified in
+// [validatorDiffs].
+func (m merkleChanges) putValidators(
+	stakers CurrentStakers,
+	validatorDiffs map[ids.ID]map[ids.NodeID]*diffValidator,
+) error {
+	for subnetID, subnetValidatorDiffs := range validatorDiffs {
+		for nodeID := range subnetValidatorDiffs {
+			if err := m.putValidator(stakers, subnetID, nodeID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 
 	ids "github.com/lasthyphen/dijetsnodego/ids"
 	djtx "github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	fx "github.com/lasthyphen/dijetsnodego/vms/platformvm/fx"
 	status "github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	txs "github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 	gomock "github.com/golang/mock/gomock"
@@ -173,6 +174,21 @@ func (mr *MockChainMockRecorder) DeleteUTXO(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockChain)(nil).DeleteUTXO), arg0)
 }
 
+// GetChainMetadata mocks base method.
+func (m *MockChain) GetChainMetadata(arg0 ids.ID) (*ChainMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainMetadata", arg0)
+	ret0, _ := ret[0].(*ChainMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainMetadata indicates an expected call of GetChainMetadata.
+func (mr *MockChainMockRecorder) GetChainMetadata(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainMetadata", reflect.TypeOf((*MockChain)(nil).GetChainMetadata), arg0)
+}
+
 // GetChains mocks base method.
 func (m *MockChain) GetChains(arg0 ids.ID) ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -248,6 +264,21 @@ func (mr *MockChainMockRecorder) GetCurrentValidator(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidator", reflect.TypeOf((*MockChain)(nil).GetCurrentValidator), arg0, arg1)
 }
 
+// GetMerkleNode mocks base method.
+func (m *MockChain) GetMerkleNode(arg0 ids.ID) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerkleNode", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerkleNode indicates an expected call of GetMerkleNode.
+func (mr *MockChainMockRecorder) GetMerkleNode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerkleNode", reflect.TypeOf((*MockChain)(nil).GetMerkleNode), arg0)
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockChain) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -308,6 +339,36 @@ func (mr *MockChainMockRecorder) GetRewardUTXOs(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardUTXOs", reflect.TypeOf((*MockChain)(nil).GetRewardUTXOs), arg0)
 }
 
+// GetStateRoot mocks base method.
+func (m *MockChain) GetStateRoot() (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStateRoot")
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStateRoot indicates an expected call of GetStateRoot.
+func (mr *MockChainMockRecorder) GetStateRoot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateRoot", reflect.TypeOf((*MockChain)(nil).GetStateRoot))
+}
+
+// GetSubnetOwner mocks base method.
+func (m *MockChain) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetOwner", arg0)
+	ret0, _ := ret[0].(fx.Owner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetOwner indicates an expected call of GetSubnetOwner.
+func (mr *MockChainMockRecorder) GetSubnetOwner(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwner", reflect.TypeOf((*MockChain)(nil).GetSubnetOwner), arg0)
+}
+
 // GetSubnetTransformation mocks base method.
 func (m *MockChain) GetSubnetTransformation(arg0 ids.ID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -431,6 +492,18 @@ func (mr *MockChainMockRecorder) PutPendingValidator(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPendingValidator", reflect.TypeOf((*MockChain)(nil).PutPendingValidator), arg0)
 }
 
+// SetChainMetadata mocks base method.
+func (m *MockChain) SetChainMetadata(arg0 ids.ID, arg1 *ChainMetadata) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetChainMetadata", arg0, arg1)
+}
+
+// SetChainMetadata indicates an expected call of SetChainMetadata.
+func (mr *MockChainMockRecorder) SetChainMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChainMetadata", reflect.TypeOf((*MockChain)(nil).SetChainMetadata), arg0, arg1)
+}
+
 // SetCurrentSupply mocks base method.
 func (m *MockChain) SetCurrentSupply(arg0 ids.ID, arg1 uint64) {
 	m.ctrl.T.Helper()
@@ -443,6 +516,18 @@ func (mr *MockChainMockRecorder) SetCurrentSupply(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrentSupply", reflect.TypeOf((*MockChain)(nil).SetCurrentSupply), arg0, arg1)
 }
 
+// SetSubnetOwner mocks base method.
+func (m *MockChain) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetOwner", arg0, arg1)
+}
+
+// SetSubnetOwner indicates an expected call of SetSubnetOwner.
+func (mr *MockChainMockRecorder) SetSubnetOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockChain)(nil).SetSubnetOwner), arg0, arg1)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockChain) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -454,3 +539,15 @@ func (mr *MockChainMockRecorder) SetTimestamp(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimestamp", reflect.TypeOf((*MockChain)(nil).SetTimestamp), arg0)
 }
+
+// UpdateCurrentValidator mocks base method.
+func (m *MockChain) UpdateCurrentValidator(arg0 *Staker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateCurrentValidator", arg0)
+}
+
+// UpdateCurrentValidator indicates an expected call of UpdateCurrentValidator.
+func (mr *MockChainMockRecorder) UpdateCurrentValidator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentValidator", reflect.TypeOf((*MockChain)(nil).UpdateCurrentValidator), arg0)
+}
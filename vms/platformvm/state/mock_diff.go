@@ -13,6 +13,7 @@ import (
 
 	ids "github.com/lasthyphen/dijetsnodego/ids"
 	djtx "github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	fx "github.com/lasthyphen/dijetsnodego/vms/platformvm/fx"
 	status "github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	txs "github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 	gomock "github.com/golang/mock/gomock"
@@ -185,6 +186,21 @@ func (mr *MockDiffMockRecorder) DeleteUTXO(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockDiff)(nil).DeleteUTXO), arg0)
 }
 
+// GetChainMetadata mocks base method.
+func (m *MockDiff) GetChainMetadata(arg0 ids.ID) (*ChainMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainMetadata", arg0)
+	ret0, _ := ret[0].(*ChainMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainMetadata indicates an expected call of GetChainMetadata.
+func (mr *MockDiffMockRecorder) GetChainMetadata(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainMetadata", reflect.TypeOf((*MockDiff)(nil).GetChainMetadata), arg0)
+}
+
 // GetChains mocks base method.
 func (m *MockDiff) GetChains(arg0 ids.ID) ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -260,6 +276,21 @@ func (mr *MockDiffMockRecorder) GetCurrentValidator(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidator", reflect.TypeOf((*MockDiff)(nil).GetCurrentValidator), arg0, arg1)
 }
 
+// GetMerkleNode mocks base method.
+func (m *MockDiff) GetMerkleNode(arg0 ids.ID) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerkleNode", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerkleNode indicates an expected call of GetMerkleNode.
+func (mr *MockDiffMockRecorder) GetMerkleNode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerkleNode", reflect.TypeOf((*MockDiff)(nil).GetMerkleNode), arg0)
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockDiff) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -320,6 +351,36 @@ func (mr *MockDiffMockRecorder) GetRewardUTXOs(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardUTXOs", reflect.TypeOf((*MockDiff)(nil).GetRewardUTXOs), arg0)
 }
 
+// GetStateRoot mocks base method.
+func (m *MockDiff) GetStateRoot() (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStateRoot")
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStateRoot indicates an expected call of GetStateRoot.
+func (mr *MockDiffMockRecorder) GetStateRoot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateRoot", reflect.TypeOf((*MockDiff)(nil).GetStateRoot))
+}
+
+// GetSubnetOwner mocks base method.
+func (m *MockDiff) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetOwner", arg0)
+	ret0, _ := ret[0].(fx.Owner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetOwner indicates an expected call of GetSubnetOwner.
+func (mr *MockDiffMockRecorder) GetSubnetOwner(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwner", reflect.TypeOf((*MockDiff)(nil).GetSubnetOwner), arg0)
+}
+
 // GetSubnetTransformation mocks base method.
 func (m *MockDiff) GetSubnetTransformation(arg0 ids.ID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -443,6 +504,18 @@ func (mr *MockDiffMockRecorder) PutPendingValidator(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPendingValidator", reflect.TypeOf((*MockDiff)(nil).PutPendingValidator), arg0)
 }
 
+// SetChainMetadata mocks base method.
+func (m *MockDiff) SetChainMetadata(arg0 ids.ID, arg1 *ChainMetadata) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetChainMetadata", arg0, arg1)
+}
+
+// SetChainMetadata indicates an expected call of SetChainMetadata.
+func (mr *MockDiffMockRecorder) SetChainMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChainMetadata", reflect.TypeOf((*MockDiff)(nil).SetChainMetadata), arg0, arg1)
+}
+
 // SetCurrentSupply mocks base method.
 func (m *MockDiff) SetCurrentSupply(arg0 ids.ID, arg1 uint64) {
 	m.ctrl.T.Helper()
@@ -455,6 +528,18 @@ func (mr *MockDiffMockRecorder) SetCurrentSupply(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrentSupply", reflect.TypeOf((*MockDiff)(nil).SetCurrentSupply), arg0, arg1)
 }
 
+// SetSubnetOwner mocks base method.
+func (m *MockDiff) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetOwner", arg0, arg1)
+}
+
+// SetSubnetOwner indicates an expected call of SetSubnetOwner.
+func (mr *MockDiffMockRecorder) SetSubnetOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockDiff)(nil).SetSubnetOwner), arg0, arg1)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockDiff) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -466,3 +551,15 @@ func (mr *MockDiffMockRecorder) SetTimestamp(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimestamp", reflect.TypeOf((*MockDiff)(nil).SetTimestamp), arg0)
 }
+
+// UpdateCurrentValidator mocks base method.
+func (m *MockDiff) UpdateCurrentValidator(arg0 *Staker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateCurrentValidator", arg0)
+}
+
+// UpdateCurrentValidator indicates an expected call of UpdateCurrentValidator.
+func (mr *MockDiffMockRecorder) UpdateCurrentValidator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentValidator", reflect.TypeOf((*MockDiff)(nil).UpdateCurrentValidator), arg0)
+}
@@ -42,6 +42,9 @@ type Staker struct {
 	StartTime       time.Time
 	EndTime         time.Time
 	PotentialReward uint64
+	// Restakes is the number of times this staker was restaked after the
+	// staking period of its tx ended.
+	Restakes uint32
 
 	// NextTime is the next time this staker will be moved from a validator set.
 	// If the staker is in the pending validator set, NextTime will equal
@@ -33,13 +33,24 @@ type CurrentStakers interface {
 	// Invariant: [staker] is currently a CurrentValidator
 	DeleteCurrentValidator(staker *Staker)
 
+	// UpdateCurrentValidator replaces the current version of the validator
+	// described by [staker] with [staker]. Only the weight and the end time of
+	// a validator may be updated, unless the validator is restaked. A restaked
+	// validator also has the start time and the potential reward of its new
+	// staking period.
+	//
+	// Invariant: A validator with the same TxID as [staker] is currently a
+	// CurrentValidator
+	UpdateCurrentValidator(staker *Staker)
+
 	// GetCurrentDelegatorIterator returns the delegators associated with the
 	// validator on [subnetID] with [nodeID]. Delegators are sorted by their
 	// removal from current staker set.
 	GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error)
 
 	// PutCurrentDelegator adds the [staker] describing a delegator to the
-	// staker set.
+	// staker set. If a delegator with the same TxID was deleted, [staker]
+	// describes the next staking period of the restaked delegator.
 	//
 	// Invariant: [staker] is not currently a CurrentDelegator
 	PutCurrentDelegator(staker *Staker)
@@ -137,15 +148,44 @@ func (v *baseStakers) PutValidator(staker *Staker) {
 
 func (v *baseStakers) DeleteValidator(staker *Staker) {
 	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
+	// [staker] may describe a different version of the validator than the one
+	// that is currently stored, if the validator was updated.
+	if validator.validator != nil {
+		staker = validator.validator
+	}
 	validator.validator = nil
 	v.pruneValidator(staker.SubnetID, staker.NodeID)
 
+	v.stakers.Delete(staker)
+
 	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorUpdated && !validatorDiff.validatorModified {
+		// The last written version of the validator is the one being removed.
+		staker = validatorDiff.oldValidator
+	}
 	validatorDiff.validatorModified = true
 	validatorDiff.validatorDeleted = true
+	validatorDiff.validatorUpdated = false
+	validatorDiff.validator = staker
+	validatorDiff.oldValidator = nil
+}
+
+func (v *baseStakers) UpdateValidator(staker *Staker) {
+	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
+	oldStaker := validator.validator
+	validator.validator = staker
+
+	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
+	if !validatorDiff.validatorModified && !validatorDiff.validatorUpdated {
+		// Keep track of the last written version of the validator so that the
+		// change in weight can be recorded.
+		validatorDiff.oldValidator = oldStaker
+	}
+	validatorDiff.validatorUpdated = true
 	validatorDiff.validator = staker
 
-	v.stakers.Delete(staker)
+	v.stakers.Delete(oldStaker)
+	v.stakers.ReplaceOrInsert(staker)
 }
 
 func (v *baseStakers) GetDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) StakerIterator {
@@ -184,6 +224,12 @@ func (v *baseStakers) DeleteDelegator(staker *Staker) {
 	v.pruneValidator(staker.SubnetID, staker.NodeID)
 
 	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.addedDelegators != nil && validatorDiff.addedDelegators.Delete(staker) != nil {
+		// The delegator hasn't been written yet. If it was restaked, the
+		// deletion of its last written version is already recorded.
+		v.stakers.Delete(staker)
+		return
+	}
 	if validatorDiff.deletedDelegators == nil {
 		validatorDiff.deletedDelegators = make(map[ids.ID]*Staker)
 	}
@@ -246,14 +292,25 @@ type diffStakers struct {
 	validatorDiffs map[ids.ID]map[ids.NodeID]*diffValidator
 	addedStakers   *btree.BTree
 	deletedStakers map[ids.ID]*Staker
+	// txID --> updated staker, for the stakers whose parent version must be
+	// masked
+	updatedStakers map[ids.ID]*Staker
 }
 
 type diffValidator struct {
 	validatorModified bool
 	// [validatorDeleted] implies [validatorModified]
 	validatorDeleted bool
+	// [validatorUpdated] implies ![validatorDeleted]. If set, the weight or the
+	// end time of [validator] differs from the tx that added it.
+	validatorUpdated bool
 	validator        *Staker
+	// [oldValidator] is the last written version of an updated validator. It
+	// is only populated by [baseStakers] if ![validatorModified].
+	oldValidator *Staker
 
+	// A delegator that is both in [addedDelegators] and in [deletedDelegators]
+	// was restaked. [addedDelegators] contains its new staking period.
 	addedDelegators   *btree.BTree
 	deletedDelegators map[ids.ID]*Staker
 }
@@ -264,7 +321,9 @@ type diffValidator struct {
 // Returns:
 //  1. If the validator was added in this diff, [staker, true] will be returned.
 //  2. If the validator was removed in this diff, [nil, true] will be returned.
-//  3. If the validator was not modified by this diff, [nil, false] will be
+//  3. If the validator was updated in this diff, [staker, true] will be
+//     returned.
+//  4. If the validator was not modified by this diff, [nil, false] will be
 //     returned.
 func (s *diffStakers) GetValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, bool) {
 	subnetValidatorDiffs, ok := s.validatorDiffs[subnetID]
@@ -277,7 +336,7 @@ func (s *diffStakers) GetValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker,
 		return nil, false
 	}
 
-	if !validatorDiff.validatorModified {
+	if !validatorDiff.validatorModified && !validatorDiff.validatorUpdated {
 		return nil, false
 	}
 
@@ -301,8 +360,13 @@ func (s *diffStakers) PutValidator(staker *Staker) {
 
 func (s *diffStakers) DeleteValidator(staker *Staker) {
 	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
+	if (validatorDiff.validatorModified && !validatorDiff.validatorDeleted) || validatorDiff.validatorUpdated {
+		// The validator was added or updated in this diff.
+		s.addedStakers.Delete(validatorDiff.validator)
+	}
 	validatorDiff.validatorModified = true
 	validatorDiff.validatorDeleted = true
+	validatorDiff.validatorUpdated = false
 	validatorDiff.validator = staker
 
 	if s.deletedStakers == nil {
@@ -311,6 +375,27 @@ func (s *diffStakers) DeleteValidator(staker *Staker) {
 	s.deletedStakers[staker.TxID] = staker
 }
 
+func (s *diffStakers) UpdateValidator(staker *Staker) {
+	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorModified || validatorDiff.validatorUpdated {
+		// The validator was already added or updated in this diff, so the
+		// previous version must be removed.
+		s.addedStakers.Delete(validatorDiff.validator)
+	} else {
+		if s.updatedStakers == nil {
+			s.updatedStakers = make(map[ids.ID]*Staker)
+		}
+		s.updatedStakers[staker.TxID] = staker
+	}
+	validatorDiff.validatorUpdated = true
+	validatorDiff.validator = staker
+
+	if s.addedStakers == nil {
+		s.addedStakers = btree.New(defaultTreeDegree)
+	}
+	s.addedStakers.ReplaceOrInsert(staker)
+}
+
 func (s *diffStakers) GetDelegatorIterator(
 	parentIterator StakerIterator,
 	subnetID ids.ID,
@@ -327,12 +412,9 @@ func (s *diffStakers) GetDelegatorIterator(
 		}
 	}
 
-	return NewMaskedIterator(
-		NewMergedIterator(
-			parentIterator,
-			addedDelegatorIterator,
-		),
-		deletedDelegators,
+	return NewMergedIterator(
+		NewMaskedIterator(parentIterator, deletedDelegators),
+		addedDelegatorIterator,
 	)
 }
 
@@ -351,6 +433,12 @@ func (s *diffStakers) PutDelegator(staker *Staker) {
 
 func (s *diffStakers) DeleteDelegator(staker *Staker) {
 	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.addedDelegators != nil && validatorDiff.addedDelegators.Delete(staker) != nil {
+		// The delegator was added in this diff. If it was restaked, the
+		// deletion of its parent version is already recorded.
+		s.addedStakers.Delete(staker)
+		return
+	}
 	if validatorDiff.deletedDelegators == nil {
 		validatorDiff.deletedDelegators = make(map[ids.ID]*Staker)
 	}
@@ -363,12 +451,15 @@ func (s *diffStakers) DeleteDelegator(staker *Staker) {
 }
 
 func (s *diffStakers) GetStakerIterator(parentIterator StakerIterator) StakerIterator {
-	return NewMaskedIterator(
-		NewMergedIterator(
-			parentIterator,
-			NewTreeIterator(s.addedStakers),
-		),
-		s.deletedStakers,
+	if len(s.updatedStakers) > 0 {
+		// The updated versions of validators are in [addedStakers].
+		parentIterator = NewMaskedIterator(parentIterator, s.updatedStakers)
+	}
+	// The new staking periods of restaked stakers are in [addedStakers], so
+	// only the parent versions are masked.
+	return NewMergedIterator(
+		NewMaskedIterator(parentIterator, s.deletedStakers),
+		NewTreeIterator(s.addedStakers),
 	)
 }
 
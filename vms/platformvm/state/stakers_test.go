@@ -106,6 +106,34 @@ func TestBaseStakersValidator(t *testing.T) {
 	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
 }
 
+func TestBaseStakersUpdateValidator(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+
+	v := newBaseStakers()
+
+	v.PutValidator(staker)
+
+	updatedStaker := *staker
+	updatedStaker.Weight++
+	v.UpdateValidator(&updatedStaker)
+
+	returnedStaker, err := v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.NoError(err)
+	require.Equal(&updatedStaker, returnedStaker)
+
+	stakerIterator := v.GetStakerIterator()
+	assertIteratorsEqual(t, NewSliceIterator(&updatedStaker), stakerIterator)
+
+	v.DeleteValidator(&updatedStaker)
+
+	_, err = v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	stakerIterator = v.GetStakerIterator()
+	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
+}
+
 func TestBaseStakersDelegator(t *testing.T) {
 	staker := newTestStaker()
 	delegator := newTestStaker()
@@ -174,6 +202,33 @@ func TestDiffStakersValidator(t *testing.T) {
 	assertIteratorsEqual(t, NewSliceIterator(delegator), stakerIterator)
 }
 
+func TestDiffStakersUpdateValidator(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+
+	v := diffStakers{}
+
+	updatedStaker := *staker
+	updatedStaker.Weight++
+	v.UpdateValidator(&updatedStaker)
+
+	returnedStaker, ok := v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.True(ok)
+	require.Equal(&updatedStaker, returnedStaker)
+
+	stakerIterator := v.GetStakerIterator(NewSliceIterator(staker))
+	assertIteratorsEqual(t, NewSliceIterator(&updatedStaker), stakerIterator)
+
+	v.DeleteValidator(&updatedStaker)
+
+	returnedStaker, ok = v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.True(ok)
+	require.Nil(returnedStaker)
+
+	stakerIterator = v.GetStakerIterator(NewSliceIterator(staker))
+	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
+}
+
 func TestDiffStakersDelegator(t *testing.T) {
 	staker := newTestStaker()
 	delegator := newTestStaker()
@@ -196,6 +251,76 @@ func TestDiffStakersDelegator(t *testing.T) {
 	assertIteratorsEqual(t, EmptyIterator, delegatorIterator)
 }
 
+func TestBaseStakersRestakeDelegator(t *testing.T) {
+	require := require.New(t)
+
+	delegator := newTestStaker()
+	restakedDelegator := *delegator
+	restakedDelegator.StartTime = delegator.EndTime
+	restakedDelegator.EndTime = delegator.EndTime.Add(time.Hour)
+	restakedDelegator.NextTime = restakedDelegator.EndTime
+
+	v := newBaseStakers()
+	v.PutDelegator(delegator)
+	v.validatorDiffs = make(map[ids.ID]map[ids.NodeID]*diffValidator)
+
+	v.DeleteDelegator(delegator)
+	v.PutDelegator(&restakedDelegator)
+
+	delegatorIterator := v.GetDelegatorIterator(delegator.SubnetID, delegator.NodeID)
+	assertIteratorsEqual(t, NewSliceIterator(&restakedDelegator), delegatorIterator)
+
+	stakerIterator := v.GetStakerIterator()
+	assertIteratorsEqual(t, NewSliceIterator(&restakedDelegator), stakerIterator)
+
+	validatorDiff := v.validatorDiffs[delegator.SubnetID][delegator.NodeID]
+	require.Equal(delegator, validatorDiff.deletedDelegators[delegator.TxID])
+	require.Equal(1, validatorDiff.addedDelegators.Len())
+
+	// Removing the restaked delegator before it is written only leaves the
+	// removal of the written version.
+	v.DeleteDelegator(&restakedDelegator)
+	require.Zero(validatorDiff.addedDelegators.Len())
+	require.Equal(delegator, validatorDiff.deletedDelegators[delegator.TxID])
+
+	stakerIterator = v.GetStakerIterator()
+	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
+}
+
+func TestDiffStakersRestakeDelegator(t *testing.T) {
+	delegator := newTestStaker()
+	restakedDelegator := *delegator
+	restakedDelegator.StartTime = delegator.EndTime
+	restakedDelegator.EndTime = delegator.EndTime.Add(time.Hour)
+	restakedDelegator.NextTime = restakedDelegator.EndTime
+
+	v := diffStakers{}
+	v.DeleteDelegator(delegator)
+	v.PutDelegator(&restakedDelegator)
+
+	delegatorIterator := v.GetDelegatorIterator(
+		NewSliceIterator(delegator),
+		delegator.SubnetID,
+		delegator.NodeID,
+	)
+	assertIteratorsEqual(t, NewSliceIterator(&restakedDelegator), delegatorIterator)
+
+	stakerIterator := v.GetStakerIterator(NewSliceIterator(delegator))
+	assertIteratorsEqual(t, NewSliceIterator(&restakedDelegator), stakerIterator)
+
+	v.DeleteDelegator(&restakedDelegator)
+
+	delegatorIterator = v.GetDelegatorIterator(
+		NewSliceIterator(delegator),
+		delegator.SubnetID,
+		delegator.NodeID,
+	)
+	assertIteratorsEqual(t, EmptyIterator, delegatorIterator)
+
+	stakerIterator = v.GetStakerIterator(NewSliceIterator(delegator))
+	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
+}
+
 func newTestStaker() *Staker {
 	startTime := time.Now().Round(time.Second)
 	endTime := startTime.Add(28 * 24 * time.Hour)
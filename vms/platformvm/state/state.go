@@ -12,6 +12,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"go.uber.org/zap"
+
 	"github.com/lasthyphen/dijetsnodego/cache"
 	"github.com/lasthyphen/dijetsnodego/cache/metercacher"
 	"github.com/lasthyphen/dijetsnodego/database"
@@ -23,6 +25,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow/choices"
 	"github.com/lasthyphen/dijetsnodego/snow/uptime"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
 	"github.com/lasthyphen/dijetsnodego/utils/hashing"
@@ -31,7 +34,9 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/config"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/genesis"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/merkle"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/metrics"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
@@ -41,12 +46,18 @@ import (
 const (
 	validatorDiffsCacheSize = 2048
 	blockCacheSize          = 2048
+	blockIDCacheSize        = 2048
 	txCacheSize             = 2048
 	rewardUTXOsCacheSize    = 2048
 	chainCacheSize          = 2048
 	chainDBCacheSize        = 2048
+	merkleNodeCacheSize     = 8192
 )
 
+// merkleizeBatchSize is the number of UTXOs inserted at once in the state tree
+// when it is built at startup, to bound the memory used to build it.
+var merkleizeBatchSize = 16384
+
 var (
 	_ State = (*state)(nil)
 
@@ -54,8 +65,11 @@ var (
 	errMissingValidatorSet          = errors.New("missing validator set")
 	errValidatorSetAlreadyPopulated = errors.New("validator set already populated")
 	errDuplicateValidatorSet        = errors.New("duplicate validator set")
+	errIsNotSubnet                  = errors.New("is not a subnet")
+	errIsNotChain                   = errors.New("is not a blockchain")
 
 	blockPrefix                   = []byte("block")
+	blockIDPrefix                 = []byte("blockID")
 	validatorsPrefix              = []byte("validators")
 	currentPrefix                 = []byte("current")
 	pendingPrefix                 = []byte("pending")
@@ -63,6 +77,8 @@ var (
 	delegatorPrefix               = []byte("delegator")
 	subnetValidatorPrefix         = []byte("subnetValidator")
 	subnetDelegatorPrefix         = []byte("subnetDelegator")
+	validatorUpdatePrefix         = []byte("validatorUpdate")
+	stakingPeriodPrefix           = []byte("stakingPeriod")
 	validatorWeightDiffsPrefix    = []byte("validatorDiffs")
 	validatorPublicKeyDiffsPrefix = []byte("publicKeyDiffs")
 	txPrefix                      = []byte("tx")
@@ -70,14 +86,21 @@ var (
 	utxoPrefix                    = []byte("utxo")
 	subnetPrefix                  = []byte("subnet")
 	transformedSubnetPrefix       = []byte("transformedSubnet")
+	subnetOwnerPrefix             = []byte("subnetOwner")
 	supplyPrefix                  = []byte("supply")
 	chainPrefix                   = []byte("chain")
+	chainMetadataPrefix           = []byte("chainMetadata")
+	merklePrefix                  = []byte("merkle")
+	merkleRemovedPrefix           = []byte("merkleRemoved")
+	merkleRemovalHeightPrefix     = []byte("merkleRemovalHeight")
 	singletonPrefix               = []byte("singleton")
 
-	timestampKey     = []byte("timestamp")
-	currentSupplyKey = []byte("current supply")
-	lastAcceptedKey  = []byte("last accepted")
-	initializedKey   = []byte("initialized")
+	timestampKey      = []byte("timestamp")
+	currentSupplyKey  = []byte("current supply")
+	lastAcceptedKey   = []byte("last accepted")
+	initializedKey    = []byte("initialized")
+	heightsIndexedKey = []byte("heights indexed")
+	stateRootKey      = []byte("state root")
 )
 
 // Chain collects all methods to manage the state of the chain for block
@@ -103,11 +126,30 @@ type Chain interface {
 	GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error)
 	AddSubnetTransformation(transformSubnetTx *txs.Tx)
 
+	// GetSubnetOwner returns the current owner of the subnet, which is the
+	// owner it was created with until its ownership is transferred.
+	GetSubnetOwner(subnetID ids.ID) (fx.Owner, error)
+	SetSubnetOwner(subnetID ids.ID, owner fx.Owner)
+
 	GetChains(subnetID ids.ID) ([]*txs.Tx, error)
 	AddChain(createChainTx *txs.Tx)
 
+	// GetChainMetadata returns the modifications made to the blockchain since
+	// it was created. If the blockchain was never modified, the metadata
+	// describes the blockchain as it was created.
+	GetChainMetadata(chainID ids.ID) (*ChainMetadata, error)
+	SetChainMetadata(chainID ids.ID, metadata *ChainMetadata)
+
 	GetTx(txID ids.ID) (*txs.Tx, status.Status, error)
 	AddTx(tx *txs.Tx, status status.Status)
+
+	// GetStateRoot returns the root of the state tree, which commits to the
+	// UTXOs, the current validators and the subnet owners of the chain.
+	GetStateRoot() (ids.ID, error)
+
+	// GetMerkleNode returns the node of the state tree with ID [nodeID], or
+	// database.ErrNotFound if the node is unknown.
+	GetMerkleNode(nodeID ids.ID) ([]byte, error)
 }
 
 type LastAccepteder interface {
@@ -118,6 +160,10 @@ type LastAccepteder interface {
 type BlockState interface {
 	GetStatelessBlock(blockID ids.ID) (blocks.Block, choices.Status, error)
 	AddStatelessBlock(block blocks.Block, status choices.Status)
+
+	// GetBlockIDAtHeight returns the ID of the block that was accepted at
+	// [height], or database.ErrNotFound if no block was accepted at [height].
+	GetBlockIDAtHeight(height uint64) (ids.ID, error)
 }
 
 type State interface {
@@ -193,6 +239,8 @@ type stateBlk struct {
  * |       '-- nodeID -> public key
  * |-. blocks
  * | '-- blockID -> block bytes
+ * |-. blockIDs
+ * | '-- height -> blockID
  * |-. txs
  * | '-- txID -> tx bytes + tx status
  * |- rewardUTXOs
@@ -208,8 +256,16 @@ type stateBlk struct {
  * | '-. subnetID
  * |   '-. list
  * |     '-- txID -> nil
+ * |-. merkle
+ * | '-- nodeID -> node bytes
+ * |-. merkleRemoved
+ * | '-- height + nodeID -> nil
+ * |-. merkleRemovalHeight
+ * | '-- nodeID -> height
  * '-. singletons
  *   |-- initializedKey -> nil
+ *   |-- heightsIndexedKey -> nil
+ *   |-- stateRootKey -> stateRoot
  *   |-- timestampKey -> timestamp
  *   |-- currentSupplyKey -> currentSupply
  *   '-- lastAcceptedKey -> lastAccepted
@@ -233,6 +289,10 @@ type state struct {
 	blockCache  cache.Cacher        // cache of blockID -> Block, if the entry is nil, it is not in the database
 	blockDB     database.Database
 
+	addedBlockIDs map[uint64]ids.ID // map of height -> blockID
+	blockIDCache  cache.Cacher      // cache of height -> blockID, if the entry is ids.Empty, it is not in the database
+	blockIDDB     database.Database
+
 	validatorsDB                 database.Database
 	currentValidatorsDB          database.Database
 	currentValidatorBaseDB       database.Database
@@ -243,6 +303,8 @@ type state struct {
 	currentSubnetValidatorList   linkeddb.LinkedDB
 	currentSubnetDelegatorBaseDB database.Database
 	currentSubnetDelegatorList   linkeddb.LinkedDB
+	currentValidatorUpdateDB     database.Database
+	currentStakingPeriodDB       database.Database
 	pendingValidatorsDB          database.Database
 	pendingValidatorBaseDB       database.Database
 	pendingValidatorList         linkeddb.LinkedDB
@@ -280,6 +342,10 @@ type state struct {
 	transformedSubnetCache cache.Cacher       // cache of subnetID -> transformSubnetTx if the entry is nil, it is not in the database
 	transformedSubnetDB    database.Database
 
+	subnetOwners     map[ids.ID]fx.Owner // map of subnetID -> owner
+	subnetOwnerCache cache.Cacher        // cache of subnetID -> owner
+	subnetOwnerDB    database.Database
+
 	modifiedSupplies map[ids.ID]uint64 // map of subnetID -> current supply
 	supplyCache      cache.Cacher      // cache of subnetID -> current supply if the entry is nil, it is not in the database
 	supplyDB         database.Database
@@ -289,6 +355,24 @@ type state struct {
 	chainDBCache cache.Cacher         // cache of subnetID -> linkedDB
 	chainDB      database.Database
 
+	modifiedChainMetadata map[ids.ID]*ChainMetadata // map of chainID -> metadata
+	chainMetadataCache    cache.Cacher              // cache of chainID -> metadata
+	chainMetadataDB       database.Database
+
+	// Nodes of the state tree are kept until the state trees of the last
+	// [stateProofRetention] heights don't include them. If
+	// [stateProofRetention] is 0, they are removed as soon as the state tree
+	// doesn't include them. If [stateProofArchival] is true, they are never
+	// removed.
+	stateProofRetention uint64
+	stateProofArchival  bool
+	stateRoot           ids.ID       // root of the state tree as of the last write
+	merkleNodeCache     cache.Cacher // cache of nodeID -> node bytes
+	merkleDB            database.Database
+	// Nodes removed from the state tree, by the height they were removed at
+	merkleRemovedDB       database.Database
+	merkleRemovalHeightDB database.Database
+
 	// The persisted fields represent the current database value
 	timestamp, persistedTimestamp         time.Time
 	currentSupply, persistedCurrentSupply uint64
@@ -318,6 +402,22 @@ func (v *ValidatorWeightDiff) Add(negative bool, amount uint64) error {
 	return nil
 }
 
+// validatorUpdate is the weight and end time of a current validator that was
+// modified after it was added.
+type validatorUpdate struct {
+	Weight  uint64 `serialize:"true"`
+	EndTime uint64 `serialize:"true"`
+}
+
+// stakingPeriod is the staking period of a current staker that was restaked
+// after the staking period of its tx ended.
+type stakingPeriod struct {
+	Weight    uint64 `serialize:"true"`
+	StartTime uint64 `serialize:"true"`
+	EndTime   uint64 `serialize:"true"`
+	Restakes  uint32 `serialize:"true"`
+}
+
 type heightWithSubnet struct {
 	Height   uint64 `serialize:"true"`
 	SubnetID ids.ID `serialize:"true"`
@@ -333,11 +433,18 @@ type txAndStatus struct {
 	status status.Status
 }
 
+// NewUTXOIterator returns an iterator over the serialized UTXOs of the state
+// stored in [db], ordered by UTXO ID.
+func NewUTXOIterator(db database.Database) database.Iterator {
+	return djtx.NewUTXOIterator(prefixdb.New(utxoPrefix, db))
+}
+
 func New(
 	db database.Database,
 	genesisBytes []byte,
 	metricsReg prometheus.Registerer,
 	cfg *config.Config,
+	execCfg *config.ExecutionConfig,
 	ctx *snow.Context,
 	metrics metrics.Metrics,
 	rewards reward.Calculator,
@@ -346,6 +453,7 @@ func New(
 		db,
 		metrics,
 		cfg,
+		execCfg,
 		ctx,
 		metricsReg,
 		rewards,
@@ -368,6 +476,7 @@ func new(
 	db database.Database,
 	metrics metrics.Metrics,
 	cfg *config.Config,
+	execCfg *config.ExecutionConfig,
 	ctx *snow.Context,
 	metricsReg prometheus.Registerer,
 	rewards reward.Calculator,
@@ -381,6 +490,24 @@ func new(
 		return nil, err
 	}
 
+	blockIDCache, err := metercacher.New(
+		"block_id_cache",
+		metricsReg,
+		&cache.LRU{Size: blockIDCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	merkleNodeCache, err := metercacher.New(
+		"merkle_node_cache",
+		metricsReg,
+		&cache.LRU{Size: merkleNodeCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	baseDB := versiondb.New(db)
 
 	validatorsDB := prefixdb.New(validatorsPrefix, baseDB)
@@ -390,6 +517,8 @@ func new(
 	currentDelegatorBaseDB := prefixdb.New(delegatorPrefix, currentValidatorsDB)
 	currentSubnetValidatorBaseDB := prefixdb.New(subnetValidatorPrefix, currentValidatorsDB)
 	currentSubnetDelegatorBaseDB := prefixdb.New(subnetDelegatorPrefix, currentValidatorsDB)
+	currentValidatorUpdateDB := prefixdb.New(validatorUpdatePrefix, currentValidatorsDB)
+	currentStakingPeriodDB := prefixdb.New(stakingPeriodPrefix, currentValidatorsDB)
 
 	pendingValidatorsDB := prefixdb.New(pendingPrefix, validatorsDB)
 	pendingValidatorBaseDB := prefixdb.New(validatorPrefix, pendingValidatorsDB)
@@ -453,6 +582,24 @@ func new(
 		return nil, err
 	}
 
+	subnetOwnerCache, err := metercacher.New(
+		"subnet_owner_cache",
+		metricsReg,
+		&cache.LRU{Size: chainCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	chainMetadataCache, err := metercacher.New(
+		"chain_metadata_cache",
+		metricsReg,
+		&cache.LRU{Size: chainCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	supplyCache, err := metercacher.New(
 		"supply_cache",
 		metricsReg,
@@ -493,6 +640,10 @@ func new(
 		blockCache:  blockCache,
 		blockDB:     prefixdb.New(blockPrefix, baseDB),
 
+		addedBlockIDs: make(map[uint64]ids.ID),
+		blockIDCache:  blockIDCache,
+		blockIDDB:     prefixdb.New(blockIDPrefix, baseDB),
+
 		currentStakers: newBaseStakers(),
 		pendingStakers: newBaseStakers(),
 
@@ -506,6 +657,8 @@ func new(
 		currentSubnetValidatorList:   linkeddb.NewDefault(currentSubnetValidatorBaseDB),
 		currentSubnetDelegatorBaseDB: currentSubnetDelegatorBaseDB,
 		currentSubnetDelegatorList:   linkeddb.NewDefault(currentSubnetDelegatorBaseDB),
+		currentValidatorUpdateDB:     currentValidatorUpdateDB,
+		currentStakingPeriodDB:       currentStakingPeriodDB,
 		pendingValidatorsDB:          pendingValidatorsDB,
 		pendingValidatorBaseDB:       pendingValidatorBaseDB,
 		pendingValidatorList:         linkeddb.NewDefault(pendingValidatorBaseDB),
@@ -539,6 +692,10 @@ func new(
 		transformedSubnetCache: transformedSubnetCache,
 		transformedSubnetDB:    prefixdb.New(transformedSubnetPrefix, baseDB),
 
+		subnetOwners:     make(map[ids.ID]fx.Owner),
+		subnetOwnerCache: subnetOwnerCache,
+		subnetOwnerDB:    prefixdb.New(subnetOwnerPrefix, baseDB),
+
 		modifiedSupplies: make(map[ids.ID]uint64),
 		supplyCache:      supplyCache,
 		supplyDB:         prefixdb.New(supplyPrefix, baseDB),
@@ -548,6 +705,17 @@ func new(
 		chainCache:   chainCache,
 		chainDBCache: chainDBCache,
 
+		modifiedChainMetadata: make(map[ids.ID]*ChainMetadata),
+		chainMetadataCache:    chainMetadataCache,
+		chainMetadataDB:       prefixdb.New(chainMetadataPrefix, baseDB),
+
+		stateProofRetention:   execCfg.StateProofRetention,
+		stateProofArchival:    execCfg.StateProofArchival,
+		merkleNodeCache:       merkleNodeCache,
+		merkleDB:              prefixdb.New(merklePrefix, baseDB),
+		merkleRemovedDB:       prefixdb.New(merkleRemovedPrefix, baseDB),
+		merkleRemovalHeightDB: prefixdb.New(merkleRemovalHeightPrefix, baseDB),
+
 		singletonDB: prefixdb.New(singletonPrefix, baseDB),
 	}, nil
 }
@@ -564,6 +732,10 @@ func (s *state) DeleteCurrentValidator(staker *Staker) {
 	s.currentStakers.DeleteValidator(staker)
 }
 
+func (s *state) UpdateCurrentValidator(staker *Staker) {
+	s.currentStakers.UpdateValidator(staker)
+}
+
 func (s *state) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
 	return s.currentStakers.GetDelegatorIterator(subnetID, nodeID), nil
 }
@@ -687,6 +859,46 @@ func (s *state) AddSubnetTransformation(transformSubnetTxIntf *txs.Tx) {
 	s.transformedSubnets[transformSubnetTx.Subnet] = transformSubnetTxIntf
 }
 
+func (s *state) GetSubnetOwner(subnetID ids.ID) (fx.Owner, error) {
+	if owner, exists := s.subnetOwners[subnetID]; exists {
+		return owner, nil
+	}
+
+	if ownerIntf, cached := s.subnetOwnerCache.Get(subnetID); cached {
+		return ownerIntf.(fx.Owner), nil
+	}
+
+	ownerBytes, err := s.subnetOwnerDB.Get(subnetID[:])
+	if err == nil {
+		var owner fx.Owner
+		if _, err := txs.GenesisCodec.Unmarshal(ownerBytes, &owner); err != nil {
+			return nil, err
+		}
+		s.subnetOwnerCache.Put(subnetID, owner)
+		return owner, nil
+	}
+	if err != database.ErrNotFound {
+		return nil, err
+	}
+
+	// The ownership of the subnet was never transferred, so it is still
+	// owned by the owner it was created with.
+	subnetIntf, _, err := s.GetTx(subnetID)
+	if err != nil {
+		return nil, err
+	}
+	subnet, ok := subnetIntf.Unsigned.(*txs.CreateSubnetTx)
+	if !ok {
+		return nil, fmt.Errorf("%q %w", subnetID, errIsNotSubnet)
+	}
+	s.subnetOwnerCache.Put(subnetID, subnet.Owner)
+	return subnet.Owner, nil
+}
+
+func (s *state) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
+	s.subnetOwners[subnetID] = owner
+}
+
 func (s *state) GetChains(subnetID ids.ID) ([]*txs.Tx, error) {
 	if chainsIntf, cached := s.chainCache.Get(subnetID); cached {
 		return chainsIntf.([]*txs.Tx), nil
@@ -727,6 +939,49 @@ func (s *state) AddChain(createChainTxIntf *txs.Tx) {
 	}
 }
 
+func (s *state) GetChainMetadata(chainID ids.ID) (*ChainMetadata, error) {
+	if metadata, exists := s.modifiedChainMetadata[chainID]; exists {
+		return metadata, nil
+	}
+
+	if metadataIntf, cached := s.chainMetadataCache.Get(chainID); cached {
+		return metadataIntf.(*ChainMetadata), nil
+	}
+
+	metadataBytes, err := s.chainMetadataDB.Get(chainID[:])
+	if err == nil {
+		metadata := &ChainMetadata{}
+		if _, err := txs.GenesisCodec.Unmarshal(metadataBytes, metadata); err != nil {
+			return nil, err
+		}
+		s.chainMetadataCache.Put(chainID, metadata)
+		return metadata, nil
+	}
+	if err != database.ErrNotFound {
+		return nil, err
+	}
+
+	// The blockchain was never modified, so it still runs the VM it was
+	// created with.
+	chainIntf, _, err := s.GetTx(chainID)
+	if err != nil {
+		return nil, err
+	}
+	chain, ok := chainIntf.Unsigned.(*txs.CreateChainTx)
+	if !ok {
+		return nil, fmt.Errorf("%q %w", chainID, errIsNotChain)
+	}
+	metadata := &ChainMetadata{
+		VMID: chain.VMID,
+	}
+	s.chainMetadataCache.Put(chainID, metadata)
+	return metadata, nil
+}
+
+func (s *state) SetChainMetadata(chainID ids.ID, metadata *ChainMetadata) {
+	s.modifiedChainMetadata[chainID] = metadata
+}
+
 func (s *state) getChainDB(subnetID ids.ID) linkeddb.LinkedDB {
 	if chainDBIntf, cached := s.chainDBCache.Get(subnetID); cached {
 		return chainDBIntf.(linkeddb.LinkedDB)
@@ -1071,6 +1326,17 @@ func (s *state) loadMetadata() error {
 	}
 	s.persistedLastAccepted = lastAccepted
 	s.lastAccepted = lastAccepted
+
+	stateRoot, err := database.GetID(s.singletonDB, stateRootKey)
+	switch err {
+	case nil:
+		s.stateRoot = stateRoot
+	case database.ErrNotFound:
+		// The state tree is built by [merkleize].
+		s.stateRoot = ids.Empty
+	default:
+		return err
+	}
 	return nil
 }
 
@@ -1108,6 +1374,12 @@ func (s *state) loadCurrentValidators() error {
 		if err != nil {
 			return err
 		}
+		if err := s.loadStakingPeriod(staker); err != nil {
+			return err
+		}
+		if err := s.loadValidatorUpdate(staker); err != nil {
+			return err
+		}
 
 		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 		validator.validator = staker
@@ -1166,6 +1438,12 @@ func (s *state) loadCurrentValidators() error {
 		if err != nil {
 			return err
 		}
+		if err := s.loadStakingPeriod(staker); err != nil {
+			return err
+		}
+		if err := s.loadValidatorUpdate(staker); err != nil {
+			return err
+		}
 		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 		validator.validator = staker
 
@@ -1207,6 +1485,9 @@ func (s *state) loadCurrentValidators() error {
 			if err != nil {
 				return err
 			}
+			if err := s.loadStakingPeriod(staker); err != nil {
+				return err
+			}
 
 			validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 			if validator.delegators == nil {
@@ -1228,6 +1509,50 @@ func (s *state) loadCurrentValidators() error {
 	return errs.Err
 }
 
+// loadStakingPeriod applies the persisted staking period of [staker], if the
+// staker was restaked.
+func (s *state) loadStakingPeriod(staker *Staker) error {
+	periodBytes, err := s.currentStakingPeriodDB.Get(staker.TxID[:])
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	period := &stakingPeriod{}
+	if _, err := blocks.GenesisCodec.Unmarshal(periodBytes, period); err != nil {
+		return fmt.Errorf("failed to parse staking period: %w", err)
+	}
+	staker.Weight = period.Weight
+	staker.StartTime = time.Unix(int64(period.StartTime), 0)
+	staker.EndTime = time.Unix(int64(period.EndTime), 0)
+	staker.NextTime = staker.EndTime
+	staker.Restakes = period.Restakes
+	return nil
+}
+
+// loadValidatorUpdate applies the persisted weight and end time of [staker],
+// if the validator was updated after it was added.
+func (s *state) loadValidatorUpdate(staker *Staker) error {
+	updateBytes, err := s.currentValidatorUpdateDB.Get(staker.TxID[:])
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	update := &validatorUpdate{}
+	if _, err := blocks.GenesisCodec.Unmarshal(updateBytes, update); err != nil {
+		return fmt.Errorf("failed to parse validator update: %w", err)
+	}
+	staker.Weight = update.Weight
+	staker.EndTime = time.Unix(int64(update.EndTime), 0)
+	staker.NextTime = staker.EndTime
+	return nil
+}
+
 func (s *state) loadPendingValidators() error {
 	s.pendingStakers = newBaseStakers()
 
@@ -1370,6 +1695,7 @@ func (s *state) validatorSet(subnetID ids.ID, vdrs validators.Set) error {
 func (s *state) write(updateValidators bool, height uint64) error {
 	errs := wrappers.Errs{}
 	errs.Add(
+		s.writeStateRoot(height), // Must be called before the modifications are written
 		s.writeBlocks(),
 		s.writeCurrentStakers(updateValidators, height),
 		s.writePendingStakers(),
@@ -1379,8 +1705,10 @@ func (s *state) write(updateValidators bool, height uint64) error {
 		s.writeUTXOs(),
 		s.writeSubnets(),
 		s.writeTransformedSubnets(),
+		s.writeSubnetOwners(),
 		s.writeSubnetSupplies(),
 		s.writeChains(),
+		s.writeChainMetadata(),
 		s.writeMetadata(),
 	)
 	return errs.Err
@@ -1396,6 +1724,8 @@ func (s *state) Close() error {
 		s.pendingValidatorsDB.Close(),
 		s.currentSubnetValidatorBaseDB.Close(),
 		s.currentSubnetDelegatorBaseDB.Close(),
+		s.currentValidatorUpdateDB.Close(),
+		s.currentStakingPeriodDB.Close(),
 		s.currentDelegatorBaseDB.Close(),
 		s.currentValidatorBaseDB.Close(),
 		s.currentValidatorsDB.Close(),
@@ -1405,8 +1735,11 @@ func (s *state) Close() error {
 		s.utxoDB.Close(),
 		s.subnetBaseDB.Close(),
 		s.transformedSubnetDB.Close(),
+		s.subnetOwnerDB.Close(),
 		s.supplyDB.Close(),
 		s.chainDB.Close(),
+		s.chainMetadataDB.Close(),
+		s.merkleDB.Close(),
 		s.singletonDB.Close(),
 		s.blockDB.Close(),
 	)
@@ -1439,9 +1772,192 @@ func (s *state) sync(genesis []byte) error {
 			err,
 		)
 	}
+
+	if err := s.indexHeights(); err != nil {
+		return fmt.Errorf(
+			"failed to index the accepted blocks by height: %w",
+			err,
+		)
+	}
+
+	if err := s.merkleize(); err != nil {
+		return fmt.Errorf(
+			"failed to build the state tree: %w",
+			err,
+		)
+	}
 	return nil
 }
 
+// indexHeights indexes the accepted blocks by height if they were accepted
+// before the height index was maintained. Blocks are indexed from the last
+// accepted block back to the genesis block.
+func (s *state) indexHeights() error {
+	indexed, err := s.singletonDB.Has(heightsIndexedKey)
+	if err != nil || indexed {
+		return err
+	}
+
+	startTime := time.Now()
+	blkID := s.lastAccepted
+	for {
+		blk, _, err := s.GetStatelessBlock(blkID)
+		if err != nil {
+			return fmt.Errorf("failed to get block %s: %w", blkID, err)
+		}
+
+		height := blk.Height()
+		s.blockIDCache.Put(height, blkID)
+		if err := database.PutID(s.blockIDDB, database.PackUInt64(height), blkID); err != nil {
+			return fmt.Errorf("failed to index block %s: %w", blkID, err)
+		}
+		if height == 0 {
+			break
+		}
+		blkID = blk.Parent()
+	}
+
+	if err := s.singletonDB.Put(heightsIndexedKey, nil); err != nil {
+		return err
+	}
+	if err := s.baseDB.Commit(); err != nil {
+		return err
+	}
+
+	s.ctx.Log.Info("indexed accepted blocks by height",
+		zap.Stringer("lastAcceptedID", s.lastAccepted),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	return nil
+}
+
+// merkleize builds the state tree if the state was written before the state
+// tree was maintained. The UTXOs are inserted in batches of
+// [merkleizeBatchSize].
+func (s *state) merkleize() error {
+	hasStateRoot, err := s.singletonDB.Has(stateRootKey)
+	if err != nil || hasStateRoot {
+		return err
+	}
+
+	startTime := time.Now()
+
+	// The UTXOs are inserted in batches, which are committed to the database
+	// before the next batch is read, so that the whole UTXO set is never held
+	// in memory.
+	var (
+		stateRoot ids.ID
+		numUTXOs  int
+		start     []byte
+	)
+	for {
+		changes, next, err := s.readMerkleizeBatch(start)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			break
+		}
+		stateRoot, err = s.writeMerkleizeBatch(stateRoot, changes)
+		if err != nil {
+			return err
+		}
+		if err := s.baseDB.Commit(); err != nil {
+			return err
+		}
+		numUTXOs += len(changes)
+		if next == nil {
+			break
+		}
+		start = next
+	}
+
+	changes := make(merkleChanges)
+	for subnetID, subnetValidators := range s.currentStakers.validators {
+		for nodeID, validator := range subnetValidators {
+			if validator.validator == nil {
+				continue
+			}
+			if err := changes.putValidator(s, subnetID, nodeID); err != nil {
+				return err
+			}
+		}
+	}
+
+	subnets, err := s.GetSubnets()
+	if err != nil {
+		return err
+	}
+	for _, subnet := range subnets {
+		subnetID := subnet.ID()
+		owner, err := s.GetSubnetOwner(subnetID)
+		if err != nil {
+			return err
+		}
+		if err := changes.putSubnetOwner(subnetID, owner); err != nil {
+			return err
+		}
+	}
+
+	stateRoot, err = s.writeMerkleizeBatch(stateRoot, changes)
+	if err != nil {
+		return err
+	}
+	s.stateRoot = stateRoot
+	if err := database.PutID(s.singletonDB, stateRootKey, stateRoot); err != nil {
+		return err
+	}
+	if err := s.baseDB.Commit(); err != nil {
+		return err
+	}
+
+	s.ctx.Log.Info("built the state tree",
+		zap.Stringer("stateRoot", stateRoot),
+		zap.Int("numUTXOs", numUTXOs),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	return nil
+}
+
+// readMerkleizeBatch returns the state tree changes that insert up to
+// [merkleizeBatchSize] UTXOs, starting at the UTXO with ID [start]. The ID of
+// the next UTXO is returned, or nil if there is no next UTXO.
+func (s *state) readMerkleizeBatch(start []byte) (merkleChanges, []byte, error) {
+	utxoIt := djtx.NewUTXOIteratorWithStart(s.utxoDB, start)
+	defer utxoIt.Release()
+
+	changes := make(merkleChanges)
+	for utxoIt.Next() {
+		if len(changes) == merkleizeBatchSize {
+			return changes, utils.CopyBytes(utxoIt.Key()), nil
+		}
+		utxoID, err := ids.ToID(utxoIt.Key())
+		if err != nil {
+			return nil, nil, err
+		}
+		// The UTXOs are stored as they are serialized in the state tree.
+		changes[UTXOMerkleKey(utxoID)] = utils.CopyBytes(utxoIt.Value())
+	}
+	return changes, nil, utxoIt.Error()
+}
+
+// writeMerkleizeBatch applies [changes] to the partially built state tree with
+// root [stateRoot]. The nodes of the partially built state trees are never
+// proven, so the nodes that are removed from them are deleted right away.
+func (s *state) writeMerkleizeBatch(stateRoot ids.ID, changes merkleChanges) (ids.ID, error) {
+	newStateRoot, removedNodes, err := s.writeMerkleNodes(stateRoot, changes)
+	if err != nil {
+		return ids.Empty, err
+	}
+	for _, nodeID := range removedNodes {
+		if err := s.merkleDB.Delete(nodeID[:]); err != nil {
+			return ids.Empty, fmt.Errorf("failed to delete state tree node: %w", err)
+		}
+		s.merkleNodeCache.Evict(nodeID)
+	}
+	return newStateRoot, nil
+}
+
 func (s *state) init(genesisBytes []byte) error {
 	// Create the genesis block and save it as being accepted (We don't do
 	// genesisBlock.Accept() because then it'd look for genesisBlock's
@@ -1460,6 +1976,12 @@ func (s *state) init(genesisBytes []byte) error {
 		return err
 	}
 
+	// The state root is only written when it changes, so it is written here
+	// in case the genesis state tree is empty.
+	if err := database.PutID(s.singletonDB, stateRootKey, s.stateRoot); err != nil {
+		return err
+	}
+
 	if err := s.doneInit(); err != nil {
 		return err
 	}
@@ -1468,11 +1990,59 @@ func (s *state) init(genesisBytes []byte) error {
 }
 
 func (s *state) AddStatelessBlock(block blocks.Block, status choices.Status) {
-	s.addedBlocks[block.ID()] = stateBlk{
+	blkID := block.ID()
+	s.addedBlocks[blkID] = stateBlk{
 		Blk:    block,
 		Bytes:  block.Bytes(),
 		Status: status,
 	}
+	if status == choices.Accepted {
+		s.addedBlockIDs[block.Height()] = blkID
+	}
+}
+
+func (s *state) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	if blkID, exists := s.addedBlockIDs[height]; exists {
+		return blkID, nil
+	}
+	if blkIDIntf, cached := s.blockIDCache.Get(height); cached {
+		blkID := blkIDIntf.(ids.ID)
+		if blkID == ids.Empty {
+			return ids.Empty, database.ErrNotFound
+		}
+		return blkID, nil
+	}
+
+	blkID, err := database.GetID(s.blockIDDB, database.PackUInt64(height))
+	if err == database.ErrNotFound {
+		s.blockIDCache.Put(height, ids.Empty)
+		return ids.Empty, database.ErrNotFound
+	}
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	s.blockIDCache.Put(height, blkID)
+	return blkID, nil
+}
+
+// GetStateRoot returns the root of the state tree as of the last write. The
+// root doesn't reflect the modifications that haven't been written yet.
+func (s *state) GetStateRoot() (ids.ID, error) {
+	return s.stateRoot, nil
+}
+
+func (s *state) GetMerkleNode(nodeID ids.ID) ([]byte, error) {
+	if nodeIntf, cached := s.merkleNodeCache.Get(nodeID); cached {
+		return nodeIntf.([]byte), nil
+	}
+
+	node, err := s.merkleDB.Get(nodeID[:])
+	if err != nil {
+		return nil, err
+	}
+	s.merkleNodeCache.Put(nodeID, node)
+	return node, nil
 }
 
 func (s *state) SetHeight(height uint64) {
@@ -1520,6 +2090,14 @@ func (s *state) writeBlocks() error {
 			return fmt.Errorf("failed to write block %s: %w", blkID, err)
 		}
 	}
+
+	for height, blkID := range s.addedBlockIDs {
+		delete(s.addedBlockIDs, height)
+		s.blockIDCache.Put(height, blkID)
+		if err := database.PutID(s.blockIDDB, database.PackUInt64(height), blkID); err != nil {
+			return fmt.Errorf("failed to index block %s at height %d: %w", blkID, height, err)
+		}
+	}
 	return nil
 }
 
@@ -1621,6 +2199,12 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64) error
 					if err := validatorDB.Delete(staker.TxID[:]); err != nil {
 						return fmt.Errorf("failed to delete current staker: %w", err)
 					}
+					if err := s.currentValidatorUpdateDB.Delete(staker.TxID[:]); err != nil {
+						return fmt.Errorf("failed to delete current validator update: %w", err)
+					}
+					if err := s.currentStakingPeriodDB.Delete(staker.TxID[:]); err != nil {
+						return fmt.Errorf("failed to delete current staking period: %w", err)
+					}
 
 					s.validatorUptimes.DeleteUptime(nodeID, subnetID)
 				} else {
@@ -1646,10 +2230,62 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64) error
 					s.validatorUptimes.LoadUptime(nodeID, subnetID, vdr)
 					isNewValidator = true
 				}
+			} else if validatorDiff.validatorUpdated {
+				// The weight of this validator may have been changed.
+				if err := weightDiff.Add(true, validatorDiff.oldValidator.Weight); err != nil {
+					return fmt.Errorf("failed to decrease node weight diff: %w", err)
+				}
+				if err := weightDiff.Add(false, validatorDiff.validator.Weight); err != nil {
+					return fmt.Errorf("failed to increase node weight diff: %w", err)
+				}
+			}
+
+			if validatorDiff.validatorUpdated && validatorDiff.oldValidator != nil &&
+				!validatorDiff.validator.StartTime.Equal(validatorDiff.oldValidator.StartTime) {
+				// The validator was restaked, so its uptime is tracked from
+				// the start of its new staking period.
+				staker := validatorDiff.validator
+				if err := writeStakingPeriod(s.currentStakingPeriodDB, staker); err != nil {
+					return err
+				}
+
+				vdr := &uptimeAndReward{
+					txID:        staker.TxID,
+					lastUpdated: staker.StartTime,
+
+					UpDuration:      0,
+					LastUpdated:     uint64(staker.StartTime.Unix()),
+					PotentialReward: staker.PotentialReward,
+				}
+
+				vdrBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, vdr)
+				if err != nil {
+					return fmt.Errorf("failed to serialize current validator: %w", err)
+				}
+
+				if err = validatorDB.Put(staker.TxID[:], vdrBytes); err != nil {
+					return fmt.Errorf("failed to write current validator to list: %w", err)
+				}
+
+				s.validatorUptimes.LoadUptime(nodeID, subnetID, vdr)
+			} else if validatorDiff.validatorUpdated {
+				staker := validatorDiff.validator
+				update := &validatorUpdate{
+					Weight:  staker.Weight,
+					EndTime: uint64(staker.EndTime.Unix()),
+				}
+				updateBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, update)
+				if err != nil {
+					return fmt.Errorf("failed to serialize validator update: %w", err)
+				}
+				if err := s.currentValidatorUpdateDB.Put(staker.TxID[:], updateBytes); err != nil {
+					return fmt.Errorf("failed to write validator update: %w", err)
+				}
 			}
 
 			err := writeCurrentDelegatorDiff(
 				delegatorDB,
+				s.currentStakingPeriodDB,
 				weightDiff,
 				validatorDiff,
 			)
@@ -1724,9 +2360,25 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64) error
 
 func writeCurrentDelegatorDiff(
 	currentDelegatorList linkeddb.LinkedDB,
+	currentStakingPeriodDB database.KeyValueWriterDeleter,
 	weightDiff *ValidatorWeightDiff,
 	validatorDiff *diffValidator,
 ) error {
+	// Deletions are written first so that the new staking periods of restaked
+	// delegators are kept.
+	for _, staker := range validatorDiff.deletedDelegators {
+		if err := weightDiff.Add(true, staker.Weight); err != nil {
+			return fmt.Errorf("failed to decrease node weight diff: %w", err)
+		}
+
+		if err := currentDelegatorList.Delete(staker.TxID[:]); err != nil {
+			return fmt.Errorf("failed to delete current staker: %w", err)
+		}
+		if err := currentStakingPeriodDB.Delete(staker.TxID[:]); err != nil {
+			return fmt.Errorf("failed to delete current staking period: %w", err)
+		}
+	}
+
 	addedDelegatorIterator := NewTreeIterator(validatorDiff.addedDelegators)
 	defer addedDelegatorIterator.Release()
 	for addedDelegatorIterator.Next() {
@@ -1739,16 +2391,29 @@ func writeCurrentDelegatorDiff(
 		if err := database.PutUInt64(currentDelegatorList, staker.TxID[:], staker.PotentialReward); err != nil {
 			return fmt.Errorf("failed to write current delegator to list: %w", err)
 		}
-	}
 
-	for _, staker := range validatorDiff.deletedDelegators {
-		if err := weightDiff.Add(true, staker.Weight); err != nil {
-			return fmt.Errorf("failed to decrease node weight diff: %w", err)
+		if _, restaked := validatorDiff.deletedDelegators[staker.TxID]; restaked {
+			if err := writeStakingPeriod(currentStakingPeriodDB, staker); err != nil {
+				return err
+			}
 		}
+	}
+	return nil
+}
 
-		if err := currentDelegatorList.Delete(staker.TxID[:]); err != nil {
-			return fmt.Errorf("failed to delete current staker: %w", err)
-		}
+func writeStakingPeriod(db database.KeyValueWriter, staker *Staker) error {
+	period := &stakingPeriod{
+		Weight:    staker.Weight,
+		StartTime: uint64(staker.StartTime.Unix()),
+		EndTime:   uint64(staker.EndTime.Unix()),
+		Restakes:  staker.Restakes,
+	}
+	periodBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, period)
+	if err != nil {
+		return fmt.Errorf("failed to serialize staking period: %w", err)
+	}
+	if err := db.Put(staker.TxID[:], periodBytes); err != nil {
+		return fmt.Errorf("failed to write staking period: %w", err)
 	}
 	return nil
 }
@@ -1903,6 +2568,24 @@ func (s *state) writeTransformedSubnets() error {
 	return nil
 }
 
+func (s *state) writeSubnetOwners() error {
+	for subnetID, owner := range s.subnetOwners {
+		owner := owner
+
+		ownerBytes, err := txs.GenesisCodec.Marshal(txs.Version, &owner)
+		if err != nil {
+			return fmt.Errorf("failed to marshal subnet owner: %w", err)
+		}
+
+		delete(s.subnetOwners, subnetID)
+		s.subnetOwnerCache.Put(subnetID, owner)
+		if err := s.subnetOwnerDB.Put(subnetID[:], ownerBytes); err != nil {
+			return fmt.Errorf("failed to write subnet owner: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *state) writeSubnetSupplies() error {
 	for subnetID, supply := range s.modifiedSupplies {
 		delete(s.modifiedSupplies, subnetID)
@@ -1929,6 +2612,22 @@ func (s *state) writeChains() error {
 	return nil
 }
 
+func (s *state) writeChainMetadata() error {
+	for chainID, metadata := range s.modifiedChainMetadata {
+		metadataBytes, err := txs.GenesisCodec.Marshal(txs.Version, metadata)
+		if err != nil {
+			return fmt.Errorf("failed to marshal chain metadata: %w", err)
+		}
+
+		delete(s.modifiedChainMetadata, chainID)
+		s.chainMetadataCache.Put(chainID, metadata)
+		if err := s.chainMetadataDB.Put(chainID[:], metadataBytes); err != nil {
+			return fmt.Errorf("failed to write chain metadata: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *state) writeMetadata() error {
 	if !s.persistedTimestamp.Equal(s.timestamp) {
 		if err := database.PutTimestamp(s.singletonDB, timestampKey, s.timestamp); err != nil {
@@ -1950,3 +2649,139 @@ func (s *state) writeMetadata() error {
 	}
 	return nil
 }
+
+func (s *state) writeStateRoot(height uint64) error {
+	changes := make(merkleChanges)
+	for utxoID, utxo := range s.modifiedUTXOs {
+		if err := changes.putUTXO(utxoID, utxo); err != nil {
+			return fmt.Errorf("failed to serialize UTXO: %w", err)
+		}
+	}
+	if err := changes.putValidators(s, s.currentStakers.validatorDiffs); err != nil {
+		return fmt.Errorf("failed to serialize validator: %w", err)
+	}
+	for _, subnet := range s.addedSubnets {
+		createSubnetTx := subnet.Unsigned.(*txs.CreateSubnetTx)
+		if err := changes.putSubnetOwner(subnet.ID(), createSubnetTx.Owner); err != nil {
+			return fmt.Errorf("failed to serialize subnet owner: %w", err)
+		}
+	}
+	for subnetID, owner := range s.subnetOwners {
+		if err := changes.putSubnetOwner(subnetID, owner); err != nil {
+			return fmt.Errorf("failed to serialize subnet owner: %w", err)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	stateRoot, removedNodes, err := s.writeMerkleNodes(s.stateRoot, changes)
+	if err != nil {
+		return err
+	}
+	if stateRoot == s.stateRoot {
+		return nil
+	}
+	s.stateRoot = stateRoot
+	if err := database.PutID(s.singletonDB, stateRootKey, stateRoot); err != nil {
+		return fmt.Errorf("failed to write state root: %w", err)
+	}
+	if s.stateProofArchival {
+		return nil
+	}
+	for _, nodeID := range removedNodes {
+		if err := s.merkleRemovedDB.Put(merkleRemovedKey(height, nodeID), nil); err != nil {
+			return fmt.Errorf("failed to write removed state tree node: %w", err)
+		}
+		if err := database.PutUInt64(s.merkleRemovalHeightDB, nodeID[:], height); err != nil {
+			return fmt.Errorf("failed to write removed state tree node: %w", err)
+		}
+	}
+	if height < s.stateProofRetention {
+		return nil
+	}
+	return s.pruneMerkleNodes(height - s.stateProofRetention)
+}
+
+// writeMerkleNodes applies [changes] to the state tree with root [stateRoot]
+// and writes the nodes of the resulting state tree. The IDs of the nodes that
+// were removed from the state tree are returned.
+func (s *state) writeMerkleNodes(stateRoot ids.ID, changes merkleChanges) (ids.ID, []ids.ID, error) {
+	newStateRoot, newNodes, removedNodes, err := merkle.Update(s, stateRoot, changes)
+	if err != nil {
+		return ids.Empty, nil, fmt.Errorf("failed to update state tree: %w", err)
+	}
+	for nodeID, node := range newNodes {
+		if err := s.merkleDB.Put(nodeID[:], node); err != nil {
+			return ids.Empty, nil, fmt.Errorf("failed to write state tree node: %w", err)
+		}
+		s.merkleNodeCache.Put(nodeID, node)
+
+		// Nodes are content addressed, so a node that was removed at a
+		// previous height may be added back.
+		removalHeight, err := database.GetUInt64(s.merkleRemovalHeightDB, nodeID[:])
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return ids.Empty, nil, fmt.Errorf("failed to read removed state tree node: %w", err)
+		}
+		if err := s.merkleRemovedDB.Delete(merkleRemovedKey(removalHeight, nodeID)); err != nil {
+			return ids.Empty, nil, fmt.Errorf("failed to delete removed state tree node: %w", err)
+		}
+		if err := s.merkleRemovalHeightDB.Delete(nodeID[:]); err != nil {
+			return ids.Empty, nil, fmt.Errorf("failed to delete removed state tree node: %w", err)
+		}
+	}
+	return newStateRoot, removedNodes, nil
+}
+
+// pruneMerkleNodes deletes the nodes that were removed from the state tree at
+// or before [height]. The state trees of [height] and of the following heights
+// are kept.
+func (s *state) pruneMerkleNodes(height uint64) error {
+	// Removed nodes are keyed by height first, so they are iterated in order of
+	// height.
+	it := s.merkleRemovedDB.NewIterator()
+	var prunedKeys [][]byte
+	for it.Next() {
+		key := it.Key()
+		removalHeight, err := database.ParseUInt64(key[:database.Uint64Size])
+		if err != nil {
+			it.Release()
+			return err
+		}
+		if removalHeight > height {
+			break
+		}
+		prunedKeys = append(prunedKeys, utils.CopyBytes(key))
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range prunedKeys {
+		nodeID := key[database.Uint64Size:]
+		if err := s.merkleDB.Delete(nodeID); err != nil {
+			return fmt.Errorf("failed to delete state tree node: %w", err)
+		}
+		if err := s.merkleRemovalHeightDB.Delete(nodeID); err != nil {
+			return fmt.Errorf("failed to delete removed state tree node: %w", err)
+		}
+		if err := s.merkleRemovedDB.Delete(key); err != nil {
+			return fmt.Errorf("failed to delete removed state tree node: %w", err)
+		}
+		id, err := ids.ToID(nodeID)
+		if err != nil {
+			return err
+		}
+		s.merkleNodeCache.Evict(id)
+	}
+	return nil
+}
+
+func merkleRemovedKey(height uint64, nodeID ids.ID) []byte {
+	return append(database.PackUInt64(height), nodeID[:]...)
+}
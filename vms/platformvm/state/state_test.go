@@ -14,19 +14,25 @@ import (
 
 	"github.com/lasthyphen/dijetsnodego/database"
 	"github.com/lasthyphen/dijetsnodego/database/memdb"
+	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/snow/choices"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/units"
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/config"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/genesis"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/merkle"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/metrics"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/signer"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/validator"
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
@@ -500,6 +506,7 @@ func newStateFromDB(require *require.Assertions, db database.Database) State {
 		&config.Config{
 			Validators: vdrs,
 		},
+		&config.ExecutionConfig{},
 		&snow.Context{},
 		prometheus.NewRegistry(),
 		reward.NewCalculator(reward.Config{
@@ -833,3 +840,567 @@ func TestStateAddRemoveValidator(t *testing.T) {
 		require.Equal(diff.expectedPublicKeyDiff, gotPublicKeyDiffs)
 	}
 }
+
+func TestStateUpdateValidator(t *testing.T) {
+	require := require.New(t)
+
+	stateIntf, db := newInitializedState(require)
+	s := stateIntf.(*state)
+
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		subnetID  = ids.GenerateTestID()
+		startTime = initialTime.Add(time.Second)
+		endTime   = startTime.Add(24 * time.Hour)
+	)
+	validatorTx := &txs.Tx{Unsigned: &txs.AddSubnetValidatorTx{
+		Validator: validator.SubnetValidator{
+			Validator: validator.Validator{
+				NodeID: nodeID,
+				Start:  uint64(startTime.Unix()),
+				End:    uint64(endTime.Unix()),
+				Wght:   10,
+			},
+			Subnet: subnetID,
+		},
+		SubnetAuth: &secp256k1fx.Input{},
+	}}
+	require.NoError(validatorTx.Sign(txs.Codec, nil))
+
+	staker, err := NewCurrentStaker(validatorTx.ID(), validatorTx.Unsigned.(txs.Staker), 0)
+	require.NoError(err)
+
+	s.AddTx(validatorTx, status.Committed)
+	s.PutCurrentValidator(staker)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	// Increase the weight and extend the end time of the validator.
+	updatedStaker := *staker
+	updatedStaker.Weight = 15
+	updatedStaker.EndTime = endTime.Add(time.Hour)
+	updatedStaker.NextTime = updatedStaker.EndTime
+	s.UpdateCurrentValidator(&updatedStaker)
+
+	returnedStaker, err := s.GetCurrentValidator(subnetID, nodeID)
+	require.NoError(err)
+	require.Equal(&updatedStaker, returnedStaker)
+
+	stakerIterator, err := s.GetCurrentStakerIterator()
+	require.NoError(err)
+	require.True(stakerIterator.Next())
+	require.Equal(&updatedStaker, stakerIterator.Value())
+	require.True(stakerIterator.Next())
+	require.Equal(initialNodeID, stakerIterator.Value().NodeID)
+	require.False(stakerIterator.Next())
+	stakerIterator.Release()
+
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	weightDiffs, err := s.GetValidatorWeightDiffs(2, subnetID)
+	require.NoError(err)
+	require.Equal(
+		map[ids.NodeID]*ValidatorWeightDiff{
+			nodeID: {
+				Decrease: false,
+				Amount:   5,
+			},
+		},
+		weightDiffs,
+	)
+
+	// Decrease the weight of the validator twice before writing.
+	reducedStaker := updatedStaker
+	reducedStaker.Weight = 12
+	s.UpdateCurrentValidator(&reducedStaker)
+	reducedStaker.Weight = 3
+	s.UpdateCurrentValidator(&reducedStaker)
+	s.SetHeight(3)
+	require.NoError(s.Commit())
+
+	weightDiffs, err = s.GetValidatorWeightDiffs(3, subnetID)
+	require.NoError(err)
+	require.Equal(
+		map[ids.NodeID]*ValidatorWeightDiff{
+			nodeID: {
+				Decrease: true,
+				Amount:   12,
+			},
+		},
+		weightDiffs,
+	)
+
+	// The update must be persisted.
+	reloadedState := newStateFromDB(require, db).(*state)
+	require.NoError(reloadedState.load())
+
+	returnedStaker, err = reloadedState.GetCurrentValidator(subnetID, nodeID)
+	require.NoError(err)
+	require.Equal(reducedStaker.Weight, returnedStaker.Weight)
+	require.Equal(reducedStaker.EndTime.Unix(), returnedStaker.EndTime.Unix())
+	require.Equal(reducedStaker.EndTime.Unix(), returnedStaker.NextTime.Unix())
+
+	// Removing the updated validator removes its current weight.
+	reloadedState.DeleteCurrentValidator(returnedStaker)
+	reloadedState.SetHeight(4)
+	require.NoError(reloadedState.Commit())
+
+	weightDiffs, err = reloadedState.GetValidatorWeightDiffs(4, subnetID)
+	require.NoError(err)
+	require.Equal(
+		map[ids.NodeID]*ValidatorWeightDiff{
+			nodeID: {
+				Decrease: true,
+				Amount:   3,
+			},
+		},
+		weightDiffs,
+	)
+
+	has, err := reloadedState.currentValidatorUpdateDB.Has(staker.TxID[:])
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestStateRestakeStakers(t *testing.T) {
+	require := require.New(t)
+
+	stateIntf, db := newInitializedState(require)
+	s := stateIntf.(*state)
+
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		subnetID  = ids.GenerateTestID()
+		startTime = initialTime.Add(time.Second)
+		endTime   = startTime.Add(24 * time.Hour)
+	)
+	validatorTx := &txs.Tx{Unsigned: &txs.AddPermissionlessValidatorTx{
+		Validator: validator.Validator{
+			NodeID: nodeID,
+			Start:  uint64(startTime.Unix()),
+			End:    uint64(endTime.Unix()),
+			Wght:   10,
+		},
+		Subnet:                subnetID,
+		Signer:                &signer.Empty{},
+		ValidatorRewardsOwner: &secp256k1fx.OutputOwners{},
+		DelegatorRewardsOwner: &secp256k1fx.OutputOwners{},
+		RestakePeriods:        1,
+	}}
+	require.NoError(validatorTx.Sign(txs.Codec, nil))
+
+	delegatorTx := &txs.Tx{Unsigned: &txs.AddPermissionlessDelegatorTx{
+		Validator: validator.Validator{
+			NodeID: nodeID,
+			Start:  uint64(startTime.Unix()),
+			End:    uint64(endTime.Unix()),
+			Wght:   5,
+		},
+		Subnet:                 subnetID,
+		DelegationRewardsOwner: &secp256k1fx.OutputOwners{},
+		RestakePeriods:         1,
+	}}
+	require.NoError(delegatorTx.Sign(txs.Codec, nil))
+
+	validatorStaker, err := NewCurrentStaker(validatorTx.ID(), validatorTx.Unsigned.(txs.Staker), 1)
+	require.NoError(err)
+	delegatorStaker, err := NewCurrentStaker(delegatorTx.ID(), delegatorTx.Unsigned.(txs.Staker), 1)
+	require.NoError(err)
+
+	s.AddTx(validatorTx, status.Committed)
+	s.AddTx(delegatorTx, status.Committed)
+	s.PutCurrentValidator(validatorStaker)
+	s.PutCurrentDelegator(delegatorStaker)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	// Restake both stakers, compounding their rewards.
+	restakedValidator := *validatorStaker
+	restakedValidator.Weight = 11
+	restakedValidator.StartTime = endTime
+	restakedValidator.EndTime = endTime.Add(24 * time.Hour)
+	restakedValidator.NextTime = restakedValidator.EndTime
+	restakedValidator.PotentialReward = 2
+	restakedValidator.Restakes = 1
+	s.UpdateCurrentValidator(&restakedValidator)
+
+	restakedDelegator := *delegatorStaker
+	restakedDelegator.Weight = 6
+	restakedDelegator.StartTime = endTime
+	restakedDelegator.EndTime = endTime.Add(24 * time.Hour)
+	restakedDelegator.NextTime = restakedDelegator.EndTime
+	restakedDelegator.PotentialReward = 2
+	restakedDelegator.Restakes = 1
+	s.DeleteCurrentDelegator(delegatorStaker)
+	s.PutCurrentDelegator(&restakedDelegator)
+
+	stakerIterator, err := s.GetCurrentStakerIterator()
+	require.NoError(err)
+	require.True(stakerIterator.Next())
+	require.Equal(&restakedDelegator, stakerIterator.Value())
+	require.True(stakerIterator.Next())
+	require.Equal(&restakedValidator, stakerIterator.Value())
+	require.True(stakerIterator.Next())
+	require.Equal(initialNodeID, stakerIterator.Value().NodeID)
+	require.False(stakerIterator.Next())
+	stakerIterator.Release()
+
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	weightDiffs, err := s.GetValidatorWeightDiffs(2, subnetID)
+	require.NoError(err)
+	require.Equal(
+		map[ids.NodeID]*ValidatorWeightDiff{
+			nodeID: {
+				Decrease: false,
+				Amount:   2,
+			},
+		},
+		weightDiffs,
+	)
+
+	// The uptime of the restaked validator is tracked from the start of its
+	// new staking period.
+	upDuration, lastUpdated, err := s.GetUptime(nodeID, subnetID)
+	require.NoError(err)
+	require.Zero(upDuration)
+	require.Equal(endTime.Unix(), lastUpdated.Unix())
+
+	// The new staking periods must be persisted.
+	reloadedState := newStateFromDB(require, db).(*state)
+	require.NoError(reloadedState.load())
+
+	returnedValidator, err := reloadedState.GetCurrentValidator(subnetID, nodeID)
+	require.NoError(err)
+	require.Equal(restakedValidator.Weight, returnedValidator.Weight)
+	require.Equal(restakedValidator.StartTime.Unix(), returnedValidator.StartTime.Unix())
+	require.Equal(restakedValidator.EndTime.Unix(), returnedValidator.EndTime.Unix())
+	require.Equal(restakedValidator.PotentialReward, returnedValidator.PotentialReward)
+	require.Equal(restakedValidator.Restakes, returnedValidator.Restakes)
+
+	delegatorIterator, err := reloadedState.GetCurrentDelegatorIterator(subnetID, nodeID)
+	require.NoError(err)
+	require.True(delegatorIterator.Next())
+	returnedDelegator := delegatorIterator.Value()
+	require.False(delegatorIterator.Next())
+	delegatorIterator.Release()
+	require.Equal(restakedDelegator.Weight, returnedDelegator.Weight)
+	require.Equal(restakedDelegator.StartTime.Unix(), returnedDelegator.StartTime.Unix())
+	require.Equal(restakedDelegator.EndTime.Unix(), returnedDelegator.EndTime.Unix())
+	require.Equal(restakedDelegator.PotentialReward, returnedDelegator.PotentialReward)
+	require.Equal(restakedDelegator.Restakes, returnedDelegator.Restakes)
+
+	// Removing the restaked stakers removes their staking periods.
+	reloadedState.DeleteCurrentDelegator(returnedDelegator)
+	reloadedState.DeleteCurrentValidator(returnedValidator)
+	reloadedState.SetHeight(3)
+	require.NoError(reloadedState.Commit())
+
+	weightDiffs, err = reloadedState.GetValidatorWeightDiffs(3, subnetID)
+	require.NoError(err)
+	require.Equal(
+		map[ids.NodeID]*ValidatorWeightDiff{
+			nodeID: {
+				Decrease: true,
+				Amount:   17,
+			},
+		},
+		weightDiffs,
+	)
+
+	for _, txID := range []ids.ID{validatorTx.ID(), delegatorTx.ID()} {
+		has, err := reloadedState.currentStakingPeriodDB.Has(txID[:])
+		require.NoError(err)
+		require.False(has)
+	}
+}
+
+func TestStateIndexHeights(t *testing.T) {
+	require := require.New(t)
+	stateIntf, db := newInitializedState(require)
+	s := stateIntf.(*state)
+	s.ctx = &snow.Context{Log: logging.NoLog{}}
+
+	genesisBlkID := s.GetLastAccepted()
+	blkID, err := s.GetBlockIDAtHeight(0)
+	require.NoError(err)
+	require.Equal(genesisBlkID, blkID)
+
+	// Only accepted blocks are indexed
+	rejectedBlk, err := blocks.NewBanffStandardBlock(initialTime, genesisBlkID, 1, nil)
+	require.NoError(err)
+	s.AddStatelessBlock(rejectedBlk, choices.Rejected)
+
+	acceptedBlk, err := blocks.NewBanffStandardBlock(initialTime.Add(time.Second), genesisBlkID, 1, nil)
+	require.NoError(err)
+	s.AddStatelessBlock(acceptedBlk, choices.Accepted)
+	s.SetLastAccepted(acceptedBlk.ID())
+	require.NoError(s.Commit())
+
+	blkID, err = s.GetBlockIDAtHeight(1)
+	require.NoError(err)
+	require.Equal(acceptedBlk.ID(), blkID)
+
+	_, err = s.GetBlockIDAtHeight(2)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Simulate a database written before the height index was maintained
+	require.NoError(s.blockIDDB.Delete(database.PackUInt64(0)))
+	require.NoError(s.blockIDDB.Delete(database.PackUInt64(1)))
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db).(*state)
+	s.ctx = &snow.Context{Log: logging.NoLog{}}
+	require.NoError(s.loadMetadata())
+
+	_, err = s.GetBlockIDAtHeight(1)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(s.indexHeights())
+
+	blkID, err = s.GetBlockIDAtHeight(0)
+	require.NoError(err)
+	require.Equal(genesisBlkID, blkID)
+
+	blkID, err = s.GetBlockIDAtHeight(1)
+	require.NoError(err)
+	require.Equal(acceptedBlk.ID(), blkID)
+
+	// The blocks are only indexed once
+	indexed, err := s.singletonDB.Has(heightsIndexedKey)
+	require.NoError(err)
+	require.True(indexed)
+}
+
+func TestStateMerkleize(t *testing.T) {
+	require := require.New(t)
+	stateIntf, db := newInitializedState(require)
+	s := stateIntf.(*state)
+
+	stateRoot, err := s.GetStateRoot()
+	require.NoError(err)
+	require.NotEqual(ids.Empty, stateRoot)
+
+	utxoID := djtx.UTXOID{
+		TxID:        initialTxID,
+		OutputIndex: 0,
+	}
+	utxo, err := s.GetUTXO(utxoID.InputID())
+	require.NoError(err)
+	expectedUTXOBytes, err := txs.GenesisCodec.Marshal(txs.Version, utxo)
+	require.NoError(err)
+
+	proof, err := merkle.Prove(s, stateRoot, UTXOMerkleKey(utxoID.InputID()))
+	require.NoError(err)
+	require.Equal(expectedUTXOBytes, proof.Value)
+	require.NoError(proof.Verify(stateRoot))
+
+	proof, err = merkle.Prove(s, stateRoot, ValidatorMerkleKey(constants.PrimaryNetworkID, initialNodeID))
+	require.NoError(err)
+	require.NotNil(proof.Value)
+	require.NoError(proof.Verify(stateRoot))
+
+	// Simulate a database written before the state root was maintained
+	require.NoError(s.singletonDB.Delete(stateRootKey))
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db).(*state)
+	s.ctx = &snow.Context{Log: logging.NoLog{}}
+	require.NoError(s.load())
+
+	require.NoError(s.merkleize())
+
+	merkleizedStateRoot, err := s.GetStateRoot()
+	require.NoError(err)
+	require.Equal(stateRoot, merkleizedStateRoot)
+
+	// The state is only merkleized once
+	hasStateRoot, err := s.singletonDB.Has(stateRootKey)
+	require.NoError(err)
+	require.True(hasStateRoot)
+}
+
+func TestStatePruneMerkleNodes(t *testing.T) {
+	require := require.New(t)
+	stateIntf, _ := newInitializedState(require)
+	s := stateIntf.(*state)
+	s.stateProofRetention = 1
+
+	utxo := &djtx.UTXO{
+		UTXOID: djtx.UTXOID{
+			TxID:        ids.GenerateTestID(),
+			OutputIndex: 0,
+		},
+		Asset: djtx.Asset{ID: initialTxID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: units.Djtx,
+		},
+	}
+	utxoKey := UTXOMerkleKey(utxo.InputID())
+
+	initialStateRoot, err := s.GetStateRoot()
+	require.NoError(err)
+
+	s.AddUTXO(utxo)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+	addedStateRoot, err := s.GetStateRoot()
+	require.NoError(err)
+
+	// Removing the UTXO adds back the nodes of the initial state tree
+	s.DeleteUTXO(utxo.InputID())
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+	removedStateRoot, err := s.GetStateRoot()
+	require.NoError(err)
+	require.Equal(initialStateRoot, removedStateRoot)
+
+	// The state tree of the previous height is kept
+	proof, err := merkle.Prove(s, addedStateRoot, utxoKey)
+	require.NoError(err)
+	require.NotNil(proof.Value)
+	require.NoError(proof.Verify(addedStateRoot))
+
+	s.AddUTXO(&djtx.UTXO{
+		UTXOID: djtx.UTXOID{
+			TxID:        ids.GenerateTestID(),
+			OutputIndex: 0,
+		},
+		Asset: djtx.Asset{ID: initialTxID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: units.Djtx,
+		},
+	})
+	s.SetHeight(3)
+	require.NoError(s.Commit())
+
+	// The nodes only used by the state tree of height 1 are pruned
+	_, err = merkle.Prove(s, addedStateRoot, utxoKey)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The nodes that were added back aren't
+	proof, err = merkle.Prove(s, removedStateRoot, utxoKey)
+	require.NoError(err)
+	require.Nil(proof.Value)
+	require.NoError(proof.Verify(removedStateRoot))
+}
+
+func TestStatePruneMerkleNodesWithoutRetention(t *testing.T) {
+	require := require.New(t)
+	stateIntf, _ := newInitializedState(require)
+	s := stateIntf.(*state)
+	s.stateProofRetention = 0
+
+	utxo := &djtx.UTXO{
+		UTXOID: djtx.UTXOID{
+			TxID:        ids.GenerateTestID(),
+			OutputIndex: 0,
+		},
+		Asset: djtx.Asset{ID: initialTxID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: units.Djtx,
+		},
+	}
+	utxoKey := UTXOMerkleKey(utxo.InputID())
+
+	initialStateRoot, err := s.GetStateRoot()
+	require.NoError(err)
+
+	s.AddUTXO(utxo)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+	addedStateRoot, err := s.GetStateRoot()
+	require.NoError(err)
+
+	// Only the last state tree is kept
+	_, err = merkle.Prove(s, initialStateRoot, utxoKey)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	proof, err := merkle.Prove(s, addedStateRoot, utxoKey)
+	require.NoError(err)
+	require.NotNil(proof.Value)
+	require.NoError(proof.Verify(addedStateRoot))
+
+	// Archival nodes keep every state tree
+	s.stateProofArchival = true
+	s.DeleteUTXO(utxo.InputID())
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	proof, err = merkle.Prove(s, addedStateRoot, utxoKey)
+	require.NoError(err)
+	require.NotNil(proof.Value)
+	require.NoError(proof.Verify(addedStateRoot))
+}
+
+func TestStateMerkleizeInBatches(t *testing.T) {
+	require := require.New(t)
+	stateIntf, db := newInitializedState(require)
+	s := stateIntf.(*state)
+
+	for i := 0; i < 10; i++ {
+		s.AddUTXO(&djtx.UTXO{
+			UTXOID: djtx.UTXOID{
+				TxID:        ids.GenerateTestID(),
+				OutputIndex: 0,
+			},
+			Asset: djtx.Asset{ID: initialTxID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: units.Djtx,
+			},
+		})
+	}
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	// merkleize rebuilds the state tree of a database written before the
+	// state tree was maintained.
+	remerkleize := func(batchSize int) (ids.ID, int) {
+		merkleDB := prefixdb.New(merklePrefix, db)
+		it := merkleDB.NewIterator()
+		var nodeIDs [][]byte
+		for it.Next() {
+			nodeIDs = append(nodeIDs, it.Key())
+		}
+		require.NoError(it.Error())
+		it.Release()
+		for _, nodeID := range nodeIDs {
+			require.NoError(merkleDB.Delete(nodeID))
+		}
+		require.NoError(s.singletonDB.Delete(stateRootKey))
+		require.NoError(s.Commit())
+
+		defaultBatchSize := merkleizeBatchSize
+		merkleizeBatchSize = batchSize
+		defer func() {
+			merkleizeBatchSize = defaultBatchSize
+		}()
+
+		s = newStateFromDB(require, db).(*state)
+		s.ctx = &snow.Context{Log: logging.NoLog{}}
+		require.NoError(s.load())
+		require.NoError(s.merkleize())
+
+		stateRoot, err := s.GetStateRoot()
+		require.NoError(err)
+
+		numNodes := 0
+		it = merkleDB.NewIterator()
+		for it.Next() {
+			numNodes++
+		}
+		require.NoError(it.Error())
+		it.Release()
+		return stateRoot, numNodes
+	}
+
+	stateRoot, numNodes := remerkleize(math.MaxInt)
+	batchedStateRoot, batchedNumNodes := remerkleize(3)
+
+	// Building the state tree in batches doesn't leave the nodes of the
+	// partially built state trees in the database.
+	require.Equal(stateRoot, batchedStateRoot)
+	require.Equal(numNodes, batchedNumNodes)
+}
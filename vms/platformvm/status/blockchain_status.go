@@ -17,12 +17,16 @@ import (
 // - [Preferred] This blockchain is currently in the preferred tip
 // - [Validating] This node is currently validating this blockchain
 // - [Syncing] This node is syncing up to the preferred block height
+// - [Halted] This blockchain was halted and will never be created again
+// - [Upgrading] This blockchain is scheduled to switch to a different VM
 const (
 	UnknownChain BlockchainStatus = iota
 	Created
 	Preferred
 	Validating
 	Syncing
+	Halted
+	Upgrading
 )
 
 var (
@@ -51,6 +55,10 @@ func (s *BlockchainStatus) UnmarshalJSON(b []byte) error {
 		*s = Validating
 	case `"Syncing"`:
 		*s = Syncing
+	case `"Halted"`:
+		*s = Halted
+	case `"Upgrading"`:
+		*s = Upgrading
 	case "null":
 	default:
 		return errUnknownStatus
@@ -61,7 +69,7 @@ func (s *BlockchainStatus) UnmarshalJSON(b []byte) error {
 // Verify that this is a valid status.
 func (s BlockchainStatus) Verify() error {
 	switch s {
-	case UnknownChain, Created, Preferred, Validating, Syncing:
+	case UnknownChain, Created, Preferred, Validating, Syncing, Halted, Upgrading:
 		return nil
 	default:
 		return errUnknownBlockchainStatus
@@ -80,6 +88,10 @@ func (s BlockchainStatus) String() string {
 		return "Validating"
 	case Syncing:
 		return "Syncing"
+	case Halted:
+		return "Halted"
+	case Upgrading:
+		return "Upgrading"
 	default:
 		return "Invalid blockchain status"
 	}
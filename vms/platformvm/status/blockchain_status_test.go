@@ -20,6 +20,8 @@ func TestBlockchainStatusJSON(t *testing.T) {
 		Created,
 		Preferred,
 		Syncing,
+		Halted,
+		Upgrading,
 	}
 	for _, status := range statuses {
 		statusJSON, err := json.Marshal(status)
@@ -60,6 +62,8 @@ func TestBlockchainStatusVerify(t *testing.T) {
 		Created,
 		Preferred,
 		Syncing,
+		Halted,
+		Upgrading,
 	}
 	for _, status := range statuses {
 		err := status.Verify()
@@ -79,6 +83,8 @@ func TestBlockchainStatusString(t *testing.T) {
 	require.Equal("Created", Created.String())
 	require.Equal("Preferred", Preferred.String())
 	require.Equal("Syncing", Syncing.String())
+	require.Equal("Halted", Halted.String())
+	require.Equal("Upgrading", Upgrading.String())
 	require.Equal("Dropped", Dropped.String())
 
 	badStatus := BlockchainStatus(math.MaxInt32)
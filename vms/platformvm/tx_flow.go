@@ -0,0 +1,112 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+var _ txs.Visitor = (*txFlow)(nil)
+
+// txFlow collects all the inputs consumed and all the outputs produced by a
+// tx, including imported inputs, exported outputs and staked outputs.
+type txFlow struct {
+	ins  []*djtx.TransferableInput
+	outs []*djtx.TransferableOutput
+}
+
+func (f *txFlow) baseTx(tx *txs.BaseTx) {
+	f.ins = append(f.ins, tx.Ins...)
+	f.outs = append(f.outs, tx.Outs...)
+}
+
+func (f *txFlow) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	f.outs = append(f.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (f *txFlow) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	f.outs = append(f.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (f *txFlow) CreateChainTx(tx *txs.CreateChainTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) ImportTx(tx *txs.ImportTx) error {
+	f.baseTx(&tx.BaseTx)
+	f.ins = append(f.ins, tx.ImportedInputs...)
+	return nil
+}
+
+func (f *txFlow) ExportTx(tx *txs.ExportTx) error {
+	f.baseTx(&tx.BaseTx)
+	f.outs = append(f.outs, tx.ExportedOutputs...)
+	return nil
+}
+
+func (*txFlow) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return nil
+}
+
+func (*txFlow) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	return nil
+}
+
+func (f *txFlow) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	f.outs = append(f.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (f *txFlow) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	f.outs = append(f.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (f *txFlow) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) ModifySubnetValidatorTx(tx *txs.ModifySubnetValidatorTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) HaltBlockchainTx(tx *txs.HaltBlockchainTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (f *txFlow) UpgradeBlockchainTx(tx *txs.UpgradeBlockchainTx) error {
+	f.baseTx(&tx.BaseTx)
+	return nil
+}
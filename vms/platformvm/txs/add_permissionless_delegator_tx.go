@@ -19,7 +19,10 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
 )
 
-var _ DelegatorTx = (*AddPermissionlessDelegatorTx)(nil)
+var (
+	_ DelegatorTx  = (*AddPermissionlessDelegatorTx)(nil)
+	_ AutoRestaker = (*AddPermissionlessDelegatorTx)(nil)
+)
 
 // AddPermissionlessDelegatorTx is an unsigned addPermissionlessDelegatorTx
 type AddPermissionlessDelegatorTx struct {
@@ -33,6 +36,12 @@ type AddPermissionlessDelegatorTx struct {
 	StakeOuts []*djtx.TransferableOutput `serialize:"true" json:"stake"`
 	// Where to send staking rewards when done validating
 	DelegationRewardsOwner fx.Owner `serialize:"true" json:"rewardsOwner"`
+	// Number of times this delegator is restaked for the same duration when it
+	// is rewarded. Only serialized by [V1Version].
+	RestakePeriods uint32 `serializeV1:"true" json:"restakePeriods,omitempty"`
+	// If true, the rewards of this delegator are added to its stake when it is
+	// restaked. Only serialized by [V1Version].
+	CompoundRewards bool `serializeV1:"true" json:"compoundRewards,omitempty"`
 }
 
 // InitCtx sets the FxID fields in the inputs and outputs of this
@@ -93,6 +102,14 @@ func (tx *AddPermissionlessDelegatorTx) RewardsOwner() fx.Owner {
 	return tx.DelegationRewardsOwner
 }
 
+func (tx *AddPermissionlessDelegatorTx) MaxRestakes() uint32 {
+	return tx.RestakePeriods
+}
+
+func (tx *AddPermissionlessDelegatorTx) CompoundsRewards() bool {
+	return tx.CompoundRewards
+}
+
 // SyntacticVerify returns nil iff [tx] is valid
 func (tx *AddPermissionlessDelegatorTx) SyntacticVerify(ctx *snow.Context) error {
 	switch {
@@ -102,6 +119,8 @@ func (tx *AddPermissionlessDelegatorTx) SyntacticVerify(ctx *snow.Context) error
 		return nil
 	case len(tx.StakeOuts) == 0: // Ensure there is provided stake
 		return errNoStake
+	case tx.CompoundRewards && tx.RestakePeriods == 0:
+		return errCompoundWithoutRestake
 	}
 
 	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
@@ -83,6 +83,26 @@ func TestAddPermissionlessDelegatorTxSyntacticVerify(t *testing.T) {
 			},
 			err: errNoStake,
 		},
+		{
+			name: "compounded rewards without restaking",
+			txFunc: func(*gomock.Controller) *AddPermissionlessDelegatorTx {
+				return &AddPermissionlessDelegatorTx{
+					BaseTx: validBaseTx,
+					StakeOuts: []*djtx.TransferableOutput{
+						{
+							Asset: djtx.Asset{
+								ID: ids.GenerateTestID(),
+							},
+							Out: &secp256k1fx.TransferOutput{
+								Amt: 1,
+							},
+						},
+					},
+					CompoundRewards: true,
+				}
+			},
+			err: errCompoundWithoutRestake,
+		},
 		{
 			name: "invalid rewards owner",
 			txFunc: func(ctrl *gomock.Controller) *AddPermissionlessDelegatorTx {
@@ -23,13 +23,15 @@ import (
 )
 
 var (
-	_ ValidatorTx = (*AddPermissionlessValidatorTx)(nil)
+	_ ValidatorTx  = (*AddPermissionlessValidatorTx)(nil)
+	_ AutoRestaker = (*AddPermissionlessValidatorTx)(nil)
 
 	errEmptyNodeID             = errors.New("validator nodeID cannot be empty")
 	errNoStake                 = errors.New("no stake")
 	errInvalidSigner           = errors.New("invalid signer")
 	errMultipleStakedAssets    = errors.New("multiple staked assets")
 	errValidatorWeightMismatch = errors.New("validator weight mismatch")
+	errCompoundWithoutRestake  = errors.New("rewards can only be compounded by restaked stakers")
 )
 
 // AddPermissionlessValidatorTx is an unsigned addPermissionlessValidatorTx
@@ -57,6 +59,12 @@ type AddPermissionlessValidatorTx struct {
 	// For example, if this validator has DelegationShares=300,000 then they
 	// take 30% of rewards from delegators
 	DelegationShares uint32 `serialize:"true" json:"shares"`
+	// Number of times this validator is restaked for the same duration when it
+	// is rewarded. Only serialized by [V1Version].
+	RestakePeriods uint32 `serializeV1:"true" json:"restakePeriods,omitempty"`
+	// If true, the validation rewards of this validator are added to its stake
+	// when it is restaked. Only serialized by [V1Version].
+	CompoundRewards bool `serializeV1:"true" json:"compoundRewards,omitempty"`
 }
 
 // InitCtx sets the FxID fields in the inputs and outputs of this
@@ -130,6 +138,14 @@ func (tx *AddPermissionlessValidatorTx) Shares() uint32 {
 	return tx.DelegationShares
 }
 
+func (tx *AddPermissionlessValidatorTx) MaxRestakes() uint32 {
+	return tx.RestakePeriods
+}
+
+func (tx *AddPermissionlessValidatorTx) CompoundsRewards() bool {
+	return tx.CompoundRewards
+}
+
 // SyntacticVerify returns nil iff [tx] is valid
 func (tx *AddPermissionlessValidatorTx) SyntacticVerify(ctx *snow.Context) error {
 	switch {
@@ -143,6 +159,8 @@ func (tx *AddPermissionlessValidatorTx) SyntacticVerify(ctx *snow.Context) error
 		return errNoStake
 	case tx.DelegationShares > reward.PercentDenominator:
 		return errTooManyShares
+	case tx.CompoundRewards && tx.RestakePeriods == 0:
+		return errCompoundWithoutRestake
 	}
 
 	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
@@ -129,6 +129,29 @@ func TestAddPermissionlessValidatorTxSyntacticVerify(t *testing.T) {
 			},
 			err: errTooManyShares,
 		},
+		{
+			name: "compounded rewards without restaking",
+			txFunc: func(*gomock.Controller) *AddPermissionlessValidatorTx {
+				return &AddPermissionlessValidatorTx{
+					BaseTx: validBaseTx,
+					Validator: validator.Validator{
+						NodeID: ids.GenerateTestNodeID(),
+					},
+					StakeOuts: []*djtx.TransferableOutput{
+						{
+							Asset: djtx.Asset{
+								ID: ids.GenerateTestID(),
+							},
+							Out: &secp256k1fx.TransferOutput{
+								Amt: 1,
+							},
+						},
+					},
+					CompoundRewards: true,
+				}
+			},
+			err: errCompoundWithoutRestake,
+		},
 		{
 			name: "invalid rewards owner",
 			txFunc: func(ctrl *gomock.Controller) *AddPermissionlessValidatorTx {
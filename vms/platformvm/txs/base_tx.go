@@ -27,6 +27,10 @@ var (
 type BaseTx struct {
 	djtx.BaseTx `serialize:"true"`
 
+	// Unix time, in seconds, after which this tx can no longer be accepted.
+	// Zero if this tx never expires. Only serialized by [V1Version].
+	Expiry uint64 `serializeV1:"true" json:"expiry,omitempty"`
+
 	// true iff this transaction has already passed syntactic verification
 	SyntacticallyVerified bool `json:"-"`
 
@@ -41,6 +45,10 @@ func (tx *BaseTx) Bytes() []byte {
 	return tx.unsignedBytes
 }
 
+func (tx *BaseTx) expiry() uint64 {
+	return tx.Expiry
+}
+
 func (tx *BaseTx) InputIDs() set.Set[ids.ID] {
 	inputIDs := set.NewSet[ids.ID](len(tx.Ins))
 	for _, in := range tx.Ins {
@@ -93,6 +93,45 @@ type DecisionTxBuilder interface {
 		keys []*crypto.PrivateKeySECP256K1R,
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
+
+	// subnetID: ID of the subnet whose ownership is transferred
+	// threshold: [threshold] of [ownerAddrs] needed to manage this subnet
+	// ownerAddrs: new control addresses of the subnet
+	// keys: keys to pay the fee and authorize the transfer
+	// changeAddr: address to send change to, if there is any
+	NewTransferSubnetOwnershipTx(
+		subnetID ids.ID,
+		threshold uint32,
+		ownerAddrs []ids.ShortID,
+		keys []*crypto.PrivateKeySECP256K1R,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
+	// subnetID: ID of the subnet that validates the chain
+	// chainID: ID of the chain to halt
+	// keys: keys to pay the fee and authorize the halt
+	// changeAddr: address to send change to, if there is any
+	NewHaltBlockchainTx(
+		subnetID ids.ID,
+		chainID ids.ID,
+		keys []*crypto.PrivateKeySECP256K1R,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
+	// subnetID: ID of the subnet that validates the chain
+	// chainID: ID of the chain to upgrade
+	// vmID: ID of the VM the chain runs once the upgrade is activated
+	// activationTime: unix time the chain starts running [vmID]
+	// keys: keys to pay the fee and authorize the upgrade
+	// changeAddr: address to send change to, if there is any
+	NewUpgradeBlockchainTx(
+		subnetID ids.ID,
+		chainID ids.ID,
+		vmID ids.ID,
+		activationTime uint64,
+		keys []*crypto.PrivateKeySECP256K1R,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
 }
 
 type ProposalTxBuilder interface {
@@ -160,6 +199,22 @@ type ProposalTxBuilder interface {
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
 
+	// Creates a transaction that modifies the validation of [nodeID] on
+	// [subnetID] without removing it from the validator set
+	// weight: the new sampling weight of the validator
+	// endTime: the new unix time the validator stops validating the subnet,
+	//          or 0 to leave it unchanged
+	// keys: keys to use for modifying the validator
+	// changeAddr: address to send change to, if there is any
+	NewModifySubnetValidatorTx(
+		weight,
+		endTime uint64,
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		keys []*crypto.PrivateKeySECP256K1R,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
 	// newAdvanceTimeTx creates a new tx that, if it is accepted and followed by a
 	// Commit block, will set the chain's timestamp to [timestamp].
 	NewAdvanceTimeTx(timestamp time.Time) (*txs.Tx, error)
@@ -426,6 +481,125 @@ func (b *builder) NewCreateSubnetTx(
 	return tx, tx.SyntacticVerify(b.ctx)
 }
 
+func (b *builder) NewTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	threshold uint32,
+	ownerAddrs []ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, outs, _, signers, err := b.Spend(keys, 0, b.cfg.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Sort control addresses
+	utils.Sort(ownerAddrs)
+
+	// Create the tx
+	utx := &txs.TransferSubnetOwnershipTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+		Owner: &secp256k1fx.OutputOwners{
+			Threshold: threshold,
+			Addrs:     ownerAddrs,
+		},
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewHaltBlockchainTx(
+	subnetID ids.ID,
+	chainID ids.ID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, outs, _, signers, err := b.Spend(keys, 0, b.cfg.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Create the tx
+	utx := &txs.HaltBlockchainTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		Subnet:       subnetID,
+		BlockchainID: chainID,
+		SubnetAuth:   subnetAuth,
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewUpgradeBlockchainTx(
+	subnetID ids.ID,
+	chainID ids.ID,
+	vmID ids.ID,
+	activationTime uint64,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, outs, _, signers, err := b.Spend(keys, 0, b.cfg.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Create the tx
+	utx := &txs.UpgradeBlockchainTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		Subnet:         subnetID,
+		BlockchainID:   chainID,
+		VMID:           vmID,
+		ActivationTime: activationTime,
+		SubnetAuth:     subnetAuth,
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
 func (b *builder) NewAddValidatorTx(
 	stakeAmount,
 	startTime,
@@ -592,6 +766,46 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 	return tx, tx.SyntacticVerify(b.ctx)
 }
 
+func (b *builder) NewModifySubnetValidatorTx(
+	weight,
+	endTime uint64,
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, outs, _, signers, err := b.Spend(keys, 0, b.cfg.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Create the tx
+	utx := &txs.ModifySubnetValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		NodeID:     nodeID,
+		Subnet:     subnetID,
+		Weight:     weight,
+		EndTime:    endTime,
+		SubnetAuth: subnetAuth,
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
 func (b *builder) NewAdvanceTimeTx(timestamp time.Time) (*txs.Tx, error) {
 	utx := &txs.AdvanceTimeTx{Time: uint64(timestamp.Unix())}
 	tx, err := txs.NewSigned(utx, txs.Codec, nil)
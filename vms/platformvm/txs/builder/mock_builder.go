@@ -145,6 +145,21 @@ func (mr *MockBuilderMockRecorder) NewExportTx(arg0, arg1, arg2, arg3, arg4 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewExportTx", reflect.TypeOf((*MockBuilder)(nil).NewExportTx), arg0, arg1, arg2, arg3, arg4)
 }
 
+// NewHaltBlockchainTx mocks base method.
+func (m *MockBuilder) NewHaltBlockchainTx(arg0, arg1 ids.ID, arg2 []*crypto.PrivateKeySECP256K1R, arg3 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewHaltBlockchainTx", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewHaltBlockchainTx indicates an expected call of NewHaltBlockchainTx.
+func (mr *MockBuilderMockRecorder) NewHaltBlockchainTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewHaltBlockchainTx", reflect.TypeOf((*MockBuilder)(nil).NewHaltBlockchainTx), arg0, arg1, arg2, arg3)
+}
+
 // NewImportTx mocks base method.
 func (m *MockBuilder) NewImportTx(arg0 ids.ID, arg1 ids.ShortID, arg2 []*crypto.PrivateKeySECP256K1R, arg3 ids.ShortID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +175,21 @@ func (mr *MockBuilderMockRecorder) NewImportTx(arg0, arg1, arg2, arg3 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewImportTx", reflect.TypeOf((*MockBuilder)(nil).NewImportTx), arg0, arg1, arg2, arg3)
 }
 
+// NewModifySubnetValidatorTx mocks base method.
+func (m *MockBuilder) NewModifySubnetValidatorTx(arg0, arg1 uint64, arg2 ids.NodeID, arg3 ids.ID, arg4 []*crypto.PrivateKeySECP256K1R, arg5 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewModifySubnetValidatorTx", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewModifySubnetValidatorTx indicates an expected call of NewModifySubnetValidatorTx.
+func (mr *MockBuilderMockRecorder) NewModifySubnetValidatorTx(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewModifySubnetValidatorTx", reflect.TypeOf((*MockBuilder)(nil).NewModifySubnetValidatorTx), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // NewRemoveSubnetValidatorTx mocks base method.
 func (m *MockBuilder) NewRemoveSubnetValidatorTx(arg0 ids.NodeID, arg1 ids.ID, arg2 []*crypto.PrivateKeySECP256K1R, arg3 ids.ShortID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -189,3 +219,33 @@ func (mr *MockBuilderMockRecorder) NewRewardValidatorTx(arg0 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRewardValidatorTx", reflect.TypeOf((*MockBuilder)(nil).NewRewardValidatorTx), arg0)
 }
+
+// NewTransferSubnetOwnershipTx mocks base method.
+func (m *MockBuilder) NewTransferSubnetOwnershipTx(arg0 ids.ID, arg1 uint32, arg2 []ids.ShortID, arg3 []*crypto.PrivateKeySECP256K1R, arg4 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewTransferSubnetOwnershipTx", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewTransferSubnetOwnershipTx indicates an expected call of NewTransferSubnetOwnershipTx.
+func (mr *MockBuilderMockRecorder) NewTransferSubnetOwnershipTx(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTransferSubnetOwnershipTx", reflect.TypeOf((*MockBuilder)(nil).NewTransferSubnetOwnershipTx), arg0, arg1, arg2, arg3, arg4)
+}
+
+// NewUpgradeBlockchainTx mocks base method.
+func (m *MockBuilder) NewUpgradeBlockchainTx(arg0, arg1, arg2 ids.ID, arg3 uint64, arg4 []*crypto.PrivateKeySECP256K1R, arg5 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewUpgradeBlockchainTx", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewUpgradeBlockchainTx indicates an expected call of NewUpgradeBlockchainTx.
+func (mr *MockBuilderMockRecorder) NewUpgradeBlockchainTx(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewUpgradeBlockchainTx", reflect.TypeOf((*MockBuilder)(nil).NewUpgradeBlockchainTx), arg0, arg1, arg2, arg3, arg4, arg5)
+}
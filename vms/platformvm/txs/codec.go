@@ -8,14 +8,30 @@ import (
 
 	"github.com/lasthyphen/dijetsnodego/codec"
 	"github.com/lasthyphen/dijetsnodego/codec/linearcodec"
+	"github.com/lasthyphen/dijetsnodego/codec/reflectcodec"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/signer"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/stakeable"
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
 )
 
-// Version is the current default codec version
-const Version = 0
+const (
+	// Version is the current default codec version
+	Version = 0
+
+	// V1Version is the codec version of txs that specify an expiry or opt in
+	// to automatic restaking. In addition to the fields serialized by
+	// [Version], it serializes the fields tagged with [V1TagName]. Txs that use
+	// neither keep being serialized with [Version] so that their bytes don't
+	// change.
+	V1Version = 1
+
+	// V1TagName is the tag of the fields only serialized by [V1Version]
+	V1TagName = reflectcodec.DefaultTagName + "V1"
+
+	maxSliceLen = 256 * units.KiB
+)
 
 var (
 	Codec codec.Manager
@@ -28,13 +44,17 @@ var (
 )
 
 func init() {
+	v1TagNames := []string{reflectcodec.DefaultTagName, V1TagName}
+
 	c := linearcodec.NewDefault()
+	c1 := linearcodec.New(v1TagNames, maxSliceLen)
 	Codec = codec.NewDefaultManager()
 	gc := linearcodec.NewCustomMaxLength(math.MaxInt32)
+	gc1 := linearcodec.New(v1TagNames, math.MaxInt32)
 	GenesisCodec = codec.NewManager(math.MaxInt32)
 
 	errs := wrappers.Errs{}
-	for _, c := range []linearcodec.Codec{c, gc} {
+	for _, c := range []linearcodec.Codec{c, c1, gc, gc1} {
 		// Order in which type are registered affect the byte representation
 		// generated by marshalling ops. To maintain codec type ordering,
 		// we skip positions for the blocks.
@@ -44,7 +64,9 @@ func init() {
 	}
 	errs.Add(
 		Codec.RegisterCodec(Version, c),
+		Codec.RegisterCodec(V1Version, c1),
 		GenesisCodec.RegisterCodec(Version, gc),
+		GenesisCodec.RegisterCodec(V1Version, gc1),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
@@ -91,6 +113,22 @@ func RegisterUnsignedTxsTypes(targetCodec codec.Registry) error {
 
 		targetCodec.RegisterType(&signer.Empty{}),
 		targetCodec.RegisterType(&signer.ProofOfPossession{}),
+
+		targetCodec.RegisterType(&TransferSubnetOwnershipTx{}),
+		targetCodec.RegisterType(&ModifySubnetValidatorTx{}),
+		targetCodec.RegisterType(&HaltBlockchainTx{}),
+		targetCodec.RegisterType(&UpgradeBlockchainTx{}),
 	)
 	return errs.Err
 }
+
+// CodecVersion returns the codec version [utx] is serialized with.
+func CodecVersion(utx UnsignedTx) uint16 {
+	if ExpiryOf(utx) != 0 {
+		return V1Version
+	}
+	if restaker, ok := utx.(AutoRestaker); ok && (restaker.MaxRestakes() != 0 || restaker.CompoundsRewards()) {
+		return V1Version
+	}
+	return Version
+}
@@ -72,6 +72,22 @@ func (*AtomicTxExecutor) AddPermissionlessDelegatorTx(*txs.AddPermissionlessDele
 	return errWrongTxType
 }
 
+func (*AtomicTxExecutor) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	return errWrongTxType
+}
+
+func (*AtomicTxExecutor) ModifySubnetValidatorTx(*txs.ModifySubnetValidatorTx) error {
+	return errWrongTxType
+}
+
+func (*AtomicTxExecutor) HaltBlockchainTx(*txs.HaltBlockchainTx) error {
+	return errWrongTxType
+}
+
+func (*AtomicTxExecutor) UpgradeBlockchainTx(*txs.UpgradeBlockchainTx) error {
+	return errWrongTxType
+}
+
 func (e *AtomicTxExecutor) ImportTx(tx *txs.ImportTx) error {
 	return e.atomicTx(tx)
 }
@@ -0,0 +1,211 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+// addTestChain adds a new chain of [testSubnet1] to the accepted state and
+// returns its ID.
+func addTestChain(require *require.Assertions, env *environment) ids.ID {
+	tx, err := env.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		constants.AVMID,
+		nil,
+		"chain name",
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	env.state.AddChain(tx)
+	env.state.AddTx(tx, status.Committed)
+	require.NoError(env.state.Commit())
+	return tx.ID()
+}
+
+func executeStandardTx(require *require.Assertions, env *environment, tx *txs.Tx) (state.Diff, error) {
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	return stateDiff, tx.Unsigned.Visit(&executor)
+}
+
+func TestHaltBlockchainTx(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment( /*postBanff*/ true)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	chainID := addTestChain(require, env)
+
+	// The chain must be validated by the subnet that authorizes the halt.
+	tx, err := env.txBuilder.NewHaltBlockchainTx(
+		testSubnet1.ID(),
+		chainID,
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	tx.Unsigned.(*txs.HaltBlockchainTx).Subnet = ids.GenerateTestID()
+	_, err = executeStandardTx(require, env, tx)
+	require.ErrorIs(err, errWrongSubnet)
+
+	tx, err = env.txBuilder.NewHaltBlockchainTx(
+		testSubnet1.ID(),
+		chainID,
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := executeStandardTx(require, env, tx)
+	require.NoError(err)
+
+	metadata, err := stateDiff.GetChainMetadata(chainID)
+	require.NoError(err)
+	require.True(metadata.Halted)
+	require.Equal(constants.AVMID, metadata.VMID)
+
+	stateDiff.AddTx(tx, status.Committed)
+	stateDiff.Apply(env.state)
+	require.NoError(env.state.Commit())
+
+	// A halted chain can't be halted or upgraded again.
+	tx, err = env.txBuilder.NewHaltBlockchainTx(
+		testSubnet1.ID(),
+		chainID,
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	_, err = executeStandardTx(require, env, tx)
+	require.ErrorIs(err, errChainHalted)
+
+	tx, err = env.txBuilder.NewUpgradeBlockchainTx(
+		testSubnet1.ID(),
+		chainID,
+		ids.GenerateTestID(),
+		uint64(env.state.GetTimestamp().Add(time.Hour).Unix()),
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	_, err = executeStandardTx(require, env, tx)
+	require.ErrorIs(err, errChainHalted)
+}
+
+func TestUpgradeBlockchainTx(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment( /*postBanff*/ true)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	chainID := addTestChain(require, env)
+	chainTime := env.state.GetTimestamp()
+	newVMID := ids.GenerateTestID()
+
+	// The upgrade can't be activated in the past.
+	tx, err := env.txBuilder.NewUpgradeBlockchainTx(
+		testSubnet1.ID(),
+		chainID,
+		newVMID,
+		uint64(chainTime.Unix()),
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	_, err = executeStandardTx(require, env, tx)
+	require.ErrorIs(err, errActivationTimeTooEarly)
+
+	// Only the subnet's owner can upgrade its chains.
+	_, err = env.txBuilder.NewUpgradeBlockchainTx(
+		testSubnet1.ID(),
+		chainID,
+		newVMID,
+		uint64(chainTime.Add(time.Hour).Unix()),
+		preFundedKeys[3:],
+		ids.ShortEmpty,
+	)
+	require.Error(err)
+
+	activationTime := chainTime.Add(time.Hour)
+	tx, err = env.txBuilder.NewUpgradeBlockchainTx(
+		testSubnet1.ID(),
+		chainID,
+		newVMID,
+		uint64(activationTime.Unix()),
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := executeStandardTx(require, env, tx)
+	require.NoError(err)
+
+	metadata, err := stateDiff.GetChainMetadata(chainID)
+	require.NoError(err)
+	require.False(metadata.Halted)
+	require.Equal(constants.AVMID, metadata.VMIDAt(chainTime))
+	require.True(metadata.UpgradePending(chainTime))
+	require.Equal(newVMID, metadata.VMIDAt(activationTime))
+}
+
+func TestBlockchainModificationNotActivated(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment( /*postBanff*/ true)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	chainID := addTestChain(require, env)
+	chainTime := env.state.GetTimestamp()
+	env.config.BlockchainModificationTime = chainTime.Add(time.Second)
+
+	haltTx, err := env.txBuilder.NewHaltBlockchainTx(
+		testSubnet1.ID(),
+		chainID,
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	_, err = executeStandardTx(require, env, haltTx)
+	require.ErrorIs(err, errBlockchainModificationNotActivated)
+
+	upgradeTx, err := env.txBuilder.NewUpgradeBlockchainTx(
+		testSubnet1.ID(),
+		chainID,
+		ids.GenerateTestID(),
+		uint64(chainTime.Add(time.Hour).Unix()),
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	_, err = executeStandardTx(require, env, upgradeTx)
+	require.ErrorIs(err, errBlockchainModificationNotActivated)
+}
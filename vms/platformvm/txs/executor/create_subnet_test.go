@@ -86,3 +86,119 @@ func TestCreateSubnetTxAP3FeeChange(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateSubnetTxExpiry(t *testing.T) {
+	expiry := defaultGenesisTime.Add(time.Hour)
+	tests := []struct {
+		name        string
+		time        time.Time
+		expiryTime  time.Time
+		expectedErr error
+	}{
+		{
+			name:        "before activation",
+			time:        expiry.Add(-time.Second),
+			expiryTime:  expiry,
+			expectedErr: errExpiryNotActivated,
+		},
+		{
+			name:        "before expiry",
+			time:        expiry.Add(-time.Second),
+			expectedErr: nil,
+		},
+		{
+			name:        "at expiry",
+			time:        expiry,
+			expectedErr: nil,
+		},
+		{
+			name:        "after expiry",
+			time:        expiry.Add(time.Second),
+			expectedErr: errTxExpired,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			env := newEnvironment( /*postBanff*/ false)
+			env.ctx.Lock.Lock()
+			defer func() {
+				require.NoError(shutdownEnvironment(env))
+			}()
+			env.config.ExpiryTime = test.expiryTime
+
+			fee := env.config.GetCreateSubnetTxFee(test.time)
+			ins, outs, _, signers, err := env.utxosHandler.Spend(preFundedKeys, 0, fee, ids.ShortEmpty)
+			require.NoError(err)
+
+			utx := &txs.CreateSubnetTx{
+				BaseTx: txs.BaseTx{
+					BaseTx: djtx.BaseTx{
+						NetworkID:    env.ctx.NetworkID,
+						BlockchainID: env.ctx.ChainID,
+						Ins:          ins,
+						Outs:         outs,
+					},
+					Expiry: uint64(expiry.Unix()),
+				},
+				Owner: &secp256k1fx.OutputOwners{},
+			}
+			tx := &txs.Tx{Unsigned: utx}
+			require.NoError(tx.Sign(txs.Codec, signers))
+
+			stateDiff, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+
+			stateDiff.SetTimestamp(test.time)
+
+			executor := StandardTxExecutor{
+				Backend: &env.backend,
+				State:   stateDiff,
+				Tx:      tx,
+			}
+			err = tx.Unsigned.Visit(&executor)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestMempoolCreateSubnetTxExpiryNotActivated(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment( /*postBanff*/ false)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+	chainTime := env.state.GetTimestamp()
+	env.config.ExpiryTime = chainTime.Add(time.Second)
+
+	fee := env.config.GetCreateSubnetTxFee(chainTime)
+	ins, outs, _, signers, err := env.utxosHandler.Spend(preFundedKeys, 0, fee, ids.ShortEmpty)
+	require.NoError(err)
+
+	utx := &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    env.ctx.NetworkID,
+				BlockchainID: env.ctx.ChainID,
+				Ins:          ins,
+				Outs:         outs,
+			},
+			Expiry: uint64(chainTime.Add(time.Hour).Unix()),
+		},
+		Owner: &secp256k1fx.OutputOwners{},
+	}
+	tx := &txs.Tx{Unsigned: utx}
+	require.NoError(tx.Sign(txs.Codec, signers))
+
+	verifier := MempoolTxVerifier{
+		Backend:       &env.backend,
+		ParentID:      lastAcceptedID,
+		StateVersions: env,
+		Tx:            tx,
+	}
+	err = tx.Unsigned.Visit(&verifier)
+	require.ErrorIs(err, errExpiryNotActivated)
+}
@@ -238,6 +238,7 @@ func defaultState(
 		genesisBytes,
 		prometheus.NewRegistry(),
 		cfg,
+		&config.ExecutionConfig{},
 		ctx,
 		metrics.Noop,
 		rewards,
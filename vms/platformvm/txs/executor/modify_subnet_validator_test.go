@@ -0,0 +1,174 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+func TestModifySubnetValidatorTx(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment( /*postBanff*/ false)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	subnetID := testSubnet1.ID()
+	nodeID := ids.NodeID(preFundedKeys[0].PublicKey().Address())
+
+	// A node that isn't validating the subnet can't be modified.
+	tx, err := env.txBuilder.NewModifySubnetValidatorTx(
+		defaultWeight,
+		0,
+		nodeID,
+		subnetID,
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errNotCurrentValidator)
+
+	// Add the node as a validator of the subnet.
+	addTx, err := env.txBuilder.NewAddSubnetValidatorTx(
+		1,
+		uint64(defaultValidateStartTime.Unix()),
+		uint64(defaultValidateStartTime.Add(defaultMinStakingDuration).Unix()),
+		nodeID,
+		subnetID,
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	staker, err := state.NewCurrentStaker(
+		addTx.ID(),
+		addTx.Unsigned.(*txs.AddSubnetValidatorTx),
+		0,
+	)
+	require.NoError(err)
+
+	env.state.PutCurrentValidator(staker)
+	env.state.AddTx(addTx, status.Committed)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	// The end time can't exceed the end time of the primary network validator.
+	tx, err = env.txBuilder.NewModifySubnetValidatorTx(
+		defaultWeight,
+		uint64(defaultValidateEndTime.Unix())+1,
+		nodeID,
+		subnetID,
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor = StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errValidatorSubset)
+
+	newEndTime := defaultValidateEndTime
+	tx, err = env.txBuilder.NewModifySubnetValidatorTx(
+		defaultWeight,
+		uint64(newEndTime.Unix()),
+		nodeID,
+		subnetID,
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor = StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&executor))
+
+	modifiedStaker, err := stateDiff.GetCurrentValidator(subnetID, nodeID)
+	require.NoError(err)
+	require.Equal(addTx.ID(), modifiedStaker.TxID)
+	require.Equal(uint64(defaultWeight), modifiedStaker.Weight)
+	require.Equal(newEndTime.Unix(), modifiedStaker.EndTime.Unix())
+	require.Equal(newEndTime.Unix(), modifiedStaker.NextTime.Unix())
+
+	stateDiff.AddTx(tx, status.Committed)
+	stateDiff.Apply(env.state)
+	env.state.SetHeight(2)
+	require.NoError(env.state.Commit())
+
+	weightDiffs, err := env.state.GetValidatorWeightDiffs(2, subnetID)
+	require.NoError(err)
+	require.Equal(
+		map[ids.NodeID]*state.ValidatorWeightDiff{
+			nodeID: {
+				Decrease: false,
+				Amount:   uint64(defaultWeight - 1),
+			},
+		},
+		weightDiffs,
+	)
+}
+
+func TestModifySubnetValidatorTxNotActivated(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment( /*postBanff*/ false)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+	env.config.SubnetValidatorModificationTime = env.state.GetTimestamp().Add(time.Second)
+
+	tx, err := env.txBuilder.NewModifySubnetValidatorTx(
+		defaultWeight,
+		0,
+		ids.NodeID(preFundedKeys[0].PublicKey().Address()),
+		testSubnet1.ID(),
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      tx,
+	}
+	err = tx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errSubnetValidatorModificationNotActivated)
+}
@@ -95,6 +95,22 @@ func (*ProposalTxExecutor) AddPermissionlessDelegatorTx(*txs.AddPermissionlessDe
 	return errWrongTxType
 }
 
+func (*ProposalTxExecutor) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) ModifySubnetValidatorTx(*txs.ModifySubnetValidatorTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) HaltBlockchainTx(*txs.HaltBlockchainTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) UpgradeBlockchainTx(*txs.UpgradeBlockchainTx) error {
+	return errWrongTxType
+}
+
 func (e *ProposalTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 	// AddValidatorTx is a proposal transaction until the Banff fork
 	// activation. Following the activation, AddValidatorTxs must be issued into
@@ -356,13 +372,23 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 
 	switch uStakerTx := stakerTx.Unsigned.(type) {
 	case txs.ValidatorTx:
-		e.OnCommitState.DeleteCurrentValidator(stakerToRemove)
+		restakedValidator, err := e.restakedValidator(stakerToRemove, uStakerTx)
+		if err != nil {
+			return fmt.Errorf("failed to restake validator: %w", err)
+		}
+		restaked := restakedValidator != nil
+		if restaked {
+			e.OnCommitState.UpdateCurrentValidator(restakedValidator)
+		} else {
+			e.OnCommitState.DeleteCurrentValidator(stakerToRemove)
+		}
 		e.OnAbortState.DeleteCurrentValidator(stakerToRemove)
 
 		stake := uStakerTx.Stake()
 		outputs := uStakerTx.Outputs()
 		// Invariant: The staked asset must be equal to the reward asset.
 		stakeAsset := stake[0].Asset
+		rewardsTxID := stakingPeriodTxID(stakerToRemove, uStakerTx)
 
 		// Refund the stake here
 		for i, out := range stake {
@@ -374,13 +400,33 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 				Asset: out.Asset,
 				Out:   out.Output(),
 			}
-			e.OnCommitState.AddUTXO(utxo)
+			if !restaked {
+				e.OnCommitState.AddUTXO(utxo)
+			}
 			e.OnAbortState.AddUTXO(utxo)
 		}
 
-		// Provide the reward here
-		if stakerToRemove.PotentialReward > 0 {
-			validationRewardsOwner := uStakerTx.ValidationRewardsOwner()
+		// Refund the compounded rewards here
+		validationRewardsOwner := uStakerTx.ValidationRewardsOwner()
+		compoundedUTXO, err := e.compoundedStakeUTXO(
+			stakerToRemove,
+			uStakerTx,
+			validationRewardsOwner,
+			len(outputs)+len(stake)+1,
+		)
+		if err != nil {
+			return err
+		}
+		if compoundedUTXO != nil {
+			if !restaked {
+				e.OnCommitState.AddUTXO(compoundedUTXO)
+			}
+			e.OnAbortState.AddUTXO(compoundedUTXO)
+		}
+
+		// Provide the reward here, unless it was added to the stake
+		isCompounded := restaked && restakedValidator.Weight != stakerToRemove.Weight
+		if stakerToRemove.PotentialReward > 0 && !isCompounded {
 			outIntf, err := e.Fx.CreateOutput(stakerToRemove.PotentialReward, validationRewardsOwner)
 			if err != nil {
 				return fmt.Errorf("failed to create output: %w", err)
@@ -392,7 +438,7 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 
 			utxo := &djtx.UTXO{
 				UTXOID: djtx.UTXOID{
-					TxID:        tx.TxID,
+					TxID:        rewardsTxID,
 					OutputIndex: uint32(len(outputs) + len(stake)),
 				},
 				Asset: stakeAsset,
@@ -412,20 +458,7 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 		stake := uStakerTx.Stake()
 		outputs := uStakerTx.Outputs()
 		stakeAsset := stake[0].Asset
-
-		// Refund the stake here
-		for i, out := range stake {
-			utxo := &djtx.UTXO{
-				UTXOID: djtx.UTXOID{
-					TxID:        tx.TxID,
-					OutputIndex: uint32(len(outputs) + i),
-				},
-				Asset: out.Asset,
-				Out:   out.Output(),
-			}
-			e.OnCommitState.AddUTXO(utxo)
-			e.OnAbortState.AddUTXO(utxo)
-		}
+		rewardsTxID := stakingPeriodTxID(stakerToRemove, uStakerTx)
 
 		// We're removing a delegator, so we need to fetch the validator they
 		// are delegated to.
@@ -461,20 +494,56 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 
 		// Calculate split of reward between delegator/delegatee
 		// The delegator gives stake to the validatee
-		validatorShares := vdrTx.Shares()
-		delegatorShares := reward.PercentDenominator - uint64(validatorShares)                            // parentTx.Shares <= reward.PercentDenominator so no underflow
-		delegatorReward := delegatorShares * (stakerToRemove.PotentialReward / reward.PercentDenominator) // delegatorShares <= reward.PercentDenominator so no overflow
-		// Delay rounding as long as possible for small numbers
-		if optimisticReward, err := math.Mul64(delegatorShares, stakerToRemove.PotentialReward); err == nil {
-			delegatorReward = optimisticReward / reward.PercentDenominator
+		delegatorReward, delegateeReward := reward.Split(stakerToRemove.PotentialReward, vdrTx.Shares())
+
+		restakedDelegator, err := e.restakedDelegator(stakerToRemove, uStakerTx, vdrStaker, delegatorReward)
+		if err != nil {
+			return fmt.Errorf("failed to restake delegator: %w", err)
+		}
+		restaked := restakedDelegator != nil
+		if restaked {
+			e.OnCommitState.PutCurrentDelegator(restakedDelegator)
+		}
+
+		// Refund the stake here
+		for i, out := range stake {
+			utxo := &djtx.UTXO{
+				UTXOID: djtx.UTXOID{
+					TxID:        tx.TxID,
+					OutputIndex: uint32(len(outputs) + i),
+				},
+				Asset: out.Asset,
+				Out:   out.Output(),
+			}
+			if !restaked {
+				e.OnCommitState.AddUTXO(utxo)
+			}
+			e.OnAbortState.AddUTXO(utxo)
+		}
+
+		// Refund the compounded rewards here
+		rewardsOwner := uStakerTx.RewardsOwner()
+		compoundedUTXO, err := e.compoundedStakeUTXO(
+			stakerToRemove,
+			uStakerTx,
+			rewardsOwner,
+			len(outputs)+len(stake)+2,
+		)
+		if err != nil {
+			return err
+		}
+		if compoundedUTXO != nil {
+			if !restaked {
+				e.OnCommitState.AddUTXO(compoundedUTXO)
+			}
+			e.OnAbortState.AddUTXO(compoundedUTXO)
 		}
-		delegateeReward := stakerToRemove.PotentialReward - delegatorReward // delegatorReward <= reward so no underflow
 
 		offset := 0
 
-		// Reward the delegator here
-		if delegatorReward > 0 {
-			rewardsOwner := uStakerTx.RewardsOwner()
+		// Reward the delegator here, unless it was added to the stake
+		isCompounded := restaked && restakedDelegator.Weight != stakerToRemove.Weight
+		if delegatorReward > 0 && !isCompounded {
 			outIntf, err := e.Fx.CreateOutput(delegatorReward, rewardsOwner)
 			if err != nil {
 				return fmt.Errorf("failed to create output: %w", err)
@@ -485,7 +554,7 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 			}
 			utxo := &djtx.UTXO{
 				UTXOID: djtx.UTXOID{
-					TxID:        tx.TxID,
+					TxID:        rewardsTxID,
 					OutputIndex: uint32(len(outputs) + len(stake)),
 				},
 				Asset: stakeAsset,
@@ -511,7 +580,7 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 			}
 			utxo := &djtx.UTXO{
 				UTXOID: djtx.UTXOID{
-					TxID:        tx.TxID,
+					TxID:        rewardsTxID,
 					OutputIndex: uint32(len(outputs) + len(stake) + offset),
 				},
 				Asset: stakeAsset,
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/lasthyphen/dijetsnodego/database"
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto"
@@ -16,9 +17,11 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/signer"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/validator"
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
 )
 
@@ -488,3 +491,266 @@ func TestRewardDelegatorTxExecuteOnAbort(t *testing.T) {
 	require.NoError(err)
 	require.Equal(initialSupply-expectedReward, newSupply, "should have removed un-rewarded tokens from the potential supply")
 }
+
+func TestRewardValidatorTxAutoRestake(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment( /*postBanff*/ true)
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	rewardAddress := ids.GenerateTestShortID()
+	rewardsOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{rewardAddress},
+	}
+	rewardAddresses := set.Set[ids.ShortID]{}
+	rewardAddresses.Add(rewardAddress)
+
+	startTime := defaultValidateStartTime.Add(time.Second)
+	endTime := startTime.Add(2 * defaultMinStakingDuration)
+	vdrTx := &txs.Tx{Unsigned: &txs.AddPermissionlessValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    env.ctx.NetworkID,
+			BlockchainID: env.ctx.ChainID,
+		}},
+		Validator: validator.Validator{
+			NodeID: ids.GenerateTestNodeID(),
+			Start:  uint64(startTime.Unix()),
+			End:    uint64(endTime.Unix()),
+			Wght:   env.config.MinValidatorStake,
+		},
+		Subnet: constants.PrimaryNetworkID,
+		Signer: &signer.Empty{},
+		StakeOuts: []*djtx.TransferableOutput{{
+			Asset: djtx.Asset{ID: env.ctx.DJTXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          env.config.MinValidatorStake,
+				OutputOwners: *rewardsOwner,
+			},
+		}},
+		ValidatorRewardsOwner: rewardsOwner,
+		DelegatorRewardsOwner: rewardsOwner,
+		RestakePeriods:        1,
+		CompoundRewards:       true,
+	}}
+	require.NoError(vdrTx.Sign(txs.Codec, nil))
+
+	vdrStaker, err := state.NewCurrentStaker(
+		vdrTx.ID(),
+		vdrTx.Unsigned.(*txs.AddPermissionlessValidatorTx),
+		1000000,
+	)
+	require.NoError(err)
+
+	env.state.PutCurrentValidator(vdrStaker)
+	env.state.AddTx(vdrTx, status.Committed)
+	env.state.SetTimestamp(endTime)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	oldSupply, err := env.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+
+	tx, err := env.txBuilder.NewRewardValidatorTx(vdrTx.ID())
+	require.NoError(err)
+
+	onCommitState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	onAbortState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	txExecutor := ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&txExecutor))
+
+	// If the reward is aborted, the validator is removed.
+	_, err = onAbortState.GetCurrentValidator(constants.PrimaryNetworkID, vdrStaker.NodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// If the reward is committed, the validator is restaked for the same
+	// duration with its reward added to its stake.
+	restakedStaker, err := onCommitState.GetCurrentValidator(constants.PrimaryNetworkID, vdrStaker.NodeID)
+	require.NoError(err)
+	require.Equal(vdrStaker.Weight+vdrStaker.PotentialReward, restakedStaker.Weight)
+	require.Equal(endTime.Unix(), restakedStaker.StartTime.Unix())
+	require.Equal(endTime.Add(2*defaultMinStakingDuration).Unix(), restakedStaker.EndTime.Unix())
+	require.Equal(restakedStaker.EndTime, restakedStaker.NextTime)
+	require.NotZero(restakedStaker.PotentialReward)
+
+	newSupply, err := onCommitState.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(oldSupply+restakedStaker.PotentialReward, newSupply)
+
+	oldBalance, err := djtx.GetBalance(env.state, rewardAddresses)
+	require.NoError(err)
+
+	onCommitState.Apply(env.state)
+	env.state.SetHeight(2)
+	require.NoError(env.state.Commit())
+
+	// Neither the stake nor the reward were paid out.
+	balance, err := djtx.GetBalance(env.state, rewardAddresses)
+	require.NoError(err)
+	require.Equal(oldBalance, balance)
+
+	// The validator only opted in to being restaked once, so committing its
+	// next reward returns its compounded stake along with the reward.
+	require.Equal(uint32(1), restakedStaker.Restakes)
+	env.state.SetTimestamp(restakedStaker.EndTime)
+
+	onCommitState, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	onAbortState, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	txExecutor = ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&txExecutor))
+
+	onCommitState.Apply(env.state)
+	env.state.SetHeight(3)
+	require.NoError(env.state.Commit())
+
+	_, err = env.state.GetCurrentValidator(constants.PrimaryNetworkID, vdrStaker.NodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	balance, err = djtx.GetBalance(env.state, rewardAddresses)
+	require.NoError(err)
+	require.Equal(oldBalance+restakedStaker.Weight+restakedStaker.PotentialReward, balance)
+}
+
+func TestRewardDelegatorTxAutoRestake(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment( /*postBanff*/ true)
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	vdrRewardAddress := ids.GenerateTestShortID()
+	delRewardAddress := ids.GenerateTestShortID()
+
+	vdrStartTime := uint64(defaultValidateStartTime.Unix()) + 1
+	vdrEndTime := uint64(defaultValidateStartTime.Add(8 * defaultMinStakingDuration).Unix())
+	vdrNodeID := ids.GenerateTestNodeID()
+
+	vdrTx, err := env.txBuilder.NewAddValidatorTx(
+		env.config.MinValidatorStake, // stakeAmt
+		vdrStartTime,
+		vdrEndTime,
+		vdrNodeID,        // node ID
+		vdrRewardAddress, // reward address
+		reward.PercentDenominator/4,
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	delStartTime := time.Unix(int64(vdrStartTime), 0)
+	delEndTime := delStartTime.Add(2 * defaultMinStakingDuration)
+	delRewardsOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{delRewardAddress},
+	}
+	delTx := &txs.Tx{Unsigned: &txs.AddPermissionlessDelegatorTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    env.ctx.NetworkID,
+			BlockchainID: env.ctx.ChainID,
+		}},
+		Validator: validator.Validator{
+			NodeID: vdrNodeID,
+			Start:  uint64(delStartTime.Unix()),
+			End:    uint64(delEndTime.Unix()),
+			Wght:   env.config.MinDelegatorStake,
+		},
+		Subnet: constants.PrimaryNetworkID,
+		StakeOuts: []*djtx.TransferableOutput{{
+			Asset: djtx.Asset{ID: env.ctx.DJTXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          env.config.MinDelegatorStake,
+				OutputOwners: *delRewardsOwner,
+			},
+		}},
+		DelegationRewardsOwner: delRewardsOwner,
+		RestakePeriods:         1,
+	}}
+	require.NoError(delTx.Sign(txs.Codec, nil))
+
+	vdrStaker, err := state.NewCurrentStaker(
+		vdrTx.ID(),
+		vdrTx.Unsigned.(*txs.AddValidatorTx),
+		0,
+	)
+	require.NoError(err)
+
+	delStaker, err := state.NewCurrentStaker(
+		delTx.ID(),
+		delTx.Unsigned.(*txs.AddPermissionlessDelegatorTx),
+		1000000,
+	)
+	require.NoError(err)
+
+	env.state.PutCurrentValidator(vdrStaker)
+	env.state.AddTx(vdrTx, status.Committed)
+	env.state.PutCurrentDelegator(delStaker)
+	env.state.AddTx(delTx, status.Committed)
+	env.state.SetTimestamp(delEndTime)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	tx, err := env.txBuilder.NewRewardValidatorTx(delTx.ID())
+	require.NoError(err)
+
+	onCommitState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	onAbortState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	txExecutor := ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&txExecutor))
+
+	delDestSet := set.Set[ids.ShortID]{}
+	delDestSet.Add(delRewardAddress)
+	oldDelBalance, err := djtx.GetBalance(env.state, delDestSet)
+	require.NoError(err)
+
+	txExecutor.OnCommitState.Apply(env.state)
+	env.state.SetHeight(2)
+	require.NoError(env.state.Commit())
+
+	// The delegator is restaked for the same duration with the same stake.
+	delegatorIterator, err := env.state.GetCurrentDelegatorIterator(constants.PrimaryNetworkID, vdrNodeID)
+	require.NoError(err)
+	require.True(delegatorIterator.Next())
+	restakedStaker := delegatorIterator.Value()
+	require.False(delegatorIterator.Next())
+	delegatorIterator.Release()
+
+	require.Equal(delStaker.TxID, restakedStaker.TxID)
+	require.Equal(delStaker.Weight, restakedStaker.Weight)
+	require.Equal(delEndTime.Unix(), restakedStaker.StartTime.Unix())
+	require.Equal(delEndTime.Add(2*defaultMinStakingDuration).Unix(), restakedStaker.EndTime.Unix())
+
+	vdrSet, ok := env.config.Validators.Get(constants.PrimaryNetworkID)
+	require.True(ok)
+	require.Equal(env.config.MinValidatorStake+env.config.MinDelegatorStake, vdrSet.GetWeight(vdrNodeID))
+
+	// Only the delegator's reward was paid out.
+	delReward, _ := reward.Split(delStaker.PotentialReward, reward.PercentDenominator/4)
+	commitDelBalance, err := djtx.GetBalance(env.state, delDestSet)
+	require.NoError(err)
+	require.Equal(oldDelBalance+delReward, commitDelBalance)
+}
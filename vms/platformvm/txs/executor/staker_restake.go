@@ -0,0 +1,239 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	stdmath "math"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/math"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/config"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+var errRestakeNotActivated = errors.New("restaking isn't activated yet")
+
+// verifyRestakeActivated returns an error if [tx] opts in to being restaked
+// or to compounding its rewards before restaking is activated.
+func verifyRestakeActivated(cfg *config.Config, chainState state.Chain, tx *txs.Tx) error {
+	restaker, ok := tx.Unsigned.(txs.AutoRestaker)
+	if !ok || (restaker.MaxRestakes() == 0 && !restaker.CompoundsRewards()) {
+		return nil
+	}
+	chainTime := chainState.GetTimestamp()
+	if !cfg.IsRestakeActivated(chainTime) {
+		return fmt.Errorf("%w: chain time (%s) is before the activation time (%s)",
+			errRestakeNotActivated,
+			chainTime,
+			cfg.RestakeTime,
+		)
+	}
+	return nil
+}
+
+// restakedValidator returns the next staking period of [validator] if it opted
+// in to being restaked and wasn't restaked as many times as it opted in to. The
+// next staking period starts when the current one
+// ends and lasts as long. If the validator compounds its rewards, its
+// [PotentialReward] is added to its weight unless the validator would then
+// exceed the maximum stake.
+//
+// A subnet validator is only restaked if its primary network validator is
+// staking for the entire next staking period.
+//
+// Returns nil if [validator] isn't restaked.
+func (e *ProposalTxExecutor) restakedValidator(
+	validator *state.Staker,
+	vdrTx txs.ValidatorTx,
+) (*state.Staker, error) {
+	restaker, ok := vdrTx.(txs.AutoRestaker)
+	if !ok || validator.Restakes >= restaker.MaxRestakes() {
+		return nil, nil
+	}
+
+	restakedValidator := nextStakingPeriod(validator)
+	if validator.SubnetID != constants.PrimaryNetworkID {
+		primaryNetworkValidator, err := e.OnCommitState.GetCurrentValidator(
+			constants.PrimaryNetworkID,
+			validator.NodeID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if restakedValidator.EndTime.After(primaryNetworkValidator.EndTime) {
+			return nil, nil
+		}
+	}
+
+	if restaker.CompoundsRewards() && validator.PotentialReward > 0 {
+		compoundedWeight, err := math.Add64(validator.Weight, validator.PotentialReward)
+		if err != nil {
+			return nil, err
+		}
+		validatorRules, err := getValidatorRules(e.Backend, e.OnCommitState, validator.SubnetID)
+		if err != nil {
+			return nil, err
+		}
+
+		compoundedValidator := *restakedValidator
+		compoundedValidator.Weight = compoundedWeight
+		maxWeight, err := GetMaxWeight(
+			e.OnCommitState,
+			&compoundedValidator,
+			compoundedValidator.StartTime,
+			compoundedValidator.EndTime,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if maxWeight <= validatorRules.maxValidatorStake {
+			restakedValidator = &compoundedValidator
+		}
+	}
+	return restakedValidator, e.setPotentialReward(restakedValidator)
+}
+
+// restakedDelegator returns the next staking period of [delegator] if it opted
+// in to being restaked and wasn't restaked as many times as it opted in to. The
+// next staking period starts when the current one
+// ends and lasts as long. If the delegator compounds its rewards,
+// [delegatorReward] is added to its weight unless [validator] would then
+// exceed its maximum weight.
+//
+// A delegator is only restaked if [validator] is staking for the entire next
+// staking period and can accept its stake.
+//
+// Invariant: [delegator] isn't a current delegator of [validator] in
+// [e.OnCommitState].
+//
+// Returns nil if [delegator] isn't restaked.
+func (e *ProposalTxExecutor) restakedDelegator(
+	delegator *state.Staker,
+	delegatorTx txs.DelegatorTx,
+	validator *state.Staker,
+	delegatorReward uint64,
+) (*state.Staker, error) {
+	restaker, ok := delegatorTx.(txs.AutoRestaker)
+	if !ok || delegator.Restakes >= restaker.MaxRestakes() {
+		return nil, nil
+	}
+
+	delegatorRules, err := getDelegatorRules(e.Backend, e.OnCommitState, delegator.SubnetID)
+	if err != nil {
+		return nil, err
+	}
+	maximumWeight, err := math.Mul64(
+		uint64(delegatorRules.maxValidatorWeightFactor),
+		validator.Weight,
+	)
+	if err != nil {
+		maximumWeight = stdmath.MaxUint64
+	}
+	maximumWeight = math.Min(maximumWeight, delegatorRules.maxValidatorStake)
+
+	candidates := []*state.Staker{nextStakingPeriod(delegator)}
+	if restaker.CompoundsRewards() && delegatorReward > 0 {
+		compoundedWeight, err := math.Add64(delegator.Weight, delegatorReward)
+		if err != nil {
+			return nil, err
+		}
+		compoundedDelegator := *candidates[0]
+		compoundedDelegator.Weight = compoundedWeight
+		candidates = []*state.Staker{&compoundedDelegator, candidates[0]}
+	}
+
+	for _, restakedDelegator := range candidates {
+		canDelegate, err := canDelegate(e.OnCommitState, validator, maximumWeight, restakedDelegator)
+		if err != nil {
+			return nil, err
+		}
+		if canDelegate {
+			return restakedDelegator, e.setPotentialReward(restakedDelegator)
+		}
+	}
+	return nil, nil
+}
+
+// setPotentialReward sets the reward [staker] will receive at the end of its
+// staking period and adds it to the current supply of [e.OnCommitState].
+func (e *ProposalTxExecutor) setPotentialReward(staker *state.Staker) error {
+	supply, err := e.OnCommitState.GetCurrentSupply(staker.SubnetID)
+	if err != nil {
+		return err
+	}
+	rewards, err := GetRewardsCalculator(e.Backend, e.OnCommitState, staker.SubnetID)
+	if err != nil {
+		return err
+	}
+
+	staker.PotentialReward = rewards.Calculate(
+		staker.EndTime.Sub(staker.StartTime),
+		staker.Weight,
+		supply,
+	)
+
+	// Invariant: [rewards.Calculate] can never return a [potentialReward]
+	//            such that [supply + potentialReward > maximumSupply].
+	e.OnCommitState.SetCurrentSupply(staker.SubnetID, supply+staker.PotentialReward)
+	return nil
+}
+
+// compoundedStakeUTXO returns the UTXO that refunds the rewards that were
+// added to the stake of [staker]. Returns nil if [staker] didn't compound any
+// rewards.
+func (e *ProposalTxExecutor) compoundedStakeUTXO(
+	staker *state.Staker,
+	stakerTx txs.PermissionlessStaker,
+	owner fx.Owner,
+	outputIndex int,
+) (*djtx.UTXO, error) {
+	if staker.Weight <= stakerTx.Weight() {
+		return nil, nil
+	}
+
+	outIntf, err := e.Fx.CreateOutput(staker.Weight-stakerTx.Weight(), owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output: %w", err)
+	}
+	out, ok := outIntf.(verify.State)
+	if !ok {
+		return nil, errInvalidState
+	}
+	return &djtx.UTXO{
+		UTXOID: djtx.UTXOID{
+			TxID:        stakingPeriodTxID(staker, stakerTx),
+			OutputIndex: uint32(outputIndex),
+		},
+		Asset: stakerTx.Stake()[0].Asset,
+		Out:   out,
+	}, nil
+}
+
+// nextStakingPeriod returns a copy of [staker] that is staking for as long as
+// [staker], starting when [staker] stops staking.
+func nextStakingPeriod(staker *state.Staker) *state.Staker {
+	restakedStaker := *staker
+	restakedStaker.Restakes++
+	restakedStaker.StartTime = staker.EndTime
+	restakedStaker.EndTime = staker.EndTime.Add(staker.EndTime.Sub(staker.StartTime))
+	restakedStaker.NextTime = restakedStaker.EndTime
+	return &restakedStaker
+}
+
+// stakingPeriodTxID returns the ID of the tx the UTXOs rewarding [staker] are
+// created by. A restaked staker is rewarded once per staking period, so the ID
+// of every staking period after the first one is derived from its start time.
+func stakingPeriodTxID(staker *state.Staker, stakerTx txs.Staker) ids.ID {
+	if staker.StartTime.Equal(stakerTx.StartTime()) {
+		return staker.TxID
+	}
+	return staker.TxID.Prefix(uint64(staker.StartTime.Unix()))
+}
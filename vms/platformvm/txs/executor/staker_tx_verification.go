@@ -30,6 +30,9 @@ var (
 	errValidatorSubset                 = errors.New("all subnets' staking period must be a subset of the primary network")
 	errNotValidator                    = errors.New("isn't a current or pending validator")
 	errRemovePermissionlessValidator   = errors.New("attempting to remove permissionless validator")
+	errNotCurrentValidator             = errors.New("isn't a current validator")
+	errModifyPermissionlessValidator   = errors.New("attempting to modify permissionless validator")
+	errEndTimeNotAfterChainTime        = errors.New("end time not after chain timestamp")
 	errStakeOverflow                   = errors.New("validator stake exceeds limit")
 	errOverDelegated                   = errors.New("validator would be over delegated")
 	errIsNotTransformSubnetTx          = errors.New("is not a transform subnet tx")
@@ -37,6 +40,8 @@ var (
 	errDuplicateValidator              = errors.New("duplicate validator")
 	errDelegateToPermissionedValidator = errors.New("delegation to permissioned validator")
 	errWrongStakedAssetID              = errors.New("incorrect staked assetID")
+
+	errSubnetValidatorModificationNotActivated = errors.New("subnet validator modifications aren't activated yet")
 )
 
 // verifyAddValidatorTx carries out the validation for an AddValidatorTx.
@@ -55,6 +60,9 @@ func verifyAddValidatorTx(
 	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
 		return nil, err
 	}
+	if err := verifyNotExpired(backend.Config, chainState, sTx); err != nil {
+		return nil, err
+	}
 
 	duration := tx.Validator.Duration()
 
@@ -151,6 +159,9 @@ func verifyAddSubnetValidatorTx(
 	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
 		return err
 	}
+	if err := verifyNotExpired(backend.Config, chainState, sTx); err != nil {
+		return err
+	}
 
 	duration := tx.Validator.Duration()
 	switch {
@@ -256,6 +267,9 @@ func removeSubnetValidatorValidation(
 	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
 		return nil, false, err
 	}
+	if err := verifyNotExpired(backend.Config, chainState, sTx); err != nil {
+		return nil, false, err
+	}
 
 	isCurrentValidator := true
 	vdr, err := chainState.GetCurrentValidator(tx.Subnet, tx.NodeID)
@@ -306,6 +320,130 @@ func removeSubnetValidatorValidation(
 	return vdr, isCurrentValidator, nil
 }
 
+// Returns the representation of [tx.NodeID] validating [tx.Subnet] after
+// applying the modifications of [tx].
+// Returns an error if the given tx is invalid.
+// The transaction is valid if:
+// * Subnet validator modifications are activated.
+// * [tx.NodeID] is a current PoA validator of [tx.Subnet].
+// * The new end time, if provided, is after the current chain time and the
+//   validator's staking period remains a subset of its staking period on the
+//   primary network.
+// * [sTx]'s creds authorize it to spend the stated inputs.
+// * [sTx]'s creds authorize it to modify a validator of [tx.Subnet].
+// * The flow checker passes.
+func verifyModifySubnetValidatorTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.ModifySubnetValidatorTx,
+) (*state.Staker, error) {
+	currentTimestamp := chainState.GetTimestamp()
+	if !backend.Config.IsSubnetValidatorModificationActivated(currentTimestamp) {
+		return nil, fmt.Errorf(
+			"%w: chain time (%s) is before the activation time (%s)",
+			errSubnetValidatorModificationNotActivated,
+			currentTimestamp,
+			backend.Config.SubnetValidatorModificationTime,
+		)
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return nil, err
+	}
+	if err := verifyNotExpired(backend.Config, chainState, sTx); err != nil {
+		return nil, err
+	}
+
+	// Pending validators can be removed and re-added without ever leaving the
+	// validator set, so only current validators can be modified.
+	vdr, err := chainState.GetCurrentValidator(tx.Subnet, tx.NodeID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%s %w of %s: %s",
+			tx.NodeID,
+			errNotCurrentValidator,
+			tx.Subnet,
+			err,
+		)
+	}
+
+	if vdr.Priority != txs.SubnetPermissionedValidatorCurrentPriority {
+		return nil, errModifyPermissionlessValidator
+	}
+
+	newVdr := *vdr
+	newVdr.Weight = tx.Weight
+	if tx.EndTime != 0 {
+		newVdr.EndTime = time.Unix(int64(tx.EndTime), 0)
+		newVdr.NextTime = newVdr.EndTime
+	}
+
+	if !backend.Bootstrapped.GetValue() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return &newVdr, nil
+	}
+
+	if tx.EndTime != 0 {
+		if !currentTimestamp.Before(newVdr.EndTime) {
+			return nil, fmt.Errorf(
+				"%w: %s >= %s",
+				errEndTimeNotAfterChainTime,
+				currentTimestamp,
+				newVdr.EndTime,
+			)
+		}
+
+		duration := newVdr.EndTime.Sub(newVdr.StartTime)
+		switch {
+		case duration < backend.Config.MinStakeDuration:
+			// Ensure staking length is not too short
+			return nil, errStakeTooShort
+
+		case duration > backend.Config.MaxStakeDuration:
+			// Ensure staking length is not too long
+			return nil, errStakeTooLong
+		}
+
+		primaryNetworkValidator, err := GetValidator(chainState, constants.PrimaryNetworkID, tx.NodeID)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to fetch the primary network validator for %s: %w",
+				tx.NodeID,
+				err,
+			)
+		}
+
+		// Ensure that the period this validator validates the specified subnet
+		// is a subset of the time they validate the primary network.
+		if newVdr.EndTime.After(primaryNetworkValidator.EndTime) {
+			return nil, errValidatorSubset
+		}
+	}
+
+	baseTxCreds, err := verifyPoASubnetAuthorization(backend, chainState, sTx, tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			backend.Ctx.DJTXAssetID: backend.Config.TxFee,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	}
+
+	return &newVdr, nil
+}
+
 // verifyAddDelegatorTx carries out the validation for an AddDelegatorTx.
 // It returns the tx outputs that should be returned if this delegator is not
 // added to the staking set.
@@ -322,6 +460,9 @@ func verifyAddDelegatorTx(
 	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
 		return nil, err
 	}
+	if err := verifyNotExpired(backend.Config, chainState, sTx); err != nil {
+		return nil, err
+	}
 
 	duration := tx.Validator.Duration()
 	switch {
@@ -426,6 +567,12 @@ func verifyAddPermissionlessValidatorTx(
 	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
 		return err
 	}
+	if err := verifyNotExpired(backend.Config, chainState, sTx); err != nil {
+		return err
+	}
+	if err := verifyRestakeActivated(backend.Config, chainState, sTx); err != nil {
+		return err
+	}
 
 	if !backend.Bootstrapped.GetValue() {
 		return nil
@@ -605,6 +752,12 @@ func verifyAddPermissionlessDelegatorTx(
 	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
 		return err
 	}
+	if err := verifyNotExpired(backend.Config, chainState, sTx); err != nil {
+		return err
+	}
+	if err := verifyRestakeActivated(backend.Config, chainState, sTx); err != nil {
+		return err
+	}
 
 	if !backend.Bootstrapped.GetValue() {
 		return nil
@@ -133,6 +133,39 @@ func TestVerifyAddPermissionlessValidatorTx(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "restake not activated",
+			backendF: func(*gomock.Controller) *Backend {
+				bootstrapped := &utils.AtomicBool{}
+				bootstrapped.SetValue(true)
+				return &Backend{
+					Ctx: snow.DefaultContextTest(),
+					Config: &config.Config{
+						RestakeTime: time.Unix(1, 0),
+					},
+					Bootstrapped: bootstrapped,
+				}
+			},
+			stateF: func(ctrl *gomock.Controller) state.Chain {
+				state := state.NewMockChain(ctrl)
+				state.EXPECT().GetTimestamp().Return(time.Unix(0, 0))
+				return state
+			},
+			sTxF: func() *txs.Tx {
+				tx := verifiedTx // Note that this copies [verifiedTx]
+				tx.RestakePeriods = 1
+				sTx := &txs.Tx{
+					Unsigned: &tx,
+					Creds:    []verify.Verifiable{},
+				}
+				sTx.Initialize([]byte{1}, []byte{2})
+				return sTx
+			},
+			txF: func() *txs.AddPermissionlessValidatorTx {
+				return &verifiedTx
+			},
+			expectedErr: errRestakeNotActivated,
+		},
 		{
 			name: "start time too early",
 			backendF: func(*gomock.Controller) *Backend {
@@ -4,15 +4,19 @@
 package executor
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
+	"go.uber.org/zap"
+
 	"github.com/lasthyphen/dijetsnodego/chains/atomic"
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/config"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/utxo"
@@ -23,6 +27,12 @@ var (
 
 	errEmptyNodeID              = errors.New("validator nodeID cannot be empty")
 	errMaxStakeDurationTooLarge = errors.New("max stake duration must be less than or equal to the global max stake duration")
+	errActivationTimeTooEarly   = errors.New("activation time must be after the current chain time")
+
+	errTxExpired          = errors.New("tx expired")
+	errExpiryNotActivated = errors.New("tx expiries aren't activated yet")
+
+	errSubnetOwnershipTransferNotActivated = errors.New("subnet ownership transfers aren't activated yet")
 )
 
 type StandardTxExecutor struct {
@@ -49,6 +59,9 @@ func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
 	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
 		return err
 	}
+	if err := verifyNotExpired(e.Config, e.State, e.Tx); err != nil {
+		return err
+	}
 
 	baseTxCreds, err := verifyPoASubnetAuthorization(e.Backend, e.State, e.Tx, tx.SubnetID, tx.SubnetAuth)
 	if err != nil {
@@ -83,7 +96,7 @@ func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
 	// If this proposal is committed and this node is a member of the subnet
 	// that validates the blockchain, create the blockchain
 	e.OnAccept = func() {
-		e.Config.CreateChain(txID, tx)
+		e.Config.CreateChain(txID, tx, tx.VMID)
 	}
 	return nil
 }
@@ -93,6 +106,9 @@ func (e *StandardTxExecutor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
 	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
 		return err
 	}
+	if err := verifyNotExpired(e.Config, e.State, e.Tx); err != nil {
+		return err
+	}
 
 	// Verify the flowcheck
 	timestamp := e.State.GetTimestamp()
@@ -125,6 +141,9 @@ func (e *StandardTxExecutor) ImportTx(tx *txs.ImportTx) error {
 	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
 		return err
 	}
+	if err := verifyNotExpired(e.Config, e.State, e.Tx); err != nil {
+		return err
+	}
 
 	e.Inputs = set.NewSet[ids.ID](len(tx.ImportedInputs))
 	utxoIDs := make([][]byte, len(tx.ImportedInputs))
@@ -198,6 +217,9 @@ func (e *StandardTxExecutor) ExportTx(tx *txs.ExportTx) error {
 	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
 		return err
 	}
+	if err := verifyNotExpired(e.Config, e.State, e.Tx); err != nil {
+		return err
+	}
 
 	outs := make([]*djtx.TransferableOutput, len(tx.Outs)+len(tx.ExportedOutputs))
 	copy(outs, tx.Outs)
@@ -368,10 +390,38 @@ func (e *StandardTxExecutor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidat
 	return nil
 }
 
+// Verifies a [*txs.ModifySubnetValidatorTx] and, if it passes, executes it on
+// [e.State]. For verification rules, see [verifyModifySubnetValidatorTx].
+// This transaction will result in the weight, and optionally the end time, of
+// [tx.NodeID] on [tx.Subnet] being replaced without the validator leaving the
+// validator set.
+func (e *StandardTxExecutor) ModifySubnetValidatorTx(tx *txs.ModifySubnetValidatorTx) error {
+	staker, err := verifyModifySubnetValidatorTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	e.State.UpdateCurrentValidator(staker)
+
+	txID := e.Tx.ID()
+	utxo.Consume(e.State, tx.Ins)
+	utxo.Produce(e.State, txID, tx.Outs)
+
+	return nil
+}
+
 func (e *StandardTxExecutor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
 	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
 		return err
 	}
+	if err := verifyNotExpired(e.Config, e.State, e.Tx); err != nil {
+		return err
+	}
 
 	// Note: math.MaxInt32 * time.Second < math.MaxInt64 - so this can never
 	// overflow.
@@ -459,3 +509,188 @@ func (e *StandardTxExecutor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionl
 
 	return nil
 }
+
+// Verifies a [*txs.TransferSubnetOwnershipTx] and, if it passes, executes it
+// on [e.State]. The tx must be authorized by the current owner of [tx.Subnet],
+// which is replaced by [tx.Owner].
+func (e *StandardTxExecutor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	currentTimestamp := e.State.GetTimestamp()
+	if !e.Config.IsSubnetOwnershipTransferActivated(currentTimestamp) {
+		return fmt.Errorf(
+			"%w: chain time (%s) is before the activation time (%s)",
+			errSubnetOwnershipTransferNotActivated,
+			currentTimestamp,
+			e.Config.SubnetOwnershipTransferTime,
+		)
+	}
+
+	// Make sure this transaction is well formed.
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+	if err := verifyNotExpired(e.Config, e.State, e.Tx); err != nil {
+		return err
+	}
+
+	baseTxCreds, err := verifyPoASubnetAuthorization(e.Backend, e.State, e.Tx, tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	if err := e.FlowChecker.VerifySpend(
+		tx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			e.Ctx.DJTXAssetID: e.Config.TxFee,
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	}
+
+	txID := e.Tx.ID()
+
+	// Consume the UTXOS
+	utxo.Consume(e.State, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.State, txID, tx.Outs)
+	// Transfer the ownership of the subnet
+	e.State.SetSubnetOwner(tx.Subnet, tx.Owner)
+	return nil
+}
+
+// verifyNotExpired returns an error if [tx] expires before the current chain
+// time of [chainState], or if it specifies an expiry before expiries are
+// activated.
+func verifyNotExpired(cfg *config.Config, chainState state.Chain, tx *txs.Tx) error {
+	expiry := txs.ExpiryOf(tx.Unsigned)
+	if expiry == 0 {
+		return nil
+	}
+	chainTime := chainState.GetTimestamp()
+	if !cfg.IsExpiryActivated(chainTime) {
+		return fmt.Errorf("%w: chain time (%s) is before the activation time (%s)",
+			errExpiryNotActivated,
+			chainTime,
+			cfg.ExpiryTime,
+		)
+	}
+	if txs.IsExpired(tx.Unsigned, chainTime) {
+		return fmt.Errorf("%w: expiry (%s) is before chain time (%s)",
+			errTxExpired,
+			time.Unix(int64(expiry), 0),
+			chainTime,
+		)
+	}
+	return nil
+}
+
+func (e *StandardTxExecutor) HaltBlockchainTx(tx *txs.HaltBlockchainTx) error {
+	// Make sure this transaction is well formed.
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+	if err := verifyNotExpired(e.Config, e.State, e.Tx); err != nil {
+		return err
+	}
+
+	metadata, baseTxCreds, err := verifyChainModification(e.Backend, e.State, e.Tx, tx.Subnet, tx.BlockchainID, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	if err := e.FlowChecker.VerifySpend(
+		tx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			e.Ctx.DJTXAssetID: e.Config.TxFee,
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	}
+
+	txID := e.Tx.ID()
+
+	// Consume the UTXOS
+	utxo.Consume(e.State, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.State, txID, tx.Outs)
+	// Mark the blockchain as halted
+	haltedMetadata := *metadata
+	haltedMetadata.Halted = true
+	e.State.SetChainMetadata(tx.BlockchainID, &haltedMetadata)
+
+	// If this transaction is accepted and this node is running the blockchain,
+	// stop it
+	e.OnAccept = func() {
+		if err := e.Config.HaltChain(context.TODO(), tx.Subnet, tx.BlockchainID); err != nil {
+			e.Ctx.Log.Warn("failed to halt blockchain",
+				zap.Stringer("blockchainID", tx.BlockchainID),
+				zap.Error(err),
+			)
+		}
+	}
+	return nil
+}
+
+func (e *StandardTxExecutor) UpgradeBlockchainTx(tx *txs.UpgradeBlockchainTx) error {
+	// Make sure this transaction is well formed.
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+	if err := verifyNotExpired(e.Config, e.State, e.Tx); err != nil {
+		return err
+	}
+
+	currentTimestamp := e.State.GetTimestamp()
+	activationTime := time.Unix(int64(tx.ActivationTime), 0)
+	if !activationTime.After(currentTimestamp) {
+		return fmt.Errorf(
+			"%w: %s <= %s",
+			errActivationTimeTooEarly,
+			activationTime,
+			currentTimestamp,
+		)
+	}
+
+	metadata, baseTxCreds, err := verifyChainModification(e.Backend, e.State, e.Tx, tx.Subnet, tx.BlockchainID, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	if err := e.FlowChecker.VerifySpend(
+		tx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			e.Ctx.DJTXAssetID: e.Config.TxFee,
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	}
+
+	txID := e.Tx.ID()
+
+	// Consume the UTXOS
+	utxo.Consume(e.State, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.State, txID, tx.Outs)
+	// Schedule the upgrade. Any previously scheduled upgrade that hasn't been
+	// activated yet is replaced.
+	e.State.SetChainMetadata(tx.BlockchainID, &state.ChainMetadata{
+		VMID:           metadata.VMIDAt(currentTimestamp),
+		UpgradeVMID:    tx.VMID,
+		ActivationTime: tx.ActivationTime,
+	})
+	return nil
+}
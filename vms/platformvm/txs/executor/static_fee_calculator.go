@@ -0,0 +1,117 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/config"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+var _ txs.Visitor = (*StaticFeeCalculator)(nil)
+
+// StaticFeeCalculator sets [Fee] to the amount of DJTX a tx must burn when
+// it is executed at [ChainTime]. Txs issued by the chain itself don't pay a
+// fee.
+type StaticFeeCalculator struct {
+	Config    *config.Config
+	ChainTime time.Time
+
+	Fee uint64
+}
+
+func (c *StaticFeeCalculator) AddValidatorTx(*txs.AddValidatorTx) error {
+	c.Fee = c.Config.AddPrimaryNetworkValidatorFee
+	return nil
+}
+
+func (c *StaticFeeCalculator) AddSubnetValidatorTx(*txs.AddSubnetValidatorTx) error {
+	c.Fee = c.Config.AddSubnetValidatorFee
+	return nil
+}
+
+func (c *StaticFeeCalculator) AddDelegatorTx(*txs.AddDelegatorTx) error {
+	c.Fee = c.Config.AddPrimaryNetworkDelegatorFee
+	return nil
+}
+
+func (c *StaticFeeCalculator) CreateChainTx(*txs.CreateChainTx) error {
+	c.Fee = c.Config.GetCreateBlockchainTxFee(c.ChainTime)
+	return nil
+}
+
+func (c *StaticFeeCalculator) CreateSubnetTx(*txs.CreateSubnetTx) error {
+	c.Fee = c.Config.GetCreateSubnetTxFee(c.ChainTime)
+	return nil
+}
+
+func (c *StaticFeeCalculator) ImportTx(*txs.ImportTx) error {
+	c.Fee = c.Config.TxFee
+	return nil
+}
+
+func (c *StaticFeeCalculator) ExportTx(*txs.ExportTx) error {
+	c.Fee = c.Config.TxFee
+	return nil
+}
+
+func (c *StaticFeeCalculator) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	c.Fee = 0
+	return nil
+}
+
+func (c *StaticFeeCalculator) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	c.Fee = 0
+	return nil
+}
+
+func (c *StaticFeeCalculator) RemoveSubnetValidatorTx(*txs.RemoveSubnetValidatorTx) error {
+	c.Fee = c.Config.TxFee
+	return nil
+}
+
+func (c *StaticFeeCalculator) TransformSubnetTx(*txs.TransformSubnetTx) error {
+	c.Fee = c.Config.TransformSubnetTxFee
+	return nil
+}
+
+func (c *StaticFeeCalculator) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	if tx.Subnet == constants.PrimaryNetworkID {
+		c.Fee = c.Config.AddPrimaryNetworkValidatorFee
+	} else {
+		c.Fee = c.Config.AddSubnetValidatorFee
+	}
+	return nil
+}
+
+func (c *StaticFeeCalculator) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	if tx.Subnet == constants.PrimaryNetworkID {
+		c.Fee = c.Config.AddPrimaryNetworkDelegatorFee
+	} else {
+		c.Fee = c.Config.AddSubnetDelegatorFee
+	}
+	return nil
+}
+
+func (c *StaticFeeCalculator) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	c.Fee = c.Config.TxFee
+	return nil
+}
+
+func (c *StaticFeeCalculator) ModifySubnetValidatorTx(*txs.ModifySubnetValidatorTx) error {
+	c.Fee = c.Config.TxFee
+	return nil
+}
+
+func (c *StaticFeeCalculator) HaltBlockchainTx(*txs.HaltBlockchainTx) error {
+	c.Fee = c.Config.TxFee
+	return nil
+}
+
+func (c *StaticFeeCalculator) UpgradeBlockchainTx(*txs.UpgradeBlockchainTx) error {
+	c.Fee = c.Config.TxFee
+	return nil
+}
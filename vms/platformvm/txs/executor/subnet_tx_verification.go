@@ -20,6 +20,12 @@ var (
 	errIsNotSubnet                    = errors.New("is not a subnet")
 	errIsImmutable                    = errors.New("is immutable")
 	errUnauthorizedSubnetModification = errors.New("unauthorized subnet modification")
+	errCantFindChain                  = errors.New("couldn't find blockchain")
+	errIsNotChain                     = errors.New("is not a blockchain")
+	errWrongSubnet                    = errors.New("blockchain isn't validated by the subnet")
+	errChainHalted                    = errors.New("blockchain is halted")
+
+	errBlockchainModificationNotActivated = errors.New("blockchain modifications aren't activated yet")
 )
 
 // verifyPoASubnetAuthorization carries out the validation for modifying a PoA
@@ -48,6 +54,61 @@ func verifyPoASubnetAuthorization(
 	return creds, nil
 }
 
+// verifyChainModification carries out the validation for modifying a
+// blockchain of a PoA subnet. Returns the current metadata of the blockchain
+// and the remaining tx credentials that should be used to authorize the other
+// operations in the tx.
+func verifyChainModification(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	subnetID ids.ID,
+	chainID ids.ID,
+	subnetAuth verify.Verifiable,
+) (*state.ChainMetadata, []verify.Verifiable, error) {
+	currentTimestamp := chainState.GetTimestamp()
+	if !backend.Config.IsBlockchainModificationActivated(currentTimestamp) {
+		return nil, nil, fmt.Errorf(
+			"%w: chain time (%s) is before the activation time (%s)",
+			errBlockchainModificationNotActivated,
+			currentTimestamp,
+			backend.Config.BlockchainModificationTime,
+		)
+	}
+
+	chainIntf, _, err := chainState.GetTx(chainID)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"%w %q: %s",
+			errCantFindChain,
+			chainID,
+			err,
+		)
+	}
+
+	chain, ok := chainIntf.Unsigned.(*txs.CreateChainTx)
+	if !ok {
+		return nil, nil, fmt.Errorf("%q %w", chainID, errIsNotChain)
+	}
+	if chain.SubnetID != subnetID {
+		return nil, nil, fmt.Errorf("%w: expected %q but got %q", errWrongSubnet, chain.SubnetID, subnetID)
+	}
+
+	metadata, err := chainState.GetChainMetadata(chainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if metadata.Halted {
+		return nil, nil, fmt.Errorf("%q %w", chainID, errChainHalted)
+	}
+
+	creds, err := verifyPoASubnetAuthorization(backend, chainState, sTx, subnetID, subnetAuth)
+	if err != nil {
+		return nil, nil, err
+	}
+	return metadata, creds, nil
+}
+
 // verifySubnetAuthorization carries out the validation for modifying a subnet.
 // The last credential in [sTx.Creds] is used as the subnet authorization.
 // Returns the remaining tx credentials that should be used to authorize the
@@ -77,12 +138,17 @@ func verifySubnetAuthorization(
 		)
 	}
 
-	subnet, ok := subnetIntf.Unsigned.(*txs.CreateSubnetTx)
-	if !ok {
+	if _, ok := subnetIntf.Unsigned.(*txs.CreateSubnetTx); !ok {
 		return nil, fmt.Errorf("%q %w", subnetID, errIsNotSubnet)
 	}
 
-	if err := backend.Fx.VerifyPermission(sTx.Unsigned, subnetAuth, subnetCred, subnet.Owner); err != nil {
+	// The subnet's ownership may have been transferred since its creation.
+	owner, err := chainState.GetSubnetOwner(subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, subnetAuth, subnetCred, owner); err != nil {
 		return nil, fmt.Errorf("%w: %s", errUnauthorizedSubnetModification, err)
 	}
 
@@ -0,0 +1,146 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func TestTransferSubnetOwnershipTx(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment( /*postBanff*/ false)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	subnetID := testSubnet1.ID()
+	newOwnerKey := preFundedKeys[4]
+	newOwnerAddr := newOwnerKey.PublicKey().Address()
+
+	// The new owner isn't able to authorize modifications of the subnet yet.
+	_, err := env.txBuilder.NewTransferSubnetOwnershipTx(
+		subnetID,
+		1,
+		[]ids.ShortID{newOwnerAddr},
+		[]*crypto.PrivateKeySECP256K1R{newOwnerKey},
+		newOwnerAddr,
+	)
+	require.Error(err)
+
+	transferTx, err := env.txBuilder.NewTransferSubnetOwnershipTx(
+		subnetID,
+		1,
+		[]ids.ShortID{newOwnerAddr},
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	// A competing transfer, authorized by the same owner.
+	competingTx, err := env.txBuilder.NewTransferSubnetOwnershipTx(
+		subnetID,
+		1,
+		[]ids.ShortID{preFundedKeys[3].PublicKey().Address()},
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      transferTx,
+	}
+	require.NoError(transferTx.Unsigned.Visit(&executor))
+
+	owner, err := stateDiff.GetSubnetOwner(subnetID)
+	require.NoError(err)
+	require.Equal(
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{newOwnerAddr},
+		},
+		owner,
+	)
+
+	stateDiff.AddTx(transferTx, status.Committed)
+	stateDiff.Apply(env.state)
+	require.NoError(env.state.Commit())
+
+	// The previous owner is no longer authorized to modify the subnet.
+	stateDiff, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor = StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      competingTx,
+	}
+	err = competingTx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errUnauthorizedSubnetModification)
+
+	_, err = env.txBuilder.NewTransferSubnetOwnershipTx(
+		subnetID,
+		1,
+		[]ids.ShortID{preFundedKeys[3].PublicKey().Address()},
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.Error(err)
+
+	// The new owner is now able to authorize modifications of the subnet.
+	_, err = env.txBuilder.NewTransferSubnetOwnershipTx(
+		subnetID,
+		1,
+		[]ids.ShortID{preFundedKeys[3].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{newOwnerKey},
+		newOwnerAddr,
+	)
+	require.NoError(err)
+}
+
+func TestTransferSubnetOwnershipTxNotActivated(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment( /*postBanff*/ false)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+	env.config.SubnetOwnershipTransferTime = env.state.GetTimestamp().Add(time.Second)
+
+	transferTx, err := env.txBuilder.NewTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		[]ids.ShortID{preFundedKeys[4].PublicKey().Address()},
+		testSubnet1ControlKeys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stateDiff,
+		Tx:      transferTx,
+	}
+	err = transferTx.Unsigned.Visit(&executor)
+	require.ErrorIs(err, errSubnetOwnershipTransferNotActivated)
+}
@@ -74,6 +74,22 @@ func (v *MempoolTxVerifier) AddPermissionlessDelegatorTx(tx *txs.AddPermissionle
 	return v.standardTx(tx)
 }
 
+func (v *MempoolTxVerifier) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) ModifySubnetValidatorTx(tx *txs.ModifySubnetValidatorTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) HaltBlockchainTx(tx *txs.HaltBlockchainTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) UpgradeBlockchainTx(tx *txs.UpgradeBlockchainTx) error {
+	return v.standardTx(tx)
+}
+
 func (v *MempoolTxVerifier) standardTx(tx txs.UnsignedTx) error {
 	baseState, err := v.standardBaseState()
 	if err != nil {
@@ -0,0 +1,26 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import "time"
+
+type expirable interface {
+	expiry() uint64
+}
+
+// ExpiryOf returns the Unix time, in seconds, after which [utx] can no longer
+// be accepted. Returns 0 if [utx] never expires.
+func ExpiryOf(utx UnsignedTx) uint64 {
+	tx, ok := utx.(expirable)
+	if !ok {
+		return 0
+	}
+	return tx.expiry()
+}
+
+// IsExpired returns true if [utx] can't be accepted at [chainTime].
+func IsExpired(utx UnsignedTx, chainTime time.Time) bool {
+	expiry := ExpiryOf(utx)
+	return expiry != 0 && uint64(chainTime.Unix()) > expiry
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/codec"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func TestExpiryCodecVersion(t *testing.T) {
+	require := require.New(t)
+
+	utx := &CreateSubnetTx{
+		Owner: &secp256k1fx.OutputOwners{},
+	}
+	require.Zero(ExpiryOf(utx))
+	require.EqualValues(Version, CodecVersion(utx))
+	require.False(IsExpired(utx, time.Unix(1, 0)))
+
+	tx, err := NewSigned(utx, Codec, nil)
+	require.NoError(err)
+	unexpiringBytes := tx.Bytes()
+
+	// A tx serialized with [V1Version] must specify an expiry.
+	wrongVersionBytes, err := Codec.Marshal(V1Version, tx)
+	require.NoError(err)
+	_, err = Parse(Codec, wrongVersionBytes)
+	require.ErrorIs(err, errWrongCodecVersion)
+
+	utx.Expiry = 10
+	require.EqualValues(10, ExpiryOf(utx))
+	require.EqualValues(V1Version, CodecVersion(utx))
+	require.False(IsExpired(utx, time.Unix(10, 0)))
+	require.True(IsExpired(utx, time.Unix(11, 0)))
+
+	tx, err = NewSigned(utx, Codec, nil)
+	require.NoError(err)
+	require.NotEqual(unexpiringBytes, tx.Bytes())
+
+	for _, c := range []codec.Manager{Codec, GenesisCodec} {
+		parsed, err := Parse(c, tx.Bytes())
+		require.NoError(err)
+		require.Equal(tx.ID(), parsed.ID())
+		require.Equal(tx.Unsigned.Bytes(), parsed.Unsigned.Bytes())
+		require.EqualValues(10, ExpiryOf(parsed.Unsigned))
+	}
+
+	// Txs that don't embed a BaseTx never expire.
+	require.EqualValues(Version, CodecVersion(&AdvanceTimeTx{}))
+}
+
+func TestAutoRestakeCodecVersion(t *testing.T) {
+	require := require.New(t)
+
+	utx := &AddPermissionlessDelegatorTx{
+		DelegationRewardsOwner: &secp256k1fx.OutputOwners{},
+	}
+	require.EqualValues(Version, CodecVersion(utx))
+
+	tx, err := NewSigned(utx, Codec, nil)
+	require.NoError(err)
+	defaultBytes := tx.Bytes()
+
+	utx.RestakePeriods = 1
+	utx.CompoundRewards = true
+	require.EqualValues(V1Version, CodecVersion(utx))
+
+	tx, err = NewSigned(utx, Codec, nil)
+	require.NoError(err)
+	require.NotEqual(defaultBytes, tx.Bytes())
+
+	parsed, err := Parse(Codec, tx.Bytes())
+	require.NoError(err)
+	require.Equal(tx.ID(), parsed.ID())
+
+	parsedUTX, ok := parsed.Unsigned.(*AddPermissionlessDelegatorTx)
+	require.True(ok)
+	require.EqualValues(1, parsedUTX.MaxRestakes())
+	require.True(parsedUTX.CompoundsRewards())
+	require.Zero(ExpiryOf(parsedUTX))
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+)
+
+var (
+	_ UnsignedTx = (*HaltBlockchainTx)(nil)
+
+	errHaltPrimaryNetworkChain = errors.New("can't halt a blockchain of the primary network")
+	errMissingBlockchainID     = errors.New("missing blockchain ID")
+)
+
+// HaltBlockchainTx is an unsigned haltBlockchainTx. Once accepted, nodes stop
+// running the blockchain and will never create it again.
+type HaltBlockchainTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet that validates the blockchain
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// ID of the blockchain to halt
+	BlockchainID ids.ID `serialize:"true" json:"blockchainID"`
+	// Proves that the issuer has the right to halt the blockchains of the
+	// subnet.
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+func (tx *HaltBlockchainTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errHaltPrimaryNetworkChain
+	case tx.BlockchainID == ids.Empty:
+		return errMissingBlockchainID
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *HaltBlockchainTx) Visit(visitor Visitor) error {
+	return visitor.HaltBlockchainTx(tx)
+}
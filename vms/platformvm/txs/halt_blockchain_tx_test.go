@@ -0,0 +1,171 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+)
+
+func TestHaltBlockchainTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name      string
+		txFunc    func(*gomock.Controller) *HaltBlockchainTx
+		shouldErr bool
+		// If [shouldErr] and [requireSpecificErr] != nil,
+		// require that the error we get is [requireSpecificErr].
+		requireSpecificErr error
+	}
+
+	var (
+		networkID            = uint32(1337)
+		chainID              = ids.GenerateTestID()
+		errInvalidSubnetAuth = errors.New("invalid subnet auth")
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that already passed syntactic verification.
+	verifiedBaseTx := BaseTx{
+		SyntacticallyVerified: true,
+	}
+	// Sanity check.
+	require.NoError(t, verifiedBaseTx.SyntacticVerify(ctx))
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: djtx.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+	// Sanity check.
+	require.NoError(t, validBaseTx.SyntacticVerify(ctx))
+	// Make sure we're not caching the verification result.
+	require.False(t, validBaseTx.SyntacticallyVerified)
+
+	// A BaseTx that fails syntactic verification.
+	invalidBaseTx := BaseTx{}
+	// Sanity check.
+	require.Error(t, invalidBaseTx.SyntacticVerify(ctx))
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *HaltBlockchainTx {
+				return nil
+			},
+			shouldErr: true,
+		},
+		{
+			name: "already verified",
+			txFunc: func(*gomock.Controller) *HaltBlockchainTx {
+				return &HaltBlockchainTx{BaseTx: verifiedBaseTx}
+			},
+			shouldErr: false,
+		},
+		{
+			name: "invalid BaseTx",
+			txFunc: func(*gomock.Controller) *HaltBlockchainTx {
+				return &HaltBlockchainTx{
+					// Set subnetID so we don't error on that check.
+					Subnet:       ids.GenerateTestID(),
+					BaseTx:       invalidBaseTx,
+					BlockchainID: ids.GenerateTestID(),
+				}
+			},
+			shouldErr: true,
+		},
+		{
+			name: "invalid subnetID",
+			txFunc: func(*gomock.Controller) *HaltBlockchainTx {
+				return &HaltBlockchainTx{
+					BaseTx:       validBaseTx,
+					Subnet:       constants.PrimaryNetworkID,
+					BlockchainID: ids.GenerateTestID(),
+				}
+			},
+			shouldErr:          true,
+			requireSpecificErr: errHaltPrimaryNetworkChain,
+		},
+		{
+			name: "invalid subnetAuth",
+			txFunc: func(ctrl *gomock.Controller) *HaltBlockchainTx {
+				// This SubnetAuth fails verification.
+				invalidSubnetAuth := verify.NewMockVerifiable(ctrl)
+				invalidSubnetAuth.EXPECT().Verify().Return(errInvalidSubnetAuth)
+				return &HaltBlockchainTx{
+					// Set subnetID so we don't error on that check.
+					Subnet:       ids.GenerateTestID(),
+					BaseTx:       validBaseTx,
+					SubnetAuth:   invalidSubnetAuth,
+					BlockchainID: ids.GenerateTestID(),
+				}
+			},
+			shouldErr:          true,
+			requireSpecificErr: errInvalidSubnetAuth,
+		},
+		{
+			name: "missing blockchainID",
+			txFunc: func(*gomock.Controller) *HaltBlockchainTx {
+				return &HaltBlockchainTx{
+					// Set subnetID so we don't error on that check.
+					Subnet: ids.GenerateTestID(),
+					BaseTx: validBaseTx,
+				}
+			},
+			shouldErr:          true,
+			requireSpecificErr: errMissingBlockchainID,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *HaltBlockchainTx {
+				// This SubnetAuth passes verification.
+				validSubnetAuth := verify.NewMockVerifiable(ctrl)
+				validSubnetAuth.EXPECT().Verify().Return(nil)
+				return &HaltBlockchainTx{
+					// Set subnetID so we don't error on that check.
+					Subnet:       ids.GenerateTestID(),
+					BaseTx:       validBaseTx,
+					SubnetAuth:   validSubnetAuth,
+					BlockchainID: ids.GenerateTestID(),
+				}
+			},
+			shouldErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			if tt.shouldErr {
+				require.Error(err)
+				if tt.requireSpecificErr != nil {
+					require.ErrorIs(err, tt.requireSpecificErr)
+				}
+				return
+			}
+			require.NoError(err)
+			require.True(tx.SyntacticallyVerified)
+		})
+	}
+}
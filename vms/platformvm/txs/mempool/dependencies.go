@@ -0,0 +1,149 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"container/heap"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+var _ heap.Interface = (*rankHeap)(nil)
+
+// trackDependencies records the UTXOs produced and consumed by [tx], so that
+// the txs in the mempool that spend its outputs, and the txs in the mempool
+// whose outputs it spends, can be found.
+func (m *mempool) trackDependencies(tx *txs.Tx) {
+	txID := tx.ID()
+	for _, utxo := range tx.UTXOs() {
+		m.producers[utxo.InputID()] = txID
+	}
+	for inputID := range tx.Unsigned.InputIDs() {
+		m.spenders[inputID] = txID
+	}
+}
+
+func (m *mempool) untrackDependencies(tx *txs.Tx) {
+	for _, utxo := range tx.UTXOs() {
+		delete(m.producers, utxo.InputID())
+	}
+	for inputID := range tx.Unsigned.InputIDs() {
+		delete(m.spenders, inputID)
+	}
+}
+
+// parents returns the IDs of the txs in the mempool whose outputs [tx] spends.
+func (m *mempool) parents(tx *txs.Tx) set.Set[ids.ID] {
+	var parents set.Set[ids.ID]
+	for inputID := range tx.Unsigned.InputIDs() {
+		if parentID, ok := m.producers[inputID]; ok {
+			parents.Add(parentID)
+		}
+	}
+	return parents
+}
+
+// children returns the IDs of the txs in the mempool that spend the outputs of
+// [tx].
+func (m *mempool) children(tx *txs.Tx) set.Set[ids.ID] {
+	var children set.Set[ids.ID]
+	for _, utxo := range tx.UTXOs() {
+		if childID, ok := m.spenders[utxo.InputID()]; ok {
+			children.Add(childID)
+		}
+	}
+	return children
+}
+
+// descendants returns the txs in the mempool that spend the outputs of [tx],
+// directly or through other txs in the mempool. Each descendant is returned
+// after its parents.
+func (m *mempool) descendants(tx *txs.Tx) []*txs.Tx {
+	var (
+		descendants []*txs.Tx
+		visited     set.Set[ids.ID]
+		toVisit     = []*txs.Tx{tx}
+	)
+	for len(toVisit) > 0 {
+		next := toVisit[0]
+		toVisit = toVisit[1:]
+		for childID := range m.children(next) {
+			if visited.Contains(childID) {
+				continue
+			}
+			visited.Add(childID)
+
+			child := m.Get(childID)
+			descendants = append(descendants, child)
+			toVisit = append(toVisit, child)
+		}
+	}
+	return descendants
+}
+
+// orderByDependencies returns [sortedTxs] reordered so that every tx comes
+// after its parents in the mempool. Among the txs whose parents were all
+// returned, the one that comes first in [sortedTxs] is returned first.
+func (m *mempool) orderByDependencies(sortedTxs []*txs.Tx) []*txs.Tx {
+	ranks := make(map[ids.ID]int, len(sortedTxs))
+	for rank, tx := range sortedTxs {
+		ranks[tx.ID()] = rank
+	}
+
+	var (
+		numParents = make([]int, len(sortedTxs))
+		ready      = make(rankHeap, 0, len(sortedTxs))
+	)
+	for rank, tx := range sortedTxs {
+		numParents[rank] = m.parents(tx).Len()
+		if numParents[rank] == 0 {
+			ready = append(ready, rank)
+		}
+	}
+	heap.Init(&ready)
+
+	ordered := make([]*txs.Tx, 0, len(sortedTxs))
+	for ready.Len() > 0 {
+		tx := sortedTxs[heap.Pop(&ready).(int)]
+		ordered = append(ordered, tx)
+
+		for childID := range m.children(tx) {
+			childRank := ranks[childID]
+			numParents[childRank]--
+			if numParents[childRank] == 0 {
+				heap.Push(&ready, childRank)
+			}
+		}
+	}
+	return ordered
+}
+
+// rankHeap is a min-heap of ranks
+type rankHeap []int
+
+func (h rankHeap) Len() int {
+	return len(h)
+}
+
+func (h rankHeap) Less(i, j int) bool {
+	return h[i] < h[j]
+}
+
+func (h rankHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *rankHeap) Push(x interface{}) {
+	*h = append(*h, x.(int))
+}
+
+func (h *rankHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
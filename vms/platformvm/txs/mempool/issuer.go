@@ -83,3 +83,23 @@ func (i *issuer) AddPermissionlessDelegatorTx(*txs.AddPermissionlessDelegatorTx)
 	i.m.addStakerTx(i.tx)
 	return nil
 }
+
+func (i *issuer) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) ModifySubnetValidatorTx(*txs.ModifySubnetValidatorTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) HaltBlockchainTx(*txs.HaltBlockchainTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) UpgradeBlockchainTx(*txs.UpgradeBlockchainTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
@@ -6,13 +6,17 @@ package mempool
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/lasthyphen/dijetsnodego/cache"
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/linkedhashmap"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/utils/timer/mockable"
 	"github.com/lasthyphen/dijetsnodego/utils/units"
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs/txheap"
 )
@@ -37,6 +41,11 @@ var (
 	errMempoolFull = errors.New("mempool is full")
 )
 
+const (
+	evictedReason       = "evicted by a tx paying a higher priority fee"
+	parentDroppedReason = "spends the outputs of a dropped tx"
+)
+
 type BlockTimer interface {
 	// ResetBlockTimer schedules a timer to notify the consensus engine once
 	// there is a block ready to be built. If a block is ready to be built when
@@ -44,6 +53,13 @@ type BlockTimer interface {
 	ResetBlockTimer()
 }
 
+// PriorityFeeCalculator computes the priority fee of the txs added to the
+// mempool, which is the amount of DJTX a tx burns in excess of its static fee.
+// Txs paying a higher priority fee per byte are included in blocks first.
+type PriorityFeeCalculator interface {
+	PriorityFee(tx *txs.Tx) (uint64, error)
+}
+
 type Mempool interface {
 	// we may want to be able to stop valid transactions
 	// from entering the mempool, e.g. during blocks creation
@@ -61,7 +77,14 @@ type Mempool interface {
 	HasTxs() bool
 	// PeekTxs returns the next txs for Banff blocks
 	// up to maxTxsBytes without removing them from the mempool.
+	// Txs are ordered by decreasing priority fee per byte, and then by the
+	// time they were added. A tx spending the outputs of other txs in the
+	// mempool is always returned after them.
 	PeekTxs(maxTxsBytes int) []*txs.Tx
+	// EstimatePriorityFeeRate returns the priority fee per byte that a tx
+	// must pay to be peeked ahead of the txs that currently fill
+	// [maxTxsBytes].
+	EstimatePriorityFeeRate(maxTxsBytes int) uint64
 
 	HasStakerTx() bool
 	// PeekStakerTx returns the next stakerTx without removing it from mempool.
@@ -69,12 +92,42 @@ type Mempool interface {
 	// It's guaranteed that the returned tx, if not nil, is a StakerTx.
 	PeekStakerTx() *txs.Tx
 
+	// RemoveExpiredTxs removes the txs that can no longer be accepted at
+	// [timestamp] because they expired, and marks them as dropped.
+	RemoveExpiredTxs(timestamp time.Time)
+
 	// Note: dropped txs are added to droppedTxIDs but not
 	// not evicted from unissued decision/staker txs.
 	// This allows previously dropped txs to be possibly
 	// reissued.
 	MarkDropped(txID ids.ID, reason string)
 	GetDropReason(txID ids.ID) (string, bool)
+
+	// GetEntry returns the tx with ID [txID] along with the time it was added
+	// to the mempool.
+	GetEntry(txID ids.ID) (Entry, bool)
+	// Entries returns the txs in the mempool, in the order they were added.
+	Entries() []Entry
+	// DroppedTxs returns the recently dropped txs, most recently dropped
+	// first.
+	DroppedTxs() []DroppedTx
+}
+
+// Entry is a tx in the mempool
+type Entry struct {
+	Tx    *txs.Tx
+	Added time.Time
+	// PriorityFee is the amount of DJTX the tx burns in excess of its static
+	// fee
+	PriorityFee uint64
+}
+
+// DroppedTx is a tx that was recently dropped, along with the reason it was
+// dropped
+type DroppedTx struct {
+	TxID    ids.ID
+	Reason  string
+	Dropped time.Time
 }
 
 // Transactions from clients that have not yet been put into blocks and added to
@@ -86,30 +139,85 @@ type mempool struct {
 	bytesAvailableMetric prometheus.Gauge
 	bytesAvailable       int
 
+	numTxsMetric            prometheus.Gauge
+	oldestTxTimestampMetric prometheus.Gauge
+	droppedTxsMetric        prometheus.Counter
+
 	unissuedDecisionTxs txheap.Heap
 	unissuedStakerTxs   txheap.Heap
 
+	// Contains all the txs in the mempool, the lowest paying first, to evict
+	// them when the mempool is full
+	txsByFeeRate txheap.Heap
+
+	// Contains the txs in the mempool that specify an expiry, the first to
+	// expire first
+	expiringTxs txheap.TimedHeap
+
+	feeCalculator PriorityFeeCalculator
+
+	// Key: Tx ID
+	// Value: Priority fee paid by the tx
+	priorityFees map[ids.ID]uint64
+
+	// Key: Tx ID
+	// Value: Time the tx was added to the mempool
+	addedTimes linkedhashmap.LinkedHashmap[ids.ID, time.Time]
+
 	// Key: Tx ID
-	// Value: String repr. of the verification error
-	droppedTxIDs *cache.LRU
+	// Value: Verification error and the time the tx was dropped
+	droppedTxIDs linkedhashmap.LinkedHashmap[ids.ID, DroppedTx]
 
 	consumedUTXOs set.Set[ids.ID]
 
+	// Key: ID of a UTXO produced by a tx in the mempool
+	// Value: ID of the tx producing it
+	producers map[ids.ID]ids.ID
+
+	// Key: ID of a UTXO consumed by a tx in the mempool
+	// Value: ID of the tx consuming it
+	spenders map[ids.ID]ids.ID
+
 	blkTimer BlockTimer
+
+	clock mockable.Clock
 }
 
 func NewMempool(
 	namespace string,
 	registerer prometheus.Registerer,
 	blkTimer BlockTimer,
+	feeCalculator PriorityFeeCalculator,
 ) (Mempool, error) {
 	bytesAvailableMetric := prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "bytes_available",
 		Help:      "Number of bytes of space currently available in the mempool",
 	})
-	if err := registerer.Register(bytesAvailableMetric); err != nil {
-		return nil, err
+	numTxsMetric := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "txs",
+		Help:      "Number of transactions currently in the mempool",
+	})
+	oldestTxTimestampMetric := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "oldest_tx_timestamp",
+		Help:      "Unix time, in seconds, at which the oldest transaction in the mempool was added. Zero if the mempool is empty",
+	})
+	droppedTxsMetric := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dropped_txs",
+		Help:      "Number of transactions marked as dropped",
+	})
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(bytesAvailableMetric),
+		registerer.Register(numTxsMetric),
+		registerer.Register(oldestTxTimestampMetric),
+		registerer.Register(droppedTxsMetric),
+	)
+	if errs.Errored() {
+		return nil, errs.Err
 	}
 
 	unissuedDecisionTxs, err := txheap.NewWithMetrics(
@@ -131,16 +239,27 @@ func NewMempool(
 	}
 
 	bytesAvailableMetric.Set(maxMempoolSize)
-	return &mempool{
-		bytesAvailableMetric: bytesAvailableMetric,
-		bytesAvailable:       maxMempoolSize,
-		unissuedDecisionTxs:  unissuedDecisionTxs,
-		unissuedStakerTxs:    unissuedStakerTxs,
-		droppedTxIDs:         &cache.LRU{Size: droppedTxIDsCacheSize},
-		consumedUTXOs:        set.NewSet[ids.ID](initialConsumedUTXOsSize),
-		dropIncoming:         false, // enable tx adding by default
-		blkTimer:             blkTimer,
-	}, nil
+	m := &mempool{
+		bytesAvailableMetric:    bytesAvailableMetric,
+		bytesAvailable:          maxMempoolSize,
+		numTxsMetric:            numTxsMetric,
+		oldestTxTimestampMetric: oldestTxTimestampMetric,
+		droppedTxsMetric:        droppedTxsMetric,
+		unissuedDecisionTxs:     unissuedDecisionTxs,
+		unissuedStakerTxs:       unissuedStakerTxs,
+		expiringTxs:             txheap.NewByExpiry(),
+		feeCalculator:           feeCalculator,
+		priorityFees:            make(map[ids.ID]uint64),
+		addedTimes:              linkedhashmap.New[ids.ID, time.Time](),
+		droppedTxIDs:            linkedhashmap.New[ids.ID, DroppedTx](),
+		consumedUTXOs:           set.NewSet[ids.ID](initialConsumedUTXOsSize),
+		producers:               make(map[ids.ID]ids.ID),
+		spenders:                make(map[ids.ID]ids.ID),
+		dropIncoming:            false, // enable tx adding by default
+		blkTimer:                blkTimer,
+	}
+	m.txsByFeeRate = txheap.NewByFeeRate(m.priorityFee)
+	return m, nil
 }
 
 func (m *mempool) EnableAdding() {
@@ -166,7 +285,18 @@ func (m *mempool) Add(tx *txs.Tx) error {
 	if len(txBytes) > targetTxSize {
 		return fmt.Errorf("tx %s size (%d) > target size (%d)", txID, len(txBytes), targetTxSize)
 	}
-	if len(txBytes) > m.bytesAvailable {
+
+	inputs := tx.Unsigned.InputIDs()
+	if m.consumedUTXOs.Overlaps(inputs) {
+		return fmt.Errorf("tx %s conflicts with a transaction in the mempool", txID)
+	}
+
+	priorityFee, err := m.feeCalculator.PriorityFee(tx)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate priority fee of tx %s: %w", txID, err)
+	}
+
+	if len(txBytes) > m.bytesAvailable && !m.evict(len(txBytes), priorityFee) {
 		return fmt.Errorf("%w, tx %s size (%d) exceeds available space (%d)",
 			errMempoolFull,
 			txID,
@@ -175,15 +305,12 @@ func (m *mempool) Add(tx *txs.Tx) error {
 		)
 	}
 
-	inputs := tx.Unsigned.InputIDs()
-	if m.consumedUTXOs.Overlaps(inputs) {
-		return fmt.Errorf("tx %s conflicts with a transaction in the mempool", txID)
-	}
-
+	m.priorityFees[txID] = priorityFee
 	if err := tx.Unsigned.Visit(&issuer{
 		m:  m,
 		tx: tx,
 	}); err != nil {
+		delete(m.priorityFees, txID)
 		return err
 	}
 
@@ -191,7 +318,7 @@ func (m *mempool) Add(tx *txs.Tx) error {
 	m.consumedUTXOs.Union(inputs)
 
 	// An explicitly added tx must not be marked as dropped.
-	m.droppedTxIDs.Evict(txID)
+	m.droppedTxIDs.Delete(txID)
 
 	m.blkTimer.ResetBlockTimer()
 	return nil
@@ -224,8 +351,7 @@ func (m *mempool) HasTxs() bool {
 }
 
 func (m *mempool) PeekTxs(maxTxsBytes int) []*txs.Tx {
-	txs := m.unissuedDecisionTxs.List()
-	txs = append(txs, m.unissuedStakerTxs.List()...)
+	txs := m.sortedByFeeRate()
 
 	size := 0
 	for i, tx := range txs {
@@ -237,6 +363,97 @@ func (m *mempool) PeekTxs(maxTxsBytes int) []*txs.Tx {
 	return txs
 }
 
+func (m *mempool) EstimatePriorityFeeRate(maxTxsBytes int) uint64 {
+	size := 0
+	for _, tx := range m.sortedByFeeRate() {
+		txSize := len(tx.Bytes())
+		size += txSize
+		if size > maxTxsBytes {
+			// Paying more than [tx] per byte is enough to be peeked before it.
+			return m.priorityFees[tx.ID()]/uint64(txSize) + 1
+		}
+	}
+	return 0
+}
+
+// sortedByFeeRate returns the txs in the mempool in the order they are
+// peeked. A tx spending the outputs of other txs in the mempool is only peeked
+// after them, so that any prefix of the returned txs can be issued in order.
+func (m *mempool) sortedByFeeRate() []*txs.Tx {
+	txs := make([]*txs.Tx, 0, m.addedTimes.Len())
+	iter := m.addedTimes.NewIterator()
+	for iter.Next() {
+		txs = append(txs, m.Get(iter.Key()))
+	}
+
+	// The sort is stable so that txs paying the same rate are peeked in the
+	// order they were added.
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txheap.CompareFeeRates(
+			m.priorityFees[txs[i].ID()],
+			len(txs[i].Bytes()),
+			m.priorityFees[txs[j].ID()],
+			len(txs[j].Bytes()),
+		) > 0
+	})
+	return m.orderByDependencies(txs)
+}
+
+// evict removes the lowest paying txs from the mempool, along with the txs
+// spending their outputs, to make [size] bytes available, if the lowest paying
+// txs all pay a lower rate than [priorityFee] per [size] bytes. Returns false,
+// without removing any tx, if that isn't possible.
+func (m *mempool) evict(size int, priorityFee uint64) bool {
+	var (
+		bytesAvailable = m.bytesAvailable
+		evicted        []*txs.Tx
+		descendants    []*txs.Tx
+	)
+	for bytesAvailable < size && m.txsByFeeRate.Len() > 0 {
+		lowest := m.txsByFeeRate.Peek()
+		lowestSize := len(lowest.Bytes())
+		if txheap.CompareFeeRates(m.priorityFee(lowest), lowestSize, priorityFee, size) >= 0 {
+			break
+		}
+		evicted = append(evicted, m.txsByFeeRate.RemoveTop())
+		bytesAvailable += lowestSize
+
+		for _, descendant := range m.descendants(lowest) {
+			// The descendant may have already been evicted with another of
+			// its ancestors.
+			if m.txsByFeeRate.Remove(descendant.ID()) == nil {
+				continue
+			}
+			descendants = append(descendants, descendant)
+			bytesAvailable += len(descendant.Bytes())
+		}
+	}
+
+	if bytesAvailable < size {
+		for _, tx := range evicted {
+			m.txsByFeeRate.Add(tx)
+		}
+		for _, tx := range descendants {
+			m.txsByFeeRate.Add(tx)
+		}
+		return false
+	}
+
+	m.Remove(evicted)
+	m.Remove(descendants)
+	for _, tx := range evicted {
+		m.MarkDropped(tx.ID(), evictedReason)
+	}
+	for _, tx := range descendants {
+		m.MarkDropped(tx.ID(), parentDroppedReason)
+	}
+	return true
+}
+
+func (m *mempool) priorityFee(tx *txs.Tx) uint64 {
+	return m.priorityFees[tx.ID()]
+}
+
 func (m *mempool) addDecisionTx(tx *txs.Tx) {
 	m.unissuedDecisionTxs.Add(tx)
 	m.register(tx)
@@ -275,22 +492,103 @@ func (m *mempool) PeekStakerTx() *txs.Tx {
 	return m.unissuedStakerTxs.Peek()
 }
 
+func (m *mempool) RemoveExpiredTxs(timestamp time.Time) {
+	for m.expiringTxs.Len() > 0 {
+		tx := m.expiringTxs.Peek()
+		if !txs.IsExpired(tx.Unsigned, timestamp) {
+			return
+		}
+
+		reason := fmt.Sprintf(
+			"expiry (%s) is before chain time (%s)",
+			m.expiringTxs.Timestamp(),
+			timestamp,
+		)
+		// The txs spending the outputs of [tx] can't be issued anymore.
+		descendants := m.descendants(tx)
+		m.Remove([]*txs.Tx{tx})
+		m.Remove(descendants)
+		m.MarkDropped(tx.ID(), reason)
+		for _, descendant := range descendants {
+			m.MarkDropped(descendant.ID(), parentDroppedReason)
+		}
+	}
+}
+
 func (m *mempool) MarkDropped(txID ids.ID, reason string) {
-	m.droppedTxIDs.Put(txID, reason)
+	// Re-inserting the tx marks it as the most recently dropped.
+	m.droppedTxIDs.Delete(txID)
+	m.droppedTxIDs.Put(txID, DroppedTx{
+		TxID:    txID,
+		Reason:  reason,
+		Dropped: m.clock.Time(),
+	})
+	m.droppedTxsMetric.Inc()
+
+	for m.droppedTxIDs.Len() > droppedTxIDsCacheSize {
+		oldestTxID, _, _ := m.droppedTxIDs.Oldest()
+		m.droppedTxIDs.Delete(oldestTxID)
+	}
 }
 
 func (m *mempool) GetDropReason(txID ids.ID) (string, bool) {
-	reason, exist := m.droppedTxIDs.Get(txID)
+	droppedTx, exist := m.droppedTxIDs.Get(txID)
 	if !exist {
 		return "", false
 	}
-	return reason.(string), true
+	return droppedTx.Reason, true
+}
+
+func (m *mempool) GetEntry(txID ids.ID) (Entry, bool) {
+	tx := m.Get(txID)
+	if tx == nil {
+		return Entry{}, false
+	}
+	added, _ := m.addedTimes.Get(txID)
+	return Entry{
+		Tx:          tx,
+		Added:       added,
+		PriorityFee: m.priorityFees[txID],
+	}, true
+}
+
+func (m *mempool) Entries() []Entry {
+	entries := make([]Entry, 0, m.addedTimes.Len())
+	iter := m.addedTimes.NewIterator()
+	for iter.Next() {
+		txID := iter.Key()
+		entries = append(entries, Entry{
+			Tx:          m.Get(txID),
+			Added:       iter.Value(),
+			PriorityFee: m.priorityFees[txID],
+		})
+	}
+	return entries
+}
+
+func (m *mempool) DroppedTxs() []DroppedTx {
+	droppedTxs := make([]DroppedTx, m.droppedTxIDs.Len())
+	i := len(droppedTxs) - 1
+	iter := m.droppedTxIDs.NewIterator()
+	for iter.Next() {
+		droppedTxs[i] = iter.Value()
+		i--
+	}
+	return droppedTxs
 }
 
 func (m *mempool) register(tx *txs.Tx) {
 	txBytes := tx.Bytes()
 	m.bytesAvailable -= len(txBytes)
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
+
+	m.txsByFeeRate.Add(tx)
+	if txs.ExpiryOf(tx.Unsigned) != 0 {
+		m.expiringTxs.Add(tx)
+	}
+	m.trackDependencies(tx)
+	m.addedTimes.Put(tx.ID(), m.clock.Time())
+	m.updateTxMetrics()
 }
 
 func (m *mempool) deregister(tx *txs.Tx) {
@@ -300,4 +598,23 @@ func (m *mempool) deregister(tx *txs.Tx) {
 
 	inputs := tx.Unsigned.InputIDs()
 	m.consumedUTXOs.Difference(inputs)
+	m.untrackDependencies(tx)
+
+	txID := tx.ID()
+	m.txsByFeeRate.Remove(txID)
+	m.expiringTxs.Remove(txID)
+	delete(m.priorityFees, txID)
+	m.addedTimes.Delete(txID)
+	m.updateTxMetrics()
+}
+
+func (m *mempool) updateTxMetrics() {
+	m.numTxsMetric.Set(float64(m.addedTimes.Len()))
+
+	_, oldest, exists := m.addedTimes.Oldest()
+	if !exists {
+		m.oldestTxTimestampMetric.Set(0)
+		return
+	}
+	m.oldestTxTimestampMetric.Set(float64(oldest.Unix()))
 }
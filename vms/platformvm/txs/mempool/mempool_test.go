@@ -28,6 +28,22 @@ type noopBlkTimer struct{}
 
 func (*noopBlkTimer) ResetBlockTimer() {}
 
+var _ PriorityFeeCalculator = (*noopFeeCalculator)(nil)
+
+type noopFeeCalculator struct{}
+
+func (*noopFeeCalculator) PriorityFee(*txs.Tx) (uint64, error) {
+	return 0, nil
+}
+
+var _ PriorityFeeCalculator = (testFeeCalculator)(nil)
+
+type testFeeCalculator map[ids.ID]uint64
+
+func (c testFeeCalculator) PriorityFee(tx *txs.Tx) (uint64, error) {
+	return c[tx.ID()], nil
+}
+
 var preFundedKeys = crypto.BuildTestKeys()
 
 // shows that valid tx is not added to mempool if this would exceed its maximum
@@ -36,7 +52,7 @@ func TestBlockBuilderMaxMempoolSizeHandling(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{})
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, &noopFeeCalculator{})
 	require.NoError(err)
 
 	decisionTxs, err := createTestDecisionTxs(1)
@@ -60,7 +76,7 @@ func TestDecisionTxsInMempool(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{})
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, &noopFeeCalculator{})
 	require.NoError(err)
 
 	decisionTxs, err := createTestDecisionTxs(2)
@@ -112,7 +128,7 @@ func TestProposalTxsInMempool(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{})
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, &noopFeeCalculator{})
 	require.NoError(err)
 
 	// The proposal txs are ordered by decreasing start time. This means after
@@ -242,3 +258,264 @@ func createTestProposalTxs(count int) ([]*txs.Tx, error) {
 	}
 	return proposalTxs, nil
 }
+
+func TestMempoolEntries(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, &noopFeeCalculator{})
+	require.NoError(err)
+
+	now := time.Unix(1607133207, 0)
+	mpool.(*mempool).clock.Set(now)
+
+	decisionTxs, err := createTestDecisionTxs(1)
+	require.NoError(err)
+	proposalTxs, err := createTestProposalTxs(1)
+	require.NoError(err)
+
+	require.NoError(mpool.Add(proposalTxs[0]))
+	mpool.(*mempool).clock.Set(now.Add(time.Second))
+	require.NoError(mpool.Add(decisionTxs[0]))
+
+	entries := mpool.Entries()
+	require.Equal([]Entry{
+		{
+			Tx:    proposalTxs[0],
+			Added: now,
+		},
+		{
+			Tx:    decisionTxs[0],
+			Added: now.Add(time.Second),
+		},
+	}, entries)
+
+	entry, ok := mpool.GetEntry(decisionTxs[0].ID())
+	require.True(ok)
+	require.Equal(entries[1], entry)
+
+	mpool.Remove([]*txs.Tx{proposalTxs[0]})
+	_, ok = mpool.GetEntry(proposalTxs[0].ID())
+	require.False(ok)
+	require.Equal(entries[1:], mpool.Entries())
+}
+
+func TestMempoolDroppedTxs(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, &noopFeeCalculator{})
+	require.NoError(err)
+
+	now := time.Unix(1607133207, 0)
+	mpool.(*mempool).clock.Set(now)
+
+	txIDs := make([]ids.ID, droppedTxIDsCacheSize+1)
+	for i := range txIDs {
+		txIDs[i] = ids.GenerateTestID()
+		mpool.MarkDropped(txIDs[i], "reason")
+	}
+
+	// The oldest drop is evicted once the cache is full.
+	_, dropped := mpool.GetDropReason(txIDs[0])
+	require.False(dropped)
+
+	droppedTxs := mpool.DroppedTxs()
+	require.Len(droppedTxs, droppedTxIDsCacheSize)
+	require.Equal(DroppedTx{
+		TxID:    txIDs[len(txIDs)-1],
+		Reason:  "reason",
+		Dropped: now,
+	}, droppedTxs[0])
+	require.Equal(txIDs[1], droppedTxs[len(droppedTxs)-1].TxID)
+
+	// Dropping a tx again makes it the most recently dropped.
+	mpool.MarkDropped(txIDs[1], "other reason")
+	droppedTxs = mpool.DroppedTxs()
+	require.Equal(txIDs[1], droppedTxs[0].TxID)
+	require.Equal("other reason", droppedTxs[0].Reason)
+	require.Equal(txIDs[2], droppedTxs[len(droppedTxs)-1].TxID)
+}
+
+func TestPeekTxsByFeeRate(t *testing.T) {
+	require := require.New(t)
+
+	decisionTxs, err := createTestDecisionTxs(3)
+	require.NoError(err)
+	txSize := len(decisionTxs[0].Bytes())
+
+	fees := testFeeCalculator{
+		decisionTxs[0].ID(): 0,
+		decisionTxs[1].ID(): uint64(10 * txSize),
+		decisionTxs[2].ID(): 0,
+	}
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, fees)
+	require.NoError(err)
+
+	for _, tx := range decisionTxs {
+		require.NoError(mpool.Add(tx))
+	}
+
+	// Txs paying the same rate are peeked in the order they were added.
+	require.Equal(
+		[]*txs.Tx{decisionTxs[1], decisionTxs[0], decisionTxs[2]},
+		mpool.PeekTxs(math.MaxInt),
+	)
+	require.Equal(
+		[]*txs.Tx{decisionTxs[1]},
+		mpool.PeekTxs(txSize),
+	)
+
+	entry, ok := mpool.GetEntry(decisionTxs[1].ID())
+	require.True(ok)
+	require.EqualValues(10*txSize, entry.PriorityFee)
+
+	require.Zero(mpool.EstimatePriorityFeeRate(3 * txSize))
+	require.EqualValues(1, mpool.EstimatePriorityFeeRate(2*txSize))
+	require.EqualValues(11, mpool.EstimatePriorityFeeRate(0))
+}
+
+func TestMempoolEviction(t *testing.T) {
+	require := require.New(t)
+
+	decisionTxs, err := createTestDecisionTxs(3)
+	require.NoError(err)
+	txSize := len(decisionTxs[0].Bytes())
+
+	fees := testFeeCalculator{
+		decisionTxs[0].ID(): uint64(txSize),
+		decisionTxs[1].ID(): uint64(2 * txSize),
+		decisionTxs[2].ID(): uint64(txSize),
+	}
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, fees)
+	require.NoError(err)
+
+	require.NoError(mpool.Add(decisionTxs[0]))
+
+	// The mempool only has room for one tx.
+	mpool.(*mempool).bytesAvailable = txSize - 1
+
+	// A tx paying the same rate doesn't evict the lowest paying tx.
+	err = mpool.Add(decisionTxs[2])
+	require.True(errors.Is(err, errMempoolFull))
+	require.True(mpool.Has(decisionTxs[0].ID()))
+
+	// A tx paying a higher rate evicts the lowest paying tx.
+	require.NoError(mpool.Add(decisionTxs[1]))
+	require.True(mpool.Has(decisionTxs[1].ID()))
+	require.False(mpool.Has(decisionTxs[0].ID()))
+
+	reason, dropped := mpool.GetDropReason(decisionTxs[0].ID())
+	require.True(dropped)
+	require.Equal(evictedReason, reason)
+	require.Equal(txSize-1, mpool.(*mempool).bytesAvailable)
+}
+
+func TestMempoolChildTxs(t *testing.T) {
+	require := require.New(t)
+
+	decisionTxs, err := createTestDecisionTxs(4)
+	require.NoError(err)
+	txSize := len(decisionTxs[0].Bytes())
+
+	// [child] spends the output of [parent].
+	parent, other, evicter := decisionTxs[0], decisionTxs[1], decisionTxs[2]
+	childUtx := decisionTxs[3].Unsigned.(*txs.CreateChainTx)
+	childUtx.Ins[0].UTXOID = djtx.UTXOID{
+		TxID:        parent.ID(),
+		OutputIndex: 0,
+	}
+	child, err := txs.NewSigned(childUtx, txs.Codec, nil)
+	require.NoError(err)
+
+	fees := testFeeCalculator{
+		parent.ID():  0,
+		other.ID():   uint64(txSize),
+		child.ID():   uint64(10 * txSize),
+		evicter.ID(): uint64(2 * txSize),
+	}
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, fees)
+	require.NoError(err)
+
+	require.NoError(mpool.Add(parent))
+	require.NoError(mpool.Add(other))
+	require.NoError(mpool.Add(child))
+
+	// Although [child] pays the highest rate, it is only peeked after its
+	// parent.
+	require.Equal(
+		[]*txs.Tx{other, parent, child},
+		mpool.PeekTxs(math.MaxInt),
+	)
+	require.Equal(
+		[]*txs.Tx{other, parent},
+		mpool.PeekTxs(2*txSize),
+	)
+
+	// Once [parent] is issued, [child] is peeked first.
+	mpool.Remove([]*txs.Tx{parent})
+	require.Equal(
+		[]*txs.Tx{child, other},
+		mpool.PeekTxs(math.MaxInt),
+	)
+	require.NoError(mpool.Add(parent))
+
+	// Evicting [parent] evicts [child] with it.
+	mpool.(*mempool).bytesAvailable = txSize - 1
+	require.NoError(mpool.Add(evicter))
+	require.True(mpool.Has(evicter.ID()))
+	require.True(mpool.Has(other.ID()))
+	require.False(mpool.Has(parent.ID()))
+	require.False(mpool.Has(child.ID()))
+
+	reason, dropped := mpool.GetDropReason(parent.ID())
+	require.True(dropped)
+	require.Equal(evictedReason, reason)
+	reason, dropped = mpool.GetDropReason(child.ID())
+	require.True(dropped)
+	require.Equal(parentDroppedReason, reason)
+	require.Equal(2*txSize-1, mpool.(*mempool).bytesAvailable)
+}
+
+func TestRemoveExpiredTxs(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, &noopFeeCalculator{})
+	require.NoError(err)
+
+	decisionTxs, err := createTestDecisionTxs(3)
+	require.NoError(err)
+
+	now := time.Unix(1607133207, 0)
+	expiries := []time.Time{
+		now.Add(-time.Second),
+		now.Add(time.Second),
+		{}, // never expires
+	}
+	for i, tx := range decisionTxs {
+		if !expiries[i].IsZero() {
+			utx := tx.Unsigned.(*txs.CreateChainTx)
+			utx.Expiry = uint64(expiries[i].Unix())
+			decisionTxs[i], err = txs.NewSigned(utx, txs.Codec, nil)
+			require.NoError(err)
+		}
+		require.NoError(mpool.Add(decisionTxs[i]))
+	}
+
+	mpool.RemoveExpiredTxs(now)
+	require.False(mpool.Has(decisionTxs[0].ID()))
+	_, dropped := mpool.GetDropReason(decisionTxs[0].ID())
+	require.True(dropped)
+	require.True(mpool.Has(decisionTxs[1].ID()))
+	require.True(mpool.Has(decisionTxs[2].ID()))
+
+	mpool.RemoveExpiredTxs(now.Add(time.Hour))
+	require.False(mpool.Has(decisionTxs[1].ID()))
+	_, dropped = mpool.GetDropReason(decisionTxs[1].ID())
+	require.True(dropped)
+	require.True(mpool.Has(decisionTxs[2].ID()))
+}
@@ -9,6 +9,7 @@ package mempool
 
 import (
 	reflect "reflect"
+	time "time"
 
 	ids "github.com/lasthyphen/dijetsnodego/ids"
 	txs "github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
@@ -64,6 +65,20 @@ func (mr *MockMempoolMockRecorder) DisableAdding() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableAdding", reflect.TypeOf((*MockMempool)(nil).DisableAdding))
 }
 
+// DroppedTxs mocks base method.
+func (m *MockMempool) DroppedTxs() []DroppedTx {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DroppedTxs")
+	ret0, _ := ret[0].([]DroppedTx)
+	return ret0
+}
+
+// DroppedTxs indicates an expected call of DroppedTxs.
+func (mr *MockMempoolMockRecorder) DroppedTxs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DroppedTxs", reflect.TypeOf((*MockMempool)(nil).DroppedTxs))
+}
+
 // EnableAdding mocks base method.
 func (m *MockMempool) EnableAdding() {
 	m.ctrl.T.Helper()
@@ -76,6 +91,34 @@ func (mr *MockMempoolMockRecorder) EnableAdding() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableAdding", reflect.TypeOf((*MockMempool)(nil).EnableAdding))
 }
 
+// Entries mocks base method.
+func (m *MockMempool) Entries() []Entry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Entries")
+	ret0, _ := ret[0].([]Entry)
+	return ret0
+}
+
+// Entries indicates an expected call of Entries.
+func (mr *MockMempoolMockRecorder) Entries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Entries", reflect.TypeOf((*MockMempool)(nil).Entries))
+}
+
+// EstimatePriorityFeeRate mocks base method.
+func (m *MockMempool) EstimatePriorityFeeRate(arg0 int) uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimatePriorityFeeRate", arg0)
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// EstimatePriorityFeeRate indicates an expected call of EstimatePriorityFeeRate.
+func (mr *MockMempoolMockRecorder) EstimatePriorityFeeRate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimatePriorityFeeRate", reflect.TypeOf((*MockMempool)(nil).EstimatePriorityFeeRate), arg0)
+}
+
 // Get mocks base method.
 func (m *MockMempool) Get(arg0 ids.ID) *txs.Tx {
 	m.ctrl.T.Helper()
@@ -105,6 +148,21 @@ func (mr *MockMempoolMockRecorder) GetDropReason(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropReason", reflect.TypeOf((*MockMempool)(nil).GetDropReason), arg0)
 }
 
+// GetEntry mocks base method.
+func (m *MockMempool) GetEntry(arg0 ids.ID) (Entry, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", arg0)
+	ret0, _ := ret[0].(Entry)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockMempoolMockRecorder) GetEntry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockMempool)(nil).GetEntry), arg0)
+}
+
 // Has mocks base method.
 func (m *MockMempool) Has(arg0 ids.ID) bool {
 	m.ctrl.T.Helper()
@@ -198,3 +256,15 @@ func (mr *MockMempoolMockRecorder) Remove(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockMempool)(nil).Remove), arg0)
 }
+
+// RemoveExpiredTxs mocks base method.
+func (m *MockMempool) RemoveExpiredTxs(arg0 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveExpiredTxs", arg0)
+}
+
+// RemoveExpiredTxs indicates an expected call of RemoveExpiredTxs.
+func (mr *MockMempoolMockRecorder) RemoveExpiredTxs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExpiredTxs", reflect.TypeOf((*MockMempool)(nil).RemoveExpiredTxs), arg0)
+}
@@ -76,3 +76,23 @@ func (*remover) RewardValidatorTx(*txs.RewardValidatorTx) error {
 	// this tx is never in mempool
 	return nil
 }
+
+func (r *remover) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) ModifySubnetValidatorTx(*txs.ModifySubnetValidatorTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) HaltBlockchainTx(*txs.HaltBlockchainTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) UpgradeBlockchainTx(*txs.UpgradeBlockchainTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
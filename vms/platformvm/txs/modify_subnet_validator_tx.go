@@ -0,0 +1,66 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+)
+
+var (
+	_ UnsignedTx = (*ModifySubnetValidatorTx)(nil)
+
+	errModifyPrimaryNetworkValidator = errors.New("can't modify primary network validator with ModifySubnetValidatorTx")
+	errModifyToZeroWeight            = errors.New("can't modify the weight of a validator to 0")
+)
+
+// Modifies the weight, and optionally the end time, of a current validator of
+// a subnet without removing it from the validator set.
+type ModifySubnetValidatorTx struct {
+	BaseTx `serialize:"true"`
+	// The node to modify the validation of.
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// The subnet the node is validating.
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// The new weight of the validator.
+	Weight uint64 `serialize:"true" json:"weight"`
+	// The new Unix time the validator stops validating the subnet. If 0, the
+	// end time of the validator is unchanged.
+	EndTime uint64 `serialize:"true" json:"endTime"`
+	// Proves that the issuer has the right to modify the validators of the
+	// subnet.
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+func (tx *ModifySubnetValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errModifyPrimaryNetworkValidator
+	case tx.Weight == 0:
+		return errModifyToZeroWeight
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *ModifySubnetValidatorTx) Visit(visitor Visitor) error {
+	return visitor.ModifySubnetValidatorTx(tx)
+}
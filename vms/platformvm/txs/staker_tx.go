@@ -43,6 +43,18 @@ type Delegator interface {
 	RewardsOwner() fx.Owner
 }
 
+// AutoRestaker is implemented by the stakers that can opt in to being restaked
+// when their staking period ends.
+type AutoRestaker interface {
+	// MaxRestakes returns the number of times the staker is restaked for the
+	// same duration when it is rewarded. The stake is returned once the staker
+	// is rewarded after its last staking period.
+	MaxRestakes() uint32
+	// CompoundsRewards returns true if the rewards of the staker are added to
+	// its stake when it is restaked.
+	CompoundsRewards() bool
+}
+
 type PermissionlessStaker interface {
 	Staker
 
@@ -0,0 +1,191 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func TestTransferSubnetOwnershipTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name      string
+		txFunc    func(*gomock.Controller) *TransferSubnetOwnershipTx
+		shouldErr bool
+		// If [shouldErr] and [requireSpecificErr] != nil,
+		// require that the error we get is [requireSpecificErr].
+		requireSpecificErr error
+	}
+
+	var (
+		networkID            = uint32(1337)
+		chainID              = ids.GenerateTestID()
+		errInvalidSubnetAuth = errors.New("invalid subnet auth")
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that already passed syntactic verification.
+	verifiedBaseTx := BaseTx{
+		SyntacticallyVerified: true,
+	}
+	// Sanity check.
+	require.NoError(t, verifiedBaseTx.SyntacticVerify(ctx))
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: djtx.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+	// Sanity check.
+	require.NoError(t, validBaseTx.SyntacticVerify(ctx))
+	// Make sure we're not caching the verification result.
+	require.False(t, validBaseTx.SyntacticallyVerified)
+
+	// A BaseTx that fails syntactic verification.
+	invalidBaseTx := BaseTx{}
+	// Sanity check.
+	require.Error(t, invalidBaseTx.SyntacticVerify(ctx))
+
+	validOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+	// Sanity check.
+	require.NoError(t, validOwner.Verify())
+
+	// An owner that can never be satisfied.
+	invalidOwner := &secp256k1fx.OutputOwners{
+		Threshold: 2,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+	// Sanity check.
+	require.Error(t, invalidOwner.Verify())
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *TransferSubnetOwnershipTx {
+				return nil
+			},
+			shouldErr: true,
+		},
+		{
+			name: "already verified",
+			txFunc: func(*gomock.Controller) *TransferSubnetOwnershipTx {
+				return &TransferSubnetOwnershipTx{BaseTx: verifiedBaseTx}
+			},
+			shouldErr: false,
+		},
+		{
+			name: "invalid BaseTx",
+			txFunc: func(*gomock.Controller) *TransferSubnetOwnershipTx {
+				return &TransferSubnetOwnershipTx{
+					// Set subnetID so we don't error on that check.
+					Subnet: ids.GenerateTestID(),
+					BaseTx: invalidBaseTx,
+					Owner:  validOwner,
+				}
+			},
+			shouldErr: true,
+		},
+		{
+			name: "invalid subnetID",
+			txFunc: func(*gomock.Controller) *TransferSubnetOwnershipTx {
+				return &TransferSubnetOwnershipTx{
+					BaseTx: validBaseTx,
+					Subnet: constants.PrimaryNetworkID,
+					Owner:  validOwner,
+				}
+			},
+			shouldErr:          true,
+			requireSpecificErr: errTransferPrimaryNetworkOwnership,
+		},
+		{
+			name: "invalid subnetAuth",
+			txFunc: func(ctrl *gomock.Controller) *TransferSubnetOwnershipTx {
+				// This SubnetAuth fails verification.
+				invalidSubnetAuth := verify.NewMockVerifiable(ctrl)
+				invalidSubnetAuth.EXPECT().Verify().Return(errInvalidSubnetAuth)
+				return &TransferSubnetOwnershipTx{
+					// Set subnetID so we don't error on that check.
+					Subnet:     ids.GenerateTestID(),
+					BaseTx:     validBaseTx,
+					SubnetAuth: invalidSubnetAuth,
+					Owner:      validOwner,
+				}
+			},
+			shouldErr:          true,
+			requireSpecificErr: errInvalidSubnetAuth,
+		},
+		{
+			name: "invalid owner",
+			txFunc: func(ctrl *gomock.Controller) *TransferSubnetOwnershipTx {
+				// This SubnetAuth passes verification.
+				validSubnetAuth := verify.NewMockVerifiable(ctrl)
+				validSubnetAuth.EXPECT().Verify().Return(nil)
+				return &TransferSubnetOwnershipTx{
+					// Set subnetID so we don't error on that check.
+					Subnet:     ids.GenerateTestID(),
+					BaseTx:     validBaseTx,
+					SubnetAuth: validSubnetAuth,
+					Owner:      invalidOwner,
+				}
+			},
+			shouldErr: true,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *TransferSubnetOwnershipTx {
+				// This SubnetAuth passes verification.
+				validSubnetAuth := verify.NewMockVerifiable(ctrl)
+				validSubnetAuth.EXPECT().Verify().Return(nil)
+				return &TransferSubnetOwnershipTx{
+					// Set subnetID so we don't error on that check.
+					Subnet:     ids.GenerateTestID(),
+					BaseTx:     validBaseTx,
+					SubnetAuth: validSubnetAuth,
+					Owner:      validOwner,
+				}
+			},
+			shouldErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			if tt.shouldErr {
+				require.Error(err)
+				if tt.requireSpecificErr != nil {
+					require.ErrorIs(err, tt.requireSpecificErr)
+				}
+				return
+			}
+			require.NoError(err)
+			require.True(tx.SyntacticallyVerified)
+		})
+	}
+}
@@ -21,6 +21,7 @@ var (
 	ErrNilSignedTx = errors.New("nil signed tx is not valid")
 
 	errSignedTxNotInitialized = errors.New("signed tx was never initialized and is not valid")
+	errWrongCodecVersion      = errors.New("wrong codec version")
 )
 
 // Tx is a signed transaction
@@ -49,10 +50,20 @@ func NewSigned(
 //       P-Chain genesis txs whose length exceed the max length of txs.Codec.
 func Parse(c codec.Manager, signedBytes []byte) (*Tx, error) {
 	tx := &Tx{}
-	if _, err := c.Unmarshal(signedBytes, tx); err != nil {
+	version, err := c.Unmarshal(signedBytes, tx)
+	if err != nil {
 		return nil, fmt.Errorf("couldn't parse tx: %w", err)
 	}
-	unsignedBytes, err := c.Marshal(Version, &tx.Unsigned)
+	// Txs must be serialized with the only version that can represent them
+	// so that they have a unique ID.
+	if expectedVersion := CodecVersion(tx.Unsigned); version != expectedVersion {
+		return nil, fmt.Errorf("%w: expected %d but got %d",
+			errWrongCodecVersion,
+			expectedVersion,
+			version,
+		)
+	}
+	unsignedBytes, err := c.Marshal(version, &tx.Unsigned)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
 	}
@@ -107,7 +118,8 @@ func (tx *Tx) SyntacticVerify(ctx *snow.Context) error {
 // Note: We explicitly pass the codec in Sign since we may need to sign P-Chain
 //       genesis txs whose length exceed the max length of txs.Codec.
 func (tx *Tx) Sign(c codec.Manager, signers [][]*crypto.PrivateKeySECP256K1R) error {
-	unsignedBytes, err := c.Marshal(Version, &tx.Unsigned)
+	version := CodecVersion(tx.Unsigned)
+	unsignedBytes, err := c.Marshal(version, &tx.Unsigned)
 	if err != nil {
 		return fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
 	}
@@ -128,7 +140,7 @@ func (tx *Tx) Sign(c codec.Manager, signers [][]*crypto.PrivateKeySECP256K1R) er
 		tx.Creds = append(tx.Creds, cred) // Attach credential
 	}
 
-	signedBytes, err := c.Marshal(Version, tx)
+	signedBytes, err := c.Marshal(version, tx)
 	if err != nil {
 		return fmt.Errorf("couldn't marshal ProposalTx: %w", err)
 	}
@@ -0,0 +1,32 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txheap
+
+import (
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+var _ TimedHeap = (*byExpiry)(nil)
+
+type byExpiry struct {
+	txHeap
+}
+
+// NewByExpiry returns a heap whose top is the tx that expires first. Only txs
+// that specify an expiry should be added to it.
+func NewByExpiry() TimedHeap {
+	h := &byExpiry{}
+	h.initialize(h)
+	return h
+}
+
+func (h *byExpiry) Less(i, j int) bool {
+	return txs.ExpiryOf(h.txs[i].tx.Unsigned) < txs.ExpiryOf(h.txs[j].tx.Unsigned)
+}
+
+func (h *byExpiry) Timestamp() time.Time {
+	return time.Unix(int64(txs.ExpiryOf(h.Peek().Unsigned)), 0)
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txheap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func TestByExpiry(t *testing.T) {
+	require := require.New(t)
+
+	txHeap := NewByExpiry()
+
+	baseTime := time.Unix(time.Now().Unix(), 0)
+
+	newTx := func(expiry time.Time) *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+			BaseTx: txs.BaseTx{
+				BaseTx: djtx.BaseTx{},
+				Expiry: uint64(expiry.Unix()),
+			},
+			Owner: &secp256k1fx.OutputOwners{},
+		}}
+		require.NoError(tx.Sign(txs.Codec, nil))
+		return tx
+	}
+	tx0 := newTx(baseTime.Add(time.Second))
+	tx1 := newTx(baseTime.Add(2 * time.Second))
+	tx2 := newTx(baseTime.Add(3 * time.Second))
+
+	txHeap.Add(tx2)
+	require.Equal(baseTime.Add(3*time.Second), txHeap.Timestamp())
+
+	txHeap.Add(tx0)
+	require.Equal(baseTime.Add(time.Second), txHeap.Timestamp())
+
+	txHeap.Add(tx1)
+	require.Equal(tx0, txHeap.RemoveTop())
+	require.Equal(tx1, txHeap.RemoveTop())
+	require.Equal(tx2, txHeap.RemoveTop())
+}
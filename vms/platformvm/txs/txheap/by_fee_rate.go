@@ -0,0 +1,66 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txheap
+
+import (
+	"math/bits"
+
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+var _ Heap = (*byFeeRate)(nil)
+
+// FeeFunc returns the fee that [tx] pays.
+type FeeFunc func(tx *txs.Tx) uint64
+
+type byFeeRate struct {
+	txHeap
+
+	fee FeeFunc
+}
+
+// NewByFeeRate returns a heap whose top is the tx paying the lowest fee per
+// byte. Among txs paying the same rate, the most recently added is on top.
+func NewByFeeRate(fee FeeFunc) Heap {
+	h := &byFeeRate{
+		fee: fee,
+	}
+	h.initialize(h)
+	return h
+}
+
+func (h *byFeeRate) Less(i, j int) bool {
+	iTx := h.txs[i]
+	jTx := h.txs[j]
+	switch CompareFeeRates(
+		h.fee(iTx.tx),
+		len(iTx.tx.Bytes()),
+		h.fee(jTx.tx),
+		len(jTx.tx.Bytes()),
+	) {
+	case -1:
+		return true
+	case 1:
+		return false
+	default:
+		return iTx.age > jTx.age
+	}
+}
+
+// CompareFeeRates returns -1, 0 or 1 if [feeA] per [sizeA] bytes is lower
+// than, equal to or higher than [feeB] per [sizeB] bytes.
+func CompareFeeRates(feeA uint64, sizeA int, feeB uint64, sizeB int) int {
+	// feeA/sizeA < feeB/sizeB if feeA*sizeB < feeB*sizeA. The products are
+	// compared as 128 bit integers to avoid overflows.
+	aHi, aLo := bits.Mul64(feeA, uint64(sizeB))
+	bHi, bLo := bits.Mul64(feeB, uint64(sizeA))
+	switch {
+	case aHi < bHi, aHi == bHi && aLo < bLo:
+		return -1
+	case aHi > bHi, aHi == bHi && aLo > bLo:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txheap
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func TestByFeeRate(t *testing.T) {
+	require := require.New(t)
+
+	newTx := func() *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+			Owner: &secp256k1fx.OutputOwners{
+				Addrs: []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		}}
+		require.NoError(tx.Sign(txs.Codec, nil))
+		return tx
+	}
+	tx0 := newTx()
+	tx1 := newTx()
+	tx2 := newTx()
+
+	fees := map[ids.ID]uint64{
+		tx0.ID(): 100,
+		tx1.ID(): 50,
+		tx2.ID(): 100,
+	}
+	txHeap := NewByFeeRate(func(tx *txs.Tx) uint64 {
+		return fees[tx.ID()]
+	})
+
+	txHeap.Add(tx0)
+	require.Equal(tx0, txHeap.Peek())
+
+	txHeap.Add(tx1)
+	require.Equal(tx1, txHeap.Peek())
+
+	// Among txs paying the same rate, the most recently added is on top.
+	txHeap.Add(tx2)
+	require.Equal(tx1, txHeap.RemoveTop())
+	require.Equal(tx2, txHeap.RemoveTop())
+	require.Equal(tx0, txHeap.RemoveTop())
+}
+
+func TestCompareFeeRates(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(0, CompareFeeRates(100, 10, 200, 20))
+	require.Equal(-1, CompareFeeRates(100, 10, 201, 20))
+	require.Equal(1, CompareFeeRates(101, 10, 200, 20))
+	require.Equal(1, CompareFeeRates(math.MaxUint64, 2, math.MaxUint64, 3))
+	require.Equal(0, CompareFeeRates(0, 1, 0, 2))
+}
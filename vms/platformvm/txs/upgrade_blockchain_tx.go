@@ -0,0 +1,69 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+)
+
+var (
+	_ UnsignedTx = (*UpgradeBlockchainTx)(nil)
+
+	errUpgradePrimaryNetworkChain = errors.New("can't upgrade a blockchain of the primary network")
+	errMissingVMID                = errors.New("missing VM ID")
+)
+
+// UpgradeBlockchainTx is an unsigned upgradeBlockchainTx. It schedules the
+// blockchain to switch to a different VM once the chain time reaches
+// [ActivationTime].
+type UpgradeBlockchainTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet that validates the blockchain
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// ID of the blockchain to upgrade
+	BlockchainID ids.ID `serialize:"true" json:"blockchainID"`
+	// ID of the VM the blockchain runs once the upgrade is activated
+	VMID ids.ID `serialize:"true" json:"vmID"`
+	// Unix time the blockchain starts running [VMID]
+	ActivationTime uint64 `serialize:"true" json:"activationTime"`
+	// Proves that the issuer has the right to upgrade the blockchains of the
+	// subnet.
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+func (tx *UpgradeBlockchainTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errUpgradePrimaryNetworkChain
+	case tx.BlockchainID == ids.Empty:
+		return errMissingBlockchainID
+	case tx.VMID == ids.Empty:
+		return errMissingVMID
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *UpgradeBlockchainTx) Visit(visitor Visitor) error {
+	return visitor.UpgradeBlockchainTx(tx)
+}
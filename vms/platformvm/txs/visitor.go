@@ -18,4 +18,8 @@ type Visitor interface {
 	TransformSubnetTx(*TransformSubnetTx) error
 	AddPermissionlessValidatorTx(*AddPermissionlessValidatorTx) error
 	AddPermissionlessDelegatorTx(*AddPermissionlessDelegatorTx) error
+	TransferSubnetOwnershipTx(*TransferSubnetOwnershipTx) error
+	ModifySubnetValidatorTx(*ModifySubnetValidatorTx) error
+	HaltBlockchainTx(*HaltBlockchainTx) error
+	UpgradeBlockchainTx(*UpgradeBlockchainTx) error
 }
@@ -0,0 +1,178 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/api"
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
+	"github.com/lasthyphen/dijetsnodego/database/versiondb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+var (
+	utxoJournalPrefix = []byte("utxoJournal")
+
+	errUTXOJournalDisabled = errors.New("historical queries require the UTXO journal to be enabled")
+	errUTXOJournalBehind   = errors.New("UTXO journal doesn't include the last accepted block")
+	errHeightAndTimestamp  = errors.New("only one of height and timestamp can be specified")
+)
+
+// utxoJournal records the UTXOs consumed and produced by each accepted block
+// so that balances and UTXOs can be read as of a past height.
+//
+// Like the address index, blocks are journaled as they are accepted. When the
+// journal is enabled on an existing node, the retained heights are
+// backfilled.
+type utxoJournal struct {
+	*blockFollower
+
+	db      *versiondb.Database
+	journal djtx.UTXOJournal
+
+	// Chain time after the last journaled block.
+	timestamp time.Time
+}
+
+func newUTXOJournal(
+	db database.Database,
+	log logging.Logger,
+	lock sync.Locker,
+	utxos *acceptedUTXOs,
+	retention uint64,
+) (*utxoJournal, error) {
+	versionDB := versiondb.New(prefixdb.New(utxoJournalPrefix, db))
+	j := &utxoJournal{
+		db:      versionDB,
+		journal: djtx.NewUTXOJournal(versionDB, txs.Codec, retention),
+	}
+
+	var nextHeight uint64
+	lastHeight, err := j.journal.LastHeight()
+	switch {
+	case err == database.ErrNotFound:
+		acceptedHeight, err := utxos.lastAcceptedHeight()
+		if err != nil {
+			return nil, err
+		}
+		if retention > 0 && acceptedHeight >= retention {
+			nextHeight = acceptedHeight - retention + 1
+		}
+		j.timestamp = time.Unix(int64(utxos.genesis.Timestamp), 0)
+	case err != nil:
+		return nil, err
+	default:
+		nextHeight = lastHeight + 1
+		j.timestamp, err = j.journal.Timestamp(lastHeight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	j.blockFollower = &blockFollower{
+		name:       "UTXO journal",
+		log:        log,
+		lock:       lock,
+		utxos:      utxos,
+		process:    j.record,
+		nextHeight: nextHeight,
+	}
+	return j, nil
+}
+
+// record journals the UTXOs consumed and produced by [blk].
+func (j *utxoJournal) record(blk blocks.Block) error {
+	appliedTxs, err := j.utxos.appliedTxs(blk)
+	if err != nil {
+		return err
+	}
+
+	var consumed, produced []*djtx.UTXO
+	if blk.Height() == 0 {
+		// The genesis txs don't produce the genesis UTXOs.
+		produced = j.utxos.genesis.UTXOs
+	} else {
+		for _, tx := range appliedTxs {
+			txConsumed, txProduced, err := j.utxos.txUTXOs(tx)
+			if err != nil {
+				return fmt.Errorf("couldn't journal tx %s: %w", tx.ID(), err)
+			}
+			consumed = append(consumed, txConsumed...)
+			produced = append(produced, txProduced...)
+		}
+	}
+
+	timestamp := j.utxos.timestamp(blk, appliedTxs, j.timestamp)
+	if err := j.journal.Record(blk.Height(), timestamp, consumed, produced); err != nil {
+		return err
+	}
+	if err := j.db.Commit(); err != nil {
+		return err
+	}
+	j.timestamp = timestamp
+	return nil
+}
+
+// getUTXOReader returns the UTXOs of [addrs] as of the point requested by
+// [args], and the time that locktimes should be compared against.
+func (vm *VM) getUTXOReader(addrs set.Set[ids.ShortID], args *api.HistoricalArgs) (djtx.UTXOReader, time.Time, error) {
+	if args.Height == nil && args.Timestamp == nil {
+		return vm.state, vm.clock.Time(), nil
+	}
+	if args.Height != nil && args.Timestamp != nil {
+		return nil, time.Time{}, errHeightAndTimestamp
+	}
+	if vm.utxoJournal == nil {
+		return nil, time.Time{}, errUTXOJournalDisabled
+	}
+
+	if err := vm.utxoJournal.backfillErr; err != nil {
+		return nil, time.Time{}, fmt.Errorf("couldn't backfill the UTXO journal: %w", err)
+	}
+
+	// The current UTXO set must be rewound from the last journaled height.
+	journal := vm.utxoJournal.journal
+	lastHeight, err := journal.LastHeight()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	acceptedHeight, err := vm.utxoJournal.utxos.lastAcceptedHeight()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if lastHeight != acceptedHeight {
+		return nil, time.Time{}, errUTXOJournalBehind
+	}
+
+	var (
+		height uint64
+		now    time.Time
+	)
+	if args.Timestamp != nil {
+		now = time.Unix(int64(*args.Timestamp), 0)
+		height, err = journal.HeightAt(now)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+	} else {
+		height = uint64(*args.Height)
+		now, err = journal.Timestamp(height)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+	}
+
+	utxos, err := journal.Rewind(vm.state, addrs, height)
+	return utxos, now, err
+}
@@ -0,0 +1,251 @@
+// Copyright (C) 2022-2023, Dijets Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/pubsub"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/utils/formatting"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
+	"github.com/lasthyphen/dijetsnodego/utils/math"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
+
+	blockexecutor "github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks/executor"
+)
+
+var (
+	_ pubsub.Filterer              = (*validatorSetChanges)(nil)
+	_ blockexecutor.AcceptListener = (*validatorSetChangesPublisher)(nil)
+)
+
+// validatorChange is the change of the weight of a validator made by a block.
+// A validator was added if [oldWeight] is 0 and removed if [newWeight] is 0.
+type validatorChange struct {
+	nodeID    ids.NodeID
+	publicKey *bls.PublicKey
+	oldWeight uint64
+	newWeight uint64
+}
+
+// validatorSetChanges are the changes made to the validator set of a subnet by
+// the block at [height].
+type validatorSetChanges struct {
+	height   uint64
+	subnetID ids.ID
+	changes  []*validatorChange
+}
+
+// Filter matches the connections that are subscribed to either the subnet or
+// one of the changed validators. Connections subscribe to them with the addIDs
+// command, for example {"addIDs":{"ids":["<subnetID>","NodeID-<nodeID>"]}}.
+func (c *validatorSetChanges) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
+	resp := make([]bool, len(filters))
+	for i, filter := range filters {
+		if filter.Check(c.subnetID[:]) {
+			resp[i] = true
+			continue
+		}
+		for _, change := range c.changes {
+			if filter.Check(change.nodeID[:]) {
+				resp[i] = true
+				break
+			}
+		}
+	}
+	return resp, c.toAPI()
+}
+
+func (c *validatorSetChanges) toAPI() APIValidatorSetChanges {
+	apiChanges := APIValidatorSetChanges{
+		Height:   json.Uint64(c.height),
+		SubnetID: c.subnetID,
+		Changes:  make([]APIValidatorChange, len(c.changes)),
+	}
+	for i, change := range c.changes {
+		apiChange := APIValidatorChange{
+			NodeID:    change.nodeID,
+			OldWeight: json.Uint64(change.oldWeight),
+			NewWeight: json.Uint64(change.newWeight),
+		}
+		if change.publicKey != nil {
+			// Hex encoding can't fail.
+			publicKey, _ := formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(change.publicKey))
+			apiChange.PublicKey = &publicKey
+		}
+		apiChanges.Changes[i] = apiChange
+	}
+	return apiChanges
+}
+
+// getValidatorSetChanges returns the changes made to the validator set of
+// [subnetID] by each block in [startHeight, endHeight], in increasing height
+// order. Heights that didn't change the validator set are omitted.
+func (vm *VM) getValidatorSetChanges(
+	ctx context.Context,
+	subnetID ids.ID,
+	startHeight uint64,
+	endHeight uint64,
+) ([]*validatorSetChanges, error) {
+	endSet, err := vm.GetValidatorSet(ctx, endHeight, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	// Subnet validators are identified by the public keys of their primary
+	// network validators.
+	primaryEndSet := endSet
+	if subnetID != constants.PrimaryNetworkID {
+		primaryEndSet, err = vm.GetValidatorSet(ctx, endHeight, constants.PrimaryNetworkID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The returned sets may be cached, so they must not be modified.
+	weights := make(map[ids.NodeID]uint64, len(endSet))
+	for nodeID, vdr := range endSet {
+		weights[nodeID] = vdr.Weight
+	}
+	publicKeys := make(map[ids.NodeID]*bls.PublicKey, len(primaryEndSet))
+	for nodeID, vdr := range primaryEndSet {
+		if vdr.PublicKey != nil {
+			publicKeys[nodeID] = vdr.PublicKey
+		}
+	}
+
+	// Walk the diffs backwards from [endHeight] so that [weights] always holds
+	// the validator set after the block at [height].
+	var setChanges []*validatorSetChanges
+	for height := endHeight; ; height-- {
+		weightDiffs, err := vm.state.GetValidatorWeightDiffs(height, subnetID)
+		if err != nil {
+			return nil, err
+		}
+		pkDiffs, err := vm.state.GetValidatorPublicKeyDiffs(height)
+		if err != nil {
+			return nil, err
+		}
+
+		changes := make([]*validatorChange, 0, len(weightDiffs))
+		for nodeID, weightDiff := range weightDiffs {
+			newWeight := weights[nodeID]
+			var oldWeight uint64
+			if weightDiff.Decrease {
+				oldWeight, err = math.Add64(newWeight, weightDiff.Amount)
+			} else {
+				oldWeight, err = math.Sub(newWeight, weightDiff.Amount)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("couldn't apply weight diff of %s at height %d: %w", nodeID, height, err)
+			}
+
+			if oldWeight == 0 {
+				delete(weights, nodeID)
+			} else {
+				weights[nodeID] = oldWeight
+			}
+			if oldWeight == newWeight {
+				continue
+			}
+
+			publicKey, ok := publicKeys[nodeID]
+			if !ok {
+				// The public key of a validator that was removed by this
+				// block is only recorded in the diff of this block.
+				publicKey = pkDiffs[nodeID]
+			}
+			changes = append(changes, &validatorChange{
+				nodeID:    nodeID,
+				publicKey: publicKey,
+				oldWeight: oldWeight,
+				newWeight: newWeight,
+			})
+		}
+		for nodeID, pk := range pkDiffs {
+			publicKeys[nodeID] = pk
+		}
+
+		if len(changes) > 0 {
+			slices.SortFunc(changes, func(a, b *validatorChange) bool {
+				return a.nodeID.Less(b.nodeID)
+			})
+			setChanges = append(setChanges, &validatorSetChanges{
+				height:   height,
+				subnetID: subnetID,
+				changes:  changes,
+			})
+		}
+
+		if height == startHeight {
+			break
+		}
+	}
+
+	// Return the changes in increasing height order.
+	for i, j := 0, len(setChanges)-1; i < j; i, j = i+1, j-1 {
+		setChanges[i], setChanges[j] = setChanges[j], setChanges[i]
+	}
+	return setChanges, nil
+}
+
+// validatorSetChangesPublisher publishes the validator set changes made by the
+// accepted blocks.
+type validatorSetChangesPublisher struct {
+	vm *VM
+}
+
+func (p *validatorSetChangesPublisher) Accepted(blk blocks.Block) error {
+	// Failing to notify the subscribers must not stop the chain.
+	if err := p.vm.publishValidatorSetChanges(context.Background(), blk.Height()); err != nil {
+		p.vm.ctx.Log.Warn("failed to publish validator set changes",
+			zap.Uint64("height", blk.Height()),
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
+// publishValidatorSetChanges notifies the subscribers of the validator set
+// changes made by the blocks accepted since the last notification, up to
+// [height].
+func (vm *VM) publishValidatorSetChanges(ctx context.Context, height uint64) error {
+	if !vm.bootstrapped.GetValue() {
+		return nil
+	}
+	if height <= vm.publishedHeight {
+		return nil
+	}
+	// The changes are only computed if someone is notified of them, so that
+	// accepting blocks isn't slowed down otherwise.
+	if vm.pubsub.NumSubscribers() == 0 {
+		vm.publishedHeight = height
+		return nil
+	}
+
+	subnetIDs := append([]ids.ID{constants.PrimaryNetworkID}, vm.WhitelistedSubnets.List()...)
+	for _, subnetID := range subnetIDs {
+		setChanges, err := vm.getValidatorSetChanges(ctx, subnetID, vm.publishedHeight+1, height)
+		if err != nil {
+			return fmt.Errorf("couldn't get validator set changes of %s: %w", subnetID, err)
+		}
+		for _, changes := range setChanges {
+			vm.pubsub.Publish(changes)
+		}
+	}
+
+	vm.ctx.Log.Verbo("published validator set changes",
+		zap.Uint64("startHeight", vm.publishedHeight+1),
+		zap.Uint64("endHeight", height),
+	)
+	vm.publishedHeight = height
+	return nil
+}
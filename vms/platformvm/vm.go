@@ -21,6 +21,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/database"
 	"github.com/lasthyphen/dijetsnodego/database/manager"
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/pubsub"
 	"github.com/lasthyphen/dijetsnodego/snow"
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowman"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
@@ -39,6 +40,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/api"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/config"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/metrics"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
@@ -103,9 +105,29 @@ type VM struct {
 	// sliding window of blocks that were recently accepted
 	recentlyAccepted window.Window[ids.ID]
 
+	// upgradingChains maps the IDs of the blockchains whose VM upgrade hasn't
+	// activated yet to the ID of the VM they run until it activates.
+	upgradingChains map[ids.ID]ids.ID
+
 	txBuilder         txbuilder.Builder
 	txExecutorBackend *txexecutor.Backend
 	manager           blockexecutor.Manager
+
+	// addressTxsIndex is nil if the index isn't enabled in the chain config.
+	addressTxsIndex *addressTxsIndex
+	// utxoJournal is nil if the journal isn't enabled in the chain config.
+	utxoJournal *utxoJournal
+	// stateProofRetention is the number of accepted heights whose state can
+	// be proven. If [stateProofArchival] is true, the state of every height
+	// can be proven.
+	stateProofRetention uint64
+	stateProofArchival  bool
+
+	// pubsub notifies websocket subscribers of validator set changes.
+	pubsub *pubsub.Server
+	// publishedHeight is the height of the last block whose validator set
+	// changes were published.
+	publishedHeight uint64
 }
 
 // Initialize this blockchain.
@@ -116,20 +138,29 @@ func (vm *VM) Initialize(
 	dbManager manager.Manager,
 	genesisBytes []byte,
 	_ []byte,
-	_ []byte,
+	configBytes []byte,
 	toEngine chan<- common.Message,
 	_ []*common.Fx,
 	appSender common.AppSender,
 ) error {
 	chainCtx.Log.Verbo("initializing platform chain")
 
+	execConfig, err := config.GetExecutionConfig(configBytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse chain config: %w", err)
+	}
+	if len(configBytes) > 0 {
+		chainCtx.Log.Info("VM config initialized",
+			zap.Reflect("config", execConfig),
+		)
+	}
+
 	registerer := prometheus.NewRegistry()
 	if err := chainCtx.Metrics.Register(registerer); err != nil {
 		return err
 	}
 
 	// Initialize metrics as soon as possible
-	var err error
 	vm.metrics, err = metrics.New("", registerer, vm.WhitelistedSubnets)
 	if err != nil {
 		return fmt.Errorf("failed to initialize metrics: %w", err)
@@ -137,6 +168,7 @@ func (vm *VM) Initialize(
 
 	vm.ctx = chainCtx
 	vm.dbManager = dbManager
+	vm.pubsub = pubsub.New(chainCtx.Log)
 
 	vm.codecRegistry = linearcodec.NewDefault()
 	vm.fx = &secp256k1fx.Fx{}
@@ -159,6 +191,7 @@ func (vm *VM) Initialize(
 		genesisBytes,
 		registerer,
 		&vm.Config,
+		execConfig,
 		vm.ctx,
 		vm.metrics,
 		rewards,
@@ -167,6 +200,44 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	vm.stateProofRetention = execConfig.StateProofRetention
+	vm.stateProofArchival = execConfig.StateProofArchival
+
+	if execConfig.IndexTransactions || execConfig.UTXOJournalEnabled {
+		acceptedUTXOs, err := newAcceptedUTXOs(vm.ctx.Log, vm.state, genesisBytes)
+		if err != nil {
+			return err
+		}
+		if execConfig.IndexTransactions {
+			vm.ctx.Log.Info("address transaction indexing is enabled")
+			vm.addressTxsIndex, err = newAddressTxsIndex(
+				vm.dbManager.Current().Database,
+				vm.ctx.Log,
+				&vm.ctx.Lock,
+				registerer,
+				acceptedUTXOs,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to initialize address transaction index: %w", err)
+			}
+		}
+		if execConfig.UTXOJournalEnabled {
+			vm.ctx.Log.Info("UTXO journal is enabled",
+				zap.Uint64("retention", execConfig.UTXOJournalRetention),
+			)
+			vm.utxoJournal, err = newUTXOJournal(
+				vm.dbManager.Current().Database,
+				vm.ctx.Log,
+				&vm.ctx.Lock,
+				acceptedUTXOs,
+				execConfig.UTXOJournalRetention,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to initialize UTXO journal: %w", err)
+			}
+		}
+	}
+
 	vm.atomicUtxosManager = djtx.NewAtomicUTXOManager(chainCtx.SharedMemory, txs.Codec)
 	utxoHandler := utxo.NewHandler(vm.ctx, &vm.clock, vm.state, vm.fx)
 	vm.uptimeManager = uptime.NewManager(vm.state)
@@ -195,17 +266,28 @@ func (vm *VM) Initialize(
 
 	// Note: There is a circular dependency between the mempool and block
 	//       builder which is broken by passing in the vm.
-	mempool, err := mempool.NewMempool("mempool", registerer, vm)
+	mempool, err := mempool.NewMempool("mempool", registerer, vm, vm)
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
 
+	acceptListeners := []blockexecutor.AcceptListener{
+		&validatorSetChangesPublisher{vm: vm},
+		&chainUpgrader{vm: vm},
+	}
+	if vm.addressTxsIndex != nil {
+		acceptListeners = append(acceptListeners, vm.addressTxsIndex)
+	}
+	if vm.utxoJournal != nil {
+		acceptListeners = append(acceptListeners, vm.utxoJournal)
+	}
 	vm.manager = blockexecutor.NewManager(
 		mempool,
 		vm.metrics,
 		vm.state,
 		vm.txExecutorBackend,
 		vm.recentlyAccepted,
+		acceptListeners...,
 	)
 	vm.Builder = blockbuilder.New(
 		mempool,
@@ -224,6 +306,13 @@ func (vm *VM) Initialize(
 		)
 	}
 
+	if vm.addressTxsIndex != nil {
+		vm.addressTxsIndex.startBackfill()
+	}
+	if vm.utxoJournal != nil {
+		vm.utxoJournal.startBackfill()
+	}
+
 	lastAcceptedID := vm.state.GetLastAccepted()
 	chainCtx.Log.Info("initializing last accepted",
 		zap.Stringer("blkID", lastAcceptedID),
@@ -233,6 +322,7 @@ func (vm *VM) Initialize(
 
 // Create all chains that exist that this node validates.
 func (vm *VM) initBlockchains() error {
+	vm.upgradingChains = make(map[ids.ID]ids.ID)
 	if err := vm.createSubnet(constants.PrimaryNetworkID); err != nil {
 		return err
 	}
@@ -268,7 +358,25 @@ func (vm *VM) createSubnet(subnetID ids.ID) error {
 		if !ok {
 			return fmt.Errorf("expected tx type *txs.CreateChainTx but got %T", chain.Unsigned)
 		}
-		vm.Config.CreateChain(chain.ID(), tx)
+
+		chainID := chain.ID()
+		metadata, err := vm.state.GetChainMetadata(chainID)
+		if err != nil {
+			return err
+		}
+		if metadata.Halted {
+			// Halted chains are never created again.
+			continue
+		}
+		// If the chain's VM was upgraded, run the VM that is active as of the
+		// last accepted chain time. The chain is restarted once an upgrade
+		// that activates later does.
+		timestamp := vm.state.GetTimestamp()
+		vmID := metadata.VMIDAt(timestamp)
+		if metadata.UpgradePending(timestamp) {
+			vm.upgradingChains[chainID] = vmID
+		}
+		vm.Config.CreateChain(chainID, tx, vmID)
 	}
 	return nil
 }
@@ -280,7 +388,7 @@ func (vm *VM) onBootstrapStarted() error {
 }
 
 // onNormalOperationsStarted marks this VM as bootstrapped
-func (vm *VM) onNormalOperationsStarted() error {
+func (vm *VM) onNormalOperationsStarted(ctx context.Context) error {
 	if vm.bootstrapped.GetValue() {
 		return nil
 	}
@@ -312,17 +420,24 @@ func (vm *VM) onNormalOperationsStarted() error {
 		return err
 	}
 
+	// Only the validator set changes made after bootstrapping are published.
+	height, err := vm.GetCurrentHeight(ctx)
+	if err != nil {
+		return err
+	}
+	vm.publishedHeight = height
+
 	// Start the block builder
 	vm.Builder.ResetBlockTimer()
 	return nil
 }
 
-func (vm *VM) SetState(_ context.Context, state snow.State) error {
+func (vm *VM) SetState(ctx context.Context, state snow.State) error {
 	switch state {
 	case snow.Bootstrapping:
 		return vm.onBootstrapStarted()
 	case snow.NormalOp:
-		return vm.onNormalOperationsStarted()
+		return vm.onNormalOperationsStarted(ctx)
 	default:
 		return snow.ErrUnknownState
 	}
@@ -335,6 +450,12 @@ func (vm *VM) Shutdown(context.Context) error {
 	}
 
 	vm.Builder.Shutdown()
+	if vm.addressTxsIndex != nil {
+		vm.addressTxsIndex.shutdown()
+	}
+	if vm.utxoJournal != nil {
+		vm.utxoJournal.shutdown()
+	}
 
 	if vm.bootstrapped.GetValue() {
 		primaryVdrIDs, exists := vm.getValidatorIDs(constants.PrimaryNetworkID)
@@ -435,6 +556,10 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, e
 		"": {
 			Handler: server,
 		},
+		"/events": {
+			LockOptions: common.NoLock,
+			Handler:     vm.pubsub,
+		},
 	}, nil
 }
 
@@ -25,6 +25,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto"
+	"github.com/lasthyphen/dijetsnodego/utils/timer/mockable"
 	"github.com/lasthyphen/dijetsnodego/version"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
@@ -366,6 +367,7 @@ func TestUnverifiedParentPanicRegression(t *testing.T) {
 			MaxStakeDuration:       defaultMaxStakingDuration,
 			RewardConfig:           defaultRewardConfig,
 			BanffTime:              banffForkTime,
+			StateRootTime:          mockable.MaxTime,
 		},
 	}}
 
@@ -681,6 +683,7 @@ func TestRejectedStateRegressionInvalidValidatorTimestamp(t *testing.T) {
 		nil,
 		prometheus.NewRegistry(),
 		&vm.Config,
+		&config.ExecutionConfig{},
 		vm.ctx,
 		metrics.Noop,
 		reward.NewCalculator(vm.Config.RewardConfig),
@@ -998,6 +1001,7 @@ func TestRejectedStateRegressionInvalidValidatorReward(t *testing.T) {
 		nil,
 		prometheus.NewRegistry(),
 		&vm.Config,
+		&config.ExecutionConfig{},
 		vm.ctx,
 		metrics.Noop,
 		reward.NewCalculator(vm.Config.RewardConfig),
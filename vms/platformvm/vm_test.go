@@ -344,6 +344,7 @@ func defaultVM() (*VM, database.Database, *mutableSharedMemory) {
 			ApricotPhase3Time:      defaultValidateEndTime,
 			ApricotPhase5Time:      defaultValidateEndTime,
 			BanffTime:              banffForkTime,
+			StateRootTime:          mockable.MaxTime,
 		},
 	}}
 
@@ -442,6 +443,7 @@ func GenesisVMWithArgs(t *testing.T, args *api.BuildGenesisArgs) ([]byte, chan c
 			MaxStakeDuration:       defaultMaxStakingDuration,
 			RewardConfig:           defaultRewardConfig,
 			BanffTime:              banffForkTime,
+			StateRootTime:          mockable.MaxTime,
 		},
 	}}
 
@@ -1531,6 +1533,7 @@ func TestRestartFullyAccepted(t *testing.T) {
 			MaxStakeDuration:       defaultMaxStakingDuration,
 			RewardConfig:           defaultRewardConfig,
 			BanffTime:              banffForkTime,
+			StateRootTime:          mockable.MaxTime,
 		},
 	}}
 
@@ -1622,6 +1625,7 @@ func TestRestartFullyAccepted(t *testing.T) {
 			MaxStakeDuration:       defaultMaxStakingDuration,
 			RewardConfig:           defaultRewardConfig,
 			BanffTime:              banffForkTime,
+			StateRootTime:          mockable.MaxTime,
 		},
 	}}
 
@@ -1681,6 +1685,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 			MaxStakeDuration:       defaultMaxStakingDuration,
 			RewardConfig:           defaultRewardConfig,
 			BanffTime:              banffForkTime,
+			StateRootTime:          mockable.MaxTime,
 		},
 	}}
 
@@ -2001,6 +2006,7 @@ func TestUnverifiedParent(t *testing.T) {
 			MaxStakeDuration:       defaultMaxStakingDuration,
 			RewardConfig:           defaultRewardConfig,
 			BanffTime:              banffForkTime,
+			StateRootTime:          mockable.MaxTime,
 		},
 	}}
 
@@ -2167,6 +2173,7 @@ func TestUptimeDisallowedWithRestart(t *testing.T) {
 			Validators:             firstVdrs,
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
 			BanffTime:              banffForkTime,
+			StateRootTime:          mockable.MaxTime,
 		},
 	}}
 
@@ -2211,6 +2218,7 @@ func TestUptimeDisallowedWithRestart(t *testing.T) {
 			Validators:             secondVdrs,
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
 			BanffTime:              banffForkTime,
+			StateRootTime:          mockable.MaxTime,
 		},
 	}}
 
@@ -2350,6 +2358,7 @@ func TestUptimeDisallowedAfterNeverConnecting(t *testing.T) {
 			Validators:             vdrs,
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
 			BanffTime:              banffForkTime,
+			StateRootTime:          mockable.MaxTime,
 		},
 	}}
 
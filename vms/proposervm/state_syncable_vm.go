@@ -159,3 +159,13 @@ func (vm *VM) buildStateSummary(ctx context.Context, innerSummary block.StateSum
 		vm:           vm,
 	}, nil
 }
+
+// StateSyncProgress reports the progress of the inner VM, as the progress of
+// the proposer state sync is only tracked by the summary.
+func (vm *VM) StateSyncProgress(ctx context.Context) ([]byte, error) {
+	progressVM, ok := vm.ChainVM.(block.StateSyncProgressVM)
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return progressVM.StateSyncProgress(ctx)
+}
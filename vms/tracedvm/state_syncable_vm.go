@@ -10,6 +10,7 @@ import (
 
 	oteltrace "go.opentelemetry.io/otel/trace"
 
+	"github.com/lasthyphen/dijetsnodego/database"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/snowman/block"
 )
 
@@ -71,3 +72,15 @@ func (vm *blockVM) GetStateSummary(ctx context.Context, height uint64) (block.St
 
 	return vm.ssVM.GetStateSummary(ctx, height)
 }
+
+func (vm *blockVM) StateSyncProgress(ctx context.Context) ([]byte, error) {
+	progressVM, ok := vm.ChainVM.(block.StateSyncProgressVM)
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+
+	ctx, span := vm.tracer.Start(ctx, "blockVM.StateSyncProgress")
+	defer span.End()
+
+	return progressVM.StateSyncProgress(ctx)
+}
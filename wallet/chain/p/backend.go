@@ -13,6 +13,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 )
 
@@ -42,13 +43,16 @@ type backend struct {
 	txsLock sync.RWMutex
 	// txID -> tx
 	txs map[ids.ID]*txs.Tx
+	// subnetID -> owner set by an accepted TransferSubnetOwnershipTx
+	subnetOwners map[ids.ID]fx.Owner
 }
 
 func NewBackend(ctx Context, utxos ChainUTXOs, txs map[ids.ID]*txs.Tx) Backend {
 	return &backend{
-		Context:    ctx,
-		ChainUTXOs: utxos,
-		txs:        txs,
+		Context:      ctx,
+		ChainUTXOs:   utxos,
+		txs:          txs,
+		subnetOwners: make(map[ids.ID]fx.Owner),
 	}
 }
 
@@ -104,3 +108,33 @@ func (b *backend) GetTx(_ stdcontext.Context, txID ids.ID) (*txs.Tx, error) {
 	}
 	return tx, nil
 }
+
+// GetSubnetOwner returns the current owner of [subnetID]. If the wallet has
+// accepted a TransferSubnetOwnershipTx for the subnet, the most recently
+// accepted owner is returned. Otherwise, the owner specified in the subnet's
+// CreateSubnetTx is returned.
+func (b *backend) GetSubnetOwner(_ stdcontext.Context, subnetID ids.ID) (fx.Owner, error) {
+	b.txsLock.RLock()
+	defer b.txsLock.RUnlock()
+
+	if owner, exists := b.subnetOwners[subnetID]; exists {
+		return owner, nil
+	}
+
+	subnetTx, exists := b.txs[subnetID]
+	if !exists {
+		return nil, database.ErrNotFound
+	}
+	subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
+	if !ok {
+		return nil, errWrongTxType
+	}
+	return subnet.Owner, nil
+}
+
+func (b *backend) setSubnetOwner(subnetID ids.ID, owner fx.Owner) {
+	b.txsLock.Lock()
+	defer b.txsLock.Unlock()
+
+	b.subnetOwners[subnetID] = owner
+}
@@ -53,6 +53,18 @@ func (b *backendVisitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx
 	return b.baseTx(&tx.BaseTx)
 }
 
+func (b *backendVisitor) ModifySubnetValidatorTx(tx *txs.ModifySubnetValidatorTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) HaltBlockchainTx(tx *txs.HaltBlockchainTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) UpgradeBlockchainTx(tx *txs.UpgradeBlockchainTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
 func (b *backendVisitor) ImportTx(tx *txs.ImportTx) error {
 	err := b.b.removeUTXOs(
 		b.ctx,
@@ -98,6 +110,11 @@ func (b *backendVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessD
 	return b.baseTx(&tx.BaseTx)
 }
 
+func (b *backendVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	b.b.setSubnetOwner(tx.Subnet, tx.Owner)
+	return b.baseTx(&tx.BaseTx)
+}
+
 func (b *backendVisitor) baseTx(tx *txs.BaseTx) error {
 	return b.b.removeUTXOs(
 		b.ctx,
@@ -16,6 +16,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/math"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/signer"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/stakeable"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
@@ -63,6 +64,18 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.CreateSubnetTx, error)
 
+	// NewTransferSubnetOwnershipTx replaces the owner of [subnetID] with
+	// [owner]. The tx must be authorized by the current owner of the subnet.
+	//
+	// - [subnetID] specifies the subnet to be modified.
+	// - [owner] specifies who will have the ability to create new chains and
+	//   add new validators to the subnet.
+	NewTransferSubnetOwnershipTx(
+		subnetID ids.ID,
+		owner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.TransferSubnetOwnershipTx, error)
+
 	// NewAddValidatorTx creates a new validator of the primary network.
 	//
 	// - [vdr] specifies all the details of the validation period such as the
@@ -96,6 +109,20 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.RemoveSubnetValidatorTx, error)
 
+	// NewModifySubnetValidatorTx modifies the validation of [nodeID] on
+	// [subnetID] without removing it from the validator set.
+	//
+	// - [weight] specifies the new sampling weight of the validator.
+	// - [endTime] specifies the new unix time the validator stops validating
+	//   the subnet. If 0, the end time of the validator is unchanged.
+	NewModifySubnetValidatorTx(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		weight uint64,
+		endTime uint64,
+		options ...common.Option,
+	) (*txs.ModifySubnetValidatorTx, error)
+
 	// NewAddDelegatorTx creates a new delegator to a validator on the primary
 	// network.
 	//
@@ -126,6 +153,28 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.CreateChainTx, error)
 
+	// NewHaltBlockchainTx halts the chain [chainID] of [subnetID]. Once
+	// halted, nodes stop running the chain and never create it again.
+	NewHaltBlockchainTx(
+		subnetID ids.ID,
+		chainID ids.ID,
+		options ...common.Option,
+	) (*txs.HaltBlockchainTx, error)
+
+	// NewUpgradeBlockchainTx schedules the chain [chainID] of [subnetID] to
+	// switch to a different VM.
+	//
+	// - [vmID] specifies the vm that the chain will run after the upgrade.
+	// - [activationTime] specifies the unix time the chain starts running
+	//   [vmID].
+	NewUpgradeBlockchainTx(
+		subnetID ids.ID,
+		chainID ids.ID,
+		vmID ids.ID,
+		activationTime uint64,
+		options ...common.Option,
+	) (*txs.UpgradeBlockchainTx, error)
+
 	// NewCreateSubnetTx creates a new subnet with the specified owner.
 	//
 	// - [owner] specifies who has the ability to create new chains and add new
@@ -252,6 +301,7 @@ type BuilderBackend interface {
 	Context
 	UTXOs(ctx stdcontext.Context, sourceChainID ids.ID) ([]*djtx.UTXO, error)
 	GetTx(ctx stdcontext.Context, txID ids.ID) (*txs.Tx, error)
+	GetSubnetOwner(ctx stdcontext.Context, subnetID ids.ID) (fx.Owner, error)
 }
 
 type builder struct {
@@ -313,13 +363,16 @@ func (b *builder) NewBaseTx(
 	djtx.SortTransferableOutputs(outputs, txs.Codec) // sort the outputs
 
 	return &txs.CreateSubnetTx{
-		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
-			NetworkID:    b.backend.NetworkID(),
-			BlockchainID: constants.PlatformChainID,
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
 		Owner: &secp256k1fx.OutputOwners{},
 	}, nil
 }
@@ -345,13 +398,16 @@ func (b *builder) NewAddValidatorTx(
 
 	utils.Sort(rewardsOwner.Addrs)
 	return &txs.AddValidatorTx{
-		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
-			NetworkID:    b.backend.NetworkID(),
-			BlockchainID: constants.PlatformChainID,
-			Ins:          inputs,
-			Outs:         baseOutputs,
-			Memo:         ops.Memo(),
-		}},
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         baseOutputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
 		Validator:        *vdr,
 		StakeOuts:        stakeOutputs,
 		RewardsOwner:     rewardsOwner,
@@ -379,13 +435,16 @@ func (b *builder) NewAddSubnetValidatorTx(
 	}
 
 	return &txs.AddSubnetValidatorTx{
-		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
-			NetworkID:    b.backend.NetworkID(),
-			BlockchainID: constants.PlatformChainID,
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
 		Validator:  *vdr,
 		SubnetAuth: subnetAuth,
 	}, nil
@@ -412,19 +471,63 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 	}
 
 	return &txs.RemoveSubnetValidatorTx{
-		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
-			NetworkID:    b.backend.NetworkID(),
-			BlockchainID: constants.PlatformChainID,
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
 		Subnet:     subnetID,
 		NodeID:     nodeID,
 		SubnetAuth: subnetAuth,
 	}, nil
 }
 
+func (b *builder) NewModifySubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	weight uint64,
+	endTime uint64,
+	options ...common.Option,
+) (*txs.ModifySubnetValidatorTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.DJTXAssetID(): b.backend.BaseTxFee(),
+	}
+	toStake := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txs.ModifySubnetValidatorTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
+		NodeID:     nodeID,
+		Subnet:     subnetID,
+		Weight:     weight,
+		EndTime:    endTime,
+		SubnetAuth: subnetAuth,
+	}, nil
+}
+
 func (b *builder) NewAddDelegatorTx(
 	vdr *validator.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -445,13 +548,16 @@ func (b *builder) NewAddDelegatorTx(
 
 	utils.Sort(rewardsOwner.Addrs)
 	return &txs.AddDelegatorTx{
-		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
-			NetworkID:    b.backend.NetworkID(),
-			BlockchainID: constants.PlatformChainID,
-			Ins:          inputs,
-			Outs:         baseOutputs,
-			Memo:         ops.Memo(),
-		}},
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         baseOutputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
 		Validator:              *vdr,
 		StakeOuts:              stakeOutputs,
 		DelegationRewardsOwner: rewardsOwner,
@@ -483,13 +589,16 @@ func (b *builder) NewCreateChainTx(
 
 	utils.Sort(fxIDs)
 	return &txs.CreateChainTx{
-		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
-			NetworkID:    b.backend.NetworkID(),
-			BlockchainID: constants.PlatformChainID,
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
 		SubnetID:    subnetID,
 		ChainName:   chainName,
 		VMID:        vmID,
@@ -499,6 +608,84 @@ func (b *builder) NewCreateChainTx(
 	}, nil
 }
 
+func (b *builder) NewHaltBlockchainTx(
+	subnetID ids.ID,
+	chainID ids.ID,
+	options ...common.Option,
+) (*txs.HaltBlockchainTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.DJTXAssetID(): b.backend.BaseTxFee(),
+	}
+	toStake := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txs.HaltBlockchainTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
+		Subnet:       subnetID,
+		BlockchainID: chainID,
+		SubnetAuth:   subnetAuth,
+	}, nil
+}
+
+func (b *builder) NewUpgradeBlockchainTx(
+	subnetID ids.ID,
+	chainID ids.ID,
+	vmID ids.ID,
+	activationTime uint64,
+	options ...common.Option,
+) (*txs.UpgradeBlockchainTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.DJTXAssetID(): b.backend.BaseTxFee(),
+	}
+	toStake := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txs.UpgradeBlockchainTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
+		Subnet:         subnetID,
+		BlockchainID:   chainID,
+		VMID:           vmID,
+		ActivationTime: activationTime,
+		SubnetAuth:     subnetAuth,
+	}, nil
+}
+
 func (b *builder) NewCreateSubnetTx(
 	owner *secp256k1fx.OutputOwners,
 	options ...common.Option,
@@ -515,17 +702,58 @@ func (b *builder) NewCreateSubnetTx(
 
 	utils.Sort(owner.Addrs)
 	return &txs.CreateSubnetTx{
-		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
-			NetworkID:    b.backend.NetworkID(),
-			BlockchainID: constants.PlatformChainID,
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
 		Owner: owner,
 	}, nil
 }
 
+func (b *builder) NewTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.TransferSubnetOwnershipTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.DJTXAssetID(): b.backend.BaseTxFee(),
+	}
+	toStake := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.Sort(owner.Addrs)
+	return &txs.TransferSubnetOwnershipTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+		Owner:      owner,
+	}, nil
+}
+
 func (b *builder) NewImportTx(
 	sourceChainID ids.ID,
 	to *secp256k1fx.OutputOwners,
@@ -586,26 +814,36 @@ func (b *builder) NewImportTx(
 		)
 	}
 
+	totalFee, err := math.Add64(txFee, ops.PriorityFee())
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		inputs       []*djtx.TransferableInput
 		outputs      = make([]*djtx.TransferableOutput, 0, len(importedAmounts))
 		importedDJTX = importedAmounts[djtxAssetID]
 	)
-	if importedDJTX > txFee {
-		importedAmounts[djtxAssetID] -= txFee
-	} else {
-		if importedDJTX < txFee { // imported amount goes toward paying tx fee
-			toBurn := map[ids.ID]uint64{
-				djtxAssetID: txFee - importedDJTX,
-			}
-			toStake := map[ids.ID]uint64{}
-			var err error
-			inputs, outputs, _, err = b.spend(toBurn, toStake, ops)
-			if err != nil {
-				return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
-			}
-		}
+	switch {
+	case importedDJTX > totalFee:
+		importedAmounts[djtxAssetID] -= totalFee
+	case importedDJTX == totalFee:
 		delete(importedAmounts, djtxAssetID)
+	default:
+		// The imported amount goes toward paying the static fee. The priority
+		// fee is burned by [spend].
+		toBurn := map[ids.ID]uint64{}
+		if importedDJTX > txFee {
+			importedAmounts[djtxAssetID] -= txFee
+		} else {
+			toBurn[djtxAssetID] = txFee - importedDJTX
+			delete(importedAmounts, djtxAssetID)
+		}
+		toStake := map[ids.ID]uint64{}
+		inputs, outputs, _, err = b.spend(toBurn, toStake, ops)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		}
 	}
 
 	for assetID, amount := range importedAmounts {
@@ -620,13 +858,16 @@ func (b *builder) NewImportTx(
 
 	djtx.SortTransferableOutputs(outputs, txs.Codec) // sort imported outputs
 	return &txs.ImportTx{
-		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
-			NetworkID:    b.backend.NetworkID(),
-			BlockchainID: constants.PlatformChainID,
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
 		SourceChain:    sourceChainID,
 		ImportedInputs: importedInputs,
 	}, nil
@@ -658,13 +899,16 @@ func (b *builder) NewExportTx(
 
 	djtx.SortTransferableOutputs(outputs, txs.Codec) // sort exported outputs
 	return &txs.ExportTx{
-		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
-			NetworkID:    b.backend.NetworkID(),
-			BlockchainID: constants.PlatformChainID,
-			Ins:          inputs,
-			Outs:         changeOutputs,
-			Memo:         ops.Memo(),
-		}},
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         changeOutputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
 		DestinationChain: chainID,
 		ExportedOutputs:  outputs,
 	}, nil
@@ -704,13 +948,16 @@ func (b *builder) NewTransformSubnetTx(
 	}
 
 	return &txs.TransformSubnetTx{
-		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
-			NetworkID:    b.backend.NetworkID(),
-			BlockchainID: constants.PlatformChainID,
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
 		Subnet:                   subnetID,
 		AssetID:                  assetID,
 		InitialSupply:            initialSupply,
@@ -757,13 +1004,16 @@ func (b *builder) NewAddPermissionlessValidatorTx(
 	utils.Sort(validationRewardsOwner.Addrs)
 	utils.Sort(delegationRewardsOwner.Addrs)
 	return &txs.AddPermissionlessValidatorTx{
-		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
-			NetworkID:    b.backend.NetworkID(),
-			BlockchainID: constants.PlatformChainID,
-			Ins:          inputs,
-			Outs:         baseOutputs,
-			Memo:         ops.Memo(),
-		}},
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         baseOutputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
 		Validator:             vdr.Validator,
 		Subnet:                vdr.Subnet,
 		Signer:                signer,
@@ -771,6 +1021,8 @@ func (b *builder) NewAddPermissionlessValidatorTx(
 		ValidatorRewardsOwner: validationRewardsOwner,
 		DelegatorRewardsOwner: delegationRewardsOwner,
 		DelegationShares:      shares,
+		RestakePeriods:        ops.RestakePeriods(),
+		CompoundRewards:       ops.CompoundRewards(),
 	}, nil
 }
 
@@ -798,17 +1050,22 @@ func (b *builder) NewAddPermissionlessDelegatorTx(
 
 	utils.Sort(rewardsOwner.Addrs)
 	return &txs.AddPermissionlessDelegatorTx{
-		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
-			NetworkID:    b.backend.NetworkID(),
-			BlockchainID: constants.PlatformChainID,
-			Ins:          inputs,
-			Outs:         baseOutputs,
-			Memo:         ops.Memo(),
-		}},
+		BaseTx: txs.BaseTx{
+			BaseTx: djtx.BaseTx{
+				NetworkID:    b.backend.NetworkID(),
+				BlockchainID: constants.PlatformChainID,
+				Ins:          inputs,
+				Outs:         baseOutputs,
+				Memo:         ops.Memo(),
+			},
+			Expiry: ops.Expiry(),
+		},
 		Validator:              vdr.Validator,
 		Subnet:                 vdr.Subnet,
 		StakeOuts:              stakeOutputs,
 		DelegationRewardsOwner: rewardsOwner,
+		RestakePeriods:         ops.RestakePeriods(),
+		CompoundRewards:        ops.CompoundRewards(),
 	}, nil
 }
 
@@ -881,6 +1138,16 @@ func (b *builder) spend(
 	stakeOutputs []*djtx.TransferableOutput,
 	err error,
 ) {
+	// The priority fee is burned in addition to the static fee.
+	if priorityFee := options.PriorityFee(); priorityFee > 0 {
+		djtxAssetID := b.backend.DJTXAssetID()
+		amountToBurn, err := math.Add64(amountsToBurn[djtxAssetID], priorityFee)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		amountsToBurn[djtxAssetID] = amountToBurn
+	}
+
 	utxos, err := b.backend.UTXOs(options.Context(), constants.PlatformChainID)
 	if err != nil {
 		return nil, nil, nil, err
@@ -1089,20 +1356,15 @@ func (b *builder) spend(
 }
 
 func (b *builder) authorizeSubnet(subnetID ids.ID, options *common.Options) (*secp256k1fx.Input, error) {
-	subnetTx, err := b.backend.GetTx(options.Context(), subnetID)
+	ownerIntf, err := b.backend.GetSubnetOwner(options.Context(), subnetID)
 	if err != nil {
 		return nil, fmt.Errorf(
-			"failed to fetch subnet %q: %w",
+			"failed to fetch subnet owner for %q: %w",
 			subnetID,
 			err,
 		)
 	}
-	subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
-	if !ok {
-		return nil, errWrongTxType
-	}
-
-	owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+	owner, ok := ownerIntf.(*secp256k1fx.OutputOwners)
 	if !ok {
 		return nil, errUnknownOwnerType
 	}
@@ -90,6 +90,22 @@ func (b *builderWithOptions) RemoveSubnetValidatorTx(
 	)
 }
 
+func (b *builderWithOptions) NewModifySubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	weight uint64,
+	endTime uint64,
+	options ...common.Option,
+) (*txs.ModifySubnetValidatorTx, error) {
+	return b.Builder.NewModifySubnetValidatorTx(
+		nodeID,
+		subnetID,
+		weight,
+		endTime,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewAddDelegatorTx(
 	vdr *validator.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -120,6 +136,34 @@ func (b *builderWithOptions) NewCreateChainTx(
 	)
 }
 
+func (b *builderWithOptions) NewHaltBlockchainTx(
+	subnetID ids.ID,
+	chainID ids.ID,
+	options ...common.Option,
+) (*txs.HaltBlockchainTx, error) {
+	return b.Builder.NewHaltBlockchainTx(
+		subnetID,
+		chainID,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
+func (b *builderWithOptions) NewUpgradeBlockchainTx(
+	subnetID ids.ID,
+	chainID ids.ID,
+	vmID ids.ID,
+	activationTime uint64,
+	options ...common.Option,
+) (*txs.UpgradeBlockchainTx, error) {
+	return b.Builder.NewUpgradeBlockchainTx(
+		subnetID,
+		chainID,
+		vmID,
+		activationTime,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewCreateSubnetTx(
 	owner *secp256k1fx.OutputOwners,
 	options ...common.Option,
@@ -130,6 +174,18 @@ func (b *builderWithOptions) NewCreateSubnetTx(
 	)
 }
 
+func (b *builderWithOptions) NewTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.TransferSubnetOwnershipTx, error) {
+	return b.Builder.NewTransferSubnetOwnershipTx(
+		subnetID,
+		owner,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewImportTx(
 	sourceChainID ids.ID,
 	to *secp256k1fx.OutputOwners,
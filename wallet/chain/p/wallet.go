@@ -81,6 +81,22 @@ type Wallet interface {
 		options ...common.Option,
 	) (ids.ID, error)
 
+	// IssueModifySubnetValidatorTx creates, signs, and issues a transaction
+	// that modifies a validator of a subnet without removing it from the
+	// validator set.
+	//
+	// - [nodeID] is the validator being modified on [subnetID].
+	// - [weight] specifies the new sampling weight of the validator.
+	// - [endTime] specifies the new unix time the validator stops validating
+	//   the subnet. If 0, the end time of the validator is unchanged.
+	IssueModifySubnetValidatorTx(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		weight uint64,
+		endTime uint64,
+		options ...common.Option,
+	) (ids.ID, error)
+
 	// IssueAddDelegatorTx creates, signs, and issues a new delegator to a
 	// validator on the primary network.
 	//
@@ -112,6 +128,29 @@ type Wallet interface {
 		options ...common.Option,
 	) (ids.ID, error)
 
+	// IssueHaltBlockchainTx creates, signs, and issues a transaction that
+	// halts the chain [chainID] of [subnetID]. Once halted, nodes stop running
+	// the chain and never create it again.
+	IssueHaltBlockchainTx(
+		subnetID ids.ID,
+		chainID ids.ID,
+		options ...common.Option,
+	) (ids.ID, error)
+
+	// IssueUpgradeBlockchainTx creates, signs, and issues a transaction that
+	// schedules the chain [chainID] of [subnetID] to switch to a different VM.
+	//
+	// - [vmID] specifies the vm that the chain will run after the upgrade.
+	// - [activationTime] specifies the unix time the chain starts running
+	//   [vmID].
+	IssueUpgradeBlockchainTx(
+		subnetID ids.ID,
+		chainID ids.ID,
+		vmID ids.ID,
+		activationTime uint64,
+		options ...common.Option,
+	) (ids.ID, error)
+
 	// IssueCreateSubnetTx creates, signs, and issues a new subnet with the
 	// specified owner.
 	//
@@ -122,6 +161,18 @@ type Wallet interface {
 		options ...common.Option,
 	) (ids.ID, error)
 
+	// IssueTransferSubnetOwnershipTx creates, signs, and issues a transaction
+	// that replaces the owner of [subnetID] with [owner].
+	//
+	// - [subnetID] specifies the subnet to be modified.
+	// - [owner] specifies who will have the ability to create new chains and
+	//   add new validators to the subnet.
+	IssueTransferSubnetOwnershipTx(
+		subnetID ids.ID,
+		owner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (ids.ID, error)
+
 	// IssueImportTx creates, signs, and issues an import transaction that
 	// attempts to consume all the available UTXOs and import the funds to [to].
 	//
@@ -321,6 +372,20 @@ func (w *wallet) IssueRemoveSubnetValidatorTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueModifySubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	weight uint64,
+	endTime uint64,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewModifySubnetValidatorTx(nodeID, subnetID, weight, endTime, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueAddDelegatorTx(
 	vdr *validator.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -348,6 +413,32 @@ func (w *wallet) IssueCreateChainTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueHaltBlockchainTx(
+	subnetID ids.ID,
+	chainID ids.ID,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewHaltBlockchainTx(subnetID, chainID, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
+func (w *wallet) IssueUpgradeBlockchainTx(
+	subnetID ids.ID,
+	chainID ids.ID,
+	vmID ids.ID,
+	activationTime uint64,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewUpgradeBlockchainTx(subnetID, chainID, vmID, activationTime, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueCreateSubnetTx(
 	owner *secp256k1fx.OutputOwners,
 	options ...common.Option,
@@ -359,6 +450,18 @@ func (w *wallet) IssueCreateSubnetTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewTransferSubnetOwnershipTx(subnetID, owner, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueImportTx(
 	sourceChainID ids.ID,
 	to *secp256k1fx.OutputOwners,
@@ -85,6 +85,22 @@ func (w *walletWithOptions) IssueRemoveSubnetValidatorTx(
 	)
 }
 
+func (w *walletWithOptions) IssueModifySubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	weight uint64,
+	endTime uint64,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueModifySubnetValidatorTx(
+		nodeID,
+		subnetID,
+		weight,
+		endTime,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueAddDelegatorTx(
 	vdr *validator.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -115,6 +131,34 @@ func (w *walletWithOptions) IssueCreateChainTx(
 	)
 }
 
+func (w *walletWithOptions) IssueHaltBlockchainTx(
+	subnetID ids.ID,
+	chainID ids.ID,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueHaltBlockchainTx(
+		subnetID,
+		chainID,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *walletWithOptions) IssueUpgradeBlockchainTx(
+	subnetID ids.ID,
+	chainID ids.ID,
+	vmID ids.ID,
+	activationTime uint64,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueUpgradeBlockchainTx(
+		subnetID,
+		chainID,
+		vmID,
+		activationTime,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueCreateSubnetTx(
 	owner *secp256k1fx.OutputOwners,
 	options ...common.Option,
@@ -125,6 +169,18 @@ func (w *walletWithOptions) IssueCreateSubnetTx(
 	)
 }
 
+func (w *walletWithOptions) IssueTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueTransferSubnetOwnershipTx(
+		subnetID,
+		owner,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueImportTx(
 	sourceChainID ids.ID,
 	to *secp256k1fx.OutputOwners,
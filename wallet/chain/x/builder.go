@@ -16,6 +16,8 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/avm/txs"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/freezefx"
+	"github.com/lasthyphen/dijetsnodego/vms/htlcfx"
 	"github.com/lasthyphen/dijetsnodego/vms/nftfx"
 	"github.com/lasthyphen/dijetsnodego/vms/propertyfx"
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
@@ -25,6 +27,9 @@ import (
 var (
 	errNoChangeAddress   = errors.New("no possible change address")
 	errInsufficientFunds = errors.New("insufficient funds")
+	errUnknownHTLC       = errors.New("unknown HTLC UTXO")
+	errCantSpendHTLC     = errors.New("can't spend HTLC UTXO")
+	errNotFreezeOutput   = errors.New("UTXO isn't a freeze output")
 
 	_ Builder = (*builder)(nil)
 )
@@ -47,6 +52,13 @@ type Builder interface {
 		options ...common.Option,
 	) (map[ids.ID]uint64, error)
 
+	// GetLockedFTBalance calculates the amount of each fungible asset that
+	// this builder will have control over once it unlocks. The amounts of an
+	// asset are keyed by the unix time they unlock at.
+	GetLockedFTBalance(
+		options ...common.Option,
+	) (map[ids.ID]map[uint64]uint64, error)
+
 	// NewBaseTx creates a new simple value transfer.
 	//
 	// - [outputs] specifies all the recipients and amounts that should be sent
@@ -56,6 +68,65 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.BaseTx, error)
 
+	// NewBaseTxTimelocked creates a new value transfer that sends funds which
+	// unlock according to a schedule, such as a vesting schedule.
+	//
+	// - [assetID] specifies the asset to send.
+	// - [owner] specifies the owners of the funds. Its locktime is ignored.
+	// - [schedule] maps the unix time at which funds unlock to the amount that
+	//   unlocks at that time.
+	NewBaseTxTimelocked(
+		assetID ids.ID,
+		owner *secp256k1fx.OutputOwners,
+		schedule map[uint64]uint64,
+		options ...common.Option,
+	) (*txs.BaseTx, error)
+
+	// NewBaseTxLockHTLC creates a new value transfer that locks funds in a
+	// hashed-timelock output.
+	//
+	// - [assetID] specifies the asset to lock.
+	// - [amount] specifies the amount of the asset to lock.
+	// - [hash] specifies the SHA-256 hash of the preimage that must be revealed
+	//   to claim the funds.
+	// - [deadline] specifies the unix time after which the funds can no longer
+	//   be claimed and can be refunded.
+	// - [receiver] specifies the owners that can claim the funds.
+	// - [refund] specifies the owners that can be refunded the funds.
+	NewBaseTxLockHTLC(
+		assetID ids.ID,
+		amount uint64,
+		hash ids.ID,
+		deadline uint64,
+		receiver *secp256k1fx.OutputOwners,
+		refund *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.BaseTx, error)
+
+	// NewBaseTxClaimHTLC creates a new value transfer that claims the funds
+	// locked in a hashed-timelock output by revealing its preimage.
+	//
+	// - [utxoID] specifies the UTXO of the hashed-timelock output.
+	// - [preimage] specifies the preimage of the hash of the output.
+	// - [to] specifies where to send the claimed funds to.
+	NewBaseTxClaimHTLC(
+		utxoID ids.ID,
+		preimage []byte,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.BaseTx, error)
+
+	// NewBaseTxRefundHTLC creates a new value transfer that refunds the funds
+	// locked in a hashed-timelock output once its deadline has passed.
+	//
+	// - [utxoID] specifies the UTXO of the hashed-timelock output.
+	// - [to] specifies where to send the refunded funds to.
+	NewBaseTxRefundHTLC(
+		utxoID ids.ID,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.BaseTx, error)
+
 	// NewCreateAssetTx creates a new asset.
 	//
 	// - [name] specifies a human readable name for this asset.
@@ -125,6 +196,36 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.OperationTx, error)
 
+	// NewOperationTxFreeze performs a state change that freezes, or unfreezes,
+	// UTXOs and addresses holding the requested asset.
+	//
+	// - [assetID] specifies the asset to freeze.
+	// - [frozen] specifies whether the targets are frozen or unfrozen.
+	// - [utxoIDs] specifies the IDs of the UTXOs to freeze.
+	// - [addrs] specifies the addresses to freeze.
+	NewOperationTxFreeze(
+		assetID ids.ID,
+		frozen bool,
+		utxoIDs []ids.ID,
+		addrs []ids.ShortID,
+		options ...common.Option,
+	) (*txs.OperationTx, error)
+
+	// NewOperationTxSendFreezable creates a transaction that sends the
+	// provided [outputs] of a freezable asset. The transaction spends and
+	// re-creates the freeze output of the asset, so that it is ordered against
+	// the freezes of the asset.
+	//
+	// - [freezeUTXO] specifies the current freeze output of the asset, which
+	//   is held by the freeze authority of the asset.
+	// - [outputs] specifies all the recipients and amounts that should be sent
+	//   from this transaction.
+	NewOperationTxSendFreezable(
+		freezeUTXO *djtx.UTXO,
+		outputs []*djtx.TransferableOutput,
+		options ...common.Option,
+	) (*txs.OperationTx, error)
+
 	// NewImportTx creates an import transaction that attempts to consume all
 	// the available UTXOs and import the funds to [to].
 	//
@@ -189,6 +290,54 @@ func (b *builder) GetImportableBalance(
 	return b.getBalance(chainID, ops)
 }
 
+func (b *builder) GetLockedFTBalance(
+	options ...common.Option,
+) (map[ids.ID]map[uint64]uint64, error) {
+	ops := common.NewOptions(options)
+	utxos, err := b.backend.UTXOs(ops.Context(), b.backend.BlockchainID())
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := ops.Addresses(b.addrs)
+	minIssuanceTime := ops.MinIssuanceTime()
+	balance := make(map[ids.ID]map[uint64]uint64)
+
+	// Iterate over the UTXOs
+	for _, utxo := range utxos {
+		outIntf := utxo.Out
+		out, ok := outIntf.(*secp256k1fx.TransferOutput)
+		if !ok {
+			// We only support [secp256k1fx.TransferOutput]s.
+			continue
+		}
+
+		locktime := out.Locktime
+		if locktime <= minIssuanceTime {
+			// This UTXO is already unlocked
+			continue
+		}
+
+		_, ok = common.MatchOwners(&out.OutputOwners, addrs, locktime)
+		if !ok {
+			// We won't be able to spend this UTXO, so we skip to the next one
+			continue
+		}
+
+		assetID := utxo.AssetID()
+		assetBalance, ok := balance[assetID]
+		if !ok {
+			assetBalance = make(map[uint64]uint64)
+			balance[assetID] = assetBalance
+		}
+		assetBalance[locktime], err = math.Add64(assetBalance[locktime], out.Amt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return balance, nil
+}
+
 func (b *builder) NewBaseTx(
 	outputs []*djtx.TransferableOutput,
 	options ...common.Option,
@@ -222,6 +371,70 @@ func (b *builder) NewBaseTx(
 	}}, nil
 }
 
+func (b *builder) NewBaseTxTimelocked(
+	assetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	schedule map[uint64]uint64,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	outputs := make([]*djtx.TransferableOutput, 0, len(schedule))
+	for locktime, amount := range schedule {
+		outputOwners := *owner
+		outputOwners.Locktime = locktime
+		outputs = append(outputs, &djtx.TransferableOutput{
+			Asset: djtx.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: outputOwners,
+			},
+		})
+	}
+	return b.NewBaseTx(outputs, options...)
+}
+
+func (b *builder) NewBaseTxLockHTLC(
+	assetID ids.ID,
+	amount uint64,
+	hash ids.ID,
+	deadline uint64,
+	receiver *secp256k1fx.OutputOwners,
+	refund *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	return b.NewBaseTx(
+		[]*djtx.TransferableOutput{{
+			Asset: djtx.Asset{ID: assetID},
+			Out: &htlcfx.TransferOutput{
+				Amt:      amount,
+				Hash:     hash,
+				Deadline: deadline,
+				Receiver: *receiver,
+				Refund:   *refund,
+			},
+		}},
+		options...,
+	)
+}
+
+func (b *builder) NewBaseTxClaimHTLC(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	ops := common.NewOptions(options)
+	return b.spendHTLC(utxoID, preimage, to, ops)
+}
+
+func (b *builder) NewBaseTxRefundHTLC(
+	utxoID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	ops := common.NewOptions(options)
+	return b.spendHTLC(utxoID, nil, to, ops)
+}
+
 func (b *builder) NewCreateAssetTx(
 	name string,
 	symbol string,
@@ -343,6 +556,49 @@ func (b *builder) NewOperationTxBurnProperty(
 	return b.NewOperationTx(operations, options...)
 }
 
+func (b *builder) NewOperationTxFreeze(
+	assetID ids.ID,
+	frozen bool,
+	utxoIDs []ids.ID,
+	addrs []ids.ShortID,
+	options ...common.Option,
+) (*txs.OperationTx, error) {
+	ops := common.NewOptions(options)
+	operations, err := b.freeze(assetID, frozen, utxoIDs, addrs, ops)
+	if err != nil {
+		return nil, err
+	}
+	return b.NewOperationTx(operations, options...)
+}
+
+func (b *builder) NewOperationTxSendFreezable(
+	freezeUTXO *djtx.UTXO,
+	outputs []*djtx.TransferableOutput,
+	options ...common.Option,
+) (*txs.OperationTx, error) {
+	freezeOut, ok := freezeUTXO.Out.(*freezefx.FreezeOutput)
+	if !ok {
+		return nil, errNotFreezeOutput
+	}
+
+	baseTx, err := b.NewBaseTx(outputs, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &txs.OperationTx{
+		BaseTx: *baseTx,
+		Ops: []*txs.Operation{{
+			Asset: freezeUTXO.Asset,
+			UTXOIDs: []*djtx.UTXOID{
+				&freezeUTXO.UTXOID,
+			},
+			Op: &freezefx.CheckOperation{
+				FreezeOutput: *freezeOut,
+			},
+		}},
+	}, nil
+}
+
 func (b *builder) NewImportTx(
 	chainID ids.ID,
 	to *secp256k1fx.OutputOwners,
@@ -620,6 +876,102 @@ func (b *builder) spend(
 	return inputs, outputs, nil
 }
 
+// spendHTLC claims the hashed-timelock UTXO [utxoID] if [preimage] is provided
+// and refunds it otherwise. The funds are sent to [to].
+func (b *builder) spendHTLC(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options *common.Options,
+) (*txs.BaseTx, error) {
+	utxos, err := b.backend.UTXOs(options.Context(), b.backend.BlockchainID())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		utxo *djtx.UTXO
+		out  *htlcfx.TransferOutput
+	)
+	for _, u := range utxos {
+		if u.InputID() != utxoID {
+			continue
+		}
+		htlcOut, ok := u.Out.(*htlcfx.TransferOutput)
+		if !ok {
+			break
+		}
+		utxo = u
+		out = htlcOut
+		break
+	}
+	if out == nil {
+		return nil, fmt.Errorf("%w: %s", errUnknownHTLC, utxoID)
+	}
+
+	owners := &out.Refund
+	if len(preimage) != 0 {
+		owners = &out.Receiver
+	}
+	addrs := options.Addresses(b.addrs)
+	inputSigIndices, ok := common.MatchOwners(owners, addrs, options.MinIssuanceTime())
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errCantSpendHTLC, utxoID)
+	}
+
+	var (
+		inputs      []*djtx.TransferableInput
+		outputs     []*djtx.TransferableOutput
+		assetID     = utxo.AssetID()
+		amount      = out.Amt
+		djtxAssetID = b.backend.DJTXAssetID()
+		txFee       = b.backend.BaseTxFee()
+	)
+	if assetID == djtxAssetID && amount >= txFee {
+		// The spent funds pay for the tx fee
+		amount -= txFee
+	} else {
+		toBurn := map[ids.ID]uint64{
+			djtxAssetID: txFee,
+		}
+		inputs, outputs, err = b.spend(toBurn, options)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		}
+	}
+
+	inputs = append(inputs, &djtx.TransferableInput{
+		UTXOID: utxo.UTXOID,
+		Asset:  utxo.Asset,
+		In: &htlcfx.TransferInput{
+			Amt:      out.Amt,
+			Preimage: preimage,
+			Input: secp256k1fx.Input{
+				SigIndices: inputSigIndices,
+			},
+		},
+	})
+	if amount > 0 {
+		outputs = append(outputs, &djtx.TransferableOutput{
+			Asset: utxo.Asset,
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: *to,
+			},
+		})
+	}
+
+	utils.Sort(inputs)                                    // sort inputs
+	djtx.SortTransferableOutputs(outputs, Parser.Codec()) // sort the outputs
+	return &txs.BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    b.backend.NetworkID(),
+		BlockchainID: b.backend.BlockchainID(),
+		Ins:          inputs,
+		Outs:         outputs,
+		Memo:         options.Memo(),
+	}}, nil
+}
+
 func (b *builder) mintFTs(
 	outputs map[ids.ID]*secp256k1fx.TransferOutput,
 	options *common.Options,
@@ -847,3 +1199,69 @@ func (b *builder) burnProperty(
 	}
 	return operations, nil
 }
+
+func (b *builder) freeze(
+	assetID ids.ID,
+	frozen bool,
+	utxoIDs []ids.ID,
+	addrs []ids.ShortID,
+	options *common.Options,
+) (
+	operations []*txs.Operation,
+	err error,
+) {
+	utxos, err := b.backend.UTXOs(options.Context(), b.backend.BlockchainID())
+	if err != nil {
+		return nil, err
+	}
+
+	ownerAddrs := options.Addresses(b.addrs)
+	minIssuanceTime := options.MinIssuanceTime()
+
+	targetUTXOIDs := make([]ids.ID, len(utxoIDs))
+	copy(targetUTXOIDs, utxoIDs)
+	utils.Sort(targetUTXOIDs)
+	targetAddrs := make([]ids.ShortID, len(addrs))
+	copy(targetAddrs, addrs)
+	utils.Sort(targetAddrs)
+
+	for _, utxo := range utxos {
+		if assetID != utxo.AssetID() {
+			continue
+		}
+
+		out, ok := utxo.Out.(*freezefx.FreezeOutput)
+		if !ok {
+			// wrong output type
+			continue
+		}
+
+		inputSigIndices, ok := common.MatchOwners(&out.OutputOwners, ownerAddrs, minIssuanceTime)
+		if !ok {
+			continue
+		}
+
+		// add the operation to the array
+		operations = append(operations, &txs.Operation{
+			Asset: djtx.Asset{ID: assetID},
+			UTXOIDs: []*djtx.UTXOID{
+				&utxo.UTXOID,
+			},
+			Op: &freezefx.FreezeOperation{
+				Input: secp256k1fx.Input{
+					SigIndices: inputSigIndices,
+				},
+				FreezeOutput:  *out,
+				Frozen:        frozen,
+				TargetUTXOIDs: targetUTXOIDs,
+				TargetAddrs:   targetAddrs,
+			},
+		})
+		return operations, nil
+	}
+	return nil, fmt.Errorf(
+		"%w: provided UTXOs not able to freeze %q",
+		errInsufficientFunds,
+		assetID,
+	)
+}
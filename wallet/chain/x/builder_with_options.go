@@ -51,6 +51,28 @@ func (b *builderWithOptions) GetImportableBalance(
 	)
 }
 
+func (b *builderWithOptions) GetLockedFTBalance(
+	options ...common.Option,
+) (map[ids.ID]map[uint64]uint64, error) {
+	return b.Builder.GetLockedFTBalance(
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
+func (b *builderWithOptions) NewBaseTxTimelocked(
+	assetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	schedule map[uint64]uint64,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	return b.Builder.NewBaseTxTimelocked(
+		assetID,
+		owner,
+		schedule,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewBaseTx(
 	outputs []*djtx.TransferableOutput,
 	options ...common.Option,
@@ -61,6 +83,52 @@ func (b *builderWithOptions) NewBaseTx(
 	)
 }
 
+func (b *builderWithOptions) NewBaseTxLockHTLC(
+	assetID ids.ID,
+	amount uint64,
+	hash ids.ID,
+	deadline uint64,
+	receiver *secp256k1fx.OutputOwners,
+	refund *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	return b.Builder.NewBaseTxLockHTLC(
+		assetID,
+		amount,
+		hash,
+		deadline,
+		receiver,
+		refund,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
+func (b *builderWithOptions) NewBaseTxClaimHTLC(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	return b.Builder.NewBaseTxClaimHTLC(
+		utxoID,
+		preimage,
+		to,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
+func (b *builderWithOptions) NewBaseTxRefundHTLC(
+	utxoID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	return b.Builder.NewBaseTxRefundHTLC(
+		utxoID,
+		to,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewCreateAssetTx(
 	name string,
 	symbol string,
@@ -133,6 +201,34 @@ func (b *builderWithOptions) NewOperationTxBurnProperty(
 	)
 }
 
+func (b *builderWithOptions) NewOperationTxFreeze(
+	assetID ids.ID,
+	frozen bool,
+	utxoIDs []ids.ID,
+	addrs []ids.ShortID,
+	options ...common.Option,
+) (*txs.OperationTx, error) {
+	return b.Builder.NewOperationTxFreeze(
+		assetID,
+		frozen,
+		utxoIDs,
+		addrs,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
+func (b *builderWithOptions) NewOperationTxSendFreezable(
+	freezeUTXO *djtx.UTXO,
+	outputs []*djtx.TransferableOutput,
+	options ...common.Option,
+) (*txs.OperationTx, error) {
+	return b.Builder.NewOperationTxSendFreezable(
+		freezeUTXO,
+		outputs,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewImportTx(
 	chainID ids.ID,
 	to *secp256k1fx.OutputOwners,
@@ -6,6 +6,8 @@ package x
 import (
 	"github.com/lasthyphen/dijetsnodego/vms/avm/fxs"
 	"github.com/lasthyphen/dijetsnodego/vms/avm/txs"
+	"github.com/lasthyphen/dijetsnodego/vms/freezefx"
+	"github.com/lasthyphen/dijetsnodego/vms/htlcfx"
 	"github.com/lasthyphen/dijetsnodego/vms/nftfx"
 	"github.com/lasthyphen/dijetsnodego/vms/propertyfx"
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
@@ -15,6 +17,8 @@ const (
 	SECP256K1FxIndex = 0
 	NFTFxIndex       = 1
 	PropertyFxIndex  = 2
+	HTLCFxIndex      = 3
+	FreezeFxIndex    = 4
 )
 
 // Parser to support serialization and deserialization
@@ -26,6 +30,8 @@ func init() {
 		&secp256k1fx.Fx{},
 		&nftfx.Fx{},
 		&propertyfx.Fx{},
+		&htlcfx.Fx{},
+		&freezefx.Fx{},
 	})
 	if err != nil {
 		panic(err)
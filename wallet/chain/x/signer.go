@@ -18,6 +18,8 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/avm/txs"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/freezefx"
+	"github.com/lasthyphen/dijetsnodego/vms/htlcfx"
 	"github.com/lasthyphen/dijetsnodego/vms/nftfx"
 	"github.com/lasthyphen/dijetsnodego/vms/propertyfx"
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
@@ -137,9 +139,15 @@ func (s *signer) getSigners(ctx stdcontext.Context, sourceChainID ids.ID, ins []
 	txCreds := make([]verify.Verifiable, len(ins))
 	txSigners := make([][]keychain.Signer, len(ins))
 	for credIndex, transferInput := range ins {
-		txCreds[credIndex] = &secp256k1fx.Credential{}
-		input, ok := transferInput.In.(*secp256k1fx.TransferInput)
-		if !ok {
+		var input *secp256k1fx.Input
+		switch in := transferInput.In.(type) {
+		case *secp256k1fx.TransferInput:
+			txCreds[credIndex] = &secp256k1fx.Credential{}
+			input = &in.Input
+		case *htlcfx.TransferInput:
+			txCreds[credIndex] = &htlcfx.Credential{}
+			input = &in.Input
+		default:
 			return nil, nil, errUnknownInputType
 		}
 
@@ -157,17 +165,27 @@ func (s *signer) getSigners(ctx stdcontext.Context, sourceChainID ids.ID, ins []
 			return nil, nil, err
 		}
 
-		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
-		if !ok {
+		var addrs []ids.ShortID
+		switch out := utxo.Out.(type) {
+		case *secp256k1fx.TransferOutput:
+			addrs = out.Addrs
+		case *htlcfx.TransferOutput:
+			// A claim is signed by the receiver and a refund by the refund
+			// owners.
+			addrs = out.Refund.Addrs
+			if in, ok := transferInput.In.(*htlcfx.TransferInput); ok && in.IsClaim() {
+				addrs = out.Receiver.Addrs
+			}
+		default:
 			return nil, nil, errUnknownOutputType
 		}
 
 		for sigIndex, addrIndex := range input.SigIndices {
-			if addrIndex >= uint32(len(out.Addrs)) {
+			if addrIndex >= uint32(len(addrs)) {
 				return nil, nil, errInvalidUTXOSigIndex
 			}
 
-			addr := out.Addrs[addrIndex]
+			addr := addrs[addrIndex]
 			key, ok := s.kc.Get(addr)
 			if !ok {
 				// If we don't have access to the key, then we can't sign this
@@ -201,6 +219,13 @@ func (s *signer) getOpsSigners(ctx stdcontext.Context, sourceChainID ids.ID, ops
 		case *propertyfx.BurnOperation:
 			txCreds[credIndex] = &propertyfx.Credential{}
 			input = &op.Input
+		case *freezefx.FreezeOperation:
+			txCreds[credIndex] = &freezefx.Credential{}
+			input = &op.Input
+		case *freezefx.CheckOperation:
+			// Freeze checks don't require any signatures.
+			txCreds[credIndex] = &freezefx.Credential{}
+			continue
 		default:
 			return nil, nil, errUnknownOpType
 		}
@@ -234,6 +259,8 @@ func (s *signer) getOpsSigners(ctx stdcontext.Context, sourceChainID ids.ID, ops
 			addrs = out.Addrs
 		case *propertyfx.OwnedOutput:
 			addrs = out.Addrs
+		case *freezefx.FreezeOutput:
+			addrs = out.Addrs
 		default:
 			return nil, nil, errUnknownOutputType
 		}
@@ -289,6 +316,10 @@ func sign(tx *txs.Tx, creds []verify.Verifiable, txSigners [][]keychain.Signer)
 			cred = &credImpl.Credential
 		case *propertyfx.Credential:
 			cred = &credImpl.Credential
+		case *htlcfx.Credential:
+			cred = &credImpl.Credential
+		case *freezefx.Credential:
+			cred = &credImpl.Credential
 		default:
 			return errUnknownCredentialType
 		}
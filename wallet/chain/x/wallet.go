@@ -40,6 +40,68 @@ type Wallet interface {
 		options ...common.Option,
 	) (ids.ID, error)
 
+	// IssueBaseTxTimelocked creates, signs, and issues a new value transfer
+	// that sends funds which unlock according to a schedule, such as a vesting
+	// schedule.
+	//
+	// - [assetID] specifies the asset to send.
+	// - [owner] specifies the owners of the funds. Its locktime is ignored.
+	// - [schedule] maps the unix time at which funds unlock to the amount that
+	//   unlocks at that time.
+	IssueBaseTxTimelocked(
+		assetID ids.ID,
+		owner *secp256k1fx.OutputOwners,
+		schedule map[uint64]uint64,
+		options ...common.Option,
+	) (ids.ID, error)
+
+	// IssueBaseTxLockHTLC creates, signs, and issues a new value transfer that
+	// locks funds in a hashed-timelock output.
+	//
+	// - [assetID] specifies the asset to lock.
+	// - [amount] specifies the amount of the asset to lock.
+	// - [hash] specifies the SHA-256 hash of the preimage that must be revealed
+	//   to claim the funds.
+	// - [deadline] specifies the unix time after which the funds can no longer
+	//   be claimed and can be refunded.
+	// - [receiver] specifies the owners that can claim the funds.
+	// - [refund] specifies the owners that can be refunded the funds.
+	IssueBaseTxLockHTLC(
+		assetID ids.ID,
+		amount uint64,
+		hash ids.ID,
+		deadline uint64,
+		receiver *secp256k1fx.OutputOwners,
+		refund *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (ids.ID, error)
+
+	// IssueBaseTxClaimHTLC creates, signs, and issues a new value transfer
+	// that claims the funds locked in a hashed-timelock output by revealing
+	// its preimage.
+	//
+	// - [utxoID] specifies the UTXO of the hashed-timelock output.
+	// - [preimage] specifies the preimage of the hash of the output.
+	// - [to] specifies where to send the claimed funds to.
+	IssueBaseTxClaimHTLC(
+		utxoID ids.ID,
+		preimage []byte,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (ids.ID, error)
+
+	// IssueBaseTxRefundHTLC creates, signs, and issues a new value transfer
+	// that refunds the funds locked in a hashed-timelock output once its
+	// deadline has passed.
+	//
+	// - [utxoID] specifies the UTXO of the hashed-timelock output.
+	// - [to] specifies where to send the refunded funds to.
+	IssueBaseTxRefundHTLC(
+		utxoID ids.ID,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (ids.ID, error)
+
 	// IssueCreateAssetTx creates, signs, and issues a new asset.
 	//
 	// - [name] specifies a human readable name for this asset.
@@ -109,6 +171,35 @@ type Wallet interface {
 		options ...common.Option,
 	) (ids.ID, error)
 
+	// IssueOperationTxFreeze creates, signs, and issues a state change that
+	// freezes, or unfreezes, UTXOs and addresses holding the requested asset.
+	//
+	// - [assetID] specifies the asset to freeze.
+	// - [frozen] specifies whether the targets are frozen or unfrozen.
+	// - [utxoIDs] specifies the IDs of the UTXOs to freeze.
+	// - [addrs] specifies the addresses to freeze.
+	IssueOperationTxFreeze(
+		assetID ids.ID,
+		frozen bool,
+		utxoIDs []ids.ID,
+		addrs []ids.ShortID,
+		options ...common.Option,
+	) (ids.ID, error)
+
+	// IssueOperationTxSendFreezable creates, signs, and issues a transaction
+	// that sends the provided [outputs] of a freezable asset, ordered against
+	// the freezes of the asset.
+	//
+	// - [freezeUTXO] specifies the current freeze output of the asset, which
+	//   is held by the freeze authority of the asset.
+	// - [outputs] specifies all the recipients and amounts that should be sent
+	//   from this transaction.
+	IssueOperationTxSendFreezable(
+		freezeUTXO *djtx.UTXO,
+		outputs []*djtx.TransferableOutput,
+		options ...common.Option,
+	) (ids.ID, error)
+
 	// IssueImportTx creates, signs, and issues an import transaction that
 	// attempts to consume all the available UTXOs and import the funds to [to].
 	//
@@ -184,6 +275,60 @@ func (w *wallet) IssueBaseTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueBaseTxTimelocked(
+	assetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	schedule map[uint64]uint64,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewBaseTxTimelocked(assetID, owner, schedule, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
+func (w *wallet) IssueBaseTxLockHTLC(
+	assetID ids.ID,
+	amount uint64,
+	hash ids.ID,
+	deadline uint64,
+	receiver *secp256k1fx.OutputOwners,
+	refund *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewBaseTxLockHTLC(assetID, amount, hash, deadline, receiver, refund, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
+func (w *wallet) IssueBaseTxClaimHTLC(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewBaseTxClaimHTLC(utxoID, preimage, to, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
+func (w *wallet) IssueBaseTxRefundHTLC(
+	utxoID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewBaseTxRefundHTLC(utxoID, to, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueCreateAssetTx(
 	name string,
 	symbol string,
@@ -256,6 +401,32 @@ func (w *wallet) IssueOperationTxBurnProperty(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueOperationTxFreeze(
+	assetID ids.ID,
+	frozen bool,
+	utxoIDs []ids.ID,
+	addrs []ids.ShortID,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewOperationTxFreeze(assetID, frozen, utxoIDs, addrs, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
+func (w *wallet) IssueOperationTxSendFreezable(
+	freezeUTXO *djtx.UTXO,
+	outputs []*djtx.TransferableOutput,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewOperationTxSendFreezable(freezeUTXO, outputs, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueImportTx(
 	chainID ids.ID,
 	to *secp256k1fx.OutputOwners,
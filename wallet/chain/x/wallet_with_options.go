@@ -46,6 +46,66 @@ func (w *walletWithOptions) IssueBaseTx(
 	)
 }
 
+func (w *walletWithOptions) IssueBaseTxTimelocked(
+	assetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	schedule map[uint64]uint64,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueBaseTxTimelocked(
+		assetID,
+		owner,
+		schedule,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *walletWithOptions) IssueBaseTxLockHTLC(
+	assetID ids.ID,
+	amount uint64,
+	hash ids.ID,
+	deadline uint64,
+	receiver *secp256k1fx.OutputOwners,
+	refund *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueBaseTxLockHTLC(
+		assetID,
+		amount,
+		hash,
+		deadline,
+		receiver,
+		refund,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *walletWithOptions) IssueBaseTxClaimHTLC(
+	utxoID ids.ID,
+	preimage []byte,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueBaseTxClaimHTLC(
+		utxoID,
+		preimage,
+		to,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *walletWithOptions) IssueBaseTxRefundHTLC(
+	utxoID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueBaseTxRefundHTLC(
+		utxoID,
+		to,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueCreateAssetTx(
 	name string,
 	symbol string,
@@ -118,6 +178,34 @@ func (w *walletWithOptions) IssueOperationTxBurnProperty(
 	)
 }
 
+func (w *walletWithOptions) IssueOperationTxFreeze(
+	assetID ids.ID,
+	frozen bool,
+	utxoIDs []ids.ID,
+	addrs []ids.ShortID,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueOperationTxFreeze(
+		assetID,
+		frozen,
+		utxoIDs,
+		addrs,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *walletWithOptions) IssueOperationTxSendFreezable(
+	freezeUTXO *djtx.UTXO,
+	outputs []*djtx.TransferableOutput,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueOperationTxSendFreezable(
+		freezeUTXO,
+		outputs,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueImportTx(
 	chainID ids.ID,
 	to *secp256k1fx.OutputOwners,
@@ -31,6 +31,13 @@ type Options struct {
 
 	memo []byte
 
+	priorityFee uint64
+
+	expiry uint64
+
+	restakePeriods  uint32
+	compoundRewards bool
+
 	assumeDecided bool
 
 	pollFrequencySet bool
@@ -93,6 +100,22 @@ func (o *Options) Memo() []byte {
 	return o.memo
 }
 
+func (o *Options) PriorityFee() uint64 {
+	return o.priorityFee
+}
+
+func (o *Options) Expiry() uint64 {
+	return o.expiry
+}
+
+func (o *Options) RestakePeriods() uint32 {
+	return o.restakePeriods
+}
+
+func (o *Options) CompoundRewards() bool {
+	return o.compoundRewards
+}
+
 func (o *Options) AssumeDecided() bool {
 	return o.assumeDecided
 }
@@ -142,6 +165,33 @@ func WithMemo(memo []byte) Option {
 	}
 }
 
+// WithPriorityFee burns [priorityFee] nDJTX in addition to the static fee of a
+// P-chain tx, so that it is included in a block ahead of txs paying less per
+// byte.
+func WithPriorityFee(priorityFee uint64) Option {
+	return func(o *Options) {
+		o.priorityFee = priorityFee
+	}
+}
+
+// WithExpiry sets the Unix time, in seconds, after which a P-chain tx can no
+// longer be accepted. After it, the tx can safely be rebuilt and reissued.
+func WithExpiry(expiry uint64) Option {
+	return func(o *Options) {
+		o.expiry = expiry
+	}
+}
+
+// WithAutoRestake makes a permissionless staker be restaked for the same
+// duration the first [periods] times it is rewarded. If [compoundRewards] is
+// true, its rewards are added to its stake rather than paid out.
+func WithAutoRestake(periods uint32, compoundRewards bool) Option {
+	return func(o *Options) {
+		o.restakePeriods = periods
+		o.compoundRewards = compoundRewards
+	}
+}
+
 func WithAssumeDecided() Option {
 	return func(o *Options) {
 		o.assumeDecided = true